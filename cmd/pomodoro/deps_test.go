@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// windowlessForbiddenDeps must not be linked into the packages the
+// windowless modes are built of, otherwise they would need a display.
+var windowlessForbiddenDeps = []string{
+	"fyne.io/fyne/v2",
+	"github.com/xaionaro-go/pomodoro/pkg/gui",
+	"github.com/xaionaro-go/pomodoro/pkg/globalhotkey",
+	"golang.design/x/hotkey",
+}
+
+func TestWindowlessDeps(t *testing.T) {
+	for _, pkg := range []string{
+		"github.com/xaionaro-go/pomodoro/pkg/pomodoro",
+		"github.com/xaionaro-go/pomodoro/pkg/tui",
+		"github.com/xaionaro-go/pomodoro/pkg/jsonrpc",
+	} {
+		t.Run(pkg, func(t *testing.T) {
+			out, err := exec.Command("go", "list", "-deps", pkg).Output()
+			if err != nil {
+				t.Skipf("unable to list the dependencies: %v", err)
+			}
+			for _, dep := range strings.Fields(string(out)) {
+				for _, forbidden := range windowlessForbiddenDeps {
+					if dep == forbidden || strings.HasPrefix(dep, forbidden+"/") {
+						t.Errorf("'%s' depends on '%s'", pkg, dep)
+					}
+				}
+			}
+		})
+	}
+}
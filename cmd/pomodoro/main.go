@@ -1,10 +1,467 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/calendar"
+	"github.com/xaionaro-go/pomodoro/pkg/datadir"
+	"github.com/xaionaro-go/pomodoro/pkg/dbusservice"
+	"github.com/xaionaro-go/pomodoro/pkg/grpcapi"
+	"github.com/xaionaro-go/pomodoro/pkg/gui"
+	"github.com/xaionaro-go/pomodoro/pkg/httpapi"
+	"github.com/xaionaro-go/pomodoro/pkg/jsonrpc"
+	"github.com/xaionaro-go/pomodoro/pkg/logging"
+	"github.com/xaionaro-go/pomodoro/pkg/metrics"
+	"github.com/xaionaro-go/pomodoro/pkg/mpris"
+	"github.com/xaionaro-go/pomodoro/pkg/mqttpublisher"
+	"github.com/xaionaro-go/pomodoro/pkg/peersync"
+	"github.com/xaionaro-go/pomodoro/pkg/plugin"
 	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+	"github.com/xaionaro-go/pomodoro/pkg/presence"
+	"github.com/xaionaro-go/pomodoro/pkg/room"
+	"github.com/xaionaro-go/pomodoro/pkg/singleinstance"
+	"github.com/xaionaro-go/pomodoro/pkg/statusbar"
+	"github.com/xaionaro-go/pomodoro/pkg/tasks"
+	"github.com/xaionaro-go/pomodoro/pkg/tracker"
+	"github.com/xaionaro-go/pomodoro/pkg/tui"
+	"github.com/xaionaro-go/pomodoro/pkg/urlscheme"
 )
 
+// usageError is a mistake in the command line, it exits with the code 2
+// (as the errors of the flags do).
+type usageError struct {
+	error
+}
+
 func main() {
-	app := pomodoro.New()
-	app.ShowAndRun()
+	err := run()
+	if err == nil {
+		return
+	}
+	fmt.Fprintln(os.Stderr, err)
+	if errors.As(err, &usageError{}) {
+		os.Exit(2)
+	}
+	os.Exit(1)
+}
+
+// run is main, but it returns the error instead of exiting, so
+// the deferred cleanup (for example, the release of the instance lock)
+// is done.
+func run() error {
+	dbusEnable := flag.Bool("dbus", runtime.GOOS == "linux", "publish the timer control interface on the DBus session bus")
+	mprisEnable := flag.Bool("mpris", runtime.GOOS == "linux", "publish the timer as an MPRIS media player (shown by the media applets of the desktop environments)")
+	listenAddr := flag.String("listen", "", "if non-empty, serve the HTTP API on this address (for example '127.0.0.1:8787')")
+	shareAddr := flag.String("share", "", "if non-empty, serve a read-only live timer page for the spectators on this address (for example ':8790'); the link and its QR code are shown by 'Share the timer...' in the Window menu")
+	grpcListenAddr := flag.String("grpc-listen", "", "if non-empty, serve the gRPC API (see pkg/grpcapi/pomodoropb/pomodoro.proto) on this address (for example 'localhost:8788')")
+	statusFormat := flag.String("status-format", "", "instead of showing the window, print the status of the running instance in this format ('waybar' or 'i3blocks') each second")
+	multiInstance := flag.Bool("multi-instance", false, "do not forward the commands to the already running instance, start a new one instead")
+	headless := flag.Bool("headless", false, "run only the timer (with the notifications and the integrations) without any windows; control it via DBus, --listen or commands")
+	tuiEnable := flag.Bool("tui", false, "show the timer in the terminal instead of a window")
+	metricsEnable := flag.Bool("metrics", false, "expose Prometheus metrics on '/metrics' of the HTTP API (requires --listen)")
+	logLevel := flag.String("log-level", "info", "the minimal level of the logged messages: 'debug', 'info', 'warn' or 'error'")
+	rpcStdio := flag.Bool("rpc-stdio", false, "serve JSON-RPC on the standard input and output (for the editor plugins) without any windows, exit when the input is closed")
+	logFile := flag.String("log-file", "", "write the log to this file instead of the standard error")
+	minimized := flag.Bool("minimized", false, "start with the window hidden, only the icon in the system tray is shown (used by the autostart entry)")
+	dataDir := flag.String("data-dir", "", "keep the preferences, the history and the logs in this directory (for example, to run from a USB stick) instead of the XDG/system ones")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] [%s]\n", os.Args[0], strings.Join(pomodoro.Commands, "|"))
+		fmt.Fprintf(flag.CommandLine.Output(), "       %s [flags] backup [FILE.zip]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		return usageError{err}
+	}
+	dirs := datadir.Default()
+	if *dataDir != "" {
+		dirs, err = datadir.Portable(*dataDir)
+		if err != nil {
+			return usageError{err}
+		}
+	}
+	if *logFile == "" {
+		*logFile, err = dirs.LogFile()
+		if err != nil {
+			return err
+		}
+	}
+	logCloser, err := logging.Init(logging.Config{
+		Level: level,
+		File:  *logFile,
+	})
+	if err != nil {
+		return err
+	}
+	defer logCloser.Close()
+	if *rpcStdio && *tuiEnable {
+		return usageError{errors.New("--rpc-stdio and --tui both need the terminal, choose one")}
+	}
+	if *metricsEnable && *listenAddr == "" {
+		slog.Warn("--metrics is ignored, since --listen is not set")
+	}
+
+	if *statusFormat != "" {
+		if err := runStatusBar(statusbar.Format(*statusFormat)); err != nil {
+			return fmt.Errorf("unable to show the status: %w", err)
+		}
+		return nil
+	}
+
+	if flag.Arg(0) == "backup" {
+		if err := runBackup(dirs, flag.Arg(1)); err != nil {
+			return fmt.Errorf("unable to back up: %w", err)
+		}
+		return nil
+	}
+
+	for _, command := range flag.Args() {
+		if !slices.Contains(pomodoro.Commands, command) && !urlscheme.IsURL(command) {
+			return usageError{fmt.Errorf("unknown command '%s', expected one of: %s", command, strings.Join(pomodoro.Commands, ", "))}
+		}
+	}
+
+	var activations chan []string
+	if !*multiInstance && !*rpcStdio {
+		activations = make(chan []string, 1)
+		instance, err := acquireInstance(dirs.InstanceName("pomodoro"), flag.Args(), activations)
+		switch {
+		case errors.Is(err, singleinstance.ErrForwarded):
+			return nil
+		case err != nil:
+			slog.Error("unable to check for another running instance", "error", err)
+		default:
+			defer instance.Close()
+		}
+	}
+
+	// the windowless modes do not even initialize Fyne, so they work
+	// without a display
+	var (
+		app    *pomodoro.Pomodoro
+		runner interface{ Run(context.Context) error }
+	)
+	if *headless || *tuiEnable || *rpcStdio {
+		app = pomodoro.New(dirs)
+		runner = app
+	} else {
+		g := gui.New(dirs)
+		g.SetStartMinimized(*minimized)
+		app, runner = g.Pomodoro, g
+	}
+	for _, command := range flag.Args() {
+		if err := app.RunCommand(command); err != nil {
+			slog.Error("unable to run the command", "command", command, "error", err)
+		}
+	}
+	if activations != nil {
+		go func() {
+			for args := range activations {
+				app.Activate(args)
+			}
+		}()
+		registerURLScheme(*dataDir)
+	}
+	urlscheme.HandleURLs(func(url string) {
+		if err := app.OpenURL(url); err != nil {
+			slog.Error("unable to open the URL", "url", url, "error", err)
+		}
+	})
+
+	settings := app.CurrentSettings()
+	if *dbusEnable {
+		dbusService, err := dbusservice.New(app)
+		if err != nil {
+			slog.Error("unable to start the DBus service", "error", err)
+		} else {
+			defer dbusService.Close()
+		}
+	}
+
+	if *mprisEnable {
+		mprisPlayer, err := mpris.New(app)
+		if err != nil {
+			slog.Error("unable to publish the MPRIS player", "error", err)
+		} else {
+			defer mprisPlayer.Close()
+		}
+	}
+
+	if mqttSettings := settings.MQTT; mqttSettings.BrokerURL != "" {
+		mqttPublisher, err := mqttpublisher.New(mqttpublisher.Config{
+			BrokerURL:   mqttSettings.BrokerURL,
+			Username:    mqttSettings.Username,
+			Password:    mqttSettings.Password,
+			TopicPrefix: mqttSettings.TopicPrefix,
+		}, app)
+		if err != nil {
+			slog.Error("unable to start the MQTT publisher", "error", err)
+		} else {
+			defer mqttPublisher.Close()
+		}
+	}
+
+	if togglSettings := settings.Toggl; togglSettings.APIToken != "" {
+		app.AddTracker(tracker.NewToggl(togglSettings.APIToken, togglSettings.WorkspaceID))
+	}
+
+	if token := settings.TaskProviders.TodoistAPIToken; token != "" {
+		app.AddTaskProvider(tasks.NewTodoist(token))
+	}
+	if token := settings.TaskProviders.GitHubToken; token != "" {
+		app.AddTaskProvider(tasks.NewGitHub(token))
+	}
+
+	if dailyNoteSettings := settings.DailyNote; dailyNoteSettings.PathTemplate != "" {
+		app.AddTracker(tracker.NewDailyNote(dailyNoteSettings.PathTemplate))
+	}
+
+	if calDAVSettings := settings.CalDAV; calDAVSettings.URL != "" {
+		calendarBlocker := calendar.NewBlocker(
+			calendar.NewCalDAV(calDAVSettings.URL, calDAVSettings.Username, calDAVSettings.Password),
+			app,
+		)
+		defer calendarBlocker.Close()
+	}
+
+	if calDAVSettings := settings.CalDAV; calDAVSettings.WarnMeetings {
+		var reader calendar.Reader
+		switch {
+		case calDAVSettings.MeetingsFeedURL != "":
+			reader = calendar.NewICSFeed(calDAVSettings.MeetingsFeedURL)
+		case calDAVSettings.URL != "":
+			reader = calendar.NewCalDAV(calDAVSettings.URL, calDAVSettings.Username, calDAVSettings.Password)
+		default:
+			slog.Warn("no calendar to warn about the meetings from, set the CalDAV URL or the meetings feed URL")
+		}
+		if reader != nil {
+			meetingGuard := calendar.NewMeetingGuard(reader, app)
+			defer meetingGuard.Close()
+		}
+	}
+
+	if len(settings.Plugins) > 0 {
+		plugins := plugin.Start(app, settings.Plugins)
+		defer plugins.Close()
+	}
+
+	if slackSettings := settings.Slack; slackSettings.Token != "" {
+		presenceUpdater := presence.NewUpdater(app, presence.NewSlack(slackSettings.Token))
+		defer presenceUpdater.Close()
+	}
+
+	if peerSyncSettings := settings.PeerSync; peerSyncSettings.ListenAddr != "" || len(peerSyncSettings.Peers) > 0 {
+		peerSyncer, err := peersync.New(peersync.Config{
+			ListenAddr: peerSyncSettings.ListenAddr,
+			Peers:      peerSyncSettings.Peers,
+			Secret:     peerSyncSettings.Secret,
+		}, app)
+		if err != nil {
+			slog.Error("unable to start the peer sync", "error", err)
+		} else {
+			defer peerSyncer.Close()
+		}
+	}
+
+	if roomSettings := settings.Room; roomSettings.HostAddr != "" || roomSettings.JoinURL != "" {
+		if r := startRoom(roomSettings, app); r != nil {
+			r.OnMembersChanged(app.SetRoomMembers)
+			app.SetRoomMembers(r.Members())
+			defer r.Close()
+		}
+	}
+
+	if *listenAddr != "" {
+		listener, err := net.Listen("tcp", *listenAddr)
+		if err != nil {
+			return fmt.Errorf("unable to listen on '%s': %w", *listenAddr, err)
+		}
+		httpServer := httpapi.New(app)
+		httpServer.AllowedHosts = []string{*listenAddr}
+		if *metricsEnable {
+			metricsExporter := metrics.New(app)
+			defer metricsExporter.Close()
+			httpServer.Mux.Handle("/metrics", metricsExporter.Handler())
+		}
+		go func() {
+			if err := httpServer.Serve(listener); err != nil {
+				slog.Error("the HTTP API server stopped", "error", err)
+			}
+		}()
+		defer httpServer.Close()
+	}
+
+	if *shareAddr != "" {
+		listener, err := net.Listen("tcp", *shareAddr)
+		if err != nil {
+			return fmt.Errorf("unable to listen on '%s': %w", *shareAddr, err)
+		}
+		shareURL := httpapi.SpectatorURL(listener.Addr())
+		slog.Info("sharing the timer", "url", shareURL)
+		app.SetShareURL(shareURL)
+		spectatorServer := httpapi.NewSpectator(app)
+		go func() {
+			if err := spectatorServer.Serve(listener); err != nil {
+				slog.Error("the spectator server stopped", "error", err)
+			}
+		}()
+		defer spectatorServer.Close()
+	}
+
+	if *grpcListenAddr != "" {
+		listener, err := net.Listen("tcp", *grpcListenAddr)
+		if err != nil {
+			return fmt.Errorf("unable to listen on '%s': %w", *grpcListenAddr, err)
+		}
+		grpcServer := grpcapi.New(app)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				slog.Error("the gRPC API server stopped", "error", err)
+			}
+		}()
+		defer grpcServer.Close()
+	}
+
+	ctx, cancelFn := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancelFn()
+	if *rpcStdio {
+		go func() {
+			if err := jsonrpc.New(app).Serve(os.Stdin, os.Stdout); err != nil {
+				slog.Error("the JSON-RPC server stopped", "error", err)
+			}
+			cancelFn()
+		}()
+	}
+	if *tuiEnable {
+		if err := tui.Run(ctx, app); err != nil {
+			slog.Error("the terminal UI failed", "error", err)
+		}
+		cancelFn()
+	}
+	if err := runner.Run(ctx); err != nil {
+		slog.Error("unable to shut down cleanly", "error", err)
+	}
+	return nil
+}
+
+type roomMembership interface {
+	Members() []string
+	OnMembersChanged(handler func(members []string))
+	Close() error
+}
+
+func startRoom(
+	settings pomodoro.RoomSettings,
+	app *pomodoro.Pomodoro,
+) roomMembership {
+	name := settings.Name
+	if name == "" {
+		if u, err := user.Current(); err == nil {
+			name = u.Username
+		}
+	}
+	if settings.HostAddr != "" {
+		host, err := room.NewHost(settings.HostAddr, name, app)
+		if err != nil {
+			slog.Error("unable to host the room", "error", err)
+			return nil
+		}
+		return host
+	}
+	return room.Join(settings.JoinURL, name, app)
+}
+
+func runStatusBar(format statusbar.Format) error {
+	client, err := dbusservice.NewClient()
+	if err != nil {
+		return fmt.Errorf("unable to connect to the running instance: %w", err)
+	}
+	defer client.Close()
+
+	ctx, cancelFn := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancelFn()
+	err = statusbar.Run(ctx, format, client, os.Stdout)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// runBackup writes the backup of the user data to the file,
+// a file named by the date in the current directory if path is empty.
+func runBackup(
+	dirs datadir.Dirs,
+	path string,
+) error {
+	if path == "" {
+		path = pomodoro.DefaultBackupFileName(time.Now())
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("unable to create '%s': %w", path, err)
+	}
+	err = pomodoro.Backup(f, dirs)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("unable to write '%s': %w", path, closeErr)
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	fmt.Println(path)
+	return nil
+}
+
+// acquireInstance makes this process the running instance, or forwards
+// the arguments to the already running one.
+func acquireInstance(
+	name string,
+	args []string,
+	activations chan<- []string,
+) (*singleinstance.Instance, error) {
+	dir, err := singleinstance.Dir(name)
+	if err != nil {
+		return nil, err
+	}
+	return singleinstance.Acquire(dir, args, func(args []string) {
+		activations <- args
+	})
+}
+
+// registerURLScheme makes the "pomodoro://" URLs (for example, the buttons
+// of the notifications) open this executable, so they are forwarded
+// to this instance.
+func registerURLScheme(dataDir string) {
+	executable, err := os.Executable()
+	if err != nil {
+		slog.Warn("unable to get the path of the executable", "error", err)
+		return
+	}
+	var args []string
+	if dataDir != "" {
+		dataDir, err = filepath.Abs(dataDir)
+		if err != nil {
+			slog.Warn("unable to get the absolute path of the data directory", "error", err)
+			return
+		}
+		args = append(args, "--data-dir", dataDir)
+	}
+	if err := urlscheme.Register(executable, args...); err != nil {
+		slog.Warn("unable to register the URL scheme", "error", err)
+	}
 }
@@ -0,0 +1,127 @@
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// converter changes the sample rate (using linear interpolation)
+// and the channel count of a stream.
+type converter struct {
+	source     Stream
+	sampleRate int
+	channels   int
+	step       float64
+	position   float64
+	prev       []float32
+	next       []float32
+	frame      []byte
+	err        error
+	started    bool
+}
+
+func convert(
+	stream Stream,
+	sampleRate int,
+	channels int,
+) Stream {
+	if stream.SampleRate() == sampleRate && stream.Channels() == channels {
+		return stream
+	}
+	return &converter{
+		source:     stream,
+		sampleRate: sampleRate,
+		channels:   channels,
+		step:       float64(stream.SampleRate()) / float64(sampleRate),
+		prev:       make([]float32, stream.Channels()),
+		next:       make([]float32, stream.Channels()),
+		frame:      make([]byte, stream.Channels()*bytesPerSample),
+	}
+}
+
+func (c *converter) SampleRate() int {
+	return c.sampleRate
+}
+
+func (c *converter) Channels() int {
+	return c.channels
+}
+
+// readFrame moves to the next frame of the source, the interpolation
+// goes from prev to next. If there is no next frame, both are the last
+// one.
+func (c *converter) readFrame() error {
+	copy(c.prev, c.next)
+	if _, err := io.ReadFull(c.source, c.frame); err != nil {
+		return err
+	}
+	for idx := range c.next {
+		c.next[idx] = math.Float32frombits(binary.LittleEndian.Uint32(c.frame[idx*bytesPerSample:]))
+	}
+	return nil
+}
+
+func (c *converter) Read(b []byte) (int, error) {
+	if !c.started {
+		c.started = true
+		if err := c.readFrame(); err != nil {
+			return 0, normalizeEOF(err)
+		}
+		if err := c.readFrame(); err != nil {
+			c.err = normalizeEOF(err)
+		}
+	}
+
+	frameSize := c.channels * bytesPerSample
+	n := 0
+	for n+frameSize <= len(b) {
+		for c.position >= 1 {
+			if c.err != nil {
+				return n, c.err
+			}
+			if err := c.readFrame(); err != nil {
+				c.err = normalizeEOF(err)
+			}
+			c.position--
+		}
+		for channel := 0; channel < c.channels; channel++ {
+			sample := c.interpolate(channel)
+			binary.LittleEndian.PutUint32(b[n:], math.Float32bits(sample))
+			n += bytesPerSample
+		}
+		c.position += c.step
+	}
+	return n, nil
+}
+
+func (c *converter) interpolate(channel int) float32 {
+	var prev, next float32
+	switch srcChannels := len(c.prev); {
+	case channel < srcChannels:
+		prev, next = c.prev[channel], c.next[channel]
+	case srcChannels == 1:
+		prev, next = c.prev[0], c.next[0]
+	default:
+		return 0
+	}
+	if c.channels == 1 && len(c.prev) > 1 {
+		prev, next = average(c.prev), average(c.next)
+	}
+	return prev + (next-prev)*float32(c.position)
+}
+
+func average(samples []float32) float32 {
+	var sum float32
+	for _, sample := range samples {
+		sum += sample
+	}
+	return sum / float32(len(samples))
+}
+
+func normalizeEOF(err error) error {
+	if err == io.ErrUnexpectedEOF {
+		return io.EOF
+	}
+	return err
+}
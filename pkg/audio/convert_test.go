@@ -0,0 +1,141 @@
+package audio
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+type testStream struct {
+	*bytes.Reader
+	sampleRate int
+	channels   int
+}
+
+func newTestStream(
+	sampleRate int,
+	channels int,
+	samples ...float32,
+) *testStream {
+	return &testStream{
+		Reader:     bytes.NewReader(float32Bytes(samples...)),
+		sampleRate: sampleRate,
+		channels:   channels,
+	}
+}
+
+func (s *testStream) SampleRate() int {
+	return s.sampleRate
+}
+
+func (s *testStream) Channels() int {
+	return s.channels
+}
+
+func TestConvert(t *testing.T) {
+	for _, tc := range []struct {
+		Name       string
+		Source     *testStream
+		SampleRate int
+		Channels   int
+		Expected   []float32
+	}{
+		{
+			Name:       "mono to stereo",
+			Source:     newTestStream(8000, 1, 0.1, 0.2, 0.3),
+			SampleRate: 8000,
+			Channels:   2,
+			Expected:   []float32{0.1, 0.1, 0.2, 0.2, 0.3, 0.3},
+		},
+		{
+			Name:       "stereo to mono",
+			Source:     newTestStream(8000, 2, 0.2, 0.4, -1, 1),
+			SampleRate: 8000,
+			Channels:   1,
+			Expected:   []float32{0.3, 0},
+		},
+		{
+			Name:       "upsampling",
+			Source:     newTestStream(4000, 1, 0, 1, 0),
+			SampleRate: 8000,
+			Channels:   1,
+			Expected:   []float32{0, 0.5, 1, 0.5, 0, 0},
+		},
+		{
+			Name:       "downsampling",
+			Source:     newTestStream(8000, 1, 0, 0.25, 0.5, 0.75, 1),
+			SampleRate: 4000,
+			Channels:   1,
+			Expected:   []float32{0, 0.5, 1},
+		},
+		{
+			Name:       "upsampling to stereo",
+			Source:     newTestStream(4000, 1, 0, 1),
+			SampleRate: 8000,
+			Channels:   2,
+			Expected:   []float32{0, 0, 0.5, 0.5, 1, 1, 1, 1},
+		},
+		{
+			Name:       "surround to stereo",
+			Source:     newTestStream(8000, 3, 0.1, 0.2, 0.3),
+			SampleRate: 8000,
+			Channels:   2,
+			Expected:   []float32{0.1, 0.2},
+		},
+		{
+			Name:       "empty",
+			Source:     newTestStream(4000, 1),
+			SampleRate: 8000,
+			Channels:   2,
+			Expected:   []float32{},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			converted := convert(tc.Source, tc.SampleRate, tc.Channels)
+			if converted.SampleRate() != tc.SampleRate || converted.Channels() != tc.Channels {
+				t.Fatalf("expected %dHz with %d channels, got %dHz with %d channels",
+					tc.SampleRate, tc.Channels, converted.SampleRate(), converted.Channels())
+			}
+			samples := readSamples(t, converted)
+			if len(samples) != len(tc.Expected) {
+				t.Fatalf("expected %v, got %v", tc.Expected, samples)
+			}
+			for idx := range samples {
+				if math.Abs(float64(samples[idx]-tc.Expected[idx])) > 1e-6 {
+					t.Fatalf("expected %v, got %v", tc.Expected, samples)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertSameFormat(t *testing.T) {
+	source := newTestStream(playerSampleRate, playerChannels, 0.5, -0.5)
+	if converted := convert(source, playerSampleRate, playerChannels); converted != Stream(source) {
+		t.Fatalf("expected the stream of the same format to be returned as is")
+	}
+}
+
+func TestConvertSmallReads(t *testing.T) {
+	source := newTestStream(4000, 1, 0, 1, 0)
+	converted := convert(source, 8000, 2)
+	var data []byte
+	buf := make([]byte, 2*bytesPerSample+1)
+	for {
+		n, err := converted.Read(buf)
+		data = append(data, buf[:n]...)
+		if err != nil {
+			break
+		}
+	}
+	samples := readSamples(t, bytes.NewReader(data))
+	expected := []float32{0, 0, 0.5, 0.5, 1, 1, 0.5, 0.5, 0, 0, 0, 0}
+	if len(samples) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, samples)
+	}
+	for idx := range samples {
+		if samples[idx] != expected[idx] {
+			t.Fatalf("expected %v, got %v", expected, samples)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatUnknown   = Format("")
+	FormatOggVorbis = Format("ogg")
+	FormatMP3       = Format("mp3")
+	FormatWAV       = Format("wav")
+)
+
+type Decoder interface {
+	NewStream(r io.Reader) (Stream, error)
+}
+
+type DecoderFunc func(r io.Reader) (Stream, error)
+
+func (fn DecoderFunc) NewStream(r io.Reader) (Stream, error) {
+	return fn(r)
+}
+
+var Decoders = map[Format]Decoder{
+	FormatOggVorbis: DecoderFunc(func(r io.Reader) (Stream, error) { return NewOggVorbisStream(r) }),
+	FormatMP3:       DecoderFunc(func(r io.Reader) (Stream, error) { return NewMP3Stream(r) }),
+	FormatWAV:       DecoderFunc(func(r io.Reader) (Stream, error) { return NewWAVStream(r) }),
+}
+
+func FormatFromExtension(fileName string) Format {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".ogg", ".oga":
+		return FormatOggVorbis
+	case ".mp3":
+		return FormatMP3
+	case ".wav", ".wave":
+		return FormatWAV
+	default:
+		return FormatUnknown
+	}
+}
+
+func FormatFromMagic(header []byte) Format {
+	switch {
+	case bytes.HasPrefix(header, []byte("OggS")):
+		return FormatOggVorbis
+	case len(header) >= 12 && bytes.Equal(header[0:4], []byte("RIFF")) && bytes.Equal(header[8:12], []byte("WAVE")):
+		return FormatWAV
+	case bytes.HasPrefix(header, []byte("ID3")):
+		return FormatMP3
+	case len(header) >= 2 && header[0] == 0xff && header[1]&0xe0 == 0xe0:
+		return FormatMP3
+	default:
+		return FormatUnknown
+	}
+}
+
+// NewStream selects a decoder by the magic bytes of the content, falling
+// back to the extension of fileName if the content is not recognized.
+func NewStream(
+	r io.Reader,
+	fileName string,
+) (Stream, error) {
+	br := bufio.NewReader(r)
+	header, _ := br.Peek(12)
+	format := FormatFromMagic(header)
+	if format == FormatUnknown {
+		format = FormatFromExtension(fileName)
+	}
+	decoder, ok := Decoders[format]
+	if !ok {
+		return nil, fmt.Errorf("unable to detect the audio format of '%s'", fileName)
+	}
+	return decoder.NewStream(br)
+}
+
+func OpenFile(path string) (Stream, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read '%s': %w", path, err)
+	}
+	return NewStream(bytes.NewReader(content), path)
+}
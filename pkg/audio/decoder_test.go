@@ -0,0 +1,187 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+)
+
+// newWAV builds a WAV file of the raw samples.
+func newWAV(
+	audioFormat uint16,
+	channels uint16,
+	sampleRate uint32,
+	bitsPerSample uint16,
+	data []byte,
+) []byte {
+	var b bytes.Buffer
+	b.WriteString("RIFF")
+	binary.Write(&b, binary.LittleEndian, uint32(4+8+16+8+len(data)))
+	b.WriteString("WAVE")
+	b.WriteString("fmt ")
+	binary.Write(&b, binary.LittleEndian, uint32(16))
+	blockAlign := channels * bitsPerSample / 8
+	binary.Write(&b, binary.LittleEndian, wavFormat{
+		AudioFormat:   audioFormat,
+		Channels:      channels,
+		SampleRate:    sampleRate,
+		ByteRate:      sampleRate * uint32(blockAlign),
+		BlockAlign:    blockAlign,
+		BitsPerSample: bitsPerSample,
+	})
+	b.WriteString("data")
+	binary.Write(&b, binary.LittleEndian, uint32(len(data)))
+	b.Write(data)
+	return b.Bytes()
+}
+
+// readSamples reads the whole stream as float32 samples.
+func readSamples(t *testing.T, stream io.Reader) []float32 {
+	t.Helper()
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("unable to read the stream: %v", err)
+	}
+	if len(data)%bytesPerSample != 0 {
+		t.Fatalf("the stream of %d bytes is not aligned to the samples", len(data))
+	}
+	samples := make([]float32, len(data)/bytesPerSample)
+	for idx := range samples {
+		samples[idx] = math.Float32frombits(binary.LittleEndian.Uint32(data[idx*bytesPerSample:]))
+	}
+	return samples
+}
+
+func float32Bytes(samples ...float32) []byte {
+	b := make([]byte, len(samples)*bytesPerSample)
+	for idx, sample := range samples {
+		binary.LittleEndian.PutUint32(b[idx*bytesPerSample:], math.Float32bits(sample))
+	}
+	return b
+}
+
+func TestWAVStream(t *testing.T) {
+	for _, tc := range []struct {
+		Name          string
+		AudioFormat   uint16
+		BitsPerSample uint16
+		Data          []byte
+		Expected      []float32
+	}{
+		{
+			Name:          "8-bit",
+			AudioFormat:   wavFormatPCM,
+			BitsPerSample: 8,
+			Data:          []byte{0, 128, 192, 255},
+			Expected:      []float32{-1, 0, 0.5, 127.0 / 128},
+		},
+		{
+			Name:          "16-bit",
+			AudioFormat:   wavFormatPCM,
+			BitsPerSample: 16,
+			Data:          []byte{0x00, 0x80, 0x00, 0x00, 0x00, 0x40, 0xff, 0x7f},
+			Expected:      []float32{-1, 0, 0.5, 32767.0 / 32768},
+		},
+		{
+			Name:          "24-bit",
+			AudioFormat:   wavFormatPCM,
+			BitsPerSample: 24,
+			Data:          []byte{0x00, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc0},
+			Expected:      []float32{-1, 0, -0.5},
+		},
+		{
+			Name:          "32-bit",
+			AudioFormat:   wavFormatPCM,
+			BitsPerSample: 32,
+			Data:          []byte{0x00, 0x00, 0x00, 0x80, 0x00, 0x00, 0x00, 0x40},
+			Expected:      []float32{-1, 0.5},
+		},
+		{
+			Name:          "float",
+			AudioFormat:   wavFormatIEEEFloat,
+			BitsPerSample: 32,
+			Data:          float32Bytes(0.25, -0.75),
+			Expected:      []float32{0.25, -0.75},
+		},
+		{
+			Name:          "truncated",
+			AudioFormat:   wavFormatPCM,
+			BitsPerSample: 16,
+			Data:          []byte{0x00, 0x40, 0x00},
+			Expected:      []float32{0.5},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			stream, err := NewStream(bytes.NewReader(newWAV(tc.AudioFormat, 1, 8000, tc.BitsPerSample, tc.Data)), "")
+			if err != nil {
+				t.Fatalf("unable to open the stream: %v", err)
+			}
+			if stream.SampleRate() != 8000 || stream.Channels() != 1 {
+				t.Fatalf("expected 8000Hz mono, got %dHz with %d channels", stream.SampleRate(), stream.Channels())
+			}
+			samples := readSamples(t, stream)
+			if len(samples) != len(tc.Expected) {
+				t.Fatalf("expected %v, got %v", tc.Expected, samples)
+			}
+			for idx := range samples {
+				if math.Abs(float64(samples[idx]-tc.Expected[idx])) > 1e-6 {
+					t.Fatalf("expected %v, got %v", tc.Expected, samples)
+				}
+			}
+		})
+	}
+}
+
+func TestWAVStreamErrors(t *testing.T) {
+	for _, tc := range []struct {
+		Name string
+		Data []byte
+	}{
+		{Name: "empty"},
+		{Name: "not RIFF", Data: []byte("RIFX\x00\x00\x00\x00WAVEfmt ")},
+		{Name: "ADPCM", Data: newWAV(0x0002, 1, 8000, 4, []byte{0})},
+		{Name: "12-bit", Data: newWAV(wavFormatPCM, 1, 8000, 12, []byte{0, 0})},
+		{Name: "64-bit float", Data: newWAV(wavFormatIEEEFloat, 1, 8000, 64, make([]byte, 8))},
+		{Name: "no fmt", Data: []byte("RIFF\x00\x00\x00\x00WAVEdata\x00\x00\x00\x00")},
+		{Name: "no data", Data: []byte("RIFF\x00\x00\x00\x00WAVEJUNK\x02\x00\x00\x00ab")},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			if _, err := NewWAVStream(bytes.NewReader(tc.Data)); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestFormatDetection(t *testing.T) {
+	for _, tc := range []struct {
+		Header   []byte
+		FileName string
+		Expected Format
+	}{
+		{Header: []byte("OggS\x00\x02"), FileName: "alarm.mp3", Expected: FormatOggVorbis},
+		{Header: []byte("RIFF\x24\x00\x00\x00WAVE"), FileName: "", Expected: FormatWAV},
+		{Header: []byte("RIFF\x24\x00\x00\x00AVI "), FileName: "", Expected: FormatUnknown},
+		{Header: []byte("ID3\x04"), FileName: "", Expected: FormatMP3},
+		{Header: []byte{0xff, 0xfb, 0x90}, FileName: "", Expected: FormatMP3},
+		{Header: []byte("garbage"), FileName: "bell.OGA", Expected: FormatOggVorbis},
+		{Header: []byte("garbage"), FileName: "bell.wave", Expected: FormatWAV},
+		{Header: []byte("garbage"), FileName: "bell.Mp3", Expected: FormatMP3},
+		{Header: []byte("garbage"), FileName: "bell.flac", Expected: FormatUnknown},
+		{Header: nil, FileName: "", Expected: FormatUnknown},
+	} {
+		format := FormatFromMagic(tc.Header)
+		if format == FormatUnknown {
+			format = FormatFromExtension(tc.FileName)
+		}
+		if format != tc.Expected {
+			t.Fatalf("expected '%s' for %q (%s), got '%s'", tc.Expected, tc.Header, tc.FileName, format)
+		}
+	}
+
+	if _, err := NewStream(bytes.NewReader([]byte("garbage")), "bell.flac"); err == nil {
+		t.Fatalf("expected an error for an unknown format")
+	}
+}
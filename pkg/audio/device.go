@@ -0,0 +1,20 @@
+package audio
+
+// Device is an audio output.
+type Device struct {
+	// ID is what is stored in the settings, it is stable across restarts.
+	ID string
+
+	// Description is a human-readable name of the device.
+	Description string
+}
+
+// SetDevice selects the output device by its ID (see OutputDevices), an
+// empty ID means the default device. The device is applied when the
+// audio context is initialized (see Prepare), so changing it
+// afterwards requires a restart.
+func (p *Player) SetDevice(id string) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.device = id
+}
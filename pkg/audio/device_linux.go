@@ -0,0 +1,73 @@
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	// pulseSinkEnvVar selects the sink for the PulseAudio clients (including
+	// the ALSA plugin, which is the default ALSA device on the systems with
+	// PulseAudio or PipeWire).
+	pulseSinkEnvVar = "PULSE_SINK"
+)
+
+// OutputDevices lists the PulseAudio (or PipeWire) sinks.
+func OutputDevices() ([]Device, error) {
+	output, err := exec.Command("pactl", "list", "short", "sinks").Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the sinks via 'pactl': %w", err)
+	}
+
+	var devices []Device
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		// index, name, driver, sample spec, state
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		devices = append(devices, Device{
+			ID:          fields[1],
+			Description: fields[1],
+		})
+	}
+	return devices, scanner.Err()
+}
+
+// applyDevice makes the audio context (created afterwards) use the device,
+// falling back to the default one if the device is not available.
+// The sink is selected via the environment, so restore should be called
+// once the context has opened the device: otherwise the child processes
+// (hooks, the text-to-speech, etc) would inherit it.
+func applyDevice(id string) (restore func()) {
+	if id == "" {
+		return func() {}
+	}
+	devices, err := OutputDevices()
+	if err != nil {
+		slog.Warn("unable to check the audio device, using the default one", "device", id, "error", err)
+		return func() {}
+	}
+	for _, device := range devices {
+		if device.ID != id {
+			continue
+		}
+		prev, wasSet := os.LookupEnv(pulseSinkEnvVar)
+		os.Setenv(pulseSinkEnvVar, id)
+		return func() {
+			if wasSet {
+				os.Setenv(pulseSinkEnvVar, prev)
+			} else {
+				os.Unsetenv(pulseSinkEnvVar)
+			}
+		}
+	}
+	slog.Warn("the audio device is not available, using the default one", "device", id)
+	return func() {}
+}
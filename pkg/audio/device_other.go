@@ -0,0 +1,20 @@
+//go:build !linux
+
+package audio
+
+import (
+	"log/slog"
+)
+
+// OutputDevices lists the output devices, the selection
+// is not supported on this platform yet.
+func OutputDevices() ([]Device, error) {
+	return nil, nil
+}
+
+func applyDevice(id string) (restore func()) {
+	if id != "" {
+		slog.Warn("selecting the audio device is not supported on this platform, using the default one")
+	}
+	return func() {}
+}
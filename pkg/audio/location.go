@@ -0,0 +1,105 @@
+package audio
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IsURL checks if the location is an HTTP(S) URL rather than a file path.
+func IsURL(location string) bool {
+	u, err := url.Parse(location)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
+
+// Open opens a file or starts downloading an URL (for example,
+// an internet radio stream). The returned Closer should be closed
+// after the playback.
+func Open(
+	ctx context.Context,
+	location string,
+) (Stream, io.Closer, error) {
+	if !IsURL(location) {
+		stream, err := OpenFile(location)
+		if err != nil {
+			return nil, nil, err
+		}
+		return stream, io.NopCloser(nil), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create a request to '%s': %w", location, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to request '%s': %w", location, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, nil, fmt.Errorf("unable to request '%s': %s", location, resp.Status)
+	}
+	stream, err := NewStream(resp.Body, location)
+	if err != nil {
+		resp.Body.Close()
+		return nil, nil, err
+	}
+	return stream, resp.Body, nil
+}
+
+// ExpandPlaylist replaces the local M3U playlists within the locations
+// with their entries (relative paths are resolved against the playlist).
+func ExpandPlaylist(locations []string) []string {
+	var result []string
+	for _, location := range locations {
+		switch strings.ToLower(filepath.Ext(location)) {
+		case ".m3u", ".m3u8":
+		default:
+			result = append(result, location)
+			continue
+		}
+		if IsURL(location) {
+			result = append(result, location)
+			continue
+		}
+		entries, err := readM3U(location)
+		if err != nil {
+			// let it fail on playback, so that the error is reported
+			result = append(result, location)
+			continue
+		}
+		result = append(result, entries...)
+	}
+	return result
+}
+
+func readM3U(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open '%s': %w", path, err)
+	}
+	defer f.Close()
+
+	var entries []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !IsURL(line) && !filepath.IsAbs(line) {
+			line = filepath.Join(filepath.Dir(path), line)
+		}
+		entries = append(entries, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read '%s': %w", path, err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,130 @@
+package audio
+
+import (
+	"sync"
+)
+
+// Channel is a kind of the played sounds, mixed by Mixer.
+type Channel string
+
+const (
+	// ChannelNone is not mixed: its sounds are played at the volume
+	// requested by the caller (for example, a preview in the settings).
+	ChannelNone      = Channel("")
+	ChannelAlarm     = Channel("alarm")
+	ChannelCountdown = Channel("countdown")
+	ChannelAmbience  = Channel("ambience")
+	ChannelMusic     = Channel("music")
+)
+
+// Channels are the mixed channels.
+var Channels = []Channel{ChannelAlarm, ChannelCountdown, ChannelAmbience, ChannelMusic}
+
+// isDucking returns true if the channel lowers the ducked ones
+// while it plays (see Mixer.SetDucking).
+func (c Channel) isDucking() bool {
+	return c == ChannelAlarm
+}
+
+// isDucked returns true if the channel is lowered while
+// a ducking one plays.
+func (c Channel) isDucked() bool {
+	return c == ChannelAmbience || c == ChannelMusic
+}
+
+// Mixer keeps the volume of each channel, which may also be muted; and
+// lowers the background channels (the ambience and the music) while the
+// alarm plays. The playbacks of Player pick up the changes within
+// volumeRefreshInterval.
+type Mixer struct {
+	locker  sync.Mutex
+	volumes map[Channel]float64
+	muted   map[Channel]bool
+	ducking float64
+	playing map[Channel]int
+}
+
+func NewMixer() *Mixer {
+	return &Mixer{
+		volumes: map[Channel]float64{},
+		muted:   map[Channel]bool{},
+		ducking: 1,
+		playing: map[Channel]int{},
+	}
+}
+
+// SetVolume sets the volume of the channel, from 0 to 1 (the default).
+func (m *Mixer) SetVolume(
+	channel Channel,
+	volume float64,
+) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	m.volumes[channel] = min(max(volume, 0), 1)
+}
+
+func (m *Mixer) SetMuted(
+	channel Channel,
+	muted bool,
+) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	m.muted[channel] = muted
+}
+
+func (m *Mixer) IsMuted(channel Channel) bool {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	return m.muted[channel]
+}
+
+// SetDucking sets the level the background channels are lowered to
+// while the alarm plays: 1 disables the ducking, 0 silences them.
+func (m *Mixer) SetDucking(level float64) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	m.ducking = min(max(level, 0), 1)
+}
+
+// Level returns the factor to apply to the volume of a playback
+// on the channel.
+func (m *Mixer) Level(channel Channel) float64 {
+	if channel == ChannelNone {
+		return 1
+	}
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	if m.muted[channel] {
+		return 0
+	}
+	level, ok := m.volumes[channel]
+	if !ok {
+		level = 1
+	}
+	if channel.isDucked() && m.isDucking() {
+		level *= m.ducking
+	}
+	return level
+}
+
+func (m *Mixer) isDucking() bool {
+	for channel, count := range m.playing {
+		if count > 0 && channel.isDucking() {
+			return true
+		}
+	}
+	return false
+}
+
+// begin and end track the playbacks on the channel (for the ducking).
+func (m *Mixer) begin(channel Channel) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	m.playing[channel]++
+}
+
+func (m *Mixer) end(channel Channel) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	m.playing[channel]--
+}
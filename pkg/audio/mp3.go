@@ -0,0 +1,43 @@
+package audio
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hajimehoshi/go-mp3"
+)
+
+const (
+	mp3Channels      = 2
+	mp3BitsPerSample = 16
+)
+
+type MP3Stream struct {
+	*float32Encoder
+	decoder *mp3.Decoder
+}
+
+var _ Stream = (*MP3Stream)(nil)
+
+func NewMP3Stream(r io.Reader) (*MP3Stream, error) {
+	decoder, err := mp3.NewDecoder(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize a decoder of the mp3 audio: %w", err)
+	}
+	samplesReader, err := newPCMSamplesReader(decoder, mp3BitsPerSample, false)
+	if err != nil {
+		return nil, err
+	}
+	return &MP3Stream{
+		float32Encoder: newFloat32Encoder(samplesReader, 4096*mp3Channels),
+		decoder:        decoder,
+	}, nil
+}
+
+func (s *MP3Stream) SampleRate() int {
+	return s.decoder.SampleRate()
+}
+
+func (s *MP3Stream) Channels() int {
+	return mp3Channels
+}
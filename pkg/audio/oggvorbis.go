@@ -0,0 +1,34 @@
+package audio
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jfreymuth/oggvorbis"
+)
+
+type OggVorbisStream struct {
+	*float32Encoder
+	decoder *oggvorbis.Reader
+}
+
+var _ Stream = (*OggVorbisStream)(nil)
+
+func NewOggVorbisStream(r io.Reader) (*OggVorbisStream, error) {
+	decoder, err := oggvorbis.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize a decoder of the ogg vorbis audio: %w", err)
+	}
+	return &OggVorbisStream{
+		float32Encoder: newFloat32Encoder(decoder, 4096*decoder.Channels()),
+		decoder:        decoder,
+	}, nil
+}
+
+func (s *OggVorbisStream) SampleRate() int {
+	return s.decoder.SampleRate()
+}
+
+func (s *OggVorbisStream) Channels() int {
+	return s.decoder.Channels()
+}
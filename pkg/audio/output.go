@@ -0,0 +1,56 @@
+package audio
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ebitengine/oto/v3"
+)
+
+// output is where Player sends the sound, it is an oto context
+// (see openOtoOutput) everywhere except the tests.
+type output interface {
+	NewPlayer(r io.Reader) outputPlayer
+	Resume() error
+	Suspend() error
+}
+
+// outputPlayer plays a single reader of an output, see oto.Player.
+type outputPlayer interface {
+	Play()
+	Pause()
+	IsPlaying() bool
+	Reset()
+	SetVolume(volume float64)
+	BufferedSize() int
+	Err() error
+	Close() error
+}
+
+var _ outputPlayer = (*oto.Player)(nil)
+
+type otoOutput struct {
+	*oto.Context
+}
+
+// openOtoOutput creates the oto context on the device (see SetDevice)
+// and waits until it is ready.
+func openOtoOutput(device string) (output, error) {
+	// the device is opened by the time the context is ready
+	restoreEnv := applyDevice(device)
+	defer restoreEnv()
+	otoCtx, readyChan, err := oto.NewContext(&oto.NewContextOptions{
+		SampleRate:   playerSampleRate,
+		ChannelCount: playerChannels,
+		Format:       oto.FormatFloat32LE,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize an oto context: %w", err)
+	}
+	<-readyChan
+	return otoOutput{Context: otoCtx}, nil
+}
+
+func (o otoOutput) NewPlayer(r io.Reader) outputPlayer {
+	return o.Context.NewPlayer(r)
+}
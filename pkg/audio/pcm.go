@@ -0,0 +1,70 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// pcmSamplesReader converts integer (or float32) little-endian PCM into
+// float32 samples.
+type pcmSamplesReader struct {
+	reader         io.Reader
+	bytesPerSample int
+	isFloat        bool
+	buf            []byte
+}
+
+func newPCMSamplesReader(
+	r io.Reader,
+	bitsPerSample int,
+	isFloat bool,
+) (*pcmSamplesReader, error) {
+	switch {
+	case isFloat && bitsPerSample != 32:
+		return nil, fmt.Errorf("float PCM with %d bits per sample is not supported", bitsPerSample)
+	case bitsPerSample != 8 && bitsPerSample != 16 && bitsPerSample != 24 && bitsPerSample != 32:
+		return nil, fmt.Errorf("PCM with %d bits per sample is not supported", bitsPerSample)
+	}
+	return &pcmSamplesReader{
+		reader:         r,
+		bytesPerSample: bitsPerSample / 8,
+		isFloat:        isFloat,
+	}, nil
+}
+
+func (r *pcmSamplesReader) Read(samples []float32) (int, error) {
+	size := len(samples) * r.bytesPerSample
+	if cap(r.buf) < size {
+		r.buf = make([]byte, size)
+	}
+	buf := r.buf[:size]
+	n, err := io.ReadFull(r.reader, buf)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	count := n / r.bytesPerSample
+	for idx := 0; idx < count; idx++ {
+		samples[idx] = r.decodeSample(buf[idx*r.bytesPerSample:])
+	}
+	return count, err
+}
+
+func (r *pcmSamplesReader) decodeSample(b []byte) float32 {
+	switch r.bytesPerSample {
+	case 1:
+		return (float32(b[0]) - 128) / 128
+	case 2:
+		return float32(int16(binary.LittleEndian.Uint16(b))) / (1 << 15)
+	case 3:
+		v := int32(b[0]) | int32(b[1])<<8 | int32(int8(b[2]))<<16
+		return float32(v) / (1 << 23)
+	default:
+		if r.isFloat {
+			return math.Float32frombits(binary.LittleEndian.Uint32(b))
+		}
+		return float32(int32(binary.LittleEndian.Uint32(b))) / (1 << 31)
+	}
+}
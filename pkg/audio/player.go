@@ -0,0 +1,368 @@
+package audio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	playerSampleRate = 48000
+	playerChannels   = 2
+	bytesPerSecond   = playerSampleRate * playerChannels * 4 // float32 samples
+
+	// volumeRefreshInterval is how often the volume of a playback
+	// is re-requested (see PlayWithVolume).
+	volumeRefreshInterval = 100 * time.Millisecond
+
+	// maxIdlePlayers is how many finished players are kept for reuse.
+	maxIdlePlayers = 4
+)
+
+// ErrUnavailable is returned if there is no audio output (for example,
+// in a container or on a headless machine), see Player.Err.
+var ErrUnavailable = errors.New("no audio output is available")
+
+// Player plays streams through a single audio context (the underlying
+// library allows only one context per process, so there should be only
+// one Player as well). The context is initialized once by Prepare (or by
+// the first playback), and the players of the finished playbacks are
+// reused. The volumes of the playbacks are scaled by Mixer.
+type Player struct {
+	Mixer *Mixer
+
+	locker     sync.Mutex
+	openOutput func(device string) (output, error)
+	output     output
+	initDone   chan struct{}
+	initErr    error
+	playing    map[outputPlayer]struct{}
+	idle       []*pooledPlayer
+	closed     bool
+	closeCh    chan struct{}
+	device     string
+}
+
+func NewPlayer() *Player {
+	return newPlayer(openOtoOutput)
+}
+
+func newPlayer(openOutput func(device string) (output, error)) *Player {
+	return &Player{
+		Mixer:      NewMixer(),
+		openOutput: openOutput,
+		playing:    map[outputPlayer]struct{}{},
+		closeCh:    make(chan struct{}),
+	}
+}
+
+// Prepare starts initializing the audio context in the background,
+// so that the first playback is not delayed. It should be called once
+// the device is set (see SetDevice).
+func (p *Player) Prepare() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.prepare()
+}
+
+func (p *Player) prepare() <-chan struct{} {
+	if p.initDone != nil {
+		return p.initDone
+	}
+	done := make(chan struct{})
+	p.initDone = done
+	device := p.device
+	go func() {
+		defer close(done)
+		output, err := p.openOutput(device)
+
+		p.locker.Lock()
+		defer p.locker.Unlock()
+		if err != nil {
+			p.initErr = fmt.Errorf("%w: %w", ErrUnavailable, err)
+			return
+		}
+		p.output = output
+	}()
+	return done
+}
+
+// Err waits for the audio context to be initialized (see Prepare) and
+// returns the error (wrapping ErrUnavailable) if there is no audio output,
+// all the playbacks fail then.
+func (p *Player) Err(ctx context.Context) error {
+	p.locker.Lock()
+	done := p.prepare()
+	p.locker.Unlock()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+	}
+
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.initErr
+}
+
+func (p *Player) context() (output, error) {
+	p.locker.Lock()
+	done := p.prepare()
+	p.locker.Unlock()
+	<-done
+
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.closed {
+		return nil, fmt.Errorf("the player is closed")
+	}
+	if p.initErr != nil {
+		// not retried: the underlying library allows to create
+		// the context only once, even if it failed
+		return nil, p.initErr
+	}
+	if err := p.output.Resume(); err != nil {
+		return nil, fmt.Errorf("unable to resume the audio output: %w", err)
+	}
+	return p.output, nil
+}
+
+// Play plays the stream on the channel and blocks until the playback
+// is finished, the context is cancelled or the Player is closed.
+func (p *Player) Play(
+	ctx context.Context,
+	channel Channel,
+	stream Stream,
+	volume float64,
+) error {
+	return p.PlayWithVolume(ctx, channel, stream, func() float64 { return volume })
+}
+
+// PlayWithVolume is the same as Play, but the volume is re-requested
+// during the playback (for example, to fade in).
+func (p *Player) PlayWithVolume(
+	ctx context.Context,
+	channel Channel,
+	stream Stream,
+	volume func() float64,
+) error {
+	result := make(chan error, 1)
+	p.Start(ctx, channel, stream, volume, func(err error) {
+		result <- err
+	})
+	return <-result
+}
+
+// Start plays the stream in the background and returns immediately,
+// onDone (if not nil) is called with the result of the playback once it
+// is finished, the context is cancelled or the Player is closed.
+func (p *Player) Start(
+	ctx context.Context,
+	channel Channel,
+	stream Stream,
+	volume func() float64,
+	onDone func(error),
+) {
+	go func() {
+		err := p.play(ctx, channel, stream, volume)
+		if onDone != nil {
+			onDone(err)
+		}
+	}()
+}
+
+func (p *Player) play(
+	ctx context.Context,
+	channel Channel,
+	stream Stream,
+	requestedVolume func() float64,
+) error {
+	output, err := p.context()
+	if err != nil {
+		return err
+	}
+	p.Mixer.begin(channel)
+	defer p.Mixer.end(channel)
+	volume := func() float64 {
+		return requestedVolume() * p.Mixer.Level(channel)
+	}
+
+	source := newEndReader(convert(stream, playerSampleRate, playerChannels))
+	p.locker.Lock()
+	if p.closed {
+		p.locker.Unlock()
+		return fmt.Errorf("the player is closed")
+	}
+	pooled := p.takeIdle()
+	p.locker.Unlock()
+	if pooled == nil {
+		pooled = newPooledPlayer(output)
+	}
+	pooled.source.set(source)
+	player := pooled.player
+
+	p.locker.Lock()
+	p.playing[player] = struct{}{}
+	p.locker.Unlock()
+	defer func() {
+		p.locker.Lock()
+		defer p.locker.Unlock()
+		delete(p.playing, player)
+		p.release(pooled)
+	}()
+
+	player.SetVolume(volume())
+	player.Play()
+	err = p.waitPlayback(ctx, player, source, volume)
+	player.Pause()
+	if err != nil {
+		return err
+	}
+	if err := player.Err(); err != nil {
+		return fmt.Errorf("unable to play the stream: %w", err)
+	}
+	return nil
+}
+
+// waitPlayback waits until the source is read to the end, and then
+// for as long as it takes to play the rest of the buffer.
+func (p *Player) waitPlayback(
+	ctx context.Context,
+	player outputPlayer,
+	source *endReader,
+	volume func() float64,
+) error {
+	volumeTicker := time.NewTicker(volumeRefreshInterval)
+	defer volumeTicker.Stop()
+	var drained <-chan time.Time
+	ended := source.ended
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.closeCh:
+			return fmt.Errorf("the player is closed")
+		case <-volumeTicker.C:
+			player.SetVolume(volume())
+			continue
+		case <-ended:
+			ended = nil
+		case <-drained:
+		}
+		if !player.IsPlaying() {
+			return nil
+		}
+		if ended == nil {
+			buffered := time.Duration(player.BufferedSize()) * time.Second / bytesPerSecond
+			drained = time.After(max(buffered, time.Millisecond))
+		}
+	}
+}
+
+// takeIdle returns a player of a finished playback, if any.
+func (p *Player) takeIdle() *pooledPlayer {
+	if len(p.idle) == 0 {
+		return nil
+	}
+	pooled := p.idle[len(p.idle)-1]
+	p.idle = p.idle[:len(p.idle)-1]
+	return pooled
+}
+
+// release keeps the player for reuse, unless it failed or
+// there are enough idle players already.
+func (p *Player) release(pooled *pooledPlayer) {
+	pooled.source.set(nil)
+	if p.closed || pooled.player.Err() != nil || len(p.idle) >= maxIdlePlayers {
+		_ = pooled.player.Close()
+		return
+	}
+	pooled.player.Reset()
+	p.idle = append(p.idle, pooled)
+}
+
+// Close stops all the playbacks and suspends the audio context.
+func (p *Player) Close() error {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	close(p.closeCh)
+	for player := range p.playing {
+		player.Pause()
+	}
+	for _, pooled := range p.idle {
+		_ = pooled.player.Close()
+	}
+	p.idle = nil
+	if p.output == nil {
+		return nil
+	}
+	if err := p.output.Suspend(); err != nil {
+		return fmt.Errorf("unable to suspend the audio output: %w", err)
+	}
+	return nil
+}
+
+// pooledPlayer is a player of the output reading from a replaceable
+// source, so that it could be reused for another stream.
+type pooledPlayer struct {
+	player outputPlayer
+	source *switchReader
+}
+
+func newPooledPlayer(output output) *pooledPlayer {
+	source := &switchReader{}
+	return &pooledPlayer{
+		player: output.NewPlayer(source),
+		source: source,
+	}
+}
+
+type switchReader struct {
+	locker sync.Mutex
+	reader io.Reader
+}
+
+func (r *switchReader) set(reader io.Reader) {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+	r.reader = reader
+}
+
+func (r *switchReader) Read(b []byte) (int, error) {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+	if r.reader == nil {
+		return 0, io.EOF
+	}
+	return r.reader.Read(b)
+}
+
+// endReader closes the channel ended once the reader returns
+// an error (including io.EOF).
+type endReader struct {
+	io.Reader
+	ended   chan struct{}
+	endOnce sync.Once
+}
+
+func newEndReader(r io.Reader) *endReader {
+	return &endReader{
+		Reader: r,
+		ended:  make(chan struct{}),
+	}
+}
+
+func (r *endReader) Read(b []byte) (int, error) {
+	n, err := r.Reader.Read(b)
+	if err != nil {
+		r.endOnce.Do(func() { close(r.ended) })
+	}
+	return n, err
+}
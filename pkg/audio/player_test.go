@@ -0,0 +1,265 @@
+package audio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeOutput plays the streams instantly, keeping what was played.
+type fakeOutput struct {
+	locker    sync.Mutex
+	players   []*fakeOutputPlayer
+	suspended bool
+}
+
+func (o *fakeOutput) NewPlayer(r io.Reader) outputPlayer {
+	o.locker.Lock()
+	defer o.locker.Unlock()
+	player := &fakeOutputPlayer{source: r}
+	o.players = append(o.players, player)
+	return player
+}
+
+func (o *fakeOutput) Resume() error {
+	o.locker.Lock()
+	defer o.locker.Unlock()
+	o.suspended = false
+	return nil
+}
+
+func (o *fakeOutput) Suspend() error {
+	o.locker.Lock()
+	defer o.locker.Unlock()
+	o.suspended = true
+	return nil
+}
+
+func (o *fakeOutput) Players() []*fakeOutputPlayer {
+	o.locker.Lock()
+	defer o.locker.Unlock()
+	return append([]*fakeOutputPlayer{}, o.players...)
+}
+
+type fakeOutputPlayer struct {
+	source io.Reader
+
+	locker    sync.Mutex
+	isPlaying bool
+	played    int // in total, across the resets
+	volume    float64
+	closed    bool
+}
+
+func (p *fakeOutputPlayer) Play() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.isPlaying {
+		return
+	}
+	p.isPlaying = true
+	go p.drain()
+}
+
+// drain reads the source like the audio device does, until the end
+// or a pause.
+func (p *fakeOutputPlayer) drain() {
+	buf := make([]byte, 4096)
+	for {
+		p.locker.Lock()
+		if !p.isPlaying {
+			p.locker.Unlock()
+			return
+		}
+		p.locker.Unlock()
+
+		n, err := p.source.Read(buf)
+
+		p.locker.Lock()
+		p.played += n
+		if err != nil {
+			p.isPlaying = false
+		}
+		p.locker.Unlock()
+		if err != nil {
+			return
+		}
+		time.Sleep(time.Microsecond)
+	}
+}
+
+func (p *fakeOutputPlayer) Pause() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.isPlaying = false
+}
+
+func (p *fakeOutputPlayer) IsPlaying() bool {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.isPlaying
+}
+
+func (p *fakeOutputPlayer) Reset() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.isPlaying = false
+}
+
+func (p *fakeOutputPlayer) SetVolume(volume float64) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.volume = volume
+}
+
+func (p *fakeOutputPlayer) BufferedSize() int {
+	return 0
+}
+
+func (p *fakeOutputPlayer) Err() error {
+	return nil
+}
+
+func (p *fakeOutputPlayer) Close() error {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.closed = true
+	return nil
+}
+
+func (p *fakeOutputPlayer) Stats() (played int, volume float64, closed bool) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.played, p.volume, p.closed
+}
+
+func newFakePlayer(t *testing.T) (*Player, *fakeOutput) {
+	t.Helper()
+	fake := &fakeOutput{}
+	p := newPlayer(func(string) (output, error) { return fake, nil })
+	t.Cleanup(func() { p.Close() })
+	return p, fake
+}
+
+// endlessStream is silence, which never ends.
+type endlessStream struct{}
+
+func (endlessStream) Read(b []byte) (int, error) {
+	clear(b)
+	return len(b) / bytesPerSample * bytesPerSample, nil
+}
+
+func (endlessStream) SampleRate() int {
+	return playerSampleRate
+}
+
+func (endlessStream) Channels() int {
+	return playerChannels
+}
+
+func TestPlayerPlay(t *testing.T) {
+	p, output := newFakePlayer(t)
+	p.Mixer.SetVolume(ChannelAlarm, 0.5)
+	sample := NewToneSample(440, 100*time.Millisecond)
+
+	if err := p.Play(context.Background(), ChannelAlarm, sample.Stream(), 0.8); err != nil {
+		t.Fatalf("unable to play: %v", err)
+	}
+	players := output.Players()
+	if len(players) != 1 {
+		t.Fatalf("expected one player, got %d", len(players))
+	}
+	played, volume, _ := players[0].Stats()
+	if played != len(sample.data) {
+		t.Fatalf("expected %d bytes to be played, got %d", len(sample.data), played)
+	}
+	if volume != 0.8*0.5 {
+		t.Fatalf("expected the volume %v, got %v", 0.8*0.5, volume)
+	}
+}
+
+func TestPlayerConvertsStreams(t *testing.T) {
+	p, output := newFakePlayer(t)
+	stream := newTestStream(playerSampleRate/2, 1, make([]float32, 100)...)
+	if err := p.Play(context.Background(), ChannelNone, stream, 1); err != nil {
+		t.Fatalf("unable to play: %v", err)
+	}
+	played, _, _ := output.Players()[0].Stats()
+	if expected := 200 * playerChannels * bytesPerSample; played != expected {
+		t.Fatalf("expected %d bytes to be played, got %d", expected, played)
+	}
+}
+
+func TestPlayerReusesPlayers(t *testing.T) {
+	p, output := newFakePlayer(t)
+	for idx := 0; idx < 3; idx++ {
+		if err := p.Play(context.Background(), ChannelNone, NewToneSample(440, 10*time.Millisecond).Stream(), 1); err != nil {
+			t.Fatalf("#%d: unable to play: %v", idx, err)
+		}
+	}
+	if players := output.Players(); len(players) != 1 {
+		t.Fatalf("expected one player for the sequential playbacks, got %d", len(players))
+	}
+}
+
+func TestPlayerCancel(t *testing.T) {
+	p, _ := newFakePlayer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	p.Start(ctx, ChannelMusic, endlessStream{}, func() float64 { return 1 }, func(err error) {
+		result <- err
+	})
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected the cancellation error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("the playback is not stopped by the cancellation")
+	}
+}
+
+func TestPlayerClose(t *testing.T) {
+	p, output := newFakePlayer(t)
+	result := make(chan error, 1)
+	p.Start(context.Background(), ChannelMusic, endlessStream{}, func() float64 { return 1 }, func(err error) {
+		result <- err
+	})
+	for len(output.Players()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("unable to close: %v", err)
+	}
+	select {
+	case err := <-result:
+		if err == nil {
+			t.Fatalf("expected an error for the playback interrupted by Close")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("the playback is not stopped by Close")
+	}
+	if !output.suspended {
+		t.Fatalf("expected the output to be suspended")
+	}
+	if err := p.Play(context.Background(), ChannelNone, NewToneSample(440, 10*time.Millisecond).Stream(), 1); err == nil {
+		t.Fatalf("expected an error for a playback after Close")
+	}
+}
+
+func TestPlayerUnavailable(t *testing.T) {
+	outputErr := errors.New("no sound card")
+	p := newPlayer(func(string) (output, error) { return nil, outputErr })
+	defer p.Close()
+	if err := p.Err(context.Background()); !errors.Is(err, ErrUnavailable) || !errors.Is(err, outputErr) {
+		t.Fatalf("expected an error wrapping ErrUnavailable and the cause, got %v", err)
+	}
+	if err := p.Play(context.Background(), ChannelNone, NewToneSample(440, 10*time.Millisecond).Stream(), 1); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Sample is a short sound decoded (and converted to the format of Player)
+// beforehand, so it can be played repeatedly and without a delay.
+type Sample struct {
+	data []byte
+}
+
+// Decode reads the whole stream into a Sample.
+func Decode(stream Stream) (*Sample, error) {
+	data, err := io.ReadAll(convert(stream, playerSampleRate, playerChannels))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the stream: %w", err)
+	}
+	return &Sample{data: data}, nil
+}
+
+// NewToneSample synthesizes a decaying sine (like a tick of a clock).
+func NewToneSample(
+	frequency float64,
+	duration time.Duration,
+) *Sample {
+	frames := int(duration.Seconds() * playerSampleRate)
+	data := make([]byte, frames*playerChannels*bytesPerSample)
+	for idx := 0; idx < frames; idx++ {
+		t := float64(idx) / playerSampleRate
+		decay := math.Exp(-5 * float64(idx) / float64(frames))
+		value := float32(math.Sin(2*math.Pi*frequency*t) * decay)
+		for channel := 0; channel < playerChannels; channel++ {
+			offset := (idx*playerChannels + channel) * bytesPerSample
+			binary.LittleEndian.PutUint32(data[offset:], math.Float32bits(value))
+		}
+	}
+	return &Sample{data: data}
+}
+
+// Padded returns the sample followed by silence up to the duration
+// (for example, to loop a tick once a second).
+func (s *Sample) Padded(duration time.Duration) *Sample {
+	size := int(duration.Seconds()*playerSampleRate) * playerChannels * bytesPerSample
+	if size <= len(s.data) {
+		return s
+	}
+	data := make([]byte, size)
+	copy(data, s.data)
+	return &Sample{data: data}
+}
+
+// Stream returns a new stream playing the sample from the beginning.
+func (s *Sample) Stream() Stream {
+	return sampleStream{Reader: bytes.NewReader(s.data)}
+}
+
+type sampleStream struct {
+	*bytes.Reader
+}
+
+func (sampleStream) SampleRate() int {
+	return playerSampleRate
+}
+
+func (sampleStream) Channels() int {
+	return playerChannels
+}
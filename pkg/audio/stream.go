@@ -0,0 +1,61 @@
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+const (
+	bytesPerSample = 4
+)
+
+// Stream is a source of interleaved float32 little-endian PCM samples.
+type Stream interface {
+	io.Reader
+	SampleRate() int
+	Channels() int
+}
+
+type floatSamplesReader interface {
+	Read(p []float32) (int, error)
+}
+
+// float32Encoder converts float32 samples into little-endian bytes on demand,
+// decoding only as much as the caller asks for.
+type float32Encoder struct {
+	source  floatSamplesReader
+	samples []float32
+	encoded []byte
+	pending []byte
+	err     error
+}
+
+func newFloat32Encoder(
+	source floatSamplesReader,
+	bufferSamples int,
+) *float32Encoder {
+	return &float32Encoder{
+		source:  source,
+		samples: make([]float32, bufferSamples),
+		encoded: make([]byte, bufferSamples*bytesPerSample),
+	}
+}
+
+func (e *float32Encoder) Read(b []byte) (int, error) {
+	for len(e.pending) == 0 {
+		if e.err != nil {
+			return 0, e.err
+		}
+		n, err := e.source.Read(e.samples)
+		e.err = err
+		for idx, sample := range e.samples[:n] {
+			binary.LittleEndian.PutUint32(e.encoded[idx*bytesPerSample:], math.Float32bits(sample))
+		}
+		e.pending = e.encoded[:n*bytesPerSample]
+	}
+
+	n := copy(b, e.pending)
+	e.pending = e.pending[n:]
+	return n, nil
+}
@@ -0,0 +1,109 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	wavFormatPCM        = 0x0001
+	wavFormatIEEEFloat  = 0x0003
+	wavFormatExtensible = 0xfffe
+)
+
+type WAVStream struct {
+	*float32Encoder
+	sampleRate int
+	channels   int
+}
+
+var _ Stream = (*WAVStream)(nil)
+
+type wavFormat struct {
+	AudioFormat   uint16
+	Channels      uint16
+	SampleRate    uint32
+	ByteRate      uint32
+	BlockAlign    uint16
+	BitsPerSample uint16
+}
+
+func NewWAVStream(r io.Reader) (*WAVStream, error) {
+	var riffHeader [12]byte
+	if _, err := io.ReadFull(r, riffHeader[:]); err != nil {
+		return nil, fmt.Errorf("unable to read the RIFF header: %w", err)
+	}
+	if string(riffHeader[0:4]) != "RIFF" || string(riffHeader[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("not a RIFF/WAVE file")
+	}
+
+	var format *wavFormat
+	for {
+		var chunkHeader [8]byte
+		if _, err := io.ReadFull(r, chunkHeader[:]); err != nil {
+			return nil, fmt.Errorf("unable to read a chunk header: %w", err)
+		}
+		chunkID := string(chunkHeader[0:4])
+		chunkSize := int64(binary.LittleEndian.Uint32(chunkHeader[4:8]))
+
+		switch chunkID {
+		case "fmt ":
+			chunk := make([]byte, chunkSize)
+			if _, err := io.ReadFull(r, chunk); err != nil {
+				return nil, fmt.Errorf("unable to read the 'fmt ' chunk: %w", err)
+			}
+			if len(chunk) < 16 {
+				return nil, fmt.Errorf("the 'fmt ' chunk is too short: %d", len(chunk))
+			}
+			format = &wavFormat{
+				AudioFormat:   binary.LittleEndian.Uint16(chunk[0:2]),
+				Channels:      binary.LittleEndian.Uint16(chunk[2:4]),
+				SampleRate:    binary.LittleEndian.Uint32(chunk[4:8]),
+				ByteRate:      binary.LittleEndian.Uint32(chunk[8:12]),
+				BlockAlign:    binary.LittleEndian.Uint16(chunk[12:14]),
+				BitsPerSample: binary.LittleEndian.Uint16(chunk[14:16]),
+			}
+			if format.AudioFormat == wavFormatExtensible && len(chunk) >= 26 {
+				format.AudioFormat = binary.LittleEndian.Uint16(chunk[24:26])
+			}
+		case "data":
+			if format == nil {
+				return nil, fmt.Errorf("the 'data' chunk goes before the 'fmt ' chunk")
+			}
+			if format.AudioFormat != wavFormatPCM && format.AudioFormat != wavFormatIEEEFloat {
+				return nil, fmt.Errorf("WAV audio format 0x%04x is not supported", format.AudioFormat)
+			}
+			samplesReader, err := newPCMSamplesReader(
+				io.LimitReader(r, chunkSize),
+				int(format.BitsPerSample),
+				format.AudioFormat == wavFormatIEEEFloat,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &WAVStream{
+				float32Encoder: newFloat32Encoder(samplesReader, 4096*int(format.Channels)),
+				sampleRate:     int(format.SampleRate),
+				channels:       int(format.Channels),
+			}, nil
+		default:
+			if _, err := io.CopyN(io.Discard, r, chunkSize); err != nil {
+				return nil, fmt.Errorf("unable to skip chunk '%s': %w", chunkID, err)
+			}
+		}
+		if chunkSize%2 == 1 && chunkID != "data" {
+			if _, err := io.CopyN(io.Discard, r, 1); err != nil {
+				return nil, fmt.Errorf("unable to skip the padding byte of chunk '%s': %w", chunkID, err)
+			}
+		}
+	}
+}
+
+func (s *WAVStream) SampleRate() int {
+	return s.sampleRate
+}
+
+func (s *WAVStream) Channels() int {
+	return s.channels
+}
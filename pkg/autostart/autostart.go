@@ -0,0 +1,11 @@
+// Package autostart makes the OS start the application on login:
+// an XDG autostart desktop entry on the freedesktop systems, a value
+// of the Run registry key on Windows and a LaunchAgent on macOS.
+package autostart
+
+const (
+	// Name identifies the autostart entry.
+	Name = "pomodoro"
+
+	appID = "center.dx.fynodoro"
+)
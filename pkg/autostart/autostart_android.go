@@ -0,0 +1,20 @@
+package autostart
+
+import (
+	"errors"
+)
+
+// Enable is not supported on Android: the apps are not started on login.
+func Enable(string, ...string) error {
+	return errors.ErrUnsupported
+}
+
+// Disable is a no-op on Android.
+func Disable() error {
+	return nil
+}
+
+// IsEnabled is always false on Android.
+func IsEnabled() bool {
+	return false
+}
@@ -0,0 +1,81 @@
+package autostart
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func agentPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to get the home directory: %w", err)
+	}
+	return filepath.Join(home, "Library", "LaunchAgents", appID+".plist"), nil
+}
+
+// Enable writes the LaunchAgent starting the executable
+// with the arguments on login.
+func Enable(
+	executable string,
+	args ...string,
+) error {
+	path, err := agentPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("unable to create the directory '%s': %w", filepath.Dir(path), err)
+	}
+	var programArgs strings.Builder
+	for _, arg := range append([]string{executable}, args...) {
+		programArgs.WriteString("\t\t<string>")
+		if err := xml.EscapeText(&programArgs, []byte(arg)); err != nil {
+			return fmt.Errorf("unable to escape the argument '%s': %w", arg, err)
+		}
+		programArgs.WriteString("</string>\n")
+	}
+	agent := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>` + appID + `</string>
+	<key>ProgramArguments</key>
+	<array>
+` + programArgs.String() + `	</array>
+	<key>RunAtLoad</key>
+	<true/>
+</dict>
+</plist>
+`
+	if err := os.WriteFile(path, []byte(agent), 0o644); err != nil {
+		return fmt.Errorf("unable to write the LaunchAgent '%s': %w", path, err)
+	}
+	return nil
+}
+
+// Disable removes the LaunchAgent, if any.
+func Disable() error {
+	path, err := agentPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to remove the LaunchAgent '%s': %w", path, err)
+	}
+	return nil
+}
+
+// IsEnabled checks if the LaunchAgent exists.
+func IsEnabled() bool {
+	path, err := agentPath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
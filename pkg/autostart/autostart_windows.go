@@ -0,0 +1,63 @@
+package autostart
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+const runKey = `Software\Microsoft\Windows\CurrentVersion\Run`
+
+// Enable sets the value of the Run registry key of the current user
+// to the command starting the executable with the arguments.
+func Enable(
+	executable string,
+	args ...string,
+) error {
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, runKey, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("unable to open the registry key '%s': %w", runKey, err)
+	}
+	defer key.Close()
+	command := []string{quote(executable)}
+	for _, arg := range args {
+		command = append(command, quote(arg))
+	}
+	if err := key.SetStringValue(Name, strings.Join(command, " ")); err != nil {
+		return fmt.Errorf("unable to set the autostart command: %w", err)
+	}
+	return nil
+}
+
+// Disable removes the value from the Run registry key, if any.
+func Disable() error {
+	key, err := registry.OpenKey(registry.CURRENT_USER, runKey, registry.SET_VALUE)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("unable to open the registry key '%s': %w", runKey, err)
+	}
+	defer key.Close()
+	if err := key.DeleteValue(Name); err != nil && !errors.Is(err, registry.ErrNotExist) {
+		return fmt.Errorf("unable to remove the autostart command: %w", err)
+	}
+	return nil
+}
+
+// IsEnabled checks if the Run registry key has the value.
+func IsEnabled() bool {
+	key, err := registry.OpenKey(registry.CURRENT_USER, runKey, registry.QUERY_VALUE)
+	if err != nil {
+		return false
+	}
+	defer key.Close()
+	_, _, err = key.GetStringValue(Name)
+	return err == nil
+}
+
+func quote(arg string) string {
+	return `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+}
@@ -0,0 +1,84 @@
+//go:build !windows && !darwin && !android
+
+package autostart
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func entryPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to get the configuration directory: %w", err)
+	}
+	return filepath.Join(configDir, "autostart", Name+".desktop"), nil
+}
+
+// Enable writes the desktop entry starting the executable
+// with the arguments into the XDG autostart directory.
+func Enable(
+	executable string,
+	args ...string,
+) error {
+	path, err := entryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("unable to create the directory '%s': %w", filepath.Dir(path), err)
+	}
+	command := []string{quote(executable)}
+	for _, arg := range args {
+		command = append(command, quote(arg))
+	}
+	entry := strings.Join([]string{
+		"[Desktop Entry]",
+		"Type=Application",
+		"Name=Pomodoro",
+		"Exec=" + strings.Join(command, " "),
+		"Icon=" + appID,
+		"Terminal=false",
+		"X-GNOME-Autostart-enabled=true",
+		"",
+	}, "\n")
+	if err := os.WriteFile(path, []byte(entry), 0o644); err != nil {
+		return fmt.Errorf("unable to write the autostart entry '%s': %w", path, err)
+	}
+	return nil
+}
+
+// Disable removes the desktop entry, if any.
+func Disable() error {
+	path, err := entryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to remove the autostart entry '%s': %w", path, err)
+	}
+	return nil
+}
+
+// IsEnabled checks if the desktop entry exists.
+func IsEnabled() bool {
+	path, err := entryPath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// quote quotes the argument of the Exec key according to
+// the Desktop Entry Specification.
+func quote(arg string) string {
+	if !strings.ContainsAny(arg, " \t\n\"'\\><~|&;$*?#()`%=") {
+		return arg
+	}
+	arg = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", `$`, `\$`, `%`, `%%`).Replace(arg)
+	return `"` + arg + `"`
+}
@@ -0,0 +1,204 @@
+// Package blocker keeps the distractions away during the focus sessions:
+// the websites are made unreachable (via the hosts file or a local proxy,
+// see SiteBlocker) and the running applications from the list are reported
+// or terminated.
+package blocker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ProcessCheckInterval is how often the running processes are checked.
+	ProcessCheckInterval = 5 * time.Second
+)
+
+// Config is what to block.
+type Config struct {
+	// Sites are the domains to block, the URLs are reduced to their
+	// domains. The subdomains are blocked only by Proxy: the hosts file
+	// has no wildcards, so HostsFile blocks the domain and its "www.".
+	Sites []string
+
+	// Processes are the names of the executables (case-insensitive,
+	// ".exe" may be omitted).
+	Processes []string
+
+	// KillProcesses terminates the found processes instead of only
+	// reporting them.
+	KillProcesses bool
+}
+
+// SiteBlocker makes the websites unreachable.
+type SiteBlocker interface {
+	Block(sites []string) error
+	Unblock() error
+}
+
+// Blocker applies a Config until it is unblocked.
+type Blocker struct {
+	sites     SiteBlocker
+	onProcess func(process Process, killed bool)
+
+	locker   sync.Mutex
+	blocking bool
+	cancelFn context.CancelFunc
+	done     chan struct{}
+}
+
+// New creates a blocker using sites to block the websites, onProcess
+// is called (from another goroutine) for every found process
+// from the list, once per process unless it is killed; Block and Unblock
+// wait for it to return, so it should not wait for their callers.
+func New(
+	sites SiteBlocker,
+	onProcess func(process Process, killed bool),
+) *Blocker {
+	return &Blocker{
+		sites:     sites,
+		onProcess: onProcess,
+	}
+}
+
+// Block starts blocking, replacing the previous config if any.
+func (b *Blocker) Block(cfg Config) error {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+	b.stopWatching()
+	b.blocking = true
+
+	var result error
+	if len(cfg.Sites) > 0 {
+		if err := b.sites.Block(cfg.Sites); err != nil {
+			result = fmt.Errorf("unable to block the sites: %w", err)
+		}
+	} else if err := b.sites.Unblock(); err != nil {
+		result = fmt.Errorf("unable to unblock the sites: %w", err)
+	}
+	if len(cfg.Processes) > 0 {
+		ctx, cancelFn := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		b.cancelFn, b.done = cancelFn, done
+		go func() {
+			defer close(done)
+			b.watchProcesses(ctx, cfg)
+		}()
+	}
+	return result
+}
+
+// Unblock stops blocking, it is also safe to call to clean up after
+// a crash (the hosts file keeps the blocked sites otherwise).
+func (b *Blocker) Unblock() error {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+	b.stopWatching()
+	b.blocking = false
+	if err := b.sites.Unblock(); err != nil {
+		return fmt.Errorf("unable to unblock the sites: %w", err)
+	}
+	return nil
+}
+
+// IsBlocking returns true between Block and Unblock.
+func (b *Blocker) IsBlocking() bool {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+	return b.blocking
+}
+
+func (b *Blocker) stopWatching() {
+	if b.cancelFn == nil {
+		return
+	}
+	b.cancelFn()
+	<-b.done
+	b.cancelFn, b.done = nil, nil
+}
+
+func (b *Blocker) watchProcesses(
+	ctx context.Context,
+	cfg Config,
+) {
+	ticker := time.NewTicker(ProcessCheckInterval)
+	defer ticker.Stop()
+	reported := map[int]bool{}
+	for {
+		b.checkProcesses(cfg, reported)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *Blocker) checkProcesses(
+	cfg Config,
+	reported map[int]bool,
+) {
+	processes, err := ListProcesses()
+	if err != nil {
+		slog.Error("unable to list the processes", "error", err)
+		return
+	}
+	for _, process := range processes {
+		if process.PID == os.Getpid() || !process.Matches(cfg.Processes) {
+			continue
+		}
+		if cfg.KillProcesses {
+			err := process.Kill()
+			if err == nil {
+				b.onProcess(process, true)
+				continue
+			}
+			slog.Error("unable to kill the process", "process", process.Name, "pid", process.PID, "error", err)
+		}
+		if reported[process.PID] {
+			continue
+		}
+		reported[process.PID] = true
+		b.onProcess(process, false)
+	}
+}
+
+// normalizeSite reduces "https://www.Example.com/path" to "example.com".
+func normalizeSite(site string) (string, error) {
+	site = strings.ToLower(strings.TrimSpace(site))
+	if strings.Contains(site, "://") {
+		u, err := url.Parse(site)
+		if err != nil {
+			return "", fmt.Errorf("unable to parse '%s': %w", site, err)
+		}
+		site = u.Hostname()
+	}
+	site, _, _ = strings.Cut(site, "/")
+	site = strings.TrimPrefix(site, "www.")
+	site = strings.Trim(site, ".")
+	if site == "" || strings.ContainsAny(site, " \t:") {
+		return "", errors.New("not a domain")
+	}
+	return site, nil
+}
+
+// normalizeSites normalizes the sites skipping (and logging) the invalid ones.
+func normalizeSites(sites []string) []string {
+	result := make([]string, 0, len(sites))
+	for _, site := range sites {
+		normalized, err := normalizeSite(site)
+		if err != nil {
+			slog.Warn("skipping the site to block", "site", site, "error", err)
+			continue
+		}
+		result = append(result, normalized)
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package blocker
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	hostsBeginMarker = "# BEGIN pomodoro blocker"
+	hostsEndMarker   = "# END pomodoro blocker"
+)
+
+// HostsFile blocks the sites by resolving them to an unroutable address
+// in the hosts file (changing it usually requires administrator rights).
+// The added lines are marked, so they are removed even after a crash.
+// Only the sites and their "www." subdomains are listed, the other
+// subdomains stay reachable (see Proxy).
+type HostsFile struct {
+	Path string
+
+	locker sync.Mutex
+}
+
+var _ SiteBlocker = (*HostsFile)(nil)
+
+// NewHostsFile creates the blocker changing the file, the hosts file
+// of the system if path is empty.
+func NewHostsFile(path string) *HostsFile {
+	if path == "" {
+		path = DefaultHostsPath()
+	}
+	return &HostsFile{Path: path}
+}
+
+func (h *HostsFile) Block(sites []string) error {
+	h.locker.Lock()
+	defer h.locker.Unlock()
+	content, _, err := h.read()
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	b.WriteString(content)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString(hostsBeginMarker + "\n")
+	for _, site := range normalizeSites(sites) {
+		for _, host := range []string{site, "www." + site} {
+			fmt.Fprintf(&b, "0.0.0.0 %s\n", host)
+			fmt.Fprintf(&b, ":: %s\n", host)
+		}
+	}
+	b.WriteString(hostsEndMarker + "\n")
+	return h.write(b.String())
+}
+
+func (h *HostsFile) Unblock() error {
+	h.locker.Lock()
+	defer h.locker.Unlock()
+	content, marked, err := h.read()
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return nil
+	}
+	return h.write(content)
+}
+
+// read returns the content of the file without the marked lines,
+// marked is true if there were any.
+func (h *HostsFile) read() (content string, marked bool, err error) {
+	b, err := os.ReadFile(h.Path)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to read '%s': %w", h.Path, err)
+	}
+	var (
+		lines  []string
+		inside bool
+	)
+	for _, line := range strings.SplitAfter(string(b), "\n") {
+		switch strings.TrimSpace(line) {
+		case hostsBeginMarker:
+			inside, marked = true, true
+			continue
+		case hostsEndMarker:
+			inside = false
+			continue
+		}
+		if !inside {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, ""), marked, nil
+}
+
+// write replaces the content in place: the hosts file is usually
+// in a directory the user cannot create files in.
+func (h *HostsFile) write(content string) error {
+	if err := os.WriteFile(h.Path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("unable to write '%s' (run with the rights to change it, or use the proxy instead): %w", h.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package blocker
+
+// DefaultHostsPath returns the path of the hosts file of the system.
+func DefaultHostsPath() string {
+	return "/etc/hosts"
+}
@@ -0,0 +1,15 @@
+package blocker
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DefaultHostsPath returns the path of the hosts file of the system.
+func DefaultHostsPath() string {
+	systemRoot := os.Getenv("SystemRoot")
+	if systemRoot == "" {
+		systemRoot = `C:\Windows`
+	}
+	return filepath.Join(systemRoot, "System32", "drivers", "etc", "hosts")
+}
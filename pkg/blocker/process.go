@@ -0,0 +1,43 @@
+package blocker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Process is a running process.
+type Process struct {
+	PID  int
+	Name string
+}
+
+// Matches returns true if the process is one of the named executables
+// (see Config.Processes).
+func (p Process) Matches(names []string) bool {
+	name := normalizeProcessName(p.Name)
+	for _, other := range names {
+		if normalizeProcessName(other) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Process) Kill() error {
+	process, err := os.FindProcess(p.PID)
+	if err != nil {
+		return fmt.Errorf("unable to find the process %d: %w", p.PID, err)
+	}
+	if err := process.Kill(); err != nil {
+		return fmt.Errorf("unable to kill the process %d ('%s'): %w", p.PID, p.Name, err)
+	}
+	return nil
+}
+
+func normalizeProcessName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	return strings.TrimSuffix(name, ".exe")
+}
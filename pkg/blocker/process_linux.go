@@ -0,0 +1,37 @@
+package blocker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ListProcesses returns the running processes named by their executables
+// (or by their commands if the executable is not accessible).
+func ListProcesses() ([]Process, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list '/proc': %w", err)
+	}
+	var result []Process
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		dir := filepath.Join("/proc", entry.Name())
+		name := ""
+		if exe, err := os.Readlink(filepath.Join(dir, "exe")); err == nil {
+			name = filepath.Base(strings.TrimSuffix(exe, " (deleted)"))
+		} else if comm, err := os.ReadFile(filepath.Join(dir, "comm")); err == nil {
+			name = strings.TrimSpace(string(comm))
+		}
+		if name == "" {
+			continue
+		}
+		result = append(result, Process{PID: pid, Name: name})
+	}
+	return result, nil
+}
@@ -0,0 +1,34 @@
+//go:build !linux && !windows
+
+package blocker
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ListProcesses returns the running processes named by their executables.
+func ListProcesses() ([]Process, error) {
+	output, err := exec.Command("ps", "-axo", "pid=,comm=").Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute 'ps': %w", err)
+	}
+	var result []Process
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		pidString, command, ok := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if !ok {
+			continue
+		}
+		pid, err := strconv.Atoi(pidString)
+		if err != nil {
+			continue
+		}
+		result = append(result, Process{PID: pid, Name: filepath.Base(strings.TrimSpace(command))})
+	}
+	return result, nil
+}
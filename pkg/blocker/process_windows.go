@@ -0,0 +1,36 @@
+package blocker
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// ListProcesses returns the running processes named by their executables.
+func ListProcesses() ([]Process, error) {
+	cmd := exec.Command("tasklist", "/fo", "csv", "/nh")
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute 'tasklist': %w", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(string(output))).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the output of 'tasklist': %w", err)
+	}
+	result := make([]Process, 0, len(records))
+	for _, record := range records {
+		if len(record) < 2 {
+			continue
+		}
+		pid, err := strconv.Atoi(record[1])
+		if err != nil {
+			continue
+		}
+		result = append(result, Process{PID: pid, Name: record[0]})
+	}
+	return result, nil
+}
@@ -0,0 +1,139 @@
+package blocker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	proxyDialTimeout = 30 * time.Second
+)
+
+// Proxy is an HTTP(S) proxy refusing the connections to the blocked sites
+// (and their subdomains), for the systems where the hosts file cannot
+// be changed; the browser should be configured to use it.
+type Proxy struct {
+	server  *http.Server
+	forward *httputil.ReverseProxy
+
+	locker sync.RWMutex
+	sites  []string
+}
+
+var _ SiteBlocker = (*Proxy)(nil)
+
+func NewProxy() *Proxy {
+	p := &Proxy{
+		// the requests to a proxy have the absolute URLs already
+		forward: &httputil.ReverseProxy{Rewrite: func(*httputil.ProxyRequest) {}},
+	}
+	p.server = &http.Server{
+		Handler:           p,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return p
+}
+
+// Serve accepts the connections until Close is called.
+func (p *Proxy) Serve(listener net.Listener) error {
+	err := p.server.Serve(listener)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+func (p *Proxy) Close() error {
+	return p.server.Close()
+}
+
+func (p *Proxy) Block(sites []string) error {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.sites = normalizeSites(sites)
+	return nil
+}
+
+func (p *Proxy) Unblock() error {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.sites = nil
+	return nil
+}
+
+func (p *Proxy) isBlocked(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	p.locker.RLock()
+	defer p.locker.RUnlock()
+	for _, site := range p.sites {
+		if host == site || strings.HasSuffix(host, "."+site) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Proxy) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	if p.isBlocked(r.URL.Hostname()) {
+		http.Error(w, "Blocked by the pomodoro timer until the end of the focus session.", http.StatusForbidden)
+		return
+	}
+	if r.Method == http.MethodConnect {
+		p.tunnel(w, r)
+		return
+	}
+	if !r.URL.IsAbs() {
+		http.Error(w, "This is a proxy, configure the browser to use it.", http.StatusBadRequest)
+		return
+	}
+	p.forward.ServeHTTP(w, r)
+}
+
+// tunnel connects the client to the requested address (HTTPS goes this way).
+func (p *Proxy) tunnel(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	ctx, cancelFn := context.WithTimeout(r.Context(), proxyDialTimeout)
+	defer cancelFn()
+	var dialer net.Dialer
+	upstream, err := dialer.DialContext(ctx, "tcp", r.Host)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to connect to '%s': %v", r.Host, err), http.StatusBadGateway)
+		return
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		upstream.Close()
+		http.Error(w, "unable to tunnel the connection", http.StatusInternalServerError)
+		return
+	}
+	client, buffered, err := hijacker.Hijack()
+	if err != nil {
+		upstream.Close()
+		return
+	}
+	if _, err := client.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n")); err != nil {
+		client.Close()
+		upstream.Close()
+		return
+	}
+
+	go func() {
+		// the client may have sent something along with the request
+		io.Copy(upstream, buffered)
+		upstream.Close()
+	}()
+	io.Copy(client, upstream)
+	client.Close()
+}
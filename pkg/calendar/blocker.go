@@ -0,0 +1,103 @@
+package calendar
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	requestTimeout = 30 * time.Second
+
+	// uidSuffix marks the events created by Blocker.
+	uidSuffix = "@pomodoro"
+)
+
+type Timer interface {
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+}
+
+// Blocker creates a "busy" calendar event for every work session and keeps
+// its end in sync with the timer (when it is stopped, resumed or extended).
+type Blocker struct {
+	Provider Provider
+	Summary  string
+
+	current     *Event
+	unsubscribe func()
+}
+
+func NewBlocker(
+	provider Provider,
+	timer Timer,
+) *Blocker {
+	b := &Blocker{
+		Provider: provider,
+		Summary:  "🍅 Focus time",
+	}
+	b.unsubscribe = timer.OnEvent(b.onEvent)
+	return b
+}
+
+// onEvent is called sequentially, so no locking is needed.
+func (b *Blocker) onEvent(ev pomodoro.Event) {
+	if ev.Restored {
+		// the restored phase is not blocked again
+		return
+	}
+	if ev.Phase != pomodoro.PhaseWork {
+		b.current = nil
+		return
+	}
+
+	switch ev.Type {
+	case pomodoro.EventTypePhaseStarted:
+		b.current = &Event{
+			UID:         newUID(),
+			Summary:     b.Summary,
+			Description: "Blocked by the pomodoro timer.",
+			Start:       ev.Time,
+			End:         ev.Deadline,
+			Busy:        true,
+		}
+	case pomodoro.EventTypeResumed, pomodoro.EventTypeTick:
+		if b.current == nil || b.current.End.Equal(ev.Deadline) {
+			return
+		}
+		b.current.End = ev.Deadline
+	case pomodoro.EventTypeStopped:
+		if b.current == nil {
+			return
+		}
+		b.current.End = ev.Time
+	default:
+		return
+	}
+
+	b.put(*b.current)
+	if ev.Type == pomodoro.EventTypeStopped {
+		b.current = nil
+	}
+}
+
+func (b *Blocker) put(event Event) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancelFn()
+	if err := b.Provider.PutEvent(ctx, event); err != nil {
+		slog.Error("unable to block the focus time in the calendar", "error", err)
+	}
+}
+
+func newUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:]) + uidSuffix
+}
+
+func (b *Blocker) Close() {
+	b.unsubscribe()
+}
@@ -0,0 +1,173 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	icalTimeLayout = "20060102T150405Z"
+)
+
+// CalDAV stores events as iCalendar resources in a CalDAV calendar
+// collection (for example, "https://dav.example.com/calendars/me/work/").
+type CalDAV struct {
+	CalendarURL string
+	Username    string
+	Password    string
+	HTTPClient  *http.Client
+}
+
+var (
+	_ Provider = (*CalDAV)(nil)
+	_ Reader   = (*CalDAV)(nil)
+)
+
+func NewCalDAV(
+	calendarURL string,
+	username string,
+	password string,
+) *CalDAV {
+	return &CalDAV{
+		CalendarURL: calendarURL,
+		Username:    username,
+		Password:    password,
+		HTTPClient:  http.DefaultClient,
+	}
+}
+
+func (c *CalDAV) PutEvent(
+	ctx context.Context,
+	event Event,
+) error {
+	url := strings.TrimSuffix(c.CalendarURL, "/") + "/" + event.UID + ".ics"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(formatICalendar(event)))
+	if err != nil {
+		return fmt.Errorf("unable to create a request to '%s': %w", url, err)
+	}
+	req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	if c.Username != "" || c.Password != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send the event to '%s': %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("unexpected status %d from '%s': %s", resp.StatusCode, url, bytes.TrimSpace(respBody))
+	}
+	return nil
+}
+
+const calDAVQuery = `<?xml version="1.0" encoding="utf-8"?>
+<C:calendar-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <D:prop>
+    <C:calendar-data>
+      <C:expand start="%[1]s" end="%[2]s"/>
+    </C:calendar-data>
+  </D:prop>
+  <C:filter>
+    <C:comp-filter name="VCALENDAR">
+      <C:comp-filter name="VEVENT">
+        <C:time-range start="%[1]s" end="%[2]s"/>
+      </C:comp-filter>
+    </C:comp-filter>
+  </C:filter>
+</C:calendar-query>`
+
+type calDAVMultistatus struct {
+	Responses []struct {
+		CalendarData []string `xml:"propstat>prop>calendar-data"`
+	} `xml:"response"`
+}
+
+// Events queries the events overlapping [from, to) with the recurring
+// ones expanded by the server.
+func (c *CalDAV) Events(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) ([]Event, error) {
+	body := fmt.Sprintf(calDAVQuery, from.UTC().Format(icalTimeLayout), to.UTC().Format(icalTimeLayout))
+	req, err := http.NewRequestWithContext(ctx, "REPORT", c.CalendarURL, strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a request to '%s': %w", c.CalendarURL, err)
+	}
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	req.Header.Set("Depth", "1")
+	if c.Username != "" || c.Password != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query the events from '%s': %w", c.CalendarURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("unexpected status %d from '%s': %s", resp.StatusCode, c.CalendarURL, bytes.TrimSpace(respBody))
+	}
+
+	var multistatus calDAVMultistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&multistatus); err != nil {
+		return nil, fmt.Errorf("unable to parse the response from '%s': %w", c.CalendarURL, err)
+	}
+	var events []Event
+	for _, response := range multistatus.Responses {
+		for _, data := range response.CalendarData {
+			parsed, err := parseICalendar([]byte(data))
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse an event from '%s': %w", c.CalendarURL, err)
+			}
+			events = append(events, parsed...)
+		}
+	}
+	return overlapping(events, from, to), nil
+}
+
+func formatICalendar(event Event) []byte {
+	transparency := "TRANSPARENT"
+	if event.Busy {
+		transparency = "OPAQUE"
+	}
+	var buf bytes.Buffer
+	for _, line := range []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//xaionaro-go//pomodoro//EN",
+		"BEGIN:VEVENT",
+		"UID:" + event.UID,
+		"DTSTAMP:" + time.Now().UTC().Format(icalTimeLayout),
+		"DTSTART:" + event.Start.UTC().Format(icalTimeLayout),
+		"DTEND:" + event.End.UTC().Format(icalTimeLayout),
+		"SUMMARY:" + escapeICalText(event.Summary),
+		"DESCRIPTION:" + escapeICalText(event.Description),
+		"TRANSP:" + transparency,
+		"END:VEVENT",
+		"END:VCALENDAR",
+	} {
+		buf.WriteString(line)
+		buf.WriteString("\r\n")
+	}
+	return buf.Bytes()
+}
+
+var icalTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\n", `\n`,
+)
+
+func escapeICalText(s string) string {
+	return icalTextEscaper.Replace(s)
+}
@@ -0,0 +1,45 @@
+// Package calendar blocks the focus time in a calendar, so that
+// the colleagues could see that one is busy, and warns about
+// the meetings colliding with the focus sessions.
+package calendar
+
+import (
+	"context"
+	"time"
+)
+
+type Event struct {
+	// UID identifies the event, putting an event with the same UID
+	// updates the existing one.
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	Busy        bool
+	AllDay      bool
+}
+
+// Provider is a calendar service.
+type Provider interface {
+	PutEvent(ctx context.Context, event Event) error
+}
+
+// Reader lists the events of a calendar overlapping [from, to).
+type Reader interface {
+	Events(ctx context.Context, from, to time.Time) ([]Event, error)
+}
+
+func overlapping(
+	events []Event,
+	from time.Time,
+	to time.Time,
+) []Event {
+	var result []Event
+	for _, event := range events {
+		if event.Start.Before(to) && event.End.After(from) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
@@ -0,0 +1,169 @@
+package calendar
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	icalLocalTimeLayout = "20060102T150405"
+	icalDateLayout      = "20060102"
+)
+
+// parseICalendar extracts the events from an iCalendar document; the
+// cancelled events are skipped and the recurrence rules are not expanded
+// (CalDAV servers expand them on request, see CalDAV.Events).
+func parseICalendar(data []byte) ([]Event, error) {
+	var (
+		events  []Event
+		current *Event
+		skip    bool
+	)
+	for _, line := range unfoldICalLines(data) {
+		name, params, value := splitICalLine(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Event{Busy: true}
+			skip = false
+			continue
+		case name == "END" && value == "VEVENT":
+			if current != nil && !skip && !current.Start.IsZero() {
+				if current.End.Before(current.Start) {
+					current.End = current.Start
+				}
+				events = append(events, *current)
+			}
+			current = nil
+			continue
+		case current == nil:
+			continue
+		}
+
+		switch name {
+		case "UID":
+			current.UID = value
+		case "SUMMARY":
+			current.Summary = unescapeICalText(value)
+		case "DESCRIPTION":
+			current.Description = unescapeICalText(value)
+		case "TRANSP":
+			current.Busy = value != "TRANSPARENT"
+		case "STATUS":
+			skip = value == "CANCELLED"
+		case "DTSTART":
+			t, allDay, err := parseICalTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse DTSTART of '%s': %w", current.UID, err)
+			}
+			current.Start = t
+			current.AllDay = allDay
+			if current.End.IsZero() && allDay {
+				current.End = t.AddDate(0, 0, 1)
+			}
+		case "DTEND":
+			t, _, err := parseICalTime(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse DTEND of '%s': %w", current.UID, err)
+			}
+			current.End = t
+		case "DURATION":
+			d, err := parseICalDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse DURATION of '%s': %w", current.UID, err)
+			}
+			current.End = current.Start.Add(d)
+		}
+	}
+	return events, nil
+}
+
+// unfoldICalLines joins the folded lines (continued by a leading
+// space or tab, see RFC 5545, section 3.1).
+func unfoldICalLines(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func splitICalLine(line string) (name string, params map[string]string, value string) {
+	head, value, _ := strings.Cut(line, ":")
+	parts := strings.Split(head, ";")
+	params = map[string]string{}
+	for _, param := range parts[1:] {
+		k, v, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, value
+}
+
+func parseICalTime(
+	value string,
+	params map[string]string,
+) (t time.Time, allDay bool, err error) {
+	switch {
+	case params["VALUE"] == "DATE" || len(value) == len(icalDateLayout):
+		t, err = time.ParseInLocation(icalDateLayout, value, time.Local)
+		return t, true, err
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse(icalTimeLayout, value)
+		return t, false, err
+	}
+	loc := time.Local
+	if tzid := params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err = time.ParseInLocation(icalLocalTimeLayout, value, loc)
+	return t, false, err
+}
+
+var icalDurationRegexp = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+func parseICalDuration(value string) (time.Duration, error) {
+	m := icalDurationRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return 0, fmt.Errorf("invalid duration '%s'", value)
+	}
+	var d time.Duration
+	for idx, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[idx+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[idx+2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s': %w", value, err)
+		}
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+var icalTextUnescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\;`, ";",
+	`\,`, ",",
+	`\n`, "\n",
+	`\N`, "\n",
+)
+
+func unescapeICalText(s string) string {
+	return icalTextUnescaper.Replace(s)
+}
@@ -0,0 +1,60 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	maxICSFeedSize = 16 << 20
+)
+
+// ICSFeed reads the events from an iCalendar feed (for example,
+// the secret address of a Google calendar). The recurring events
+// are taken only as their first occurrence.
+type ICSFeed struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+var _ Reader = (*ICSFeed)(nil)
+
+func NewICSFeed(url string) *ICSFeed {
+	return &ICSFeed{
+		URL:        url,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+func (f *ICSFeed) Events(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) ([]Event, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a request to '%s': %w", f.URL, err)
+	}
+	resp, err := f.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch '%s': %w", f.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("unexpected status %d from '%s': %s", resp.StatusCode, f.URL, bytes.TrimSpace(respBody))
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxICSFeedSize))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read '%s': %w", f.URL, err)
+	}
+	events, err := parseICalendar(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse '%s': %w", f.URL, err)
+	}
+	return overlapping(events, from, to), nil
+}
@@ -0,0 +1,81 @@
+package calendar
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+type MeetingTimer interface {
+	Timer
+	OfferToEndBefore(meeting string, startsAt time.Time)
+}
+
+// MeetingGuard checks the calendar when a work session starts and, if
+// a meeting starts before the session ends, offers to shorten it.
+type MeetingGuard struct {
+	Reader Reader
+
+	timer       MeetingTimer
+	unsubscribe func()
+}
+
+func NewMeetingGuard(
+	reader Reader,
+	timer MeetingTimer,
+) *MeetingGuard {
+	g := &MeetingGuard{
+		Reader: reader,
+		timer:  timer,
+	}
+	g.unsubscribe = timer.OnEvent(g.onEvent)
+	return g
+}
+
+func (g *MeetingGuard) onEvent(ev pomodoro.Event) {
+	if ev.Type != pomodoro.EventTypePhaseStarted || ev.Phase != pomodoro.PhaseWork || ev.Restored {
+		return
+	}
+	ctx, cancelFn := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancelFn()
+	events, err := g.Reader.Events(ctx, ev.Time, ev.Deadline)
+	if err != nil {
+		slog.Error("unable to read the meetings from the calendar", "error", err)
+		return
+	}
+	if meeting, ok := firstMeeting(events, ev.Time, ev.Deadline); ok {
+		g.timer.OfferToEndBefore(meeting.Summary, meeting.Start)
+	}
+}
+
+// firstMeeting returns the earliest busy event starting within (from, to),
+// ignoring the all-day events and the focus time blocked by Blocker.
+func firstMeeting(
+	events []Event,
+	from time.Time,
+	to time.Time,
+) (Event, bool) {
+	var (
+		first Event
+		found bool
+	)
+	for _, event := range events {
+		switch {
+		case !event.Busy, event.AllDay, strings.HasSuffix(event.UID, uidSuffix):
+			continue
+		case !event.Start.After(from), !event.Start.Before(to):
+			continue
+		case found && !event.Start.Before(first.Start):
+			continue
+		}
+		first, found = event, true
+	}
+	return first, found
+}
+
+func (g *MeetingGuard) Close() {
+	g.unsubscribe()
+}
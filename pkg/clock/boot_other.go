@@ -0,0 +1,11 @@
+//go:build !linux && !darwin && !windows
+
+package clock
+
+import (
+	"time"
+)
+
+func sinceBoot() (time.Duration, bool) {
+	return 0, false
+}
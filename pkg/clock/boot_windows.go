@@ -0,0 +1,18 @@
+package clock
+
+import (
+	"syscall"
+	"time"
+)
+
+// getTickCount64 counts the time spent in the sleep and the hibernation,
+// unlike the interrupt time used by Go.
+var getTickCount64 = syscall.NewLazyDLL("kernel32.dll").NewProc("GetTickCount64")
+
+func sinceBoot() (time.Duration, bool) {
+	if err := getTickCount64.Find(); err != nil {
+		return 0, false
+	}
+	ms, _, _ := getTickCount64.Call()
+	return time.Duration(ms) * time.Millisecond, true
+}
@@ -0,0 +1,33 @@
+package clock
+
+import (
+	"time"
+)
+
+// Clock is the source of time used by the timer, so that it could be
+// replaced with Fake in tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTicker(d time.Duration) Ticker
+
+	// SinceBoot returns the time passed since the system was started,
+	// including the time it was suspended (which the monotonic clock
+	// of Go does not count on some systems) and not affected by the
+	// changes of the wall clock; ok is false if the system does not
+	// provide it.
+	SinceBoot() (d time.Duration, ok bool)
+}
+
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+func Until(c Clock, t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+func Since(c Clock, t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
@@ -0,0 +1,113 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a manually driven Clock: the time moves only on Advance.
+type Fake struct {
+	locker  sync.Mutex
+	start   time.Time
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+var _ Clock = (*Fake)(nil)
+
+type fakeWaiter struct {
+	deadline time.Time
+	period   time.Duration
+	ch       chan time.Time
+	stopped  bool
+}
+
+func NewFake(now time.Time) *Fake {
+	return &Fake{start: now, now: now}
+}
+
+func (c *Fake) Now() time.Time {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	return c.now
+}
+
+// SinceBoot counts the time since the creation of the clock,
+// a Fake is never suspended.
+func (c *Fake) SinceBoot() (time.Duration, bool) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	return c.now.Sub(c.start), true
+}
+
+func (c *Fake) After(d time.Duration) <-chan time.Time {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	w := &fakeWaiter{
+		deadline: c.now.Add(d),
+		ch:       make(chan time.Time, 1),
+	}
+	c.waiters = append(c.waiters, w)
+	return w.ch
+}
+
+func (c *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for Fake.NewTicker")
+	}
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	w := &fakeWaiter{
+		deadline: c.now.Add(d),
+		period:   d,
+		ch:       make(chan time.Time, 1),
+	}
+	c.waiters = append(c.waiters, w)
+	return &fakeTicker{clock: c, waiter: w}
+}
+
+// Advance moves the time forward and fires every timer and ticker which
+// deadline has been reached. Like time.Ticker, a ticker drops ticks
+// if the receiver is not keeping up.
+func (c *Fake) Advance(d time.Duration) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	c.now = c.now.Add(d)
+
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.stopped {
+			continue
+		}
+		for !w.deadline.After(c.now) {
+			select {
+			case w.ch <- w.deadline:
+			default:
+			}
+			if w.period == 0 {
+				w.stopped = true
+				break
+			}
+			w.deadline = w.deadline.Add(w.period)
+		}
+		if !w.stopped {
+			waiters = append(waiters, w)
+		}
+	}
+	c.waiters = waiters
+}
+
+type fakeTicker struct {
+	clock  *Fake
+	waiter *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.waiter.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.locker.Lock()
+	defer t.clock.locker.Unlock()
+	t.waiter.stopped = true
+}
@@ -0,0 +1,33 @@
+package clock
+
+import (
+	"time"
+)
+
+type Real struct{}
+
+var _ Clock = Real{}
+
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+func (Real) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (Real) NewTicker(d time.Duration) Ticker {
+	return realTicker{Ticker: time.NewTicker(d)}
+}
+
+func (Real) SinceBoot() (time.Duration, bool) {
+	return sinceBoot()
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
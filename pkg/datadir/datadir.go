@@ -0,0 +1,136 @@
+// Package datadir resolves where the application keeps its files: the
+// preferences, the session history and the logs. By default the XDG Base
+// Directory variables are respected, and a single directory may be used
+// instead to run the application from a USB stick or per project.
+package datadir
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+const (
+	appName = "fynodoro"
+
+	// AppID identifies the application for Fyne, which keeps
+	// the preferences in a directory named by it (see FyneStorageDir).
+	AppID = "center.dx.fynodoro"
+)
+
+// Dirs are the directories of the application files. Empty values mean
+// the storage of the Fyne application with the windows (see gui.New),
+// and nothing is stored without them.
+type Dirs struct {
+	// Config is where the preferences are stored.
+	Config string
+
+	// Data is where the history and the imported sounds are stored.
+	Data string
+
+	// Logs is where the log file is written to, empty means
+	// the standard error.
+	Logs string
+
+	// IsPortable is set if all the files are in one directory
+	// (see Portable).
+	IsPortable bool
+}
+
+// Default returns the directories following the XDG Base Directory
+// specification on the systems using it, and the storage of the Fyne
+// application elsewhere. The preferences are always kept where Fyne
+// keeps them (which respects XDG_CONFIG_HOME), so that the runs with
+// and without the windows share them.
+func Default() Dirs {
+	storage := FyneStorageDir()
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd", "dragonfly":
+	default:
+		return Dirs{
+			Config: storage,
+			Data:   storage,
+		}
+	}
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return Dirs{Config: storage}
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return Dirs{
+		Config: storage,
+		Data:   filepath.Join(dataHome, appName),
+	}
+}
+
+// FyneStorageDir returns the directory Fyne keeps the preferences (and
+// the other files of the application) in on the desktop systems, it is
+// empty elsewhere (Fyne is asked for it by gui.New there). The files
+// used to be kept only there (see pomodoro.Pomodoro.dataFilePath).
+func FyneStorageDir() string {
+	var root string
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd", "dragonfly":
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return ""
+		}
+		root = configDir
+	case "windows":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		root = filepath.Join(home, "AppData", "Roaming")
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		root = filepath.Join(home, "Library", "Preferences")
+	default:
+		return ""
+	}
+	return filepath.Join(root, "fyne", AppID)
+}
+
+// Portable returns the directories within root.
+func Portable(root string) (Dirs, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return Dirs{}, fmt.Errorf("unable to get the absolute path of '%s': %w", root, err)
+	}
+	return Dirs{
+		Config:     filepath.Join(root, "config"),
+		Data:       filepath.Join(root, "data"),
+		Logs:       filepath.Join(root, "logs"),
+		IsPortable: true,
+	}, nil
+}
+
+// LogFile returns the path of the log file, empty if Logs is not set.
+func (d Dirs) LogFile() (string, error) {
+	if d.Logs == "" {
+		return "", nil
+	}
+	if err := os.MkdirAll(d.Logs, 0o755); err != nil {
+		return "", fmt.Errorf("unable to create the directory '%s': %w", d.Logs, err)
+	}
+	return filepath.Join(d.Logs, appName+".log"), nil
+}
+
+// InstanceName is the name to tell the running instances apart: the ones
+// using different portable directories are independent.
+func (d Dirs) InstanceName(name string) string {
+	if !d.IsPortable {
+		return name
+	}
+	hash := sha256.Sum256([]byte(d.Data))
+	return name + "-" + hex.EncodeToString(hash[:4])
+}
@@ -0,0 +1,262 @@
+package datadir
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	preferencesFileName = "preferences.json"
+)
+
+// Preferences keeps the preferences in a JSON file in a directory of
+// choice, in the same format as Fyne does (it implements fyne.Preferences,
+// see gui.New, without depending on Fyne).
+type Preferences struct {
+	// path is empty for the preferences kept only in memory.
+	path string
+
+	locker    sync.Mutex
+	values    map[string]json.RawMessage
+	listeners []func()
+}
+
+// OpenPreferences loads the preferences from the directory
+// (it is created on the first change).
+func OpenPreferences(dir string) (*Preferences, error) {
+	p := &Preferences{
+		path:   filepath.Join(dir, preferencesFileName),
+		values: map[string]json.RawMessage{},
+	}
+	b, err := os.ReadFile(p.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return p, nil
+	case err != nil:
+		return nil, fmt.Errorf("unable to read '%s': %w", p.path, err)
+	}
+	if err := json.Unmarshal(b, &p.values); err != nil {
+		return nil, fmt.Errorf("unable to parse '%s': %w", p.path, err)
+	}
+	return p, nil
+}
+
+// NewPreferences creates empty preferences kept only in memory,
+// for example to be written with Encode.
+func NewPreferences() *Preferences {
+	return &Preferences{
+		values: map[string]json.RawMessage{},
+	}
+}
+
+// DecodePreferences reads the preferences written by Encode,
+// they are kept only in memory.
+func DecodePreferences(r io.Reader) (*Preferences, error) {
+	p := NewPreferences()
+	if err := json.NewDecoder(r).Decode(&p.values); err != nil {
+		return nil, fmt.Errorf("unable to parse the preferences: %w", err)
+	}
+	return p, nil
+}
+
+// Encode writes the preferences in the format of the file.
+func (p *Preferences) Encode(w io.Writer) error {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	if err := encoder.Encode(p.values); err != nil {
+		return fmt.Errorf("unable to encode the preferences: %w", err)
+	}
+	return nil
+}
+
+func get[T any](
+	p *Preferences,
+	key string,
+	fallback T,
+) T {
+	p.locker.Lock()
+	raw, ok := p.values[key]
+	p.locker.Unlock()
+	if !ok {
+		return fallback
+	}
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return fallback
+	}
+	return value
+}
+
+func set[T any](
+	p *Preferences,
+	key string,
+	value T,
+) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		slog.Error("unable to encode the preference", "key", key, "error", err)
+		return
+	}
+	p.locker.Lock()
+	p.values[key] = raw
+	p.locker.Unlock()
+	p.changed()
+}
+
+// changed saves the preferences and notifies the listeners.
+func (p *Preferences) changed() {
+	p.locker.Lock()
+	err := p.save()
+	listeners := append([]func(){}, p.listeners...)
+	p.locker.Unlock()
+	if err != nil {
+		slog.Error("unable to save the preferences", "error", err)
+	}
+	for _, listener := range listeners {
+		listener()
+	}
+}
+
+func (p *Preferences) save() error {
+	if p.path == "" {
+		return nil
+	}
+	b, err := json.MarshalIndent(p.values, "", "\t")
+	if err != nil {
+		return fmt.Errorf("unable to encode the preferences: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o755); err != nil {
+		return fmt.Errorf("unable to create the directory '%s': %w", filepath.Dir(p.path), err)
+	}
+	tmpPath := p.path + ".tmp"
+	if err := os.WriteFile(tmpPath, b, 0o600); err != nil {
+		return fmt.Errorf("unable to write '%s': %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, p.path); err != nil {
+		return fmt.Errorf("unable to replace '%s': %w", p.path, err)
+	}
+	return nil
+}
+
+func (p *Preferences) Bool(key string) bool {
+	return p.BoolWithFallback(key, false)
+}
+
+func (p *Preferences) BoolWithFallback(key string, fallback bool) bool {
+	return get(p, key, fallback)
+}
+
+func (p *Preferences) SetBool(key string, value bool) {
+	set(p, key, value)
+}
+
+func (p *Preferences) BoolList(key string) []bool {
+	return p.BoolListWithFallback(key, nil)
+}
+
+func (p *Preferences) BoolListWithFallback(key string, fallback []bool) []bool {
+	return get(p, key, fallback)
+}
+
+func (p *Preferences) SetBoolList(key string, value []bool) {
+	set(p, key, value)
+}
+
+func (p *Preferences) Float(key string) float64 {
+	return p.FloatWithFallback(key, 0)
+}
+
+func (p *Preferences) FloatWithFallback(key string, fallback float64) float64 {
+	return get(p, key, fallback)
+}
+
+func (p *Preferences) SetFloat(key string, value float64) {
+	set(p, key, value)
+}
+
+func (p *Preferences) FloatList(key string) []float64 {
+	return p.FloatListWithFallback(key, nil)
+}
+
+func (p *Preferences) FloatListWithFallback(key string, fallback []float64) []float64 {
+	return get(p, key, fallback)
+}
+
+func (p *Preferences) SetFloatList(key string, value []float64) {
+	set(p, key, value)
+}
+
+func (p *Preferences) Int(key string) int {
+	return p.IntWithFallback(key, 0)
+}
+
+func (p *Preferences) IntWithFallback(key string, fallback int) int {
+	return get(p, key, fallback)
+}
+
+func (p *Preferences) SetInt(key string, value int) {
+	set(p, key, value)
+}
+
+func (p *Preferences) IntList(key string) []int {
+	return p.IntListWithFallback(key, nil)
+}
+
+func (p *Preferences) IntListWithFallback(key string, fallback []int) []int {
+	return get(p, key, fallback)
+}
+
+func (p *Preferences) SetIntList(key string, value []int) {
+	set(p, key, value)
+}
+
+func (p *Preferences) String(key string) string {
+	return p.StringWithFallback(key, "")
+}
+
+func (p *Preferences) StringWithFallback(key, fallback string) string {
+	return get(p, key, fallback)
+}
+
+func (p *Preferences) SetString(key string, value string) {
+	set(p, key, value)
+}
+
+func (p *Preferences) StringList(key string) []string {
+	return p.StringListWithFallback(key, nil)
+}
+
+func (p *Preferences) StringListWithFallback(key string, fallback []string) []string {
+	return get(p, key, fallback)
+}
+
+func (p *Preferences) SetStringList(key string, value []string) {
+	set(p, key, value)
+}
+
+func (p *Preferences) RemoveValue(key string) {
+	p.locker.Lock()
+	delete(p.values, key)
+	p.locker.Unlock()
+	p.changed()
+}
+
+func (p *Preferences) AddChangeListener(listener func()) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.listeners = append(p.listeners, listener)
+}
+
+func (p *Preferences) ChangeListeners() []func() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return append([]func(){}, p.listeners...)
+}
@@ -0,0 +1,51 @@
+package dbusservice
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// RemoteStatus is the status of a timer running in another process.
+type RemoteStatus struct {
+	Phase     string
+	IsRunning bool
+	IsPaused  bool
+	TimeLeft  time.Duration
+}
+
+// Client controls a timer published by Service (possibly in another process).
+type Client struct {
+	conn *dbus.Conn
+	obj  dbus.BusObject
+}
+
+func NewClient() (*Client, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the session bus: %w", err)
+	}
+	return &Client{
+		conn: conn,
+		obj:  conn.Object(ServiceName, ObjectPath),
+	}, nil
+}
+
+func (c *Client) GetStatus() (RemoteStatus, error) {
+	var (
+		status          RemoteStatus
+		timeLeftSeconds int64
+	)
+	err := c.obj.Call(InterfaceName+".GetStatus", 0).
+		Store(&status.Phase, &status.IsRunning, &status.IsPaused, &timeLeftSeconds)
+	if err != nil {
+		return RemoteStatus{}, fmt.Errorf("unable to get the status: %w", err)
+	}
+	status.TimeLeft = time.Duration(timeLeftSeconds) * time.Second
+	return status, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,146 @@
+package dbusservice
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	ServiceName   = "center.dx.pomodoro"
+	InterfaceName = "center.dx.pomodoro"
+	ObjectPath    = dbus.ObjectPath("/center/dx/pomodoro")
+)
+
+type Timer interface {
+	Start(isWork bool)
+	Pause()
+	Resume()
+	StopTimer()
+	Status() pomodoro.Status
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+}
+
+type Service struct {
+	conn        *dbus.Conn
+	timer       Timer
+	unsubscribe func()
+}
+
+func New(timer Timer) (*Service, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the session bus: %w", err)
+	}
+	s := &Service{
+		conn:  conn,
+		timer: timer,
+	}
+	if err := s.init(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Service) init() error {
+	if err := s.conn.Export(methods{timer: s.timer}, ObjectPath, InterfaceName); err != nil {
+		return fmt.Errorf("unable to export the methods: %w", err)
+	}
+	if err := s.conn.Export(introspect.Introspectable(introspectionXML), ObjectPath, "org.freedesktop.DBus.Introspectable"); err != nil {
+		return fmt.Errorf("unable to export the introspection data: %w", err)
+	}
+
+	reply, err := s.conn.RequestName(ServiceName, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		return fmt.Errorf("unable to request name '%s': %w", ServiceName, err)
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		return fmt.Errorf("name '%s' is already taken", ServiceName)
+	}
+
+	s.unsubscribe = s.timer.OnEvent(s.onEvent)
+	return nil
+}
+
+func (s *Service) onEvent(ev pomodoro.Event) {
+	switch ev.Type {
+	case pomodoro.EventTypeTick:
+		return
+	}
+	err := s.conn.Emit(
+		ObjectPath,
+		InterfaceName+".PhaseChanged",
+		ev.Type.String(),
+		ev.Phase.String(),
+		int64(ev.DisplayedTimeLeft.Seconds()),
+	)
+	if err != nil {
+		slog.Error("unable to emit the PhaseChanged signal", "error", err)
+	}
+}
+
+func (s *Service) Close() error {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	return s.conn.Close()
+}
+
+type methods struct {
+	timer Timer
+}
+
+func (m methods) StartWork() *dbus.Error {
+	m.timer.Start(true)
+	return nil
+}
+
+func (m methods) StartRest() *dbus.Error {
+	m.timer.Start(false)
+	return nil
+}
+
+func (m methods) Pause() *dbus.Error {
+	m.timer.Pause()
+	return nil
+}
+
+func (m methods) Resume() *dbus.Error {
+	m.timer.Resume()
+	return nil
+}
+
+func (m methods) Stop() *dbus.Error {
+	m.timer.StopTimer()
+	return nil
+}
+
+func (m methods) GetStatus() (string, bool, bool, int64, *dbus.Error) {
+	status := m.timer.Status()
+	return status.Phase.String(), status.IsRunning, status.IsPaused, int64(status.DisplayedTimeLeft.Seconds()), nil
+}
+
+const introspectionXML = `
+<node>
+	<interface name="` + InterfaceName + `">
+		<method name="StartWork"/>
+		<method name="StartRest"/>
+		<method name="Pause"/>
+		<method name="Resume"/>
+		<method name="Stop"/>
+		<method name="GetStatus">
+			<arg name="phase" direction="out" type="s"/>
+			<arg name="is_running" direction="out" type="b"/>
+			<arg name="is_paused" direction="out" type="b"/>
+			<arg name="time_left_seconds" direction="out" type="x"/>
+		</method>
+		<signal name="PhaseChanged">
+			<arg name="event" type="s"/>
+			<arg name="phase" type="s"/>
+			<arg name="time_left_seconds" type="x"/>
+		</signal>
+	</interface>` + introspect.IntrospectDataString + `</node>`
@@ -0,0 +1,7 @@
+package dnd
+
+// Controller toggles the Do-Not-Disturb mode of the OS.
+type Controller interface {
+	Enable() error
+	Disable() error
+}
@@ -0,0 +1,40 @@
+package dnd
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+const (
+	DefaultEnableShortcut  = "Pomodoro DND On"
+	DefaultDisableShortcut = "Pomodoro DND Off"
+)
+
+func NewDefaultController() Controller {
+	return &ShortcutsController{
+		EnableShortcut:  DefaultEnableShortcut,
+		DisableShortcut: DefaultDisableShortcut,
+	}
+}
+
+// ShortcutsController runs user-defined Shortcuts.app shortcuts, since
+// macOS does not provide an API to toggle a Focus mode directly.
+type ShortcutsController struct {
+	EnableShortcut  string
+	DisableShortcut string
+}
+
+func (c *ShortcutsController) Enable() error {
+	return runShortcut(c.EnableShortcut)
+}
+
+func (c *ShortcutsController) Disable() error {
+	return runShortcut(c.DisableShortcut)
+}
+
+func runShortcut(name string) error {
+	if output, err := exec.Command("shortcuts", "run", name).CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to run shortcut '%s': %w (output: '%s')", name, err, output)
+	}
+	return nil
+}
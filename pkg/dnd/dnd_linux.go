@@ -0,0 +1,114 @@
+package dnd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func NewDefaultController() Controller {
+	if strings.Contains(strings.ToUpper(os.Getenv("XDG_CURRENT_DESKTOP")), "GNOME") {
+		return &GNOMEController{}
+	}
+	return &FreedesktopController{}
+}
+
+// GNOMEController toggles the "show-banners" setting of GNOME Shell,
+// restoring the previous value on Disable.
+type GNOMEController struct {
+	locker       sync.Mutex
+	previousShow string
+}
+
+const (
+	gnomeNotificationsSchema = "org.gnome.desktop.notifications"
+	gnomeShowBannersKey      = "show-banners"
+)
+
+func (c *GNOMEController) Enable() error {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	if c.previousShow != "" {
+		return nil
+	}
+	output, err := exec.Command("gsettings", "get", gnomeNotificationsSchema, gnomeShowBannersKey).Output()
+	if err != nil {
+		return fmt.Errorf("unable to get the current value of %s: %w", gnomeShowBannersKey, err)
+	}
+	previousShow := strings.TrimSpace(string(output))
+	if err := exec.Command("gsettings", "set", gnomeNotificationsSchema, gnomeShowBannersKey, "false").Run(); err != nil {
+		return fmt.Errorf("unable to disable %s: %w", gnomeShowBannersKey, err)
+	}
+	c.previousShow = previousShow
+	return nil
+}
+
+func (c *GNOMEController) Disable() error {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	if c.previousShow == "" {
+		return nil
+	}
+	if err := exec.Command("gsettings", "set", gnomeNotificationsSchema, gnomeShowBannersKey, c.previousShow).Run(); err != nil {
+		return fmt.Errorf("unable to restore %s: %w", gnomeShowBannersKey, err)
+	}
+	c.previousShow = ""
+	return nil
+}
+
+// FreedesktopController uses the Inhibit call of the
+// org.freedesktop.Notifications interface (supported by KDE Plasma).
+type FreedesktopController struct {
+	locker sync.Mutex
+	cookie uint32
+}
+
+const (
+	notificationsDest = "org.freedesktop.Notifications"
+	notificationsPath = dbus.ObjectPath("/org/freedesktop/Notifications")
+)
+
+func (c *FreedesktopController) Enable() error {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	if c.cookie != 0 {
+		return nil
+	}
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return fmt.Errorf("unable to connect to the session bus: %w", err)
+	}
+	var cookie uint32
+	err = conn.Object(notificationsDest, notificationsPath).
+		Call(notificationsDest+".Inhibit", 0, "center.dx.pomodoro", "Focus session", map[string]dbus.Variant{}).
+		Store(&cookie)
+	if err != nil {
+		return fmt.Errorf("unable to inhibit notifications: %w", err)
+	}
+	c.cookie = cookie
+	return nil
+}
+
+func (c *FreedesktopController) Disable() error {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	if c.cookie == 0 {
+		return nil
+	}
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return fmt.Errorf("unable to connect to the session bus: %w", err)
+	}
+	err = conn.Object(notificationsDest, notificationsPath).
+		Call(notificationsDest+".UnInhibit", 0, c.cookie).
+		Err
+	if err != nil {
+		return fmt.Errorf("unable to uninhibit notifications: %w", err)
+	}
+	c.cookie = 0
+	return nil
+}
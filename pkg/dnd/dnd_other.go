@@ -0,0 +1,22 @@
+//go:build !linux && !darwin
+
+package dnd
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func NewDefaultController() Controller {
+	return unsupportedController{}
+}
+
+type unsupportedController struct{}
+
+func (unsupportedController) Enable() error {
+	return fmt.Errorf("Do-Not-Disturb control is not supported on %s", runtime.GOOS)
+}
+
+func (unsupportedController) Disable() error {
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package globalhotkey registers system-wide keyboard shortcuts, which
+// work even when the window is not focused.
+package globalhotkey
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupported is returned by Register if the global hotkeys cannot
+// work on this platform or in this session (for example, without
+// a display).
+var ErrUnsupported = errors.New("global hotkeys are unsupported")
+
+// parse splits a binding like "ctrl+alt+p" into the modifiers and the key.
+func parse(binding string) (modifiers []string, key string, err error) {
+	parts := strings.Split(strings.ToLower(strings.ReplaceAll(binding, " ", "")), "+")
+	if len(parts) < 2 {
+		return nil, "", fmt.Errorf("binding '%s' should contain at least one modifier and a key, for example 'ctrl+alt+p'", binding)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return nil, "", fmt.Errorf("binding '%s' contains an empty part", binding)
+		}
+	}
+	return parts[:len(parts)-1], parts[len(parts)-1], nil
+}
+
+// Validate checks if the binding could be registered on this platform.
+func Validate(binding string) error {
+	_, _, err := resolve(binding)
+	return err
+}
@@ -0,0 +1,57 @@
+package globalhotkey
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		Binding   string
+		Modifiers []string
+		Key       string
+		IsValid   bool
+	}{
+		{Binding: "ctrl+alt+p", Modifiers: []string{"ctrl", "alt"}, Key: "p", IsValid: true},
+		{Binding: "Ctrl + Shift + F5", Modifiers: []string{"ctrl", "shift"}, Key: "f5", IsValid: true},
+		{Binding: "p"},
+		{Binding: "ctrl++p"},
+		{Binding: ""},
+	} {
+		t.Run(tc.Binding, func(t *testing.T) {
+			modifiers, key, err := parse(tc.Binding)
+			if !tc.IsValid {
+				if err == nil {
+					t.Fatalf("expected an error, got %v and '%s'", modifiers, key)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if key != tc.Key || len(modifiers) != len(tc.Modifiers) {
+				t.Fatalf("expected %v and '%s', got %v and '%s'", tc.Modifiers, tc.Key, modifiers, key)
+			}
+			for idx := range modifiers {
+				if modifiers[idx] != tc.Modifiers[idx] {
+					t.Fatalf("expected %v, got %v", tc.Modifiers, modifiers)
+				}
+			}
+		})
+	}
+}
+
+// TestRegisterWithoutDisplay checks that a session without a display
+// (a server, SSH) gets an error instead of a crash.
+func TestRegisterWithoutDisplay(t *testing.T) {
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+	unregister, err := Register("ctrl+alt+p", func() {})
+	if err == nil {
+		unregister()
+		t.Skip("the global hotkeys work without a display on this platform")
+	}
+	if !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+}
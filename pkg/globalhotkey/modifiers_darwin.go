@@ -0,0 +1,16 @@
+//go:build cgo
+
+package globalhotkey
+
+import (
+	"golang.design/x/hotkey"
+)
+
+var modifiers = map[string]hotkey.Modifier{
+	"ctrl":   hotkey.ModCtrl,
+	"shift":  hotkey.ModShift,
+	"alt":    hotkey.ModOption,
+	"option": hotkey.ModOption,
+	"cmd":    hotkey.ModCmd,
+	"super":  hotkey.ModCmd,
+}
@@ -0,0 +1,11 @@
+//go:build cgo && !android
+
+package globalhotkey
+
+// modifiers are the X11 modifier masks (see X11/X.h).
+var modifiers = map[string]uint32{
+	"ctrl":  1 << 2, // ControlMask
+	"shift": 1 << 0, // ShiftMask
+	"alt":   1 << 3, // Mod1Mask
+	"super": 1 << 6, // Mod4Mask
+}
@@ -0,0 +1,13 @@
+package globalhotkey
+
+import (
+	"golang.design/x/hotkey"
+)
+
+var modifiers = map[string]hotkey.Modifier{
+	"ctrl":  hotkey.ModCtrl,
+	"shift": hotkey.ModShift,
+	"alt":   hotkey.ModAlt,
+	"win":   hotkey.ModWin,
+	"super": hotkey.ModWin,
+}
@@ -0,0 +1,102 @@
+//go:build windows || (darwin && cgo && !android)
+
+package globalhotkey
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.design/x/hotkey"
+)
+
+var keys = map[string]hotkey.Key{
+	"space": hotkey.KeySpace,
+	"a":     hotkey.KeyA,
+	"b":     hotkey.KeyB,
+	"c":     hotkey.KeyC,
+	"d":     hotkey.KeyD,
+	"e":     hotkey.KeyE,
+	"f":     hotkey.KeyF,
+	"g":     hotkey.KeyG,
+	"h":     hotkey.KeyH,
+	"i":     hotkey.KeyI,
+	"j":     hotkey.KeyJ,
+	"k":     hotkey.KeyK,
+	"l":     hotkey.KeyL,
+	"m":     hotkey.KeyM,
+	"n":     hotkey.KeyN,
+	"o":     hotkey.KeyO,
+	"p":     hotkey.KeyP,
+	"q":     hotkey.KeyQ,
+	"r":     hotkey.KeyR,
+	"s":     hotkey.KeyS,
+	"t":     hotkey.KeyT,
+	"u":     hotkey.KeyU,
+	"v":     hotkey.KeyV,
+	"w":     hotkey.KeyW,
+	"x":     hotkey.KeyX,
+	"y":     hotkey.KeyY,
+	"z":     hotkey.KeyZ,
+	"f1":    hotkey.KeyF1,
+	"f2":    hotkey.KeyF2,
+	"f3":    hotkey.KeyF3,
+	"f4":    hotkey.KeyF4,
+	"f5":    hotkey.KeyF5,
+	"f6":    hotkey.KeyF6,
+	"f7":    hotkey.KeyF7,
+	"f8":    hotkey.KeyF8,
+	"f9":    hotkey.KeyF9,
+	"f10":   hotkey.KeyF10,
+	"f11":   hotkey.KeyF11,
+	"f12":   hotkey.KeyF12,
+}
+
+func resolve(binding string) ([]hotkey.Modifier, hotkey.Key, error) {
+	modifierNames, keyName, err := parse(binding)
+	if err != nil {
+		return nil, 0, err
+	}
+	var mods []hotkey.Modifier
+	for _, name := range modifierNames {
+		mod, ok := modifiers[name]
+		if !ok {
+			return nil, 0, fmt.Errorf("unknown modifier '%s' in binding '%s'", name, binding)
+		}
+		mods = append(mods, mod)
+	}
+	key, ok := keys[keyName]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown key '%s' in binding '%s'", keyName, binding)
+	}
+	return mods, key, nil
+}
+
+// Register calls the handler (from a dedicated goroutine) each time
+// the binding is pressed, until unregister is called.
+func Register(
+	binding string,
+	handler func(),
+) (unregister func(), err error) {
+	mods, key, err := resolve(binding)
+	if err != nil {
+		return nil, err
+	}
+	hk := hotkey.New(mods, key)
+	if err := hk.Register(); err != nil {
+		return nil, fmt.Errorf("unable to register the global hotkey '%s': %w", binding, err)
+	}
+
+	keydown := hk.Keydown()
+	go func() {
+		for range keydown {
+			handler()
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			_ = hk.Unregister()
+		})
+	}, nil
+}
@@ -0,0 +1,19 @@
+//go:build !windows && !((linux || darwin) && cgo && !android)
+
+package globalhotkey
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func resolve(binding string) ([]string, string, error) {
+	return parse(binding)
+}
+
+func Register(
+	binding string,
+	handler func(),
+) (unregister func(), err error) {
+	return nil, fmt.Errorf("%w on %s (or without cgo)", ErrUnsupported, runtime.GOOS)
+}
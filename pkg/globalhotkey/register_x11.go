@@ -0,0 +1,176 @@
+//go:build linux && cgo && !android
+
+package globalhotkey
+
+/*
+#cgo LDFLAGS: -lX11
+
+#include <errno.h>
+#include <poll.h>
+#include <X11/Xlib.h>
+
+static int grabFailed;
+
+static int onGrabError(Display *d, XErrorEvent *ev) {
+	grabFailed = 1;
+	return 0;
+}
+
+// grabKey grabs the key on the root window, also with Caps Lock and
+// Num Lock on (they are the modifiers for X11 too); it returns non-zero
+// if the key is grabbed by another client already.
+static int grabKey(Display *d, int keycode, unsigned int mods) {
+	unsigned int locks[] = {0, LockMask, Mod2Mask, LockMask | Mod2Mask};
+	grabFailed = 0;
+	XErrorHandler prev = XSetErrorHandler(onGrabError);
+	for (int i = 0; i < 4; i++) {
+		XGrabKey(d, keycode, mods | locks[i], DefaultRootWindow(d), False, GrabModeAsync, GrabModeAsync);
+	}
+	XSync(d, False);
+	XSetErrorHandler(prev);
+	return grabFailed;
+}
+
+// waitKeyPress waits for a press of the grabbed key up to timeoutMs
+// milliseconds: it returns 1 if it is pressed, 0 if not and -1 if
+// the connection is broken.
+static int waitKeyPress(Display *d, int timeoutMs) {
+	while (!XPending(d)) {
+		struct pollfd fd = {ConnectionNumber(d), POLLIN, 0};
+		int n = poll(&fd, 1, timeoutMs);
+		if (n < 0 && errno == EINTR) {
+			continue;
+		}
+		if (n < 0 || (fd.revents & (POLLERR | POLLHUP))) {
+			return -1;
+		}
+		if (n == 0) {
+			return 0;
+		}
+	}
+	XEvent ev;
+	XNextEvent(d, &ev);
+	return ev.type == KeyPress;
+}
+*/
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+)
+
+const (
+	// pollTimeoutMs is how often the unregistering is checked for
+	// while waiting for the key.
+	pollTimeoutMs = 200
+)
+
+// keys are the X11 keysyms (see X11/keysymdef.h).
+var keys = func() map[string]uint32 {
+	m := map[string]uint32{
+		"space": 0x0020,
+	}
+	for c := 'a'; c <= 'z'; c++ {
+		m[string(c)] = uint32(c)
+	}
+	for n := uint32(1); n <= 12; n++ {
+		m[fmt.Sprintf("f%d", n)] = 0xffbe + n - 1
+	}
+	return m
+}()
+
+// grabLocker serializes the grabs, since the X11 error handler
+// is global (see grabKey).
+var grabLocker sync.Mutex
+
+func resolve(binding string) (uint32, uint32, error) {
+	modifierNames, keyName, err := parse(binding)
+	if err != nil {
+		return 0, 0, err
+	}
+	var mods uint32
+	for _, name := range modifierNames {
+		mod, ok := modifiers[name]
+		if !ok {
+			return 0, 0, fmt.Errorf("unknown modifier '%s' in binding '%s'", name, binding)
+		}
+		mods |= mod
+	}
+	key, ok := keys[keyName]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown key '%s' in binding '%s'", keyName, binding)
+	}
+	return mods, key, nil
+}
+
+// Register calls the handler (from a dedicated goroutine) each time
+// the binding is pressed, until unregister is called. The key is grabbed
+// through its own connection to the X11 display, so without the display
+// (a server, an SSH session, a pure Wayland session) ErrUnsupported
+// is returned.
+func Register(
+	binding string,
+	handler func(),
+) (unregister func(), err error) {
+	mods, keysym, err := resolve(binding)
+	if err != nil {
+		return nil, err
+	}
+	if os.Getenv("DISPLAY") == "" {
+		return nil, fmt.Errorf("%w: there is no X11 display (DISPLAY is not set)", ErrUnsupported)
+	}
+	display := C.XOpenDisplay(nil)
+	if display == nil {
+		return nil, fmt.Errorf("%w: unable to open the X11 display '%s'", ErrUnsupported, os.Getenv("DISPLAY"))
+	}
+	keycode := C.XKeysymToKeycode(display, C.KeySym(keysym))
+	if keycode == 0 {
+		C.XCloseDisplay(display)
+		return nil, fmt.Errorf("the key of the binding '%s' is not on the keyboard", binding)
+	}
+	grabLocker.Lock()
+	failed := C.grabKey(display, C.int(keycode), C.uint(mods))
+	grabLocker.Unlock()
+	if failed != 0 {
+		C.XCloseDisplay(display)
+		return nil, fmt.Errorf("unable to register the global hotkey '%s': it is taken by another application", binding)
+	}
+
+	// the handler is called from another goroutine, so that unregister
+	// could be called while it runs
+	presses := make(chan struct{}, 1)
+	go func() {
+		for range presses {
+			handler()
+		}
+	}()
+	ctx, cancelFn := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer C.XCloseDisplay(display)
+		for ctx.Err() == nil {
+			switch C.waitKeyPress(display, pollTimeoutMs) {
+			case 1:
+				select {
+				case presses <- struct{}{}:
+				default:
+				}
+			case -1:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancelFn()
+			<-done
+			close(presses)
+		})
+	}, nil
+}
@@ -0,0 +1,5 @@
+// Package pomodoropb contains the protobuf messages and the gRPC client
+// and server stubs generated from pomodoro.proto.
+package pomodoropb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative pomodoro.proto
@@ -0,0 +1,391 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.3
+// source: pomodoro.proto
+
+package pomodoropb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Pomodoro_GetStatus_FullMethodName = "/pomodoro.v1.Pomodoro/GetStatus"
+	Pomodoro_Start_FullMethodName     = "/pomodoro.v1.Pomodoro/Start"
+	Pomodoro_Pause_FullMethodName     = "/pomodoro.v1.Pomodoro/Pause"
+	Pomodoro_Resume_FullMethodName    = "/pomodoro.v1.Pomodoro/Resume"
+	Pomodoro_Stop_FullMethodName      = "/pomodoro.v1.Pomodoro/Stop"
+	Pomodoro_Extend_FullMethodName    = "/pomodoro.v1.Pomodoro/Extend"
+	Pomodoro_SetTask_FullMethodName   = "/pomodoro.v1.Pomodoro/SetTask"
+	Pomodoro_Subscribe_FullMethodName = "/pomodoro.v1.Pomodoro/Subscribe"
+)
+
+// PomodoroClient is the client API for Pomodoro service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PomodoroClient interface {
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*Status, error)
+	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*Status, error)
+	Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*Status, error)
+	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*Status, error)
+	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*Status, error)
+	Extend(ctx context.Context, in *ExtendRequest, opts ...grpc.CallOption) (*Status, error)
+	SetTask(ctx context.Context, in *SetTaskRequest, opts ...grpc.CallOption) (*Status, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type pomodoroClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPomodoroClient(cc grpc.ClientConnInterface) PomodoroClient {
+	return &pomodoroClient{cc}
+}
+
+func (c *pomodoroClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Pomodoro_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pomodoroClient) Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Pomodoro_Start_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pomodoroClient) Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Pomodoro_Pause_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pomodoroClient) Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Pomodoro_Resume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pomodoroClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Pomodoro_Stop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pomodoroClient) Extend(ctx context.Context, in *ExtendRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Pomodoro_Extend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pomodoroClient) SetTask(ctx context.Context, in *SetTaskRequest, opts ...grpc.CallOption) (*Status, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Status)
+	err := c.cc.Invoke(ctx, Pomodoro_SetTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pomodoroClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Pomodoro_ServiceDesc.Streams[0], Pomodoro_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Pomodoro_SubscribeClient = grpc.ServerStreamingClient[Event]
+
+// PomodoroServer is the server API for Pomodoro service.
+// All implementations must embed UnimplementedPomodoroServer
+// for forward compatibility.
+type PomodoroServer interface {
+	GetStatus(context.Context, *GetStatusRequest) (*Status, error)
+	Start(context.Context, *StartRequest) (*Status, error)
+	Pause(context.Context, *PauseRequest) (*Status, error)
+	Resume(context.Context, *ResumeRequest) (*Status, error)
+	Stop(context.Context, *StopRequest) (*Status, error)
+	Extend(context.Context, *ExtendRequest) (*Status, error)
+	SetTask(context.Context, *SetTaskRequest) (*Status, error)
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedPomodoroServer()
+}
+
+// UnimplementedPomodoroServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPomodoroServer struct{}
+
+func (UnimplementedPomodoroServer) GetStatus(context.Context, *GetStatusRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedPomodoroServer) Start(context.Context, *StartRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
+}
+func (UnimplementedPomodoroServer) Pause(context.Context, *PauseRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedPomodoroServer) Resume(context.Context, *ResumeRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedPomodoroServer) Stop(context.Context, *StopRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
+}
+func (UnimplementedPomodoroServer) Extend(context.Context, *ExtendRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Extend not implemented")
+}
+func (UnimplementedPomodoroServer) SetTask(context.Context, *SetTaskRequest) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTask not implemented")
+}
+func (UnimplementedPomodoroServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedPomodoroServer) mustEmbedUnimplementedPomodoroServer() {}
+func (UnimplementedPomodoroServer) testEmbeddedByValue()                  {}
+
+// UnsafePomodoroServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PomodoroServer will
+// result in compilation errors.
+type UnsafePomodoroServer interface {
+	mustEmbedUnimplementedPomodoroServer()
+}
+
+func RegisterPomodoroServer(s grpc.ServiceRegistrar, srv PomodoroServer) {
+	// If the following call pancis, it indicates UnimplementedPomodoroServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Pomodoro_ServiceDesc, srv)
+}
+
+func _Pomodoro_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PomodoroServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Pomodoro_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PomodoroServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pomodoro_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PomodoroServer).Start(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Pomodoro_Start_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PomodoroServer).Start(ctx, req.(*StartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pomodoro_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PomodoroServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Pomodoro_Pause_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PomodoroServer).Pause(ctx, req.(*PauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pomodoro_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PomodoroServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Pomodoro_Resume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PomodoroServer).Resume(ctx, req.(*ResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pomodoro_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PomodoroServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Pomodoro_Stop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PomodoroServer).Stop(ctx, req.(*StopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pomodoro_Extend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PomodoroServer).Extend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Pomodoro_Extend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PomodoroServer).Extend(ctx, req.(*ExtendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pomodoro_SetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PomodoroServer).SetTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Pomodoro_SetTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PomodoroServer).SetTask(ctx, req.(*SetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pomodoro_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PomodoroServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Pomodoro_SubscribeServer = grpc.ServerStreamingServer[Event]
+
+// Pomodoro_ServiceDesc is the grpc.ServiceDesc for Pomodoro service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Pomodoro_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pomodoro.v1.Pomodoro",
+	HandlerType: (*PomodoroServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatus",
+			Handler:    _Pomodoro_GetStatus_Handler,
+		},
+		{
+			MethodName: "Start",
+			Handler:    _Pomodoro_Start_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _Pomodoro_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _Pomodoro_Resume_Handler,
+		},
+		{
+			MethodName: "Stop",
+			Handler:    _Pomodoro_Stop_Handler,
+		},
+		{
+			MethodName: "Extend",
+			Handler:    _Pomodoro_Extend_Handler,
+		},
+		{
+			MethodName: "SetTask",
+			Handler:    _Pomodoro_SetTask_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Pomodoro_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pomodoro.proto",
+}
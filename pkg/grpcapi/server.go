@@ -0,0 +1,190 @@
+// Package grpcapi serves the timer over gRPC (see pomodoropb/pomodoro.proto),
+// for the other tools to control it through a typed client.
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/grpcapi/pomodoropb"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// subscriberQueueSize is how many events may wait for a slow
+	// subscriber, the older ones are dropped (the state is sent
+	// in full with every event anyway).
+	subscriberQueueSize = 16
+)
+
+type Timer interface {
+	Start(isWork bool)
+	Pause()
+	Resume()
+	StopTimer()
+	Extend(d time.Duration)
+	SetTask(task string)
+	Status() pomodoro.Status
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+}
+
+type Server struct {
+	pomodoropb.UnimplementedPomodoroServer
+	Timer      Timer
+	grpcServer *grpc.Server
+}
+
+var _ pomodoropb.PomodoroServer = (*Server)(nil)
+
+func New(timer Timer) *Server {
+	s := &Server{
+		Timer:      timer,
+		grpcServer: grpc.NewServer(),
+	}
+	pomodoropb.RegisterPomodoroServer(s.grpcServer, s)
+	return s
+}
+
+// Serve serves the API on the listener until Close is called.
+func (s *Server) Serve(listener net.Listener) error {
+	return s.grpcServer.Serve(listener)
+}
+
+func (s *Server) Close() error {
+	s.grpcServer.GracefulStop()
+	return nil
+}
+
+func NewStatus(st pomodoro.Status) *pomodoropb.Status {
+	result := &pomodoropb.Status{
+		Phase:                 newPhase(st.Phase),
+		Running:               st.IsRunning,
+		Paused:                st.IsPaused,
+		TimeLeft:              durationpb.New(st.TimeLeft),
+		Elapsed:               durationpb.New(st.Elapsed),
+		Task:                  st.Task,
+		CompletedWorkSessions: uint32(st.CompletedWorkSessions),
+		CycleWorkSessions:     uint32(st.CycleWorkSessions),
+		BreakDebt:             durationpb.New(st.BreakDebt),
+	}
+	if st.IsRunning && !st.Deadline.IsZero() {
+		result.Deadline = timestamppb.New(st.Deadline)
+	}
+	return result
+}
+
+func newPhase(phase pomodoro.Phase) pomodoropb.Phase {
+	switch phase {
+	case pomodoro.PhaseWork:
+		return pomodoropb.Phase_PHASE_WORK
+	case pomodoro.PhaseRest:
+		return pomodoropb.Phase_PHASE_REST
+	case pomodoro.PhaseLongRest:
+		return pomodoropb.Phase_PHASE_LONG_REST
+	case pomodoro.PhaseStopwatch:
+		return pomodoropb.Phase_PHASE_STOPWATCH
+	default:
+		return pomodoropb.Phase_PHASE_UNSPECIFIED
+	}
+}
+
+func (s *Server) GetStatus(
+	context.Context,
+	*pomodoropb.GetStatusRequest,
+) (*pomodoropb.Status, error) {
+	return NewStatus(s.Timer.Status()), nil
+}
+
+func (s *Server) Start(
+	_ context.Context,
+	req *pomodoropb.StartRequest,
+) (*pomodoropb.Status, error) {
+	switch req.GetPhase() {
+	case pomodoropb.Phase_PHASE_UNSPECIFIED, pomodoropb.Phase_PHASE_WORK:
+		s.Timer.Start(true)
+	case pomodoropb.Phase_PHASE_REST:
+		s.Timer.Start(false)
+	default:
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unable to start phase %s, expected PHASE_WORK or PHASE_REST", req.GetPhase()))
+	}
+	return NewStatus(s.Timer.Status()), nil
+}
+
+func (s *Server) Pause(
+	context.Context,
+	*pomodoropb.PauseRequest,
+) (*pomodoropb.Status, error) {
+	s.Timer.Pause()
+	return NewStatus(s.Timer.Status()), nil
+}
+
+func (s *Server) Resume(
+	context.Context,
+	*pomodoropb.ResumeRequest,
+) (*pomodoropb.Status, error) {
+	s.Timer.Resume()
+	return NewStatus(s.Timer.Status()), nil
+}
+
+func (s *Server) Stop(
+	context.Context,
+	*pomodoropb.StopRequest,
+) (*pomodoropb.Status, error) {
+	s.Timer.StopTimer()
+	return NewStatus(s.Timer.Status()), nil
+}
+
+func (s *Server) Extend(
+	_ context.Context,
+	req *pomodoropb.ExtendRequest,
+) (*pomodoropb.Status, error) {
+	if err := req.GetBy().CheckValid(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid 'by': %v", err))
+	}
+	s.Timer.Extend(req.GetBy().AsDuration())
+	return NewStatus(s.Timer.Status()), nil
+}
+
+func (s *Server) SetTask(
+	_ context.Context,
+	req *pomodoropb.SetTaskRequest,
+) (*pomodoropb.Status, error) {
+	s.Timer.SetTask(req.GetTask())
+	return NewStatus(s.Timer.Status()), nil
+}
+
+func (s *Server) Subscribe(
+	_ *pomodoropb.SubscribeRequest,
+	stream pomodoropb.Pomodoro_SubscribeServer,
+) error {
+	events := make(chan *pomodoropb.Event, subscriberQueueSize)
+	unsubscribe := s.Timer.OnEvent(func(ev pomodoro.Event) {
+		event := &pomodoropb.Event{
+			Type:   ev.Type.String(),
+			Status: NewStatus(s.Timer.Status()),
+		}
+		select {
+		case events <- event:
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	err := stream.Send(&pomodoropb.Event{Status: NewStatus(s.Timer.Status())})
+	for err == nil {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			err = stream.Send(event)
+		}
+	}
+	return fmt.Errorf("unable to send the event: %w", err)
+}
@@ -0,0 +1,129 @@
+package gui
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// highContrastColors replace Settings.Colors with the high-contrast theme.
+var highContrastColors = pomodoro.PhaseColors{
+	Digits:      color.NRGBA{R: 255, G: 255, B: 255, A: 255},
+	Delimiter:   color.NRGBA{R: 255, G: 255, A: 255},
+	Description: color.NRGBA{R: 255, G: 255, A: 255},
+}
+
+type secondaryText struct {
+	Text  *canvas.Text
+	Size  float32
+	Color color.Color // nil if the color is managed elsewhere
+}
+
+func (g *GUI) secondaryTexts() []secondaryText {
+	return []secondaryText{
+		{Text: g.CounterText, Size: 20, Color: color.Gray{Y: 160}},
+		{Text: g.TaskText, Size: 20, Color: color.Gray{Y: 192}},
+		{Text: g.EndsAtText, Size: 16, Color: color.Gray{Y: 160}},
+		{Text: g.GoalText, Size: 16, Color: color.Gray{Y: 160}},
+		{Text: g.RoomText, Size: 14, Color: color.Gray{Y: 160}},
+		{Text: g.BreakDebtText, Size: 14},
+		{Text: g.OffHoursText, Size: 14, Color: color.Gray{Y: 160}},
+	}
+}
+
+// secondaryTextColor returns the color to draw the dimmed texts with,
+// which is not dimmed in the high-contrast theme (and the grays are
+// inverted on the light background).
+func (g *GUI) secondaryTextColor(c color.Color) color.Color {
+	if g.settings.Theme == pomodoro.ThemeVariantHighContrast {
+		return color.White
+	}
+	if gray, ok := c.(color.Gray); ok && g.themeVariant() == theme.VariantLight {
+		return color.Gray{Y: 255 - gray.Y}
+	}
+	return c
+}
+
+// applyTextStyle adapts the texts around the timer to the
+// high-contrast and the large-text themes.
+func (g *GUI) applyTextStyle() {
+	scale := float32(1)
+	if g.settings.Theme == pomodoro.ThemeVariantLargeText {
+		scale = largeTextScale
+	}
+	for _, t := range g.secondaryTexts() {
+		t.Text.TextSize = scale * t.Size
+		if t.Color != nil {
+			t.Text.Color = g.secondaryTextColor(t.Color)
+		}
+		t.Text.Refresh()
+	}
+	g.refreshBreakDebt()
+}
+
+// timerReadout makes the timer reachable with the Tab key: it is
+// outlined when focused, reads the timer aloud on focus and on Enter
+// and passes the rest of the keys to the shortcuts.
+type timerReadout struct {
+	widget.BaseWidget
+	Content fyne.CanvasObject
+	OnRead  func()
+	OnKey   func(*fyne.KeyEvent)
+	outline *canvas.Rectangle
+}
+
+var _ fyne.Focusable = (*timerReadout)(nil)
+
+func newTimerReadout(
+	content fyne.CanvasObject,
+	onRead func(),
+	onKey func(*fyne.KeyEvent),
+) *timerReadout {
+	r := &timerReadout{
+		Content: content,
+		OnRead:  onRead,
+		OnKey:   onKey,
+		outline: canvas.NewRectangle(color.Transparent),
+	}
+	r.outline.StrokeWidth = 2
+	r.outline.Hide()
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+func (r *timerReadout) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewStack(r.Content, r.outline))
+}
+
+func (r *timerReadout) FocusGained() {
+	r.outline.StrokeColor = theme.Color(theme.ColorNameFocus)
+	r.outline.Show()
+	r.outline.Refresh()
+	if r.OnRead != nil {
+		r.OnRead()
+	}
+}
+
+func (r *timerReadout) FocusLost() {
+	r.outline.Hide()
+}
+
+func (r *timerReadout) TypedRune(rune) {}
+
+func (r *timerReadout) TypedKey(ev *fyne.KeyEvent) {
+	switch ev.Name {
+	case fyne.KeyReturn, fyne.KeyEnter:
+		if r.OnRead != nil {
+			r.OnRead()
+		}
+	default:
+		if r.OnKey != nil {
+			r.OnKey(ev)
+		}
+	}
+}
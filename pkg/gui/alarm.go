@@ -0,0 +1,107 @@
+package gui
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+type tapArea struct {
+	widget.BaseWidget
+	Content  fyne.CanvasObject
+	OnTapped func()
+}
+
+var _ fyne.Tappable = (*tapArea)(nil)
+
+func newTapArea(
+	content fyne.CanvasObject,
+	onTapped func(),
+) *tapArea {
+	a := &tapArea{
+		Content:  content,
+		OnTapped: onTapped,
+	}
+	a.ExtendBaseWidget(a)
+	return a
+}
+
+func (a *tapArea) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(a.Content)
+}
+
+func (a *tapArea) Tapped(*fyne.PointEvent) {
+	if a.OnTapped != nil {
+		a.OnTapped()
+	}
+}
+
+// newAlarmOverlay creates the overlay shown over the timer while the alarm
+// is playing, a click anywhere on it silences the alarm.
+func (g *GUI) newAlarmOverlay() fyne.CanvasObject {
+	overlay := newTapArea(container.NewStack(
+		canvas.NewRectangle(color.NRGBA{A: 96}),
+		container.NewCenter(widget.NewButtonWithIcon(
+			l10n.T("Acknowledge"),
+			theme.VolumeMuteIcon(),
+			func() { g.AcknowledgeAlarm() },
+		)),
+	), func() { g.AcknowledgeAlarm() })
+	overlay.Hide()
+	return overlay
+}
+
+// refreshAlarm shows the overlay while the alarm is ringing and
+// the banner once it is escalated (see pomodoro.View.IsAlarmEscalated).
+func (g *GUI) refreshAlarm() {
+	v := g.view
+	if !v.IsAlarmRinging {
+		g.alarmOverlay.Hide()
+	} else {
+		g.alarmOverlay.Show()
+	}
+	if v.IsAlarmRinging && v.IsAlarmEscalated {
+		g.showAlarmBanner()
+		return
+	}
+	if g.alarmBanner != nil {
+		g.alarmBanner.Close()
+		g.alarmBanner = nil
+	}
+}
+
+// showAlarmBanner shows a full-screen banner, which stays until
+// the alarm is acknowledged (see Settings.AlarmEscalation).
+func (g *GUI) showAlarmBanner() {
+	if g.alarmBanner != nil || !g.isShown {
+		return
+	}
+
+	colors := g.currentColors()
+	title := canvas.NewText(l10n.T("Time is up!"), colors.Digits)
+	title.Alignment = fyne.TextAlignCenter
+	title.TextSize = 80
+	title.TextStyle = fyne.TextStyle{Monospace: true}
+	w := g.App.NewWindow(l10n.T("Time is up!"))
+	w.SetCloseIntercept(func() {})
+	w.SetContent(container.NewStack(
+		canvas.NewRectangle(colors.Background),
+		container.NewCenter(container.NewVBox(
+			title,
+			container.NewCenter(widget.NewButtonWithIcon(
+				l10n.T("Acknowledge"),
+				theme.VolumeMuteIcon(),
+				g.AcknowledgeAlarm,
+			)),
+		)),
+	))
+	w.SetFullScreen(true)
+	w.Show()
+	w.RequestFocus()
+	g.alarmBanner = w
+}
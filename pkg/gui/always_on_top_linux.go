@@ -0,0 +1,37 @@
+package gui
+
+import (
+	"fmt"
+	"os/exec"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver"
+)
+
+func setAlwaysOnTop(
+	w fyne.Window,
+	enable bool,
+) error {
+	nativeWindow, ok := w.(driver.NativeWindow)
+	if !ok {
+		return fmt.Errorf("the window does not provide access to the native window")
+	}
+
+	var err error
+	nativeWindow.RunNative(func(ctx any) {
+		x11Ctx, ok := ctx.(driver.X11WindowContext)
+		if !ok {
+			err = fmt.Errorf("always-on-top is supported only on X11, but the context is %T", ctx)
+			return
+		}
+		action := "remove"
+		if enable {
+			action = "add"
+		}
+		cmd := exec.Command("wmctrl", "-i", "-r", fmt.Sprintf("0x%x", x11Ctx.WindowHandle), "-b", action+",above")
+		if output, _err := cmd.CombinedOutput(); _err != nil {
+			err = fmt.Errorf("unable to execute wmctrl: %w (output: '%s')", _err, output)
+		}
+	})
+	return err
+}
@@ -0,0 +1,17 @@
+//go:build !linux && !windows
+
+package gui
+
+import (
+	"fmt"
+	"runtime"
+
+	"fyne.io/fyne/v2"
+)
+
+func setAlwaysOnTop(
+	w fyne.Window,
+	enable bool,
+) error {
+	return fmt.Errorf("always-on-top is not supported on %s", runtime.GOOS)
+}
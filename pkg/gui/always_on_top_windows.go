@@ -0,0 +1,49 @@
+package gui
+
+import (
+	"fmt"
+	"syscall"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver"
+)
+
+const (
+	hwndTopMost   = ^uintptr(0)     // HWND_TOPMOST (-1)
+	hwndNoTopMost = ^uintptr(1)     // HWND_NOTOPMOST (-2)
+	swpNoSize     = uintptr(0x0001) // SWP_NOSIZE
+	swpNoMove     = uintptr(0x0002) // SWP_NOMOVE
+)
+
+var (
+	user32           = syscall.NewLazyDLL("user32.dll")
+	procSetWindowPos = user32.NewProc("SetWindowPos")
+)
+
+func setAlwaysOnTop(
+	w fyne.Window,
+	enable bool,
+) error {
+	nativeWindow, ok := w.(driver.NativeWindow)
+	if !ok {
+		return fmt.Errorf("the window does not provide access to the native window")
+	}
+
+	var err error
+	nativeWindow.RunNative(func(ctx any) {
+		winCtx, ok := ctx.(driver.WindowsWindowContext)
+		if !ok {
+			err = fmt.Errorf("unexpected native context type %T", ctx)
+			return
+		}
+		insertAfter := hwndNoTopMost
+		if enable {
+			insertAfter = hwndTopMost
+		}
+		r, _, _err := procSetWindowPos.Call(winCtx.HWND, insertAfter, 0, 0, 0, 0, swpNoMove|swpNoSize)
+		if r == 0 {
+			err = fmt.Errorf("SetWindowPos failed: %w", _err)
+		}
+	})
+	return err
+}
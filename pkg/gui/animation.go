@@ -0,0 +1,201 @@
+package gui
+
+import (
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	endFlashDuration = 300 * time.Millisecond
+	endFlashCount    = 3
+
+	// endFlashAlpha is the opacity of the flash over the window
+	// (the controls should stay visible).
+	endFlashAlpha = 160
+)
+
+var (
+	urgencyYellow = color.NRGBA{R: 255, G: 224, B: 64, A: 255}
+	urgencyRed    = color.NRGBA{R: 255, G: 64, B: 64, A: 255}
+
+	urgencyLightYellow = color.NRGBA{R: 160, G: 128, B: 0, A: 255}
+	urgencyLightRed    = color.NRGBA{R: 192, G: 0, B: 0, A: 255}
+
+	// the red of the Okabe-Ito palette (see ColorPaletteColorBlind)
+	urgencyVermilion      = color.NRGBA{R: 240, G: 110, B: 20, A: 255}
+	urgencyLightVermilion = color.NRGBA{R: 176, G: 64, B: 0, A: 255}
+)
+
+// urgencyColors returns the colors the digits shift to
+// (see urgencyDigitsColor).
+func (g *GUI) urgencyColors() (yellow, red color.NRGBA) {
+	light := g.themeVariant() == theme.VariantLight
+	switch {
+	case g.settings.ColorPalette == pomodoro.ColorPaletteColorBlind && light:
+		return urgencyLightYellow, urgencyLightVermilion
+	case g.settings.ColorPalette == pomodoro.ColorPaletteColorBlind:
+		return urgencyYellow, urgencyVermilion
+	case light:
+		return urgencyLightYellow, urgencyLightRed
+	default:
+		return urgencyYellow, urgencyRed
+	}
+}
+
+// animations keeps the currently running animations, so that they could
+// be stopped when the state of the timer changes.
+type animations struct {
+	delimiter *fyne.Animation
+	flash     *fyne.Animation
+}
+
+func blinkCurve(t float32) float32 {
+	if t < 0.5 {
+		return 0
+	}
+	return 1
+}
+
+func newDelimiterAnimation(
+	kind pomodoro.DelimiterAnimation,
+	colors pomodoro.PhaseColors,
+	fn func(color.Color),
+) *fyne.Animation {
+	var a *fyne.Animation
+	switch kind {
+	case pomodoro.DelimiterAnimationBlink:
+		a = canvas.NewColorRGBAAnimation(colors.Delimiter, colors.Background, time.Second, fn)
+		a.Curve = blinkCurve
+		a.AutoReverse = true
+	case pomodoro.DelimiterAnimationFade:
+		a = canvas.NewColorRGBAAnimation(colors.Delimiter, colors.Background, time.Second, fn)
+		a.Curve = fyne.AnimationEaseInOut
+		a.AutoReverse = true
+	case pomodoro.DelimiterAnimationPulse:
+		a = canvas.NewColorRGBAAnimation(colors.Digits, colors.Delimiter, time.Second, fn)
+		a.Curve = fyne.AnimationEaseOut
+	default:
+		return nil
+	}
+	a.RepeatCount = fyne.AnimationRepeatForever
+	return a
+}
+
+// refreshAnimations (re-)starts the delimiter animation according to
+// the settings and the state of the timer.
+func (g *GUI) refreshAnimations() {
+	if g.animations.delimiter != nil {
+		g.animations.delimiter.Stop()
+		g.animations.delimiter = nil
+	}
+	colors := g.currentColors()
+	g.Delimiter.Color = colors.Delimiter
+	g.Delimiter.Refresh()
+	if !g.view.IsRunning || !g.isShown || g.settings.PowerSaving {
+		return
+	}
+
+	a := newDelimiterAnimation(g.settings.DelimiterAnimation, colors, func(c color.Color) {
+		g.Delimiter.Color = c
+		g.Delimiter.Refresh()
+	})
+	if a == nil {
+		return
+	}
+	g.animations.delimiter = a
+	a.Start()
+}
+
+// urgencyDigitsColor returns the color of the digits shifted towards
+// yellow and then red over the last Settings.UrgencyColors of an interval,
+// so that one could feel how much time is left without reading it.
+func (g *GUI) urgencyDigitsColor(
+	base color.NRGBA,
+	timeLeft time.Duration,
+) color.NRGBA {
+	span := g.settings.UrgencyColors
+	if span <= 0 || timeLeft >= span {
+		return base
+	}
+	progress := 1 - float64(max(timeLeft, 0))/float64(span)
+	yellow, red := g.urgencyColors()
+	if progress < 0.5 {
+		return blendColors(base, yellow, progress*2)
+	}
+	return blendColors(yellow, red, progress*2-1)
+}
+
+func blendColors(
+	from color.NRGBA,
+	to color.NRGBA,
+	t float64,
+) color.NRGBA {
+	blend := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+	}
+	return color.NRGBA{
+		R: blend(from.R, to.R),
+		G: blend(from.G, to.G),
+		B: blend(from.B, to.B),
+		A: blend(from.A, to.A),
+	}
+}
+
+// applyUrgencyColor re-colors the digits on every render of the time
+// left (see urgencyDigitsColor), the steps are small enough to look
+// like a smooth transition. It returns true if the color has changed
+// (the digits are refreshed by renderDigits).
+func (g *GUI) applyUrgencyColor(
+	timeLeft time.Duration,
+) bool {
+	v := g.view
+	if g.settings.UrgencyColors <= 0 || v.Phase == pomodoro.PhaseStopwatch || v.IsOvertime || g.settings.Theme == pomodoro.ThemeVariantHighContrast {
+		return false
+	}
+	if !v.IsRunning && !v.IsPaused {
+		return false
+	}
+	c := g.urgencyDigitsColor(g.timerColors().ForPhase(v.Phase).Digits, timeLeft)
+	if g.MinutesText.Color == color.Color(c) {
+		return false
+	}
+	g.MinutesText.Color = c
+	g.SecondsText.Color = c
+	return true
+}
+
+// flashWindow flashes the whole window (over the controls) with the color
+// of the digits to draw attention to the end of an interval.
+func (g *GUI) flashWindow() {
+	g.stopFlash()
+	if !g.isShown {
+		return
+	}
+	from := g.currentColors().Digits
+	from.A = 0
+	to := from
+	to.A = endFlashAlpha
+	a := canvas.NewColorRGBAAnimation(from, to, endFlashDuration, func(c color.Color) {
+		g.flashOverlay.FillColor = c
+		g.flashOverlay.Refresh()
+	})
+	a.AutoReverse = true
+	a.RepeatCount = endFlashCount
+	g.animations.flash = a
+	a.Start()
+}
+
+func (g *GUI) stopFlash() {
+	if g.animations.flash == nil {
+		return
+	}
+	g.animations.flash.Stop()
+	g.animations.flash = nil
+	g.flashOverlay.FillColor = color.Transparent
+	g.flashOverlay.Refresh()
+}
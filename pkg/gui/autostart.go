@@ -0,0 +1,21 @@
+package gui
+
+// SetStartMinimized makes Run keep the window hidden and show only
+// the icon in the system tray (if there is a system tray).
+func (g *GUI) SetStartMinimized(minimized bool) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	g.startMinimized = minimized
+}
+
+// startsInTray sets the tray up if the window should start minimized,
+// it returns false if it should not or there is no tray.
+func (g *GUI) startsInTray() bool {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	if !g.startMinimized {
+		return false
+	}
+	g.setupTrayMenu()
+	return g.trayIcon.menuSet
+}
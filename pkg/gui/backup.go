@@ -0,0 +1,65 @@
+package gui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+func (g *GUI) showBackupDialog() {
+	d := dialog.NewFileSave(func(f fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, g.parentWindow())
+			return
+		}
+		if f == nil {
+			return
+		}
+		defer f.Close()
+		if err := g.Backup(f); err != nil {
+			dialog.ShowError(fmt.Errorf("unable to back up to '%s': %w", f.URI().Path(), err), g.parentWindow())
+		}
+	}, g.parentWindow())
+	d.SetFileName(pomodoro.DefaultBackupFileName(g.Clock().Now()))
+	d.SetFilter(storage.NewExtensionFileFilter([]string{pomodoro.BackupFileExtension}))
+	d.Show()
+}
+
+func (g *GUI) showRestoreDialog() {
+	d := dialog.NewFileOpen(func(f fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, g.parentWindow())
+			return
+		}
+		if f == nil {
+			return
+		}
+		data, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("unable to read '%s': %w", f.URI().Name(), err), g.parentWindow())
+			return
+		}
+		dialog.ShowConfirm(
+			l10n.T("Restore from a backup..."),
+			l10n.T("The settings, the profiles and the history will be replaced with the ones from '%s'.", f.URI().Name()),
+			func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				if err := g.Restore(bytes.NewReader(data), int64(len(data))); err != nil {
+					dialog.ShowError(fmt.Errorf("unable to restore from '%s': %w", f.URI().Name(), err), g.parentWindow())
+				}
+			},
+			g.parentWindow(),
+		)
+	}, g.parentWindow())
+	d.SetFilter(storage.NewExtensionFileFilter([]string{pomodoro.BackupFileExtension}))
+	d.Show()
+}
@@ -0,0 +1,131 @@
+package gui
+
+import (
+	"image/color"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	barChartMinHeight = 160
+	barChartBarWidth  = 24
+	barChartTextSize  = 11
+)
+
+type BarChartBar struct {
+	Label string
+	Value float64
+}
+
+// BarChart draws vertical bars scaled to the maximal value,
+// with the value above and the label below each bar.
+type BarChart struct {
+	widget.BaseWidget
+	Bars  []BarChartBar
+	Color color.Color
+}
+
+var _ fyne.Widget = (*BarChart)(nil)
+
+func NewBarChart(
+	bars []BarChartBar,
+	barColor color.Color,
+) *BarChart {
+	c := &BarChart{
+		Bars:  bars,
+		Color: barColor,
+	}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+func (c *BarChart) CreateRenderer() fyne.WidgetRenderer {
+	r := &barChartRenderer{chart: c}
+	r.rebuild()
+	return r
+}
+
+type barChartRenderer struct {
+	chart   *BarChart
+	rects   []*canvas.Rectangle
+	values  []*canvas.Text
+	labels  []*canvas.Text
+	objects []fyne.CanvasObject
+}
+
+func newBarChartText(text string) *canvas.Text {
+	t := canvas.NewText(text, theme.Color(theme.ColorNameForeground))
+	t.TextSize = barChartTextSize
+	t.Alignment = fyne.TextAlignCenter
+	return t
+}
+
+func (r *barChartRenderer) rebuild() {
+	r.rects, r.values, r.labels, r.objects = nil, nil, nil, nil
+	for _, bar := range r.chart.Bars {
+		rect := canvas.NewRectangle(r.chart.Color)
+		value := newBarChartText("")
+		if bar.Value > 0 {
+			value.Text = strconv.FormatFloat(bar.Value, 'f', -1, 64)
+		}
+		label := newBarChartText(bar.Label)
+		r.rects = append(r.rects, rect)
+		r.values = append(r.values, value)
+		r.labels = append(r.labels, label)
+		r.objects = append(r.objects, rect, value, label)
+	}
+}
+
+func (r *barChartRenderer) Layout(size fyne.Size) {
+	count := len(r.chart.Bars)
+	if count == 0 {
+		return
+	}
+	var maxValue float64
+	for _, bar := range r.chart.Bars {
+		maxValue = max(maxValue, bar.Value)
+	}
+	textHeight := newBarChartText("0").MinSize().Height
+	slotWidth := size.Width / float32(count)
+	barWidth := min(slotWidth*0.8, barChartBarWidth)
+	chartHeight := size.Height - 2*textHeight
+	for idx, bar := range r.chart.Bars {
+		height := float32(0)
+		if maxValue > 0 {
+			height = chartHeight * float32(bar.Value/maxValue)
+		}
+		x := slotWidth * float32(idx)
+		top := textHeight + chartHeight - height
+		r.rects[idx].Resize(fyne.NewSize(barWidth, height))
+		r.rects[idx].Move(fyne.NewPos(x+(slotWidth-barWidth)/2, top))
+		r.values[idx].Resize(fyne.NewSize(slotWidth, textHeight))
+		r.values[idx].Move(fyne.NewPos(x, top-textHeight))
+		r.labels[idx].Resize(fyne.NewSize(slotWidth, textHeight))
+		r.labels[idx].Move(fyne.NewPos(x, size.Height-textHeight))
+	}
+}
+
+func (r *barChartRenderer) MinSize() fyne.Size {
+	var labelWidth float32
+	for _, label := range r.labels {
+		labelWidth = max(labelWidth, label.MinSize().Width)
+	}
+	width := max(labelWidth, barChartBarWidth) * float32(len(r.chart.Bars))
+	return fyne.NewSize(width, barChartMinHeight)
+}
+
+func (r *barChartRenderer) Refresh() {
+	r.rebuild()
+	r.Layout(r.chart.Size())
+	canvas.Refresh(r.chart)
+}
+
+func (r *barChartRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *barChartRenderer) Destroy() {}
@@ -0,0 +1,101 @@
+package gui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2/data/binding"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// TimerBindings expose the state displayed by the timer, so that
+// an embedder could attach its own widgets to it
+// (for example, with widget.NewLabelWithData).
+type TimerBindings struct {
+	Minutes     binding.Int
+	Seconds     binding.Int
+	Tenths      binding.Int // -1 if not shown (see Settings.ShowTenths)
+	Description binding.String
+	EndsAt      binding.String // empty if not counting down (see PhaseEndsAt)
+	Overtime    binding.Bool   // the time is past the end (see Settings.Overtime)
+}
+
+func newTimerBindings() TimerBindings {
+	tenths := binding.NewInt()
+	_ = tenths.Set(-1)
+	return TimerBindings{
+		Minutes:     binding.NewInt(),
+		Seconds:     binding.NewInt(),
+		Tenths:      tenths,
+		Description: binding.NewString(),
+		EndsAt:      binding.NewString(),
+		Overtime:    binding.NewBool(),
+	}
+}
+
+// bindTexts makes the canvas texts of the timer follow the bindings. The
+// digits are not among them: they change on every tick, so they are
+// rendered right away (see renderDigits) instead of by the listeners
+// called in the background one binding at a time.
+func (g *GUI) bindTexts() {
+	g.Bindings.Description.AddListener(binding.NewDataListener(func() {
+		g.Description.Text, _ = g.Bindings.Description.Get()
+		g.Description.Refresh()
+	}))
+	g.Bindings.EndsAt.AddListener(binding.NewDataListener(func() {
+		g.EndsAtText.Text, _ = g.Bindings.EndsAt.Get()
+		g.EndsAtText.Refresh()
+	}))
+}
+
+func formatMinutes(
+	minutes uint,
+	overtime bool,
+) string {
+	if overtime {
+		return fmt.Sprintf("-%02d", minutes)
+	}
+	return fmt.Sprintf("%2d", minutes)
+}
+
+// formatSeconds formats the seconds, with the tenths
+// unless they are negative.
+func formatSeconds(
+	seconds uint,
+	tenths int,
+) string {
+	if tenths < 0 {
+		return fmt.Sprintf("%02d", seconds)
+	}
+	return fmt.Sprintf("%02d.%d", seconds, tenths)
+}
+
+// renderDigits shows the time, refreshing only the changed texts (so the
+// minutes are refreshed once a minute) unless recolored is true. If the
+// width of a text changes, the digits are re-centered and re-scaled (see
+// digitsLayout) by a single refresh of their container instead.
+func (g *GUI) renderDigits(
+	d pomodoro.Digits,
+	recolored bool,
+) {
+	_ = g.Bindings.Overtime.Set(d.Minus)
+	_ = g.Bindings.Minutes.Set(int(d.Minutes))
+	_ = g.Bindings.Seconds.Set(int(d.Seconds))
+	_ = g.Bindings.Tenths.Set(d.Tenths)
+	minutes := formatMinutes(d.Minutes, d.Minus)
+	seconds := formatSeconds(d.Seconds, d.Tenths)
+	minutesChanged := recolored || minutes != g.MinutesText.Text
+	secondsChanged := recolored || seconds != g.SecondsText.Text
+	widthChanged := len(minutes) != len(g.MinutesText.Text) || len(seconds) != len(g.SecondsText.Text)
+	g.MinutesText.Text = minutes
+	g.SecondsText.Text = seconds
+	if widthChanged {
+		g.digitsContainer.Refresh()
+		return
+	}
+	if minutesChanged {
+		g.MinutesText.Refresh()
+	}
+	if secondsChanged {
+		g.SecondsText.Refresh()
+	}
+}
@@ -0,0 +1,65 @@
+package gui
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// newTestDigits creates only the digits of the timer (see newContent)
+// in a window.
+func newTestDigits(b *testing.B) *GUI {
+	test.NewTempApp(b)
+	g := &GUI{Bindings: newTimerBindings()}
+	g.MinutesText = canvas.NewText("", color.White)
+	g.MinutesText.TextSize = digitsTextSize
+	g.Delimiter = canvas.NewText(":", color.White)
+	g.Delimiter.TextSize = digitsTextSize
+	g.SecondsText = canvas.NewText("", color.White)
+	g.SecondsText.TextSize = digitsTextSize
+	g.digitsContainer = container.New(
+		newDigitsLayout(g.MinutesText, g.Delimiter, g.SecondsText),
+		g.MinutesText,
+		g.Delimiter,
+		g.SecondsText,
+	)
+	w := test.NewWindow(g.digitsContainer)
+	b.Cleanup(w.Close)
+	w.Resize(fyne.NewSize(300, 200))
+	return g
+}
+
+// BenchmarkRenderDigits is the countdown of an hour, a second per tick.
+func BenchmarkRenderDigits(b *testing.B) {
+	g := newTestDigits(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for idx := 0; idx < b.N; idx++ {
+		left := 3599 - idx%3600
+		g.renderDigits(pomodoro.Digits{
+			Minutes: uint(left / 60),
+			Seconds: uint(left % 60),
+			Tenths:  -1,
+		}, false)
+	}
+}
+
+// BenchmarkRenderDigitsTenths is the countdown of the last seconds,
+// a tenth of a second per tick (see Settings.ShowTenths).
+func BenchmarkRenderDigitsTenths(b *testing.B) {
+	g := newTestDigits(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for idx := 0; idx < b.N; idx++ {
+		left := 99 - idx%100
+		g.renderDigits(pomodoro.Digits{
+			Seconds: uint(left / 10),
+			Tenths:  left % 10,
+		}, false)
+	}
+}
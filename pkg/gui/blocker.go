@@ -0,0 +1,29 @@
+package gui
+
+import (
+	"fyne.io/fyne/v2/dialog"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// showUnblockDistractionsDialog asks to confirm the override, since
+// it is exactly the moment the blocker is for.
+func (g *GUI) showUnblockDistractionsDialog() {
+	w := g.parentWindow()
+	if w == nil {
+		return
+	}
+	if !g.IsBlockingDistractions() {
+		dialog.ShowInformation(l10n.T("Unblock the distractions..."), l10n.T("Nothing is blocked right now."), w)
+		return
+	}
+	dialog.ShowConfirm(
+		l10n.T("Unblock the distractions..."),
+		l10n.T("The blocked sites and applications will be allowed until the end of this session.\n\nIs it really necessary?"),
+		func(confirmed bool) {
+			if confirmed {
+				g.UnblockDistractions()
+			}
+		},
+		w,
+	)
+}
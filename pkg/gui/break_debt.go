@@ -0,0 +1,23 @@
+package gui
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+func (g *GUI) refreshBreakDebt() {
+	if g.settings.BreakDebt.Limit == 0 || g.view.BreakDebt < time.Minute {
+		g.BreakDebtText.Text = ""
+		g.BreakDebtText.Hide()
+		return
+	}
+	g.BreakDebtText.Text = l10n.T("Break debt: %d min", int(g.view.BreakDebt/time.Minute))
+	g.BreakDebtText.Color = g.secondaryTextColor(color.Gray{Y: 160})
+	if g.view.BreakDebt >= g.settings.BreakDebt.Limit {
+		g.BreakDebtText.Color = color.NRGBA{R: 255, G: 215, A: 255}
+	}
+	g.BreakDebtText.Show()
+	g.BreakDebtText.Refresh()
+}
@@ -0,0 +1,189 @@
+package gui
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// lightTheme is DefaultTheme for the light background.
+func lightTheme() pomodoro.Theme {
+	return pomodoro.Theme{
+		Idle: pomodoro.PhaseColors{
+			Digits:      color.NRGBA{R: 32, G: 32, B: 32, A: 255},
+			Delimiter:   color.NRGBA{R: 128, G: 128, B: 128, A: 255},
+			Description: color.NRGBA{R: 64, G: 64, B: 64, A: 255},
+		},
+		Work: pomodoro.PhaseColors{
+			Digits:      color.NRGBA{R: 128, G: 0, B: 0, A: 255},
+			Delimiter:   color.NRGBA{R: 176, G: 112, B: 112, A: 255},
+			Description: color.NRGBA{R: 160, G: 48, B: 48, A: 255},
+			Background:  color.NRGBA{R: 255, G: 0, B: 0, A: 24},
+		},
+		Rest: pomodoro.PhaseColors{
+			Digits:      color.NRGBA{R: 0, G: 96, B: 0, A: 255},
+			Delimiter:   color.NRGBA{R: 112, G: 160, B: 112, A: 255},
+			Description: color.NRGBA{R: 48, G: 128, B: 48, A: 255},
+			Background:  color.NRGBA{R: 0, G: 255, B: 0, A: 24},
+		},
+		LongRest: pomodoro.PhaseColors{
+			Digits:      color.NRGBA{R: 0, G: 64, B: 128, A: 255},
+			Delimiter:   color.NRGBA{R: 112, G: 136, B: 176, A: 255},
+			Description: color.NRGBA{R: 48, G: 96, B: 160, A: 255},
+			Background:  color.NRGBA{R: 0, G: 128, B: 255, A: 24},
+		},
+	}
+}
+
+// colorBlindTheme is the theme of ColorPaletteColorBlind
+// for the dark background.
+func colorBlindTheme() pomodoro.Theme {
+	t := pomodoro.DefaultTheme()
+	t.Work = pomodoro.PhaseColors{
+		Digits:      color.NRGBA{R: 255, G: 200, B: 120, A: 255},
+		Delimiter:   color.NRGBA{R: 170, G: 120, B: 40, A: 255},
+		Description: color.NRGBA{R: 240, G: 180, B: 90, A: 255},
+		Background:  color.NRGBA{R: 90, G: 50, B: 0, A: 64},
+	}
+	t.Rest = pomodoro.PhaseColors{
+		Digits:      color.NRGBA{R: 170, G: 220, B: 255, A: 255},
+		Delimiter:   color.NRGBA{R: 60, G: 130, B: 180, A: 255},
+		Description: color.NRGBA{R: 120, G: 190, B: 240, A: 255},
+		Background:  color.NRGBA{R: 0, G: 40, B: 80, A: 64},
+	}
+	t.LongRest = pomodoro.PhaseColors{
+		Digits:      color.NRGBA{R: 240, G: 190, B: 220, A: 255},
+		Delimiter:   color.NRGBA{R: 160, G: 100, B: 140, A: 255},
+		Description: color.NRGBA{R: 220, G: 150, B: 190, A: 255},
+		Background:  color.NRGBA{R: 60, G: 0, B: 50, A: 64},
+	}
+	return t
+}
+
+// colorBlindLightTheme is the theme of ColorPaletteColorBlind
+// for the light background.
+func colorBlindLightTheme() pomodoro.Theme {
+	t := lightTheme()
+	t.Work = pomodoro.PhaseColors{
+		Digits:      color.NRGBA{R: 160, G: 70, B: 0, A: 255},
+		Delimiter:   color.NRGBA{R: 200, G: 140, B: 80, A: 255},
+		Description: color.NRGBA{R: 190, G: 100, B: 0, A: 255},
+		Background:  color.NRGBA{R: 230, G: 159, B: 0, A: 32},
+	}
+	t.Rest = pomodoro.PhaseColors{
+		Digits:      color.NRGBA{R: 0, G: 90, B: 150, A: 255},
+		Delimiter:   color.NRGBA{R: 100, G: 150, B: 190, A: 255},
+		Description: color.NRGBA{R: 0, G: 114, B: 178, A: 255},
+		Background:  color.NRGBA{R: 86, G: 180, B: 233, A: 32},
+	}
+	t.LongRest = pomodoro.PhaseColors{
+		Digits:      color.NRGBA{R: 130, G: 50, B: 100, A: 255},
+		Delimiter:   color.NRGBA{R: 180, G: 120, B: 160, A: 255},
+		Description: color.NRGBA{R: 160, G: 80, B: 130, A: 255},
+		Background:  color.NRGBA{R: 204, G: 121, B: 167, A: 32},
+	}
+	return t
+}
+
+// timerColors returns the colors of the timer for the variant of the
+// theme: the customized Settings.Colors are used as is (unless the
+// color-blind palette is chosen), the default ones are for the dark
+// background only.
+func timerColors(
+	s pomodoro.Settings,
+	variant fyne.ThemeVariant,
+) pomodoro.Theme {
+	light := variant == theme.VariantLight
+	switch {
+	case s.ColorPalette == pomodoro.ColorPaletteColorBlind && light:
+		return colorBlindLightTheme()
+	case s.ColorPalette == pomodoro.ColorPaletteColorBlind:
+		return colorBlindTheme()
+	case light && s.Colors == pomodoro.DefaultTheme():
+		return lightTheme()
+	default:
+		return s.Colors
+	}
+}
+
+// themeVariant returns whether the window is light or dark now
+// (following the system unless the theme is chosen explicitly).
+func (g *GUI) themeVariant() fyne.ThemeVariant {
+	switch g.settings.Theme {
+	case pomodoro.ThemeVariantDark, pomodoro.ThemeVariantHighContrast:
+		return theme.VariantDark
+	case pomodoro.ThemeVariantLight:
+		return theme.VariantLight
+	default:
+		return g.App.Settings().ThemeVariant()
+	}
+}
+
+// chartColor is the color of the bars and of the heatmap in the
+// statistics.
+func (g *GUI) chartColor() color.Color {
+	if g.settings.ColorPalette == pomodoro.ColorPaletteColorBlind {
+		return g.timerColors().Work.Description
+	}
+	return theme.Color(theme.ColorNamePrimary)
+}
+
+// followSystemTheme re-colors the timer once the system switches
+// between the light and the dark theme.
+func (g *GUI) followSystemTheme() {
+	changes := make(chan fyne.Settings, 1)
+	g.App.Settings().AddChangeListener(changes)
+	for {
+		select {
+		case <-g.ctx.Done():
+			return
+		case <-changes:
+		}
+		g.locker.Lock()
+		g.applyColors()
+		g.applyTextStyle()
+		g.locker.Unlock()
+	}
+}
+
+func (g *GUI) currentColors() pomodoro.PhaseColors {
+	v := g.view
+	if g.settings.Theme == pomodoro.ThemeVariantHighContrast {
+		return highContrastColors
+	}
+	if !v.IsRunning && !v.IsPaused {
+		return g.timerColors().Idle
+	}
+	colors := g.timerColors().ForPhase(v.Phase)
+	if v.IsOvertime {
+		colors.Digits = g.overtimeDigitsColor()
+		return colors
+	}
+	if v.Phase != pomodoro.PhaseStopwatch {
+		colors.Digits = g.urgencyDigitsColor(colors.Digits, v.TimeLeft)
+	}
+	return colors
+}
+
+func (g *GUI) applyColors() {
+	g.stopFlash()
+	colors := g.currentColors()
+	g.MinutesText.Color = colors.Digits
+	g.SecondsText.Color = colors.Digits
+	g.Description.Color = colors.Description
+	g.Background.FillColor = colors.Background
+	track := colors.Digits
+	track.A = 32
+	g.ProgressRing.SetColors(colors.Digits, track)
+	g.MinutesText.Refresh()
+	g.SecondsText.Refresh()
+	g.Description.Refresh()
+	g.Background.Refresh()
+	g.refreshAnimations()
+}
+
+func (g *GUI) timerColors() pomodoro.Theme {
+	return timerColors(g.settings, g.themeVariant())
+}
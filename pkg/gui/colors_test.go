@@ -0,0 +1,107 @@
+package gui
+
+import (
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/xaionaro-go/pomodoro/pkg/datadir"
+)
+
+// themeSwitchingApp is an application which system theme is switched
+// by the test.
+type themeSwitchingApp struct {
+	fyne.App
+	settings *themeSwitchingSettings
+}
+
+func (a themeSwitchingApp) Settings() fyne.Settings {
+	return a.settings
+}
+
+type themeSwitchingSettings struct {
+	fyne.Settings
+
+	locker    sync.Mutex
+	theme     fyne.Theme
+	variant   fyne.ThemeVariant
+	listeners []chan fyne.Settings
+}
+
+// SetTheme keeps the theme instead of applying it: the test application
+// would clear the caches in the background, while they are used.
+func (s *themeSwitchingSettings) SetTheme(theme fyne.Theme) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	s.theme = theme
+}
+
+func (s *themeSwitchingSettings) ThemeVariant() fyne.ThemeVariant {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	return s.variant
+}
+
+func (s *themeSwitchingSettings) AddChangeListener(listener chan fyne.Settings) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	s.listeners = append(s.listeners, listener)
+}
+
+// switchTo changes the variant, returning false if nobody listens
+// for the change yet.
+func (s *themeSwitchingSettings) switchTo(variant fyne.ThemeVariant) bool {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	s.variant = variant
+	for _, listener := range s.listeners {
+		select {
+		case listener <- s:
+		default:
+		}
+	}
+	return len(s.listeners) > 0
+}
+
+// TestWidgetFollowsSystemTheme checks that the embedded timer (which has
+// no window of its own) is re-colored once the system theme changes.
+func TestWidgetFollowsSystemTheme(t *testing.T) {
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	testApp := test.NewTempApp(t)
+	settings := &themeSwitchingSettings{
+		Settings: testApp.Settings(),
+		variant:  theme.VariantDark,
+	}
+	g, content := NewWidget(themeSwitchingApp{App: testApp, settings: settings}, datadir.Dirs{Data: t.TempDir()})
+	defer g.Close()
+	w := test.NewWindow(content)
+	defer w.Close()
+
+	digitsColor := func() any {
+		g.locker.Lock()
+		defer g.locker.Unlock()
+		return g.MinutesText.Color
+	}
+	expected := lightTheme().Idle.Digits
+	if digitsColor() == any(expected) {
+		t.Fatalf("expected the dark theme colors before the switch")
+	}
+	for deadline := time.Now().Add(time.Second); !settings.switchTo(theme.VariantLight); {
+		// the listener is subscribed in the background
+		if time.Now().After(deadline) {
+			t.Fatalf("the timer does not listen for the changes of the theme")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for deadline := time.Now().Add(time.Second); digitsColor() != any(expected); {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the digits to be recolored to %v, got %v", expected, digitsColor())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
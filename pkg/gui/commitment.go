@@ -0,0 +1,67 @@
+package gui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// unlessCommitted wraps a bail-out action of the UI: during a committed
+// work session it asks for the phrase first (or does nothing if
+// the session is locked).
+func (g *GUI) unlessCommitted(action func()) func() {
+	return func() {
+		if !g.IsCommitted() {
+			action()
+			return
+		}
+		g.locker.Lock()
+		commitment := g.settings.Commitment
+		g.locker.Unlock()
+		w := g.parentWindow()
+		if commitment.Mode != pomodoro.CommitmentModePhrase || w == nil {
+			return
+		}
+
+		phraseEntry := widget.NewEntry()
+		phraseEntry.SetPlaceHolder(commitment.Phrase)
+		dialog.ShowForm(
+			l10n.T("You committed to this session"),
+			l10n.T("Continue"),
+			l10n.T("Cancel"),
+			[]*widget.FormItem{
+				widget.NewFormItem(l10n.T("Type '%s'", commitment.Phrase), phraseEntry),
+			},
+			func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				if err := g.ReleaseCommitment(phraseEntry.Text); err != nil {
+					dialog.ShowError(err, w)
+					return
+				}
+				action()
+			},
+			w,
+		)
+	}
+}
+
+// refreshCommitmentControls disables the bail-out controls
+// while a locked work session runs.
+func (g *GUI) refreshCommitmentControls() {
+	locked := g.view.IsCommitted && g.settings.Commitment.Mode == pomodoro.CommitmentModeLocked
+	controls := append([]fyne.Disableable{}, g.commitmentControls...)
+	for _, c := range g.presetsContainer.Objects {
+		controls = append(controls, c.(fyne.Disableable))
+	}
+	for _, c := range controls {
+		if locked {
+			c.Disable()
+		} else {
+			c.Enable()
+		}
+	}
+}
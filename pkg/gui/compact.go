@@ -0,0 +1,126 @@
+package gui
+
+import (
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+func (g *GUI) ToggleCompactMode() {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	g.setCompactMode(!g.isCompact)
+}
+
+func (g *GUI) SetCompactMode(
+	isCompact bool,
+) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	g.setCompactMode(isCompact)
+}
+
+func (g *GUI) setCompactMode(
+	isCompact bool,
+) {
+	if g.isPresenting {
+		g.setPresentationMode(false)
+	}
+	g.isCompact = isCompact
+	if isCompact {
+		g.descriptionContainer.Hide()
+		g.goalContainer.Hide()
+		g.controlsContainer.Hide()
+	} else {
+		g.descriptionContainer.Show()
+		g.goalContainer.Show()
+		g.controlsContainer.Show()
+	}
+	if g.Window == nil {
+		// embedded into another application, which manages the window
+		return
+	}
+	if isCompact {
+		g.Window.SetMainMenu(nil)
+	} else {
+		g.Window.SetMainMenu(g.newMainMenu())
+	}
+	if err := setAlwaysOnTop(g.Window, isCompact); err != nil {
+		slog.Error("unable to change the always-on-top state of the window", "error", err)
+	}
+	g.Window.Resize(g.Window.Content().MinSize())
+}
+
+// TogglePresentationMode shows only the phase and the time (scaled up
+// to the screen, see digitsLayout) in full screen, for projecting
+// the timer at workshops.
+func (g *GUI) TogglePresentationMode() {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	g.setPresentationMode(!g.isPresenting)
+}
+
+func (g *GUI) ExitPresentationMode() {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	if g.isPresenting {
+		g.setPresentationMode(false)
+	}
+}
+
+func (g *GUI) setPresentationMode(
+	isPresenting bool,
+) {
+	g.isPresenting = isPresenting
+	if isPresenting {
+		g.descriptionContainer.Show()
+		g.goalContainer.Hide()
+		g.controlsContainer.Hide()
+	} else if !g.isCompact {
+		g.goalContainer.Show()
+		g.controlsContainer.Show()
+	} else {
+		g.descriptionContainer.Hide()
+	}
+	if g.Window == nil {
+		// embedded into another application, which manages the window
+		return
+	}
+	if isPresenting || g.isCompact {
+		g.Window.SetMainMenu(nil)
+	} else {
+		g.Window.SetMainMenu(g.newMainMenu())
+	}
+	g.Window.SetFullScreen(isPresenting)
+}
+
+type doubleTapArea struct {
+	widget.BaseWidget
+	Content        fyne.CanvasObject
+	OnDoubleTapped func()
+}
+
+var _ fyne.DoubleTappable = (*doubleTapArea)(nil)
+
+func newDoubleTapArea(
+	content fyne.CanvasObject,
+	onDoubleTapped func(),
+) *doubleTapArea {
+	a := &doubleTapArea{
+		Content:        content,
+		OnDoubleTapped: onDoubleTapped,
+	}
+	a.ExtendBaseWidget(a)
+	return a
+}
+
+func (a *doubleTapArea) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(a.Content)
+}
+
+func (a *doubleTapArea) DoubleTapped(*fyne.PointEvent) {
+	if a.OnDoubleTapped != nil {
+		a.OnDoubleTapped()
+	}
+}
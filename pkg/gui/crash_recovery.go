@@ -0,0 +1,31 @@
+package gui
+
+import (
+	"fyne.io/fyne/v2/dialog"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// OfferRecovery implements pomodoro.Frontend. It is called while
+// the timer is created, so the offer is shown once the window is
+// (see showPendingRecovery).
+func (g *GUI) OfferRecovery(message string) bool {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	if !g.isShown {
+		return false
+	}
+	g.pendingRecovery = message
+	return true
+}
+
+func (g *GUI) showPendingRecovery() {
+	g.locker.Lock()
+	message := g.pendingRecovery
+	g.pendingRecovery = ""
+	w := g.window()
+	g.locker.Unlock()
+	if message == "" || w == nil {
+		return
+	}
+	dialog.ShowConfirm(l10n.T("Resume the interrupted interval?"), message, g.RecoverSession, w)
+}
@@ -0,0 +1,42 @@
+package gui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// ShowDailySummary opens a window with the summary of the day.
+func (g *GUI) ShowDailySummary() {
+	g.showDailySummary(g.DailySummary())
+}
+
+func (g *GUI) showDailySummary(summary pomodoro.DailySummary) {
+	goal := "—"
+	if summary.Goal > 0 {
+		goal = fmt.Sprintf("%d/%d", summary.Sessions, summary.Goal)
+	}
+	form := widget.NewForm(
+		widget.NewFormItem(l10n.T("Sessions completed"), widget.NewLabel(fmt.Sprint(summary.Sessions))),
+		widget.NewFormItem(l10n.T("Focus time"), widget.NewLabel(summary.Focus.Round(time.Minute).String())),
+		widget.NewFormItem(l10n.T("Interruptions"), widget.NewLabel(fmt.Sprint(summary.Interruptions))),
+		widget.NewFormItem(l10n.T("Daily goal"), widget.NewLabel(goal)),
+	)
+	for _, task := range summary.Tasks {
+		name := task.Task
+		if name == "" {
+			name = l10n.T("(no task)")
+		}
+		form.Append(name, widget.NewLabel(task.Focus.Round(time.Minute).String()))
+	}
+
+	w := g.App.NewWindow(fmt.Sprintf("%s — %s", windowTitle, l10n.T("Today's summary")))
+	w.SetContent(container.NewPadded(form))
+	w.Resize(fyne.NewSize(360, 0))
+	w.Show()
+}
@@ -0,0 +1,51 @@
+package gui
+
+import (
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"github.com/xaionaro-go/pomodoro/pkg/datadir"
+)
+
+var _ fyne.Preferences = (*datadir.Preferences)(nil)
+
+// appWithPreferences replaces the preferences of the Fyne application
+// (see datadir.Dirs.Config).
+type appWithPreferences struct {
+	fyne.App
+	prefs fyne.Preferences
+}
+
+func (a *appWithPreferences) Preferences() fyne.Preferences {
+	return a.prefs
+}
+
+func withDirs(
+	a fyne.App,
+	dirs datadir.Dirs,
+) fyne.App {
+	if dirs.Config == "" {
+		return a
+	}
+	prefs, err := datadir.OpenPreferences(dirs.Config)
+	if err != nil {
+		slog.Error("unable to open the preferences, using the default ones", "dir", dirs.Config, "error", err)
+		return a
+	}
+	return &appWithPreferences{App: a, prefs: prefs}
+}
+
+// appDataDir returns the directory for the history and the sounds,
+// empty if there is no storage.
+func appDataDir(
+	a fyne.App,
+	dirs datadir.Dirs,
+) string {
+	if dirs.Data != "" {
+		return dirs.Data
+	}
+	if rootURI := a.Storage().RootURI(); rootURI != nil {
+		return rootURI.Path()
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package gui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+const (
+	digitsTextSize = 90
+)
+
+// digitsLayout places the texts of the time in a row in the center,
+// scaling their font up to fill the available space (but not below
+// digitsTextSize), so that the time is readable from afar on a large
+// window (see TogglePresentationMode).
+type digitsLayout struct {
+	texts []*canvas.Text
+}
+
+var _ fyne.Layout = (*digitsLayout)(nil)
+
+func newDigitsLayout(texts ...*canvas.Text) *digitsLayout {
+	return &digitsLayout{
+		texts: texts,
+	}
+}
+
+func (l *digitsLayout) measure(textSize float32) fyne.Size {
+	var result fyne.Size
+	for _, text := range l.texts {
+		size := fyne.MeasureText(text.Text, textSize, text.TextStyle)
+		result.Width += size.Width
+		result.Height = max(result.Height, size.Height)
+	}
+	return result
+}
+
+func (l *digitsLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	return l.measure(digitsTextSize)
+}
+
+func (l *digitsLayout) Layout(
+	_ []fyne.CanvasObject,
+	size fyne.Size,
+) {
+	textSize := float32(digitsTextSize)
+	if natural := l.measure(textSize); natural.Width > 0 && natural.Height > 0 {
+		scale := min(size.Width/natural.Width, size.Height/natural.Height)
+		textSize = max(textSize, textSize*scale)
+	}
+
+	total := l.measure(textSize)
+	x := (size.Width - total.Width) / 2
+	y := (size.Height - total.Height) / 2
+	for _, text := range l.texts {
+		if text.TextSize != textSize {
+			text.TextSize = textSize
+			text.Refresh()
+		}
+		width := fyne.MeasureText(text.Text, textSize, text.TextStyle).Width
+		text.Move(fyne.NewPos(x, y))
+		text.Resize(fyne.NewSize(width, total.Height))
+		x += width
+	}
+}
@@ -0,0 +1,82 @@
+package gui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// handleEndActionsEvent offers what to do next when an interval ends
+// (see Settings.EndActionChooser), the dialog is closed if the next
+// interval is started in any other way.
+func (g *GUI) handleEndActionsEvent(ev pomodoro.Event) {
+	switch ev.Type {
+	case pomodoro.EventTypePhaseEnded:
+		g.locker.Lock()
+		enabled := g.settings.EndActionChooser
+		g.locker.Unlock()
+		if !enabled || ev.Phase == pomodoro.PhaseStopwatch {
+			return
+		}
+		g.showEndActionsDialog(ev.Phase)
+	case pomodoro.EventTypePhaseStarted, pomodoro.EventTypeStopped:
+		g.locker.Lock()
+		defer g.locker.Unlock()
+		g.closeEndActionsDialog()
+	}
+}
+
+func (g *GUI) closeEndActionsDialog() {
+	if g.endActionsDialog == nil {
+		return
+	}
+	g.endActionsDialog.Hide()
+	g.endActionsDialog = nil
+}
+
+func (g *GUI) showEndActionsDialog(ended pomodoro.Phase) {
+	w := g.parentWindow()
+	if w == nil {
+		return
+	}
+
+	var d *dialog.CustomDialog
+	action := func(fn func()) func() {
+		return func() {
+			d.Hide()
+			fn()
+		}
+	}
+	var buttons []fyne.CanvasObject
+	title := l10n.T("The break is over")
+	if ended == pomodoro.PhaseWork {
+		title = l10n.T("The focus session is over")
+		buttons = append(buttons,
+			widget.NewButtonWithIcon(l10n.T("Start break"), theme.MediaPlayIcon(), action(func() { g.Start(false) })),
+			widget.NewButtonWithIcon(l10n.T("One more pomodoro"), theme.MediaReplayIcon(), action(g.afterRitual(func() { g.Start(true) }))),
+		)
+	} else {
+		buttons = append(buttons,
+			widget.NewButtonWithIcon(l10n.T("Start work"), theme.MediaPlayIcon(), action(g.afterRitual(func() { g.Start(true) }))),
+		)
+	}
+	buttons = append(buttons,
+		widget.NewButtonWithIcon(l10n.T("Extend 5 min"), theme.ContentAddIcon(), action(func() { g.ContinueEnded(pomodoro.ExtendStep) })),
+		widget.NewButtonWithIcon(l10n.T("Done for now"), theme.MediaStopIcon(), action(g.DoneForNow)),
+	)
+	d = dialog.NewCustomWithoutButtons(title, container.NewVBox(buttons...), w)
+
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	if g.view.IsRunning || g.view.IsPaused {
+		// already started by auto-continue or by the user
+		return
+	}
+	g.closeEndActionsDialog()
+	g.endActionsDialog = d
+	d.Show()
+}
@@ -0,0 +1,18 @@
+package gui
+
+import (
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// handleEndsAtEvent updates Bindings.EndsAt, the ticks are handled
+// as well, since the deadline is moved by Extend, the suspends and
+// the changes of the wall clock.
+func (g *GUI) handleEndsAtEvent(pomodoro.Event) {
+	endsAt, ok := g.PhaseEndsAt()
+	if !ok {
+		_ = g.Bindings.EndsAt.Set("")
+		return
+	}
+	_ = g.Bindings.EndsAt.Set(l10n.T("ends at %s", l10n.FormatClock(endsAt)))
+}
@@ -0,0 +1,86 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// newTagSelect creates a selector of the tags with the first option
+// meaning no filtering (an empty tag is passed to onChanged).
+func (g *GUI) newTagSelect(onChanged func(tag string)) *widget.Select {
+	options := []string{l10n.T("(all tags)")}
+	for _, tag := range g.Tags() {
+		options = append(options, "#"+tag)
+	}
+	tagSelect := widget.NewSelect(options, nil)
+	tagSelect.SetSelectedIndex(0)
+	tagSelect.OnChanged = func(string) {
+		if onChanged != nil {
+			onChanged(selectedTag(tagSelect))
+		}
+	}
+	return tagSelect
+}
+
+func selectedTag(tagSelect *widget.Select) string {
+	if tagSelect.SelectedIndex() <= 0 {
+		return ""
+	}
+	return strings.TrimPrefix(tagSelect.Selected, "#")
+}
+
+func (g *GUI) showExportDialog(format history.Format) {
+	if len(g.Tags()) == 0 {
+		g.showExportFileDialog(format, "")
+		return
+	}
+
+	tagSelect := g.newTagSelect(nil)
+	dialog.ShowForm(
+		l10n.T("Export history as %s...", strings.ToUpper(string(format))),
+		l10n.T("Export"),
+		l10n.T("Cancel"),
+		[]*widget.FormItem{
+			widget.NewFormItem(l10n.T("Tag"), tagSelect),
+		},
+		func(confirmed bool) {
+			if confirmed {
+				g.showExportFileDialog(format, selectedTag(tagSelect))
+			}
+		},
+		g.parentWindow(),
+	)
+}
+
+func (g *GUI) showExportFileDialog(
+	format history.Format,
+	tag string,
+) {
+	d := dialog.NewFileSave(func(f fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, g.parentWindow())
+			return
+		}
+		if f == nil {
+			return
+		}
+		defer f.Close()
+		if err := g.ExportHistory(f, format, tag); err != nil {
+			dialog.ShowError(fmt.Errorf("unable to export the history to '%s': %w", f.URI().Path(), err), g.parentWindow())
+		}
+	}, g.parentWindow())
+	fileName := "pomodoro-history"
+	if tag != "" {
+		fileName += "-" + tag
+	}
+	d.SetFileName(fileName + "." + string(format))
+	d.SetFilter(storage.NewExtensionFileFilter([]string{"." + string(format)}))
+	d.Show()
+}
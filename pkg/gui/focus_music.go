@@ -0,0 +1,59 @@
+package gui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// focusMusicPanel controls the focus music (see pomodoro.FocusMusicSettings).
+type focusMusicPanel struct {
+	panel           *widget.Accordion
+	trackLabel      *widget.Label
+	playPauseButton *widget.Button
+	volumeSlider    *widget.Slider
+}
+
+func (g *GUI) newFocusMusicPanel() fyne.CanvasObject {
+	m := &g.focusMusic
+	m.trackLabel = widget.NewLabel("")
+	m.trackLabel.Truncation = fyne.TextTruncateEllipsis
+	m.playPauseButton = widget.NewButtonWithIcon("", theme.MediaPauseIcon(), func() { g.ToggleFocusMusic() })
+	m.volumeSlider = widget.NewSlider(0, 1)
+	m.volumeSlider.Step = 0.05
+	m.volumeSlider.OnChangeEnded = func(volume float64) { g.SetFocusMusicVolume(volume) }
+	m.panel = widget.NewAccordion(widget.NewAccordionItem(
+		l10n.T("Focus music"),
+		container.NewBorder(
+			nil, nil,
+			container.NewHBox(
+				widget.NewButtonWithIcon("", theme.MediaSkipPreviousIcon(), func() { g.PreviousFocusTrack() }),
+				m.playPauseButton,
+				widget.NewButtonWithIcon("", theme.MediaSkipNextIcon(), func() { g.NextFocusTrack() }),
+			),
+			container.NewGridWrap(fyne.NewSize(100, m.volumeSlider.MinSize().Height), m.volumeSlider),
+			m.trackLabel,
+		),
+	))
+	m.panel.Hide()
+	return m.panel
+}
+
+func (g *GUI) refreshFocusMusicPanel() {
+	m := &g.focusMusic
+	v := g.view.FocusMusic
+	if v.Track == "" {
+		m.panel.Hide()
+		return
+	}
+	m.panel.Show()
+	if v.IsPaused {
+		m.playPauseButton.SetIcon(theme.MediaPlayIcon())
+	} else {
+		m.playPauseButton.SetIcon(theme.MediaPauseIcon())
+	}
+	m.trackLabel.SetText(v.Track)
+	m.volumeSlider.SetValue(v.Volume)
+}
@@ -0,0 +1,55 @@
+package gui
+
+import (
+	"log/slog"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+func (g *GUI) handleFocusRatingEvent(ev pomodoro.Event) {
+	if ev.Type != pomodoro.EventTypePhaseEnded || ev.Phase != pomodoro.PhaseWork {
+		return
+	}
+	g.locker.Lock()
+	enabled := g.settings.AskFocusRating
+	g.locker.Unlock()
+	if !enabled {
+		return
+	}
+	g.showFocusRatingDialog()
+}
+
+func (g *GUI) showFocusRatingDialog() {
+	w := g.parentWindow()
+	if w == nil {
+		return
+	}
+
+	var d *dialog.CustomDialog
+	buttons := make([]fyne.CanvasObject, 0, history.MaxRating)
+	for rating := uint8(history.MinRating); rating <= history.MaxRating; rating++ {
+		buttons = append(buttons, widget.NewButton(strconv.Itoa(int(rating)), func() {
+			d.Hide()
+			if err := g.RateSession(rating); err != nil {
+				slog.Error("unable to rate the session", "error", err)
+			}
+		}))
+	}
+	d = dialog.NewCustom(
+		l10n.T("How well did you focus?"),
+		l10n.T("Skip"),
+		container.NewVBox(
+			widget.NewLabel(l10n.T("From 1 (constantly distracted) to 5 (deep focus)")),
+			container.NewGridWithColumns(len(buttons), buttons...),
+		),
+		w,
+	)
+	d.Show()
+}
@@ -0,0 +1,40 @@
+package gui
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/xaionaro-go/pomodoro/pkg/globalhotkey"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// registerGlobalHotkeys (re-)registers the system-wide shortcuts
+// according to the settings.
+func (g *GUI) registerGlobalHotkeys(s pomodoro.GlobalHotkeySettings) {
+	for _, unregister := range g.globalHotkeysUnregister {
+		unregister()
+	}
+	g.globalHotkeysUnregister = nil
+
+	for _, binding := range []struct {
+		Keys    string
+		Handler func()
+	}{
+		{Keys: s.TogglePause, Handler: g.TogglePause},
+		{Keys: s.StartWork, Handler: g.afterRitual(func() { g.Start(true) })},
+	} {
+		if binding.Keys == "" {
+			continue
+		}
+		unregister, err := globalhotkey.Register(binding.Keys, binding.Handler)
+		if errors.Is(err, globalhotkey.ErrUnsupported) {
+			slog.Warn("the global hotkeys are not registered", "error", err)
+			return
+		}
+		if err != nil {
+			slog.Error("unable to register the global hotkey", "error", err)
+			continue
+		}
+		g.globalHotkeysUnregister = append(g.globalHotkeysUnregister, unregister)
+	}
+}
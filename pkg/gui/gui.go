@@ -0,0 +1,613 @@
+// Package gui shows the timer (see pkg/pomodoro) in the Fyne windows.
+package gui
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/datadir"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// GUI is the timer with its windows, it implements pomodoro.Frontend.
+type GUI struct {
+	*pomodoro.Pomodoro
+
+	App           fyne.App
+	Window        fyne.Window
+	Description   *canvas.Text
+	CounterText   *canvas.Text
+	TaskText      *canvas.Text
+	GoalText      *canvas.Text
+	RoomText      *canvas.Text
+	BreakDebtText *canvas.Text
+	OffHoursText  *canvas.Text
+	Background    *canvas.Rectangle
+	MinutesText   *canvas.Text
+	Delimiter     *canvas.Text
+	SecondsText   *canvas.Text
+	EndsAtText    *canvas.Text
+	ProgressRing  *ProgressRing
+	Content       fyne.CanvasObject
+	Bindings      TimerBindings
+
+	// locker guards the state of the UI: the widgets and the view
+	// and the settings they show (the last ones passed to Refresh
+	// and SettingsApplied). It is locked under the lock of the timer
+	// (see pomodoro.Frontend), so the timer must not be called
+	// with it locked.
+	locker       sync.Mutex
+	view         pomodoro.View
+	settings     pomodoro.Settings
+	isCompact    bool
+	isPresenting bool
+	isShown      bool // see Widget
+	mobile       bool
+
+	ctx      context.Context
+	cancelFn context.CancelFunc
+
+	animations   animations
+	alarmOverlay fyne.CanvasObject
+	alarmBanner  fyne.Window
+	flashOverlay *canvas.Rectangle
+
+	autoContinuePopUp *widget.PopUp
+	autoContinueLabel *widget.Label
+
+	startMinimized  bool   // see SetStartMinimized
+	pendingRecovery string // see OfferRecovery
+
+	startNudgeDialog  *dialog.CustomDialog
+	endActionsDialog  *dialog.CustomDialog
+	screenLockWarning *screenLockWarning
+	strictBreak       *strictBreakOverlay
+	overrunNudge      *overrunNudge
+	miniWindows       []*miniWindow
+
+	undoToast     fyne.CanvasObject
+	undoToastText *widget.Label
+
+	focusMusic focusMusicPanel
+	sideTimers sideTimersPanel
+	ritual     ritualDialog
+	trayIcon   trayIconState
+
+	descriptionContainer *fyne.Container
+	digitsContainer      *fyne.Container
+	controlsContainer    *fyne.Container
+	goalContainer        *fyne.Container
+	taskEntry            *widget.SelectEntry
+	profileSelect        *widget.Select
+	intervalEntry        *widget.Entry
+	pickTaskButton       *widget.Button
+	commitmentControls   []fyne.Disableable
+
+	presetsContainer *fyne.Container
+
+	globalHotkeysUnregister []func()
+	taskbarLastError        string
+}
+
+var _ pomodoro.Frontend = (*GUI)(nil)
+
+// New creates the timer application with its main window,
+// the files are kept in dirs (see datadir.Default).
+func New(dirs datadir.Dirs) *GUI {
+	a := withDirs(app.NewWithID(datadir.AppID), dirs)
+	g := newGUI(a, dirs, true)
+	w := a.NewWindow(windowTitle)
+	w.SetContent(g.Content)
+	g.restoreWindowSize(w)
+	w.SetMaster()
+	w.SetCloseIntercept(func() {
+		go func() {
+			g.saveWindowGeometry()
+			w.Close()
+		}()
+	})
+	w.Canvas().SetOnTypedKey(g.onTypedKey)
+	g.addPaletteShortcut(w.Canvas())
+	g.addUndoShortcut(w.Canvas())
+	w.SetMainMenu(g.newMainMenu())
+	w.SetOnDropped(g.handleDropped)
+	a.Lifecycle().SetOnStarted(func() {
+		if !g.restoreWindowPlacement() {
+			w.CenterOnScreen()
+		}
+		g.showPendingRecovery()
+		g.offerOnboarding()
+	})
+	a.Lifecycle().SetOnEnteredForeground(g.EnteredForeground)
+	a.Lifecycle().SetOnExitedForeground(g.ExitedForeground)
+	g.locker.Lock()
+	g.Window = w
+	g.locker.Unlock()
+	g.OnEvent(g.handleTitleEvent)
+	g.OnEvent(g.handleTrayIconEvent)
+	g.OnEvent(g.handleStrictBreakEvent)
+	g.start()
+	return g
+}
+
+// NewEngine creates the timer within an already existing application
+// without creating any windows. Use Widget to show its UI.
+//
+// The files are kept in dirs (see datadir.Default, the data directory
+// defaults to the storage of the application) and opts configure the timer,
+// the GUI is its frontend.
+func NewEngine(
+	a fyne.App,
+	dirs datadir.Dirs,
+	opts ...pomodoro.Option,
+) *GUI {
+	g := newGUI(a, dirs, false, opts...)
+	g.start()
+	return g
+}
+
+// NewWidget creates the timer within an already existing application
+// (see NewEngine) and returns it with its UI to be embedded.
+//
+// The embedding application is responsible for the window (its title,
+// theme and keyboard shortcuts) and for closing the timer.
+func NewWidget(
+	a fyne.App,
+	dirs datadir.Dirs,
+	opts ...pomodoro.Option,
+) (*GUI, fyne.CanvasObject) {
+	g := NewEngine(a, dirs, opts...)
+	return g, g.Widget()
+}
+
+// Widget returns the UI of the timer created by NewEngine to be embedded
+// into a window of the application.
+func (g *GUI) Widget() fyne.CanvasObject {
+	g.locker.Lock()
+	wasShown := g.isShown
+	g.isShown = true
+	g.locker.Unlock()
+	if !wasShown {
+		g.OnEvent(g.handleStrictBreakEvent)
+	}
+	return g.Content
+}
+
+func newGUI(
+	a fyne.App,
+	dirs datadir.Dirs,
+	isShown bool,
+	extraOpts ...pomodoro.Option,
+) *GUI {
+	opts := []pomodoro.Option{
+		pomodoro.WithPreferences(a.Preferences()),
+		pomodoro.WithAppNotifications(appNotifications{App: a}),
+	}
+	dirs.Data = appDataDir(a, dirs)
+	g := &GUI{
+		App:      a,
+		Bindings: newTimerBindings(),
+		isShown:  isShown,
+		mobile:   fyne.CurrentDevice().IsMobile(),
+	}
+	if g.mobile {
+		opts = append(opts, pomodoro.WithMobile())
+	}
+	g.ctx, g.cancelFn = context.WithCancel(context.Background())
+	g.newContent()
+	opts = append(opts, extraOpts...)
+	g.Pomodoro = pomodoro.New(dirs, append(opts, pomodoro.WithFrontend(g))...)
+	return g
+}
+
+// start subscribes the UI to the events of the timer, once
+// the timer is created.
+func (g *GUI) start() {
+	g.OnEvent(g.handleEndsAtEvent)
+	g.OnEvent(g.handleSessionNoteEvent)
+	g.OnEvent(g.handleFocusRatingEvent)
+	g.OnEvent(g.handleEndActionsEvent)
+	g.OnEvent(g.handleStartNudgeEvent)
+	g.OnEvent(g.handleTaskOptionsEvent)
+	g.refreshTaskOptions(g.Status().Task)
+	go g.followSystemTheme()
+}
+
+// newContent creates the widgets. The timer is created after them (it
+// shows its state right away), so its methods are called via closures.
+func (g *GUI) newContent() {
+	textStyle := fyne.TextStyle{Monospace: true}
+	g.Background = canvas.NewRectangle(color.Transparent)
+	g.flashOverlay = canvas.NewRectangle(color.Transparent)
+	g.Description = canvas.NewText("", color.Gray{Y: 224})
+	g.Description.Alignment = fyne.TextAlignCenter
+	g.Description.TextSize = descriptionTextSize
+	g.Description.TextStyle = textStyle
+	g.CounterText = canvas.NewText("", color.Gray{Y: 160})
+	g.CounterText.TextSize = 20
+	g.TaskText = canvas.NewText("", color.Gray{Y: 192})
+	g.TaskText.Alignment = fyne.TextAlignCenter
+	g.TaskText.TextSize = 20
+	g.descriptionContainer = container.NewVBox(
+		container.New(newDescriptionLayout(g.Description), g.Description, g.CounterText),
+		g.TaskText,
+	)
+	g.MinutesText = canvas.NewText("", color.White)
+	g.MinutesText.TextSize = digitsTextSize
+	g.MinutesText.TextStyle = textStyle
+	g.Delimiter = canvas.NewText(":", color.Gray{Y: 128})
+	g.Delimiter.TextSize = digitsTextSize
+	g.Delimiter.TextStyle = textStyle
+	g.SecondsText = canvas.NewText("", color.White)
+	g.SecondsText.TextSize = digitsTextSize
+	g.SecondsText.TextStyle = textStyle
+	g.EndsAtText = canvas.NewText("", color.Gray{Y: 160})
+	g.EndsAtText.Alignment = fyne.TextAlignCenter
+	g.EndsAtText.TextSize = 16
+	g.digitsContainer = container.New(
+		newDigitsLayout(g.MinutesText, g.Delimiter, g.SecondsText),
+		g.MinutesText,
+		g.Delimiter,
+		g.SecondsText,
+	)
+	g.bindTexts()
+	g.ProgressRing = NewProgressRing(g.digitsContainer)
+	onDoubleTapped := g.ToggleCompactMode
+	if g.mobile {
+		// no windows to resize
+		onDoubleTapped = nil
+	}
+	timerContainer := newTimerReadout(
+		newDoubleTapArea(g.ProgressRing, onDoubleTapped),
+		func() { g.ReadTimerAloud() },
+		g.onTypedKey,
+	)
+	g.GoalText = canvas.NewText("", color.Gray{Y: 160})
+	g.GoalText.Alignment = fyne.TextAlignCenter
+	g.GoalText.TextSize = 16
+	g.RoomText = canvas.NewText("", color.Gray{Y: 160})
+	g.RoomText.Alignment = fyne.TextAlignCenter
+	g.RoomText.TextSize = 14
+	g.RoomText.Hide()
+	g.BreakDebtText = canvas.NewText("", color.Gray{Y: 160})
+	g.BreakDebtText.Alignment = fyne.TextAlignCenter
+	g.BreakDebtText.TextSize = 14
+	g.BreakDebtText.Hide()
+	g.OffHoursText = canvas.NewText("", color.Gray{Y: 160})
+	g.OffHoursText.Alignment = fyne.TextAlignCenter
+	g.OffHoursText.TextSize = 14
+	g.OffHoursText.Hide()
+	g.goalContainer = container.NewCenter(container.NewVBox(g.EndsAtText, g.GoalText, g.BreakDebtText, g.OffHoursText, g.RoomText))
+	g.presetsContainer = container.NewGridWithColumns(1)
+	g.intervalEntry = widget.NewEntry()
+	g.intervalEntry.SetPlaceHolder(l10n.T("min"))
+	g.intervalEntry.OnSubmitted = g.submitCustomInterval
+	setIsWorkButton := widget.NewButtonWithIcon(l10n.T("WORK"), theme.MediaPlayIcon(), g.afterRitual(func() { g.Start(true) }))
+	setIsRestButton := widget.NewButtonWithIcon(l10n.T("REST"), theme.MediaPlayIcon(), g.unlessCommitted(func() { g.Start(false) }))
+	stopButton := widget.NewButtonWithIcon(l10n.T("STOP"), theme.MediaStopIcon(), g.unlessCommitted(func() { g.StopTimer() }))
+	interruptButton := widget.NewButtonWithIcon(l10n.T("Interrupted"), theme.WarningIcon(), g.ShowInterruptionDialog)
+	pauseButton := widget.NewButtonWithIcon(l10n.T("PAUSE"), theme.MediaPauseIcon(), func() { g.TogglePause() })
+	compactButton := widget.NewButtonWithIcon("", theme.ViewRestoreIcon(), g.ToggleCompactMode)
+	settingsButton := widget.NewButtonWithIcon("", theme.SettingsIcon(), g.ShowSettings)
+	extendButton := widget.NewButtonWithIcon(l10n.T("+5 min"), theme.ContentAddIcon(), func() { g.Extend(pomodoro.ExtendStep) })
+	shortenButton := widget.NewButtonWithIcon(l10n.T("−5 min"), theme.ContentRemoveIcon(), g.unlessCommitted(func() { g.Extend(-pomodoro.ExtendStep) }))
+	g.commitmentControls = []fyne.Disableable{setIsRestButton, stopButton, shortenButton, g.intervalEntry}
+	var buttonsContainer *fyne.Container
+	if g.mobile {
+		// the buttons fill the width to be easy to hit with a finger
+		buttonsContainer = container.NewGridWithColumns(2,
+			setIsWorkButton,
+			setIsRestButton,
+			pauseButton,
+			stopButton,
+			extendButton,
+			shortenButton,
+			interruptButton,
+			settingsButton,
+		)
+	} else {
+		buttonsContainer = container.NewVBox(
+			container.NewHBox(
+				setIsWorkButton,
+				pauseButton,
+				extendButton,
+				settingsButton,
+			),
+			container.NewHBox(
+				setIsRestButton,
+				stopButton,
+				interruptButton,
+				shortenButton,
+				compactButton,
+			),
+		)
+	}
+	g.taskEntry = widget.NewSelectEntry(nil)
+	g.taskEntry.SetPlaceHolder(l10n.T("Task (optional)"))
+	g.taskEntry.OnChanged = func(task string) {
+		g.SetTask(task)
+		g.refreshTaskOptions(task)
+	}
+	g.taskEntry.OnSubmitted = func(string) {
+		g.unfocus()
+		g.Start(true)
+	}
+	g.pickTaskButton = widget.NewButtonWithIcon("", theme.ListIcon(), g.ShowTaskPicker)
+	g.pickTaskButton.Hide()
+	g.profileSelect = widget.NewSelect(nil, func(name string) { g.SelectProfile(name) })
+	g.profileSelect.PlaceHolder = l10n.T("(profile)")
+	taskContainer := container.NewBorder(nil, nil, nil, container.NewHBox(g.pickTaskButton, g.intervalEntry, g.profileSelect), g.taskEntry)
+	sideTimersPanel := g.newSideTimersPanel()
+	if g.mobile {
+		taskContainer = container.NewVBox(
+			g.taskEntry,
+			container.NewGridWithColumns(3, g.pickTaskButton, g.intervalEntry, g.profileSelect),
+		)
+	}
+	g.controlsContainer = container.NewVBox(
+		taskContainer,
+		g.presetsContainer,
+		buttonsContainer,
+		g.newFocusMusicPanel(),
+	)
+	var sidePanel fyne.CanvasObject
+	if g.mobile {
+		g.controlsContainer.Add(sideTimersPanel)
+	} else {
+		sidePanel = sideTimersPanel
+	}
+	g.alarmOverlay = g.newAlarmOverlay()
+	g.undoToast = g.newUndoToast()
+	g.Content = container.NewStack(
+		g.Background,
+		container.NewBorder(
+			g.descriptionContainer,
+			container.NewVBox(g.goalContainer, g.controlsContainer),
+			nil,
+			sidePanel,
+			timerContainer,
+		),
+		g.flashOverlay,
+		g.undoToast,
+		g.alarmOverlay,
+	)
+}
+
+// Run shows the window until ctx is cancelled or the window is closed,
+// then it closes the timer.
+func (g *GUI) Run(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		g.App.Quit()
+	}()
+	if g.startsInTray() {
+		g.App.Run()
+	} else {
+		g.Window.ShowAndRun()
+	}
+	return g.Close()
+}
+
+// Close stops the timer and releases the system-wide shortcuts.
+func (g *GUI) Close() error {
+	g.cancelFn()
+	g.locker.Lock()
+	for _, unregister := range g.globalHotkeysUnregister {
+		unregister()
+	}
+	g.globalHotkeysUnregister = nil
+	g.locker.Unlock()
+	return g.Pomodoro.Close()
+}
+
+// Refresh implements pomodoro.Frontend.
+func (g *GUI) Refresh(v pomodoro.View) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	prev := g.view
+	g.view = v
+	g.render(prev)
+}
+
+// render redraws the parts of the UI, which changed since prev.
+func (g *GUI) render(prev pomodoro.View) {
+	v := g.view
+	recolored := false
+	if v.Phase != prev.Phase || v.IsRunning != prev.IsRunning || v.IsPaused != prev.IsPaused || v.IsOvertime != prev.IsOvertime {
+		g.applyColors()
+		recolored = true
+	} else {
+		recolored = g.applyUrgencyColor(v.TimeLeft)
+	}
+	if v.Digits != prev.Digits || recolored {
+		g.renderDigits(v.Digits, recolored)
+	}
+	if v.Progress != prev.Progress {
+		g.ProgressRing.SetValue(v.Progress)
+	}
+	if v.Description != prev.Description {
+		_ = g.Bindings.Description.Set(v.Description)
+	}
+	if v.Caption != prev.Caption {
+		g.TaskText.Text = v.Caption
+		g.TaskText.Refresh()
+	}
+	if v.CycleWorkSessions != prev.CycleWorkSessions || g.CounterText.Text == "" {
+		g.CounterText.Text = fmt.Sprintf("🍅 x%d", v.CycleWorkSessions)
+		g.CounterText.Refresh()
+	}
+	if v.CompletedToday != prev.CompletedToday {
+		g.refreshGoal()
+	}
+	if v.BreakDebt != prev.BreakDebt {
+		g.refreshBreakDebt()
+	}
+	if v.IsOffHours != prev.IsOffHours {
+		g.refreshOffHours()
+	}
+	if strings.Join(v.RoomMembers, "\n") != strings.Join(prev.RoomMembers, "\n") {
+		g.refreshRoom()
+	}
+	if v.IsCommitted != prev.IsCommitted {
+		g.refreshCommitmentControls()
+	}
+	if v.CanPickTask != prev.CanPickTask {
+		if v.CanPickTask {
+			g.pickTaskButton.Show()
+		} else {
+			g.pickTaskButton.Hide()
+		}
+	}
+	if v.IsAlarmRinging != prev.IsAlarmRinging || v.IsAlarmEscalated != prev.IsAlarmEscalated {
+		g.refreshAlarm()
+	}
+	if v.UndoOffer != prev.UndoOffer {
+		g.refreshUndoToast()
+	}
+	if v.AutoContinue != prev.AutoContinue {
+		g.refreshAutoContinue()
+	}
+	if v.ScreenLockIn != prev.ScreenLockIn {
+		g.refreshScreenLockWarning()
+	}
+	g.refreshSideTimersPanel(prev.SideTimers)
+	if v.FocusMusic != prev.FocusMusic {
+		g.refreshFocusMusicPanel()
+	}
+	for _, w := range g.miniWindows {
+		w.refresh()
+	}
+}
+
+// SettingsApplied implements pomodoro.Frontend.
+func (g *GUI) SettingsApplied(s pomodoro.Settings) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	prev := g.settings
+	g.settings = s
+	if s.GlobalHotkeys != prev.GlobalHotkeys || g.globalHotkeysUnregister == nil {
+		g.registerGlobalHotkeys(s.GlobalHotkeys)
+	}
+	if s.Theme != prev.Theme {
+		g.App.Settings().SetTheme(newVariantTheme(s.Theme))
+	}
+	g.refreshPresets()
+	g.refreshProfiles()
+	g.applyColors()
+	g.applyTextStyle()
+	g.refreshGoal()
+	g.refreshBreakDebt()
+	g.refreshCommitmentControls()
+	g.refreshFocusMusicPanel()
+	if !s.TrayIcon {
+		g.showStaticTrayIcon()
+	}
+}
+
+// Flash implements pomodoro.Frontend.
+func (g *GUI) Flash() {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	g.flashWindow()
+}
+
+// Raise implements pomodoro.Frontend.
+func (g *GUI) Raise() {
+	g.locker.Lock()
+	w := g.Window
+	g.locker.Unlock()
+	if w == nil {
+		return
+	}
+	w.Show()
+	w.RequestFocus()
+}
+
+// DailySummaryDue implements pomodoro.Frontend.
+func (g *GUI) DailySummaryDue(summary pomodoro.DailySummary) {
+	g.locker.Lock()
+	shown := g.isShown
+	g.locker.Unlock()
+	if !shown {
+		return
+	}
+	g.showDailySummary(summary)
+}
+
+func (g *GUI) refreshOffHours() {
+	if !g.view.IsOffHours {
+		g.OffHoursText.Text = ""
+		g.OffHoursText.Hide()
+		return
+	}
+	g.OffHoursText.Text = l10n.T("🌙 Off hours")
+	g.OffHoursText.Show()
+	g.OffHoursText.Refresh()
+}
+
+func (g *GUI) refreshRoom() {
+	members := g.view.RoomMembers
+	if len(members) == 0 {
+		g.RoomText.Text = ""
+		g.RoomText.Hide()
+		return
+	}
+	g.RoomText.Text = "👥 " + strings.Join(members, ", ")
+	g.RoomText.Show()
+	g.RoomText.Refresh()
+}
+
+func (g *GUI) refreshGoal() {
+	goal := g.settings.DailyGoal
+	if goal == 0 {
+		g.GoalText.Text = ""
+		g.GoalText.Refresh()
+		return
+	}
+	done := g.view.CompletedToday
+	filled := min(done, goal)
+	g.GoalText.Text = fmt.Sprintf(
+		"%s%s %d/%d",
+		strings.Repeat("●", int(filled)),
+		strings.Repeat("○", int(goal-filled)),
+		done, goal,
+	)
+	g.GoalText.Refresh()
+}
+
+// refreshAutoContinue shows the countdown to the automatic start
+// of the next interval (see pomodoro.Settings.AutoContinue).
+func (g *GUI) refreshAutoContinue() {
+	text := g.view.AutoContinue
+	if text == "" {
+		if g.autoContinuePopUp != nil {
+			g.autoContinuePopUp.Hide()
+			g.autoContinuePopUp = nil
+		}
+		return
+	}
+	if g.autoContinuePopUp == nil {
+		c := g.canvas()
+		if c == nil {
+			return
+		}
+		g.autoContinueLabel = widget.NewLabel("")
+		g.autoContinuePopUp = widget.NewModalPopUp(container.NewVBox(
+			g.autoContinueLabel,
+			widget.NewButton(l10n.T("Cancel"), func() { g.CancelAutoContinue() }),
+		), c)
+		g.autoContinuePopUp.Show()
+	}
+	g.autoContinueLabel.SetText(text)
+}
@@ -0,0 +1,355 @@
+package gui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"slices"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+const (
+	heatmapCellSize = 10
+	heatmapCellGap  = 2
+	heatmapTextSize = 10
+	heatmapLevels   = 4
+	heatmapWeeks    = 53
+
+	// heatmapImageScale is how much larger the exported image is drawn
+	// than the widget, to be readable when shared.
+	heatmapImageScale = 2
+)
+
+type HeatmapCell struct {
+	Date    time.Time
+	Value   float64
+	Tooltip string
+}
+
+// Heatmap draws the days as the cells of a calendar (a column per week,
+// Monday on the top) colored by the value, the way the contributions are
+// shown by GitHub. The first cell should be a Monday.
+type Heatmap struct {
+	widget.BaseWidget
+	Cells []HeatmapCell
+	Color color.Color
+
+	hovered int // the index of the cell under the mouse, -1 if none
+}
+
+var (
+	_ fyne.Widget       = (*Heatmap)(nil)
+	_ desktop.Hoverable = (*Heatmap)(nil)
+)
+
+func NewHeatmap(
+	cells []HeatmapCell,
+	cellColor color.Color,
+) *Heatmap {
+	h := &Heatmap{
+		Cells:   cells,
+		Color:   cellColor,
+		hovered: -1,
+	}
+	h.ExtendBaseWidget(h)
+	return h
+}
+
+// level returns from 0 (nothing) to heatmapLevels (the maximal value).
+func (h *Heatmap) level(value float64) int {
+	var maxValue float64
+	for _, cell := range h.Cells {
+		maxValue = max(maxValue, cell.Value)
+	}
+	if value <= 0 || maxValue <= 0 {
+		return 0
+	}
+	return min(max(int(math.Ceil(heatmapLevels*value/maxValue)), 1), heatmapLevels)
+}
+
+// levelColor blends the color of the heatmap into the empty one.
+func (h *Heatmap) levelColor(
+	level int,
+	empty color.Color,
+) color.Color {
+	if level == 0 {
+		return empty
+	}
+	share := float64(level) / heatmapLevels
+	blend := func(from, to uint32) uint8 {
+		return uint8((float64(from)*(1-share) + float64(to)*share) / 0x101)
+	}
+	er, eg, eb, _ := empty.RGBA()
+	cr, cg, cb, _ := h.Color.RGBA()
+	return color.NRGBA{R: blend(er, cr), G: blend(eg, cg), B: blend(eb, cb), A: 0xff}
+}
+
+// cellPosition returns the top-left corner of the cell relative to the grid.
+func cellPosition(idx int) (x, y float32) {
+	const step = heatmapCellSize + heatmapCellGap
+	return float32(idx/7) * step, float32(idx%7) * step
+}
+
+func (h *Heatmap) columns() int {
+	return (len(h.Cells) + 6) / 7
+}
+
+// Image draws the cells (without the labels) for the export.
+func (h *Heatmap) Image() image.Image {
+	const (
+		cellSize = heatmapCellSize * heatmapImageScale
+		step     = (heatmapCellSize + heatmapCellGap) * heatmapImageScale
+		padding  = step
+	)
+	width := 2*padding + h.columns()*step - heatmapCellGap*heatmapImageScale
+	height := 2*padding + 7*step - heatmapCellGap*heatmapImageScale
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(theme.Color(theme.ColorNameBackground)), image.Point{}, draw.Src)
+	empty := theme.Color(theme.ColorNameInputBackground)
+	for idx, cell := range h.Cells {
+		x, y := padding+idx/7*step, padding+idx%7*step
+		draw.Draw(
+			img,
+			image.Rect(x, y, x+cellSize, y+cellSize),
+			image.NewUniform(h.levelColor(h.level(cell.Value), empty)),
+			image.Point{},
+			draw.Src,
+		)
+	}
+	return img
+}
+
+func (h *Heatmap) MouseIn(ev *desktop.MouseEvent) {
+	h.MouseMoved(ev)
+}
+
+func (h *Heatmap) MouseMoved(ev *desktop.MouseEvent) {
+	hovered := h.cellAt(ev.Position)
+	if hovered == h.hovered {
+		return
+	}
+	h.hovered = hovered
+	h.Refresh()
+}
+
+func (h *Heatmap) MouseOut() {
+	h.hovered = -1
+	h.Refresh()
+}
+
+// cellAt returns the index of the cell at pos, -1 if there is none.
+func (h *Heatmap) cellAt(pos fyne.Position) int {
+	const step = heatmapCellSize + heatmapCellGap
+	top := newHeatmapText("").MinSize().Height
+	if pos.X < 0 || pos.Y < top {
+		return -1
+	}
+	column, row := int(pos.X/step), int((pos.Y-top)/step)
+	idx := column*7 + row
+	if row >= 7 || idx >= len(h.Cells) {
+		return -1
+	}
+	return idx
+}
+
+func (h *Heatmap) CreateRenderer() fyne.WidgetRenderer {
+	r := &heatmapRenderer{heatmap: h}
+	r.rebuild()
+	return r
+}
+
+type heatmapRenderer struct {
+	heatmap        *Heatmap
+	cells          []*canvas.Rectangle
+	months         []*canvas.Text
+	monthColumns   []int
+	tooltip        *canvas.Text
+	tooltipOutline *canvas.Rectangle
+	objects        []fyne.CanvasObject
+}
+
+func newHeatmapText(text string) *canvas.Text {
+	t := canvas.NewText(text, theme.Color(theme.ColorNameForeground))
+	t.TextSize = heatmapTextSize
+	return t
+}
+
+// monthName returns the short name of the month for the labels.
+func monthName(month time.Month) string {
+	return []string{
+		l10n.T("Jan"), l10n.T("Feb"), l10n.T("Mar"), l10n.T("Apr"),
+		l10n.T("May"), l10n.T("Jun"), l10n.T("Jul"), l10n.T("Aug"),
+		l10n.T("Sep"), l10n.T("Oct"), l10n.T("Nov"), l10n.T("Dec"),
+	}[month-time.January]
+}
+
+func (r *heatmapRenderer) rebuild() {
+	h := r.heatmap
+	r.cells, r.months, r.monthColumns, r.objects = nil, nil, nil, nil
+	empty := theme.Color(theme.ColorNameInputBackground)
+	for idx, cell := range h.Cells {
+		rect := canvas.NewRectangle(h.levelColor(h.level(cell.Value), empty))
+		rect.CornerRadius = 2
+		r.cells = append(r.cells, rect)
+		r.objects = append(r.objects, rect)
+
+		// a month is labeled above the first week starting in it
+		if idx%7 != 0 || (idx > 0 && cell.Date.Month() == h.Cells[idx-7].Date.Month()) {
+			continue
+		}
+		month := newHeatmapText(monthName(cell.Date.Month()))
+		r.months = append(r.months, month)
+		r.monthColumns = append(r.monthColumns, idx/7)
+		r.objects = append(r.objects, month)
+	}
+	if len(r.monthColumns) > 1 && r.monthColumns[1]-r.monthColumns[0] < 3 {
+		// the partial first month does not fit its label
+		r.objects = slices.DeleteFunc(r.objects, func(obj fyne.CanvasObject) bool { return obj == r.months[0] })
+		r.months, r.monthColumns = r.months[1:], r.monthColumns[1:]
+	}
+
+	r.tooltipOutline = canvas.NewRectangle(theme.Color(theme.ColorNameOverlayBackground))
+	r.tooltipOutline.StrokeColor = theme.Color(theme.ColorNameShadow)
+	r.tooltipOutline.StrokeWidth = 1
+	r.tooltip = newHeatmapText("")
+	if h.hovered >= 0 && h.hovered < len(h.Cells) && h.Cells[h.hovered].Tooltip != "" {
+		r.tooltip.Text = h.Cells[h.hovered].Tooltip
+		r.objects = append(r.objects, r.tooltipOutline, r.tooltip)
+	}
+}
+
+func (r *heatmapRenderer) Layout(size fyne.Size) {
+	const step = heatmapCellSize + heatmapCellGap
+	top := newHeatmapText("").MinSize().Height
+	for idx, rect := range r.cells {
+		x, y := cellPosition(idx)
+		rect.Resize(fyne.NewSquareSize(heatmapCellSize))
+		rect.Move(fyne.NewPos(x, top+y))
+	}
+	for idx, month := range r.months {
+		month.Resize(month.MinSize())
+		month.Move(fyne.NewPos(float32(r.monthColumns[idx])*step, 0))
+	}
+
+	hovered := r.heatmap.hovered
+	if hovered < 0 || hovered >= len(r.cells) {
+		return
+	}
+	// the tooltip is above the hovered cell unless it does not fit there
+	const padding = 4
+	textSize := r.tooltip.MinSize()
+	tooltipSize := fyne.NewSize(textSize.Width+2*padding, textSize.Height+2*padding)
+	x, y := cellPosition(hovered)
+	y += top
+	tooltipPos := fyne.NewPos(x+heatmapCellSize/2-tooltipSize.Width/2, y-tooltipSize.Height-heatmapCellGap)
+	if tooltipPos.Y < 0 {
+		tooltipPos.Y = y + heatmapCellSize + heatmapCellGap
+	}
+	tooltipPos.X = max(min(tooltipPos.X, size.Width-tooltipSize.Width), 0)
+	r.tooltipOutline.Resize(tooltipSize)
+	r.tooltipOutline.Move(tooltipPos)
+	r.tooltip.Resize(textSize)
+	r.tooltip.Move(tooltipPos.AddXY(padding, padding))
+}
+
+func (r *heatmapRenderer) MinSize() fyne.Size {
+	const step = heatmapCellSize + heatmapCellGap
+	top := newHeatmapText("").MinSize().Height
+	return fyne.NewSize(
+		float32(r.heatmap.columns())*step-heatmapCellGap,
+		top+7*step-heatmapCellGap,
+	)
+}
+
+func (r *heatmapRenderer) Refresh() {
+	r.rebuild()
+	r.Layout(r.heatmap.Size())
+	canvas.Refresh(r.heatmap)
+}
+
+func (r *heatmapRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *heatmapRenderer) Destroy() {}
+
+// yearHeatmapCells returns the focus minutes per day of the last year
+// starting on a Monday (see Heatmap).
+func yearHeatmapCells(
+	sessions []history.Session,
+	now time.Time,
+	boundary time.Duration,
+) []HeatmapCell {
+	today := history.DayStart(now, boundary)
+	days := 7*(heatmapWeeks-1) + (int(today.Weekday())+6)%7 + 1
+	periods := history.Daily(sessions, now, days, boundary)
+	cells := make([]HeatmapCell, 0, len(periods))
+	for _, period := range periods {
+		minutes := int(period.Duration / time.Minute)
+		cells = append(cells, HeatmapCell{
+			Date:  period.Start,
+			Value: float64(minutes),
+			Tooltip: l10n.T(
+				"%s: %d sessions, %d min of focus",
+				period.Start.Format("02.01.2006"), period.Sessions, minutes,
+			),
+		})
+	}
+	return cells
+}
+
+func newYearHeatmapContent(
+	sessions []history.Session,
+	now time.Time,
+	boundary time.Duration,
+	cellColor color.Color,
+	w fyne.Window,
+) fyne.CanvasObject {
+	heatmap := NewHeatmap(yearHeatmapCells(sessions, now, boundary), cellColor)
+	exportButton := widget.NewButtonWithIcon(l10n.T("Export the image..."), theme.DocumentSaveIcon(), func() {
+		showHeatmapExportDialog(heatmap, now, w)
+	})
+	return container.NewVBox(
+		widget.NewLabel(l10n.T("Focus minutes per day during the last year")),
+		container.NewCenter(heatmap),
+		container.NewHBox(layout.NewSpacer(), exportButton),
+	)
+}
+
+func showHeatmapExportDialog(
+	heatmap *Heatmap,
+	now time.Time,
+	w fyne.Window,
+) {
+	d := dialog.NewFileSave(func(f fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if f == nil {
+			return
+		}
+		defer f.Close()
+		if err := png.Encode(f, heatmap.Image()); err != nil {
+			dialog.ShowError(fmt.Errorf("unable to export the image to '%s': %w", f.URI().Path(), err), w)
+		}
+	}, w)
+	d.SetFileName("pomodoro-heatmap-" + now.Format(time.DateOnly) + ".png")
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".png"}))
+	d.Show()
+}
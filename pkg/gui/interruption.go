@@ -0,0 +1,56 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// ShowInterruptionDialog pauses the work session and asks
+// for the kind and the reason of the interruption.
+func (g *GUI) ShowInterruptionDialog() {
+	s := g.Status()
+	g.Pause()
+	if s.Phase != pomodoro.PhaseWork || !s.IsRunning {
+		return
+	}
+
+	kindOptions := map[string]history.InterruptionKind{
+		l10n.T("internal"): history.InterruptionKindInternal,
+		l10n.T("external"): history.InterruptionKindExternal,
+	}
+	kindRadio := widget.NewRadioGroup([]string{l10n.T("internal"), l10n.T("external")}, nil)
+	kindRadio.Horizontal = true
+	kindRadio.Required = true
+	kindRadio.SetSelected(l10n.T("internal"))
+	reasonEntry := widget.NewEntry()
+	reasonEntry.SetPlaceHolder(l10n.T("one word, e.g. 'phone'"))
+	reasonEntry.Validator = func(s string) error {
+		if len(strings.Fields(s)) > 1 {
+			return fmt.Errorf("expected a single word")
+		}
+		return nil
+	}
+
+	dialog.ShowForm(
+		l10n.T("Interrupted"),
+		l10n.T("Save"),
+		l10n.T("Cancel"),
+		[]*widget.FormItem{
+			widget.NewFormItem(l10n.T("Kind"), kindRadio),
+			widget.NewFormItem(l10n.T("Reason"), reasonEntry),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			g.RecordInterruption(kindOptions[kindRadio.Selected], strings.TrimSpace(reasonEntry.Text))
+		},
+		g.parentWindow(),
+	)
+}
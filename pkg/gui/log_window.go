@@ -0,0 +1,44 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/logging"
+)
+
+// ShowLog shows the recent log records (for example, to find out
+// why an integration does not work).
+func (g *GUI) ShowLog() {
+	text := widget.NewMultiLineEntry()
+	text.Wrapping = fyne.TextWrapOff
+	text.TextStyle = fyne.TextStyle{Monospace: true}
+	var lines []string
+	refresh := func() {
+		lines = logging.Recent()
+		text.SetText(strings.Join(lines, "\n"))
+		text.CursorRow = len(lines)
+	}
+	refresh()
+	// read-only, but still selectable and copyable
+	// (unlike a disabled entry)
+	text.OnChanged = func(s string) {
+		if s != strings.Join(lines, "\n") {
+			text.SetText(strings.Join(lines, "\n"))
+		}
+	}
+
+	w := g.App.NewWindow(fmt.Sprintf("%s — %s", windowTitle, l10n.T("Log")))
+	w.SetContent(container.NewBorder(
+		nil,
+		container.NewHBox(widget.NewButton(l10n.T("Refresh"), refresh)),
+		nil, nil,
+		text,
+	))
+	w.Resize(fyne.NewSize(720, 400))
+	w.Show()
+}
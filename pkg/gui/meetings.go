@@ -0,0 +1,29 @@
+package gui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2/dialog"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// OfferToEndAt implements pomodoro.Frontend.
+func (g *GUI) OfferToEndAt(message string, endAt time.Time) bool {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	w := g.window()
+	if w == nil {
+		return false
+	}
+	dialog.ShowConfirm(
+		l10n.T("A meeting is coming"),
+		message+"\n"+l10n.T("End the session at %s?", l10n.FormatClock(endAt)),
+		func(confirmed bool) {
+			if confirmed {
+				g.EndAt(endAt)
+			}
+		},
+		w,
+	)
+	return true
+}
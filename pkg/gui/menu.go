@@ -0,0 +1,54 @@
+package gui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+func (g *GUI) newMainMenu() *fyne.MainMenu {
+	var exportItems []*fyne.MenuItem
+	for _, format := range history.Formats {
+		exportItems = append(exportItems, fyne.NewMenuItem(
+			l10n.T("Export history as %s...", strings.ToUpper(string(format))),
+			func() { g.showExportDialog(format) },
+		))
+	}
+	exportItems = append(exportItems,
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(l10n.T("Import a profile..."), g.showImportProfileDialog),
+		fyne.NewMenuItem(l10n.T("Export the profile..."), g.showExportProfileDialog),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(l10n.T("Back up all the data..."), g.showBackupDialog),
+		fyne.NewMenuItem(l10n.T("Restore from a backup..."), g.showRestoreDialog),
+	)
+	paletteItem := fyne.NewMenuItem(l10n.T("Command palette"), g.ShowCommandPalette)
+	paletteItem.Shortcut = paletteShortcut
+	undoItem := fyne.NewMenuItem(l10n.T("Undo"), g.Undo)
+	undoItem.Shortcut = undoShortcut
+	return fyne.NewMainMenu(
+		fyne.NewMenu(l10n.T("File"), exportItems...),
+		fyne.NewMenu(l10n.T("Timer"),
+			fyne.NewMenuItem(l10n.T("Start a stopwatch"), g.unlessCommitted(g.StartStopwatch)),
+			fyne.NewMenuItem(l10n.T("Convert the stopwatch into a pomodoro"), g.ConvertStopwatch),
+			fyne.NewMenuItem(l10n.T("Pick a task..."), g.ShowTaskPicker),
+			fyne.NewMenuItem(l10n.T("New side timer..."), g.ShowNewSideTimerDialog),
+			fyne.NewMenuItem(l10n.T("Unblock the distractions..."), g.showUnblockDistractionsDialog),
+			fyne.NewMenuItemSeparator(),
+			undoItem,
+		),
+		fyne.NewMenu(l10n.T("Window"),
+			paletteItem,
+			fyne.NewMenuItem(l10n.T("Statistics"), g.ShowStatistics),
+			fyne.NewMenuItem(l10n.T("Today's summary"), g.ShowDailySummary),
+			fyne.NewMenuItem(l10n.T("New mini timer"), g.ShowMiniWindow),
+			fyne.NewMenuItem(l10n.T("Close mini timers"), g.CloseMiniWindows),
+			fyne.NewMenuItem(l10n.T("Presentation mode"), g.TogglePresentationMode),
+			fyne.NewMenuItem(l10n.T("Share the timer..."), g.ShowShareDialog),
+			fyne.NewMenuItem(l10n.T("Sound mixer..."), g.ShowMixer),
+			fyne.NewMenuItem(l10n.T("Log"), g.ShowLog),
+		),
+	)
+}
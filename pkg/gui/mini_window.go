@@ -0,0 +1,82 @@
+package gui
+
+import (
+	"fmt"
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+)
+
+type miniWindow struct {
+	GUI        *GUI
+	Window     fyne.Window
+	Background *canvas.Rectangle
+	Text       *canvas.Text
+}
+
+// ShowMiniWindow opens a small always-on-top window showing only
+// the countdown, it is meant to be dragged to another monitor.
+func (g *GUI) ShowMiniWindow() {
+	background := canvas.NewRectangle(nil)
+	text := canvas.NewText("", nil)
+	text.TextSize = 40
+	text.TextStyle = fyne.TextStyle{Monospace: true}
+	text.Alignment = fyne.TextAlignCenter
+
+	w := g.App.NewWindow("Pomodoro")
+	w.SetContent(container.NewStack(background, container.NewPadded(text)))
+	w.SetFixedSize(true)
+	mini := &miniWindow{
+		GUI:        g,
+		Window:     w,
+		Background: background,
+		Text:       text,
+	}
+
+	g.locker.Lock()
+	mini.refresh()
+	g.miniWindows = append(g.miniWindows, mini)
+	g.locker.Unlock()
+	w.SetOnClosed(func() {
+		g.locker.Lock()
+		defer g.locker.Unlock()
+		for idx, other := range g.miniWindows {
+			if other == mini {
+				g.miniWindows = append(g.miniWindows[:idx], g.miniWindows[idx+1:]...)
+				break
+			}
+		}
+	})
+
+	w.Show()
+	if err := setAlwaysOnTop(w, true); err != nil {
+		slog.Error("unable to make the mini window always-on-top", "error", err)
+	}
+}
+
+// refresh shows the time of the view, the GUI is expected to be locked.
+func (w *miniWindow) refresh() {
+	digits := w.GUI.view.Digits
+	colors := w.GUI.currentColors()
+	text := fmt.Sprintf("%02d:%02d", digits.Minutes, digits.Seconds)
+	if text == w.Text.Text && colors.Digits == w.Text.Color && colors.Background == w.Background.FillColor {
+		return
+	}
+	w.Text.Text = text
+	w.Text.Color = colors.Digits
+	w.Background.FillColor = colors.Background
+	w.Text.Refresh()
+	w.Background.Refresh()
+}
+
+func (g *GUI) CloseMiniWindows() {
+	g.locker.Lock()
+	miniWindows := g.miniWindows
+	g.miniWindows = nil
+	g.locker.Unlock()
+	for _, w := range miniWindows {
+		w.Window.Close()
+	}
+}
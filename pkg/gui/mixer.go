@@ -0,0 +1,75 @@
+package gui
+
+import (
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/audio"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+func channelTitle(channel audio.Channel) string {
+	switch channel {
+	case audio.ChannelAlarm:
+		return l10n.T("Alarm")
+	case audio.ChannelCountdown:
+		return l10n.T("Countdown")
+	case audio.ChannelAmbience:
+		return l10n.T("Ambient sound")
+	case audio.ChannelMusic:
+		return l10n.T("Focus music")
+	default:
+		return string(channel)
+	}
+}
+
+// ShowMixer shows the volumes of the sound channels, the changes
+// apply immediately (including the sounds being played).
+func (g *GUI) ShowMixer() {
+	w := g.parentWindow()
+	if w == nil {
+		return
+	}
+	g.locker.Lock()
+	s := g.settings
+	g.locker.Unlock()
+
+	form := widget.NewForm()
+	for _, channel := range audio.Channels {
+		volumeSlider := widget.NewSlider(0, 1)
+		volumeSlider.Step = 0.05
+		volumeSlider.SetValue(*s.ChannelVolume(channel))
+		volumeSlider.OnChangeEnded = func(volume float64) {
+			g.ChangeMixer(func(s *pomodoro.Settings) {
+				*s.ChannelVolume(channel) = volume
+			})
+		}
+		muteCheck := widget.NewCheck(l10n.T("Mute"), func(muted bool) {
+			g.ChangeMixer(func(s *pomodoro.Settings) {
+				s.Mixer.Muted = slices.DeleteFunc(s.Mixer.Muted, func(c audio.Channel) bool { return c == channel })
+				if muted {
+					s.Mixer.Muted = append(s.Mixer.Muted, channel)
+				}
+			})
+		})
+		muteCheck.Checked = slices.Contains(s.Mixer.Muted, channel)
+		form.Append(channelTitle(channel), container.NewBorder(nil, nil, nil, muteCheck, volumeSlider))
+	}
+	duckingSlider := widget.NewSlider(0, 1)
+	duckingSlider.Step = 0.05
+	duckingSlider.SetValue(s.Mixer.Ducking)
+	duckingSlider.OnChangeEnded = func(level float64) {
+		g.ChangeMixer(func(s *pomodoro.Settings) {
+			s.Mixer.Ducking = level
+		})
+	}
+	form.Append(l10n.T("Background during the alarm"), duckingSlider)
+
+	d := dialog.NewCustom(l10n.T("Sound mixer..."), l10n.T("Close"), form, w)
+	d.Resize(fyne.NewSize(420, 0))
+	d.Show()
+}
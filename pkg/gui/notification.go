@@ -0,0 +1,19 @@
+package gui
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/xaionaro-go/pomodoro/pkg/notify"
+)
+
+// appNotifications sends the notifications through the Fyne application
+// (which uses the native mechanism of the OS), see notify.Config.Fyne.
+type appNotifications struct {
+	App fyne.App
+}
+
+var _ notify.Backend = appNotifications{}
+
+func (b appNotifications) Notify(n notify.Notification) error {
+	b.App.SendNotification(fyne.NewNotification(n.Title, n.Body))
+	return nil
+}
@@ -0,0 +1,160 @@
+package gui
+
+import (
+	"log/slog"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/notify"
+)
+
+// onboardingProfile is a profile offered by the first-run wizard.
+type onboardingProfile struct {
+	Name  string
+	Title string
+}
+
+func onboardingProfiles() []onboardingProfile {
+	return []onboardingProfile{
+		{Name: "standard", Title: l10n.T("25 min of work, 5 min of rest (the classic)")},
+		{Name: "long focus", Title: l10n.T("50 min of work, 10 min of rest")},
+	}
+}
+
+// offerOnboarding shows the first-run wizard if needed, otherwise the timer
+// would silently start with the defaults nobody chose.
+func (g *GUI) offerOnboarding() {
+	if !g.NeedsOnboarding() {
+		return
+	}
+	w := g.parentWindow()
+	if w == nil {
+		return
+	}
+	g.showOnboardingWizard(w)
+}
+
+func (g *GUI) showOnboardingWizard(w fyne.Window) {
+	g.locker.Lock()
+	s := g.settings
+	g.locker.Unlock()
+
+	profiles := onboardingProfiles()
+	var profileTitles []string
+	for _, profile := range profiles {
+		profileTitles = append(profileTitles, profile.Title)
+	}
+	profileRadio := widget.NewRadioGroup(profileTitles, nil)
+	profileRadio.Required = true
+	profileRadio.SetSelected(profileTitles[0])
+
+	notificationsCheck := widget.NewCheck(l10n.T("Show a notification when an interval ends"), nil)
+	notificationsCheck.SetChecked(true)
+	soundCheck := widget.NewCheck(l10n.T("Play a sound when an interval ends"), nil)
+	soundCheck.SetChecked(true)
+
+	dailyGoalEntry := newUintEntry(uint64(s.DailyGoal))
+	dailyGoalCheck := widget.NewCheck(l10n.T("Set a daily goal"), func(enabled bool) {
+		if enabled {
+			dailyGoalEntry.Enable()
+		} else {
+			dailyGoalEntry.Disable()
+		}
+	})
+	dailyGoalCheck.SetChecked(true)
+
+	steps := []fyne.CanvasObject{
+		container.NewVBox(
+			widget.NewLabel(l10n.T("How long do you want to focus at a time?")),
+			profileRadio,
+			widget.NewLabel(l10n.T("The profiles can be changed any time later.")),
+		),
+		container.NewVBox(
+			widget.NewLabel(l10n.T("How to let you know that an interval is over?")),
+			notificationsCheck,
+			soundCheck,
+		),
+		container.NewVBox(
+			widget.NewLabel(l10n.T("How many work sessions a day are you aiming for?")),
+			dailyGoalCheck,
+			widget.NewForm(widget.NewFormItem(l10n.T("Sessions per day"), dailyGoalEntry)),
+		),
+	}
+	stepsContainer := container.NewStack(steps...)
+
+	var (
+		d          *dialog.CustomDialog
+		step       int
+		backButton *widget.Button
+		nextButton *widget.Button
+	)
+	showStep := func() {
+		for idx, obj := range steps {
+			if idx == step {
+				obj.Show()
+			} else {
+				obj.Hide()
+			}
+		}
+		if step == 0 {
+			backButton.Disable()
+		} else {
+			backButton.Enable()
+		}
+		nextButton.SetText(l10n.T("Next"))
+		if step == len(steps)-1 {
+			nextButton.SetText(l10n.T("Finish"))
+		}
+	}
+	finish := func() {
+		d.Hide()
+		if idx := slices.Index(profileTitles, profileRadio.Selected); idx >= 0 {
+			if err := s.UseProfile(profiles[idx].Name); err != nil {
+				slog.Error("unable to use the profile chosen on the first run", "error", err)
+			}
+		}
+		s.Notifications.OnPhaseEnd = notificationsCheck.Checked
+		switch {
+		case !notificationsCheck.Checked:
+			s.Notifications.Backends = nil
+		case len(s.Notifications.Backends) == 0:
+			s.Notifications.Backends = []notify.BackendName{notify.BackendNameFyne}
+		}
+		s.AlarmEnabled = soundCheck.Checked
+		s.DailyGoal = 0
+		if dailyGoalCheck.Checked {
+			s.DailyGoal = uint(parseUint(dailyGoalEntry.Text))
+		}
+		g.FinishOnboarding(&s)
+	}
+	backButton = widget.NewButtonWithIcon(l10n.T("Back"), theme.NavigateBackIcon(), func() {
+		step--
+		showStep()
+	})
+	nextButton = widget.NewButtonWithIcon(l10n.T("Next"), theme.NavigateNextIcon(), func() {
+		if step == len(steps)-1 {
+			finish()
+			return
+		}
+		step++
+		showStep()
+	})
+	nextButton.Importance = widget.HighImportance
+
+	d = dialog.NewCustomWithoutButtons(l10n.T("Welcome to Pomodoro"), stepsContainer, w)
+	d.SetButtons([]fyne.CanvasObject{
+		widget.NewButton(l10n.T("Skip"), func() {
+			d.Hide()
+			g.FinishOnboarding(nil)
+		}),
+		backButton,
+		nextButton,
+	})
+	showStep()
+	d.Show()
+}
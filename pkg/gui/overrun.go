@@ -0,0 +1,86 @@
+package gui
+
+import (
+	"image/color"
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+type overrunNudge struct {
+	Window  fyne.Window
+	Dimmer  *canvas.Rectangle
+	Message *canvas.Text
+}
+
+func (g *GUI) newOverrunNudge() *overrunNudge {
+	message := canvas.NewText("", color.White)
+	message.Alignment = fyne.TextAlignCenter
+	message.TextSize = 24
+	startBreakButton := widget.NewButtonWithIcon(l10n.T("Start the break"), theme.MediaPlayIcon(), func() {
+		g.Start(false)
+	})
+	dimmer := canvas.NewRectangle(color.Transparent)
+
+	w := g.App.NewWindow(l10n.T("Break"))
+	w.SetContent(container.NewStack(
+		dimmer,
+		container.NewCenter(container.NewVBox(
+			message,
+			container.NewCenter(startBreakButton),
+		)),
+	))
+	if err := setAlwaysOnTop(w, true); err != nil {
+		slog.Error("unable to make the reminder always on top", "error", err)
+	}
+	return &overrunNudge{
+		Window:  w,
+		Dimmer:  dimmer,
+		Message: message,
+	}
+}
+
+const (
+	overrunNudgeLevelWindow     = 2
+	overrunNudgeLevelFullScreen = 4
+)
+
+// NudgeOverrun implements pomodoro.Frontend.
+func (g *GUI) NudgeOverrun(message string, level int) bool {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	if !g.isShown {
+		return false
+	}
+	if g.overrunNudge == nil {
+		g.overrunNudge = g.newOverrunNudge()
+	}
+	nudge := g.overrunNudge
+	nudge.Message.Text = message
+	nudge.Message.Refresh()
+	// darken progressively with every step
+	dimLevel := min(level-overrunNudgeLevelWindow+1, 4)
+	nudge.Dimmer.FillColor = color.NRGBA{A: uint8(dimLevel * 255 / 4)}
+	nudge.Dimmer.Refresh()
+	if level >= overrunNudgeLevelFullScreen {
+		nudge.Window.SetFullScreen(true)
+	}
+	nudge.Window.Show()
+	nudge.Window.RequestFocus()
+	return true
+}
+
+// CloseOverrunNudge implements pomodoro.Frontend.
+func (g *GUI) CloseOverrunNudge() {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	if g.overrunNudge != nil {
+		g.overrunNudge.Window.Close()
+		g.overrunNudge = nil
+	}
+}
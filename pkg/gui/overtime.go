@@ -0,0 +1,19 @@
+package gui
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2/theme"
+)
+
+func (g *GUI) overtimeDigitsColor() color.NRGBA {
+	if g.themeVariant() == theme.VariantLight {
+		return overtimeLightDigitsColor
+	}
+	return overtimeDigitsColor
+}
+
+var (
+	overtimeDigitsColor      = color.NRGBA{R: 255, G: 160, B: 64, A: 255}
+	overtimeLightDigitsColor = color.NRGBA{R: 176, G: 88, B: 0, A: 255}
+)
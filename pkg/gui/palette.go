@@ -0,0 +1,251 @@
+package gui
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+var paletteShortcut = &desktop.CustomShortcut{
+	KeyName:  fyne.KeyK,
+	Modifier: fyne.KeyModifierShortcutDefault,
+}
+
+type paletteAction struct {
+	Title string
+	Run   func()
+}
+
+func (g *GUI) paletteActions() []paletteAction {
+	g.locker.Lock()
+	workIntervals := []time.Duration{g.settings.WorkInterval}
+	for _, preset := range g.settings.Presets {
+		if preset.Phase == pomodoro.PhaseWork {
+			workIntervals = append(workIntervals, preset.Duration)
+		}
+	}
+	profiles := g.settings.ProfileNames()
+	g.locker.Unlock()
+
+	actions := []paletteAction{
+		{l10n.T("Start work"), g.afterRitual(func() { g.Start(true) })},
+	}
+	var seen []time.Duration
+	for _, interval := range workIntervals {
+		if slices.Contains(seen, interval) {
+			continue
+		}
+		seen = append(seen, interval)
+		actions = append(actions, paletteAction{
+			l10n.T("Start work for %d minutes", int(interval/time.Minute)),
+			func() { g.StartWorkFor(interval) },
+		})
+	}
+	actions = append(actions,
+		paletteAction{l10n.T("Start rest"), g.unlessCommitted(func() { g.Start(false) })},
+		paletteAction{l10n.T("Pause/resume"), g.TogglePause},
+		paletteAction{l10n.T("Stop the timer"), g.unlessCommitted(g.StopTimer)},
+		paletteAction{l10n.T("Undo the last stop/start"), g.Undo},
+		paletteAction{l10n.T("Acknowledge the alarm"), g.AcknowledgeAlarm},
+		paletteAction{l10n.T("Start a stopwatch"), g.unlessCommitted(g.StartStopwatch)},
+		paletteAction{l10n.T("Convert the stopwatch into a pomodoro"), g.ConvertStopwatch},
+		paletteAction{l10n.T("Extend by 5 minutes"), func() { g.Extend(pomodoro.ExtendStep) }},
+		paletteAction{l10n.T("Shorten by 5 minutes"), g.unlessCommitted(func() { g.Extend(-pomodoro.ExtendStep) })},
+		paletteAction{l10n.T("Record an interruption"), g.ShowInterruptionDialog},
+		paletteAction{l10n.T("Pick a task..."), g.ShowTaskPicker},
+		paletteAction{l10n.T("New side timer..."), g.ShowNewSideTimerDialog},
+		paletteAction{l10n.T("Unblock the distractions..."), g.showUnblockDistractionsDialog},
+		paletteAction{l10n.T("Toggle the compact mode"), g.ToggleCompactMode},
+		paletteAction{l10n.T("Toggle the presentation mode"), g.TogglePresentationMode},
+		paletteAction{l10n.T("Share the timer..."), g.ShowShareDialog},
+		paletteAction{l10n.T("Sound mixer..."), g.ShowMixer},
+		paletteAction{l10n.T("Statistics"), g.ShowStatistics},
+		paletteAction{l10n.T("Today's summary"), g.ShowDailySummary},
+		paletteAction{l10n.T("Settings"), g.ShowSettings},
+		paletteAction{l10n.T("New mini timer"), g.ShowMiniWindow},
+		paletteAction{l10n.T("Close mini timers"), g.CloseMiniWindows},
+		paletteAction{l10n.T("Log"), g.ShowLog},
+	)
+	for _, format := range history.Formats {
+		actions = append(actions, paletteAction{
+			l10n.T("Export history as %s...", strings.ToUpper(string(format))),
+			func() { g.showExportDialog(format) },
+		})
+	}
+	actions = append(actions,
+		paletteAction{l10n.T("Import a profile..."), g.showImportProfileDialog},
+		paletteAction{l10n.T("Export the profile..."), g.showExportProfileDialog},
+		paletteAction{l10n.T("Back up all the data..."), g.showBackupDialog},
+		paletteAction{l10n.T("Restore from a backup..."), g.showRestoreDialog},
+	)
+	for _, profile := range profiles {
+		actions = append(actions, paletteAction{
+			l10n.T("Use the profile '%s'", profile),
+			func() { g.SelectProfile(profile) },
+		})
+	}
+	return actions
+}
+
+// fuzzyScore checks if all the runes of the pattern are found in s in
+// the same order (case-insensitively). Consecutive runes and runes at
+// the beginning of words are scored higher.
+func fuzzyScore(pattern, s string) (int, bool) {
+	patternRunes := []rune(strings.ToLower(pattern))
+	if len(patternRunes) == 0 {
+		return 0, true
+	}
+
+	score := 0
+	matchIdx := 0
+	prevMatched := false
+	prev := ' '
+	for _, r := range strings.ToLower(s) {
+		if matchIdx < len(patternRunes) && r == patternRunes[matchIdx] {
+			score++
+			if prevMatched {
+				score += 3
+			}
+			if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+				score += 2
+			}
+			matchIdx++
+			prevMatched = true
+		} else {
+			prevMatched = false
+		}
+		prev = r
+	}
+	return score, matchIdx == len(patternRunes)
+}
+
+func filterPaletteActions(actions []paletteAction, query string) []paletteAction {
+	type scoredAction struct {
+		paletteAction
+		score int
+	}
+	var scored []scoredAction
+	for _, action := range actions {
+		score, ok := fuzzyScore(query, action.Title)
+		if !ok {
+			continue
+		}
+		scored = append(scored, scoredAction{action, score})
+	}
+	slices.SortStableFunc(scored, func(a, b scoredAction) int {
+		return b.score - a.score
+	})
+	result := make([]paletteAction, 0, len(scored))
+	for _, action := range scored {
+		result = append(result, action.paletteAction)
+	}
+	return result
+}
+
+type paletteEntry struct {
+	widget.Entry
+	onKey func(ev *fyne.KeyEvent) bool
+}
+
+func newPaletteEntry() *paletteEntry {
+	e := &paletteEntry{}
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+func (e *paletteEntry) TypedKey(ev *fyne.KeyEvent) {
+	if e.onKey != nil && e.onKey(ev) {
+		return
+	}
+	e.Entry.TypedKey(ev)
+}
+
+// ShowCommandPalette shows a search field over all the actions
+// (see paletteActions), Enter runs the selected one.
+func (g *GUI) ShowCommandPalette() {
+	w := g.parentWindow()
+	if w == nil {
+		return
+	}
+	c := w.Canvas()
+
+	actions := g.paletteActions()
+	filtered := actions
+	selected := 0
+
+	list := widget.NewList(
+		func() int { return len(filtered) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(filtered[id].Title)
+		},
+	)
+	entry := newPaletteEntry()
+	entry.SetPlaceHolder(l10n.T("Type a command"))
+
+	var popUp *widget.PopUp
+	run := func(idx int) {
+		if idx < 0 || idx >= len(filtered) {
+			return
+		}
+		popUp.Hide()
+		filtered[idx].Run()
+	}
+	sel := func(idx int) {
+		if len(filtered) == 0 {
+			return
+		}
+		selected = max(0, min(idx, len(filtered)-1))
+		list.Select(selected)
+		list.ScrollTo(selected)
+	}
+	list.OnSelected = func(id widget.ListItemID) {
+		if id != selected {
+			// clicked
+			run(id)
+		}
+	}
+	entry.OnChanged = func(query string) {
+		filtered = filterPaletteActions(actions, query)
+		list.UnselectAll()
+		list.Refresh()
+		sel(0)
+	}
+	entry.OnSubmitted = func(string) {
+		run(selected)
+	}
+	entry.onKey = func(ev *fyne.KeyEvent) bool {
+		switch ev.Name {
+		case fyne.KeyDown:
+			sel(selected + 1)
+		case fyne.KeyUp:
+			sel(selected - 1)
+		case fyne.KeyEscape:
+			popUp.Hide()
+		default:
+			return false
+		}
+		return true
+	}
+
+	popUp = widget.NewModalPopUp(container.NewBorder(entry, nil, nil, nil, list), c)
+	popUp.Resize(fyne.NewSize(400, 300))
+	popUp.Show()
+	sel(0)
+	c.Focus(entry)
+}
+
+func (g *GUI) addPaletteShortcut(c fyne.Canvas) {
+	c.AddShortcut(paletteShortcut, func(fyne.Shortcut) {
+		g.ShowCommandPalette()
+	})
+}
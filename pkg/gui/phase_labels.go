@@ -0,0 +1,83 @@
+package gui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+)
+
+const (
+	descriptionTextSize    = 45
+	descriptionMinTextSize = 14
+)
+
+// descriptionLayout places the description and the counter in a row
+// (like HBox), shrinking the font of the description if it does not fit.
+type descriptionLayout struct {
+	description *canvas.Text
+}
+
+var _ fyne.Layout = (*descriptionLayout)(nil)
+
+func newDescriptionLayout(description *canvas.Text) *descriptionLayout {
+	return &descriptionLayout{
+		description: description,
+	}
+}
+
+func (l *descriptionLayout) measure(textSize float32) fyne.Size {
+	return fyne.MeasureText(l.description.Text, textSize, l.description.TextStyle)
+}
+
+func (l *descriptionLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	var result fyne.Size
+	for _, obj := range objects {
+		if !obj.Visible() {
+			continue
+		}
+		size := obj.MinSize()
+		if obj == l.description {
+			size = fyne.NewSize(l.measure(descriptionMinTextSize).Width, l.measure(descriptionTextSize).Height)
+		}
+		if result.Width > 0 {
+			result.Width += theme.Padding()
+		}
+		result.Width += size.Width
+		result.Height = max(result.Height, size.Height)
+	}
+	return result
+}
+
+func (l *descriptionLayout) Layout(
+	objects []fyne.CanvasObject,
+	size fyne.Size,
+) {
+	available := size.Width
+	for _, obj := range objects {
+		if obj != l.description && obj.Visible() {
+			available -= obj.MinSize().Width + theme.Padding()
+		}
+	}
+	textSize := float32(descriptionTextSize)
+	if width := l.measure(textSize).Width; width > available && width > 0 {
+		textSize = max(descriptionMinTextSize, textSize*available/width)
+	}
+	if textSize != l.description.TextSize {
+		l.description.TextSize = textSize
+		l.description.Refresh()
+	}
+
+	x := float32(0)
+	for _, obj := range objects {
+		if !obj.Visible() {
+			continue
+		}
+		width := obj.MinSize().Width
+		if obj == l.description {
+			width = l.measure(textSize).Width
+		}
+		obj.Move(fyne.NewPos(x, 0))
+		obj.Resize(fyne.NewSize(width, size.Height))
+		x += width + theme.Padding()
+	}
+}
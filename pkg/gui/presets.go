@@ -0,0 +1,59 @@
+package gui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+func presetButtonLabel(preset pomodoro.Preset) string {
+	if preset.Label != "" {
+		return preset.Label
+	}
+	minutes := int(preset.Duration / time.Minute)
+	switch preset.Phase {
+	case pomodoro.PhaseRest:
+		return l10n.T("rest %d", minutes)
+	case pomodoro.PhaseLongRest:
+		return l10n.T("long rest %d", minutes)
+	}
+	switch {
+	case minutes < 10:
+		return fmt.Sprintf("  %d  ", minutes)
+	case minutes < 100:
+		return fmt.Sprintf(" %d ", minutes)
+	default:
+		return strconv.Itoa(minutes)
+	}
+}
+
+// refreshPresets rebuilds the grid of the preset buttons.
+func (g *GUI) refreshPresets() {
+	var buttons []fyne.CanvasObject
+	for _, preset := range g.settings.Presets {
+		buttons = append(buttons, widget.NewButton(presetButtonLabel(preset), g.unlessCommitted(func() {
+			g.ApplyPreset(preset)
+		})))
+	}
+	g.presetsContainer.Layout = layout.NewGridLayoutWithColumns(max(1, int(g.settings.PresetColumns)))
+	g.presetsContainer.Objects = buttons
+	g.presetsContainer.Refresh()
+	g.refreshCommitmentControls()
+}
+
+func (g *GUI) submitCustomInterval(s string) {
+	minutes, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || minutes <= 0 {
+		return
+	}
+	g.SetNextInterval(time.Duration(minutes) * time.Minute)
+	g.intervalEntry.SetText("")
+	g.unfocus()
+}
@@ -0,0 +1,7 @@
+package gui
+
+func (g *GUI) refreshProfiles() {
+	g.profileSelect.Options = g.settings.ProfileNames()
+	g.profileSelect.Selected = g.settings.ActiveProfile
+	g.profileSelect.Refresh()
+}
@@ -0,0 +1,136 @@
+package gui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+func (g *GUI) showExportProfileDialog() {
+	g.locker.Lock()
+	names := g.settings.ProfileNames()
+	active := g.settings.ActiveProfile
+	g.locker.Unlock()
+	if len(names) == 0 {
+		dialog.ShowInformation(l10n.T("Export the profile..."), l10n.T("There are no profiles, save one in the settings first."), g.parentWindow())
+		return
+	}
+
+	profileSelect := widget.NewSelect(names, nil)
+	profileSelect.SetSelectedIndex(0)
+	if active != "" {
+		profileSelect.SetSelected(active)
+	}
+	dialog.ShowForm(
+		l10n.T("Export the profile..."),
+		l10n.T("Export"),
+		l10n.T("Cancel"),
+		[]*widget.FormItem{
+			widget.NewFormItem(l10n.T("Profile"), profileSelect),
+		},
+		func(confirmed bool) {
+			if confirmed {
+				g.showExportProfileFileDialog(profileSelect.Selected)
+			}
+		},
+		g.parentWindow(),
+	)
+}
+
+func (g *GUI) showExportProfileFileDialog(name string) {
+	d := dialog.NewFileSave(func(f fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, g.parentWindow())
+			return
+		}
+		if f == nil {
+			return
+		}
+		defer f.Close()
+		if err := g.ExportProfile(f, name); err != nil {
+			dialog.ShowError(fmt.Errorf("unable to export the profile to '%s': %w", f.URI().Path(), err), g.parentWindow())
+		}
+	}, g.parentWindow())
+	d.SetFileName(name + pomodoro.ProfileFileExtension)
+	d.SetFilter(storage.NewExtensionFileFilter([]string{pomodoro.ProfileFileExtension}))
+	d.Show()
+}
+
+func (g *GUI) showImportProfileDialog() {
+	d := dialog.NewFileOpen(func(f fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, g.parentWindow())
+			return
+		}
+		if f == nil {
+			return
+		}
+		g.importProfileFrom(f)
+	}, g.parentWindow())
+	d.SetFilter(storage.NewExtensionFileFilter([]string{pomodoro.ProfileFileExtension}))
+	d.Show()
+}
+
+// importProfileFrom reads the profile file and imports it; the user is
+// asked to confirm the hooks first, since they run arbitrary commands.
+func (g *GUI) importProfileFrom(r fyne.URIReadCloser) {
+	f, err := pomodoro.ReadProfileFile(r)
+	r.Close()
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("unable to import '%s': %w", r.URI().Name(), err), g.parentWindow())
+		return
+	}
+
+	doImport := func() {
+		if err := g.ImportProfile(f); err != nil {
+			dialog.ShowError(fmt.Errorf("unable to import the profile '%s': %w", f.Profile.Name, err), g.parentWindow())
+		}
+	}
+	hooks := f.Profile.Hooks
+	if hooks == nil || (hooks.OnWorkStart == "" && hooks.OnWorkEnd == "" && hooks.OnRestEnd == "") {
+		doImport()
+		return
+	}
+	var commands []string
+	for _, command := range []string{hooks.OnWorkStart, hooks.OnWorkEnd, hooks.OnRestEnd} {
+		if command != "" {
+			commands = append(commands, command)
+		}
+	}
+	dialog.ShowConfirm(
+		l10n.T("Import the profile '%s'", f.Profile.Name),
+		l10n.T("The profile runs these commands:\n\n%s\n\nImport it only if you trust its author.", strings.Join(commands, "\n")),
+		func(confirmed bool) {
+			if confirmed {
+				doImport()
+			}
+		},
+		g.parentWindow(),
+	)
+}
+
+// handleDropped imports the profile files dropped onto the window.
+func (g *GUI) handleDropped(
+	_ fyne.Position,
+	uris []fyne.URI,
+) {
+	for _, uri := range uris {
+		if uri.Extension() != pomodoro.ProfileFileExtension {
+			slog.Info("ignoring the dropped file: not a profile file", "file", uri.Name())
+			continue
+		}
+		r, err := storage.Reader(uri)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("unable to open '%s': %w", uri.Name(), err), g.parentWindow())
+			continue
+		}
+		g.importProfileFrom(r)
+	}
+}
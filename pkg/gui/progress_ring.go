@@ -0,0 +1,144 @@
+package gui
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	progressRingScale     = 1.45
+	progressRingThickness = 0.08
+
+	// progressRingSteps is how finely the value is drawn, so that the ring
+	// (redrawn pixel by pixel) is not redrawn on every tick of a long
+	// interval while the change would not be visible.
+	progressRingSteps = 1000
+)
+
+// ProgressRing draws an elliptic ring around its content, filled clockwise
+// (starting from the top) proportionally to Value.
+type ProgressRing struct {
+	widget.BaseWidget
+	Content fyne.CanvasObject
+
+	locker     sync.Mutex
+	value      float64
+	color      color.Color
+	trackColor color.Color
+	raster     *canvas.Raster
+}
+
+var _ fyne.Widget = (*ProgressRing)(nil)
+
+func NewProgressRing(content fyne.CanvasObject) *ProgressRing {
+	r := &ProgressRing{
+		Content:    content,
+		color:      color.White,
+		trackColor: color.NRGBA{R: 255, G: 255, B: 255, A: 32},
+	}
+	r.raster = canvas.NewRaster(r.draw)
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+// SetValue sets the filled share of the ring, the ring is redrawn
+// only if it changes by at least 1/progressRingSteps.
+func (r *ProgressRing) SetValue(value float64) {
+	value = math.Round(math.Max(0, math.Min(1, value))*progressRingSteps) / progressRingSteps
+	r.locker.Lock()
+	changed := value != r.value
+	r.value = value
+	r.locker.Unlock()
+	if changed {
+		r.raster.Refresh()
+	}
+}
+
+func (r *ProgressRing) SetColors(
+	fill color.Color,
+	track color.Color,
+) {
+	r.locker.Lock()
+	changed := fill != r.color || track != r.trackColor
+	r.color = fill
+	r.trackColor = track
+	r.locker.Unlock()
+	if changed {
+		r.raster.Refresh()
+	}
+}
+
+// draw renders the ring of the size w x h (in pixels).
+func (r *ProgressRing) draw(w, h int) image.Image {
+	r.locker.Lock()
+	value := r.value
+	fill := color.NRGBAModel.Convert(r.color).(color.NRGBA)
+	track := color.NRGBAModel.Convert(r.trackColor).(color.NRGBA)
+	r.locker.Unlock()
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	rx, ry := float64(w)/2, float64(h)/2
+	for y := 0; y < h; y++ {
+		ny := (float64(y) - ry) / ry
+		for x := 0; x < w; x++ {
+			nx := (float64(x) - rx) / rx
+			dist := math.Hypot(nx, ny)
+			if dist > 1 || dist < 1-progressRingThickness {
+				continue
+			}
+			angle := math.Atan2(nx, -ny)
+			if angle < 0 {
+				angle += 2 * math.Pi
+			}
+			if angle/(2*math.Pi) <= value {
+				img.SetNRGBA(x, y, fill)
+			} else {
+				img.SetNRGBA(x, y, track)
+			}
+		}
+	}
+	return img
+}
+
+func (r *ProgressRing) CreateRenderer() fyne.WidgetRenderer {
+	return &progressRingRenderer{
+		ring:    r,
+		objects: []fyne.CanvasObject{r.raster, r.Content},
+	}
+}
+
+type progressRingRenderer struct {
+	ring    *ProgressRing
+	objects []fyne.CanvasObject
+}
+
+func (rr *progressRingRenderer) Layout(size fyne.Size) {
+	rr.ring.raster.Resize(size)
+	rr.ring.raster.Move(fyne.NewPos(0, 0))
+	contentSize := fyne.NewSize(size.Width/progressRingScale, size.Height/progressRingScale)
+	rr.ring.Content.Resize(contentSize)
+	rr.ring.Content.Move(fyne.NewPos((size.Width-contentSize.Width)/2, (size.Height-contentSize.Height)/2))
+}
+
+func (rr *progressRingRenderer) MinSize() fyne.Size {
+	size := rr.ring.Content.MinSize()
+	return fyne.NewSize(size.Width*progressRingScale, size.Height*progressRingScale)
+}
+
+func (rr *progressRingRenderer) Refresh() {
+	for _, obj := range rr.objects {
+		obj.Refresh()
+	}
+}
+
+func (rr *progressRingRenderer) Objects() []fyne.CanvasObject {
+	return rr.objects
+}
+
+func (rr *progressRingRenderer) Destroy() {}
@@ -0,0 +1,39 @@
+package gui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+)
+
+func newTestProgressRing(b *testing.B) *ProgressRing {
+	test.NewTempApp(b)
+	r := NewProgressRing(canvas.NewText("25:00", nil))
+	w := test.NewWindow(r)
+	b.Cleanup(w.Close)
+	w.Resize(fyne.NewSize(300, 200))
+	return r
+}
+
+// BenchmarkProgressRingSetValue is the progress set on each tick of
+// an hour-long interval, the ring is redrawn only on the visible changes.
+func BenchmarkProgressRingSetValue(b *testing.B) {
+	r := newTestProgressRing(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for idx := 0; idx < b.N; idx++ {
+		r.SetValue(float64(idx%3600) / 3600)
+	}
+}
+
+func BenchmarkProgressRingDraw(b *testing.B) {
+	r := newTestProgressRing(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for idx := 0; idx < b.N; idx++ {
+		r.SetValue(float64(idx%progressRingSteps) / progressRingSteps)
+		r.draw(300, 200)
+	}
+}
@@ -0,0 +1,82 @@
+package gui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// afterRitual wraps the action starting a work session, so that it is
+// done after the ritual checklist is ticked or skipped. It is used
+// only for the starts by the user, the automatic ones (and the remote
+// control) do not wait for anybody to go through the checklist.
+func (g *GUI) afterRitual(start func()) func() {
+	return func() {
+		g.locker.Lock()
+		s := g.settings.Ritual
+		g.locker.Unlock()
+		w := g.parentWindow()
+		if !s.IsEnabled() || w == nil {
+			start()
+			return
+		}
+		g.showRitualDialog(s.Items, start, w)
+	}
+}
+
+func (g *GUI) showRitualDialog(
+	items []string,
+	start func(),
+	w fyne.Window,
+) {
+	var d *dialog.CustomDialog
+	finish := func(completed bool) {
+		d.Hide()
+		g.SetRitualResult(completed)
+		start()
+	}
+
+	startButton := widget.NewButtonWithIcon(l10n.T("Start work"), theme.MediaPlayIcon(), func() { finish(true) })
+	startButton.Importance = widget.HighImportance
+	startButton.Disable()
+	checks := make([]*widget.Check, 0, len(items))
+	onChanged := func(bool) {
+		for _, check := range checks {
+			if !check.Checked {
+				startButton.Disable()
+				return
+			}
+		}
+		startButton.Enable()
+	}
+	list := container.NewVBox()
+	for _, item := range items {
+		check := widget.NewCheck(item, onChanged)
+		checks = append(checks, check)
+		list.Add(check)
+	}
+
+	d = dialog.NewCustomWithoutButtons(l10n.T("Before the session"), list, w)
+	d.SetButtons([]fyne.CanvasObject{
+		widget.NewButtonWithIcon(l10n.T("Cancel"), theme.CancelIcon(), d.Hide),
+		widget.NewButtonWithIcon(l10n.T("Skip"), theme.MediaSkipNextIcon(), func() { finish(false) }),
+		startButton,
+	})
+
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	if g.ritual.dialog != nil {
+		g.ritual.dialog.Hide()
+	}
+	g.ritual.dialog = d
+	d.Show()
+}
+
+// ritualDialog is the checklist shown before a work session
+// (see pomodoro.RitualSettings).
+type ritualDialog struct {
+	dialog *dialog.CustomDialog
+}
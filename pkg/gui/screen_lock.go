@@ -0,0 +1,53 @@
+package gui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// refreshScreenLockWarning shows the countdown to locking the screen
+// (see pomodoro.View.ScreenLockIn).
+func (g *GUI) refreshScreenLockWarning() {
+	timeLeft := g.view.ScreenLockIn
+	if timeLeft == 0 || !g.isShown {
+		if g.screenLockWarning != nil {
+			g.screenLockWarning.Window.Close()
+			g.screenLockWarning = nil
+		}
+		return
+	}
+	if g.screenLockWarning == nil {
+		g.screenLockWarning = g.newScreenLockWarning()
+	}
+	g.screenLockWarning.refresh(timeLeft)
+}
+
+type screenLockWarning struct {
+	Window fyne.Window
+	Label  *widget.Label
+}
+
+func (g *GUI) newScreenLockWarning() *screenLockWarning {
+	label := widget.NewLabel("")
+	label.Alignment = fyne.TextAlignCenter
+	w := g.App.NewWindow(l10n.T("Long break"))
+	w.SetCloseIntercept(func() {})
+	w.SetContent(container.NewVBox(
+		label,
+		container.NewCenter(widget.NewButtonWithIcon(l10n.T("Lock now"), theme.LoginIcon(), func() {
+			g.LockScreenNow()
+		})),
+	))
+	w.Show()
+	w.RequestFocus()
+	return &screenLockWarning{Window: w, Label: label}
+}
+
+func (w *screenLockWarning) refresh(timeLeft time.Duration) {
+	w.Label.SetText(l10n.T("Save your work: the screen will be locked in %d seconds.", int((timeLeft+time.Second-1)/time.Second)))
+}
@@ -0,0 +1,53 @@
+package gui
+
+import (
+	"log/slog"
+	"strings"
+
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+func (g *GUI) handleSessionNoteEvent(ev pomodoro.Event) {
+	if ev.Type != pomodoro.EventTypePhaseEnded || ev.Phase != pomodoro.PhaseWork {
+		return
+	}
+	g.locker.Lock()
+	enabled := g.settings.AskSessionNote
+	g.locker.Unlock()
+	if !enabled {
+		return
+	}
+	g.showSessionNoteDialog()
+}
+
+func (g *GUI) showSessionNoteDialog() {
+	w := g.parentWindow()
+	if w == nil {
+		return
+	}
+
+	noteEntry := widget.NewMultiLineEntry()
+	noteEntry.SetPlaceHolder(l10n.T("e.g. 'drafted the introduction'"))
+	noteEntry.SetMinRowsVisible(3)
+	dialog.ShowForm(
+		l10n.T("What did you accomplish?"),
+		l10n.T("Save"),
+		l10n.T("Skip"),
+		[]*widget.FormItem{
+			widget.NewFormItem(l10n.T("Note"), noteEntry),
+		},
+		func(confirmed bool) {
+			note := strings.TrimSpace(noteEntry.Text)
+			if !confirmed || note == "" {
+				return
+			}
+			if err := g.SetSessionNote(note); err != nil {
+				slog.Error("unable to attach the note to the session", "error", err)
+			}
+		},
+		w,
+	)
+}
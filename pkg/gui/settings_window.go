@@ -0,0 +1,943 @@
+package gui
+
+import (
+	"fmt"
+	"image/color"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/audio"
+	"github.com/xaionaro-go/pomodoro/pkg/globalhotkey"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/notify"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+type settingsSection struct {
+	Title string
+	Items []*widget.FormItem
+	Apply func(s *pomodoro.Settings)
+}
+
+func (g *GUI) ShowSettings() {
+	g.locker.Lock()
+	s := g.settings
+	g.locker.Unlock()
+
+	w := g.App.NewWindow(l10n.T("Settings"))
+
+	sections := []settingsSection{
+		g.generalSettingsSection(s),
+		g.alarmSettingsSection(s, w),
+		g.colorsSettingsSection(s, w),
+		g.labelsSettingsSection(s),
+		g.hotkeysSettingsSection(s),
+		g.hooksSettingsSection(s),
+		g.blockerSettingsSection(s),
+		g.integrationsSettingsSection(s),
+	}
+
+	tabs := container.NewAppTabs()
+	var forms []*widget.Form
+	for _, section := range sections {
+		form := widget.NewForm(section.Items...)
+		forms = append(forms, form)
+		tabs.Append(container.NewTabItem(section.Title, container.NewVScroll(form)))
+	}
+
+	profileEntry := widget.NewSelectEntry(s.ProfileNames())
+	profileEntry.SetPlaceHolder(l10n.T("(no profile)"))
+	profileEntry.SetText(s.ActiveProfile)
+
+	saveButton := widget.NewButtonWithIcon(l10n.T("Save"), theme.DocumentSaveIcon(), func() {
+		for _, form := range forms {
+			if err := form.Validate(); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+		}
+		for _, section := range sections {
+			section.Apply(&s)
+		}
+		if name := strings.TrimSpace(profileEntry.Text); name != "" {
+			s.StoreProfile(name)
+		} else {
+			s.ActiveProfile = ""
+		}
+		g.ApplySettings(s)
+		w.Close()
+	})
+	saveButton.Importance = widget.HighImportance
+	cancelButton := widget.NewButtonWithIcon(l10n.T("Cancel"), theme.CancelIcon(), w.Close)
+
+	w.SetContent(container.NewBorder(
+		nil,
+		container.NewBorder(
+			nil,
+			nil,
+			widget.NewLabel(l10n.T("Profile:")),
+			container.NewHBox(cancelButton, saveButton),
+			profileEntry,
+		),
+		nil,
+		nil,
+		tabs,
+	))
+	w.Resize(fyne.NewSize(480, 420))
+	w.Show()
+}
+
+func (g *GUI) generalSettingsSection(s pomodoro.Settings) settingsSection {
+	workIntervalEntry := newMinutesEntry(s.WorkInterval)
+	restIntervalEntry := newMinutesEntry(s.RestInterval)
+	longRestIntervalEntry := newMinutesEntry(s.LongRestInterval)
+	longBreakEveryEntry := newUintEntry(uint64(s.LongBreakEvery))
+	presetColumnsEntry := newUintEntry(uint64(s.PresetColumns))
+	presetsEntry := widget.NewMultiLineEntry()
+	presetsEntry.SetPlaceHolder(l10n.T("One preset per line: label, minutes, phase (work, rest or long_rest)"))
+	presetsEntry.SetText(pomodoro.FormatPresets(s.Presets))
+	presetsEntry.Validator = func(s string) error {
+		_, err := pomodoro.ParsePresets(s)
+		return err
+	}
+	var delimiterAnimationOptions []string
+	for _, a := range pomodoro.DelimiterAnimations {
+		delimiterAnimationOptions = append(delimiterAnimationOptions, string(a))
+	}
+	delimiterAnimationSelect := widget.NewSelect(delimiterAnimationOptions, nil)
+	delimiterAnimationSelect.SetSelected(string(s.DelimiterAnimation))
+	endFlashCheck := widget.NewCheck("", nil)
+	endFlashCheck.SetChecked(s.EndFlash)
+	urgencyColorsEntry := newUintEntry(uint64(s.UrgencyColors / time.Minute))
+	powerSavingCheck := widget.NewCheck("", nil)
+	powerSavingCheck.SetChecked(s.PowerSaving)
+	showTenthsCheck := widget.NewCheck("", nil)
+	showTenthsCheck.SetChecked(s.ShowTenths)
+	var timeRoundingOptions []string
+	for _, r := range pomodoro.TimeRoundings {
+		timeRoundingOptions = append(timeRoundingOptions, string(r))
+	}
+	timeRoundingSelect := widget.NewSelect(timeRoundingOptions, nil)
+	timeRoundingSelect.SetSelected(string(s.TimeLeftRounding))
+	var themeOptions []string
+	for _, v := range pomodoro.ThemeVariants {
+		themeOptions = append(themeOptions, string(v))
+	}
+	themeSelect := widget.NewSelect(themeOptions, nil)
+	themeSelect.SetSelected(string(s.Theme))
+	var colorPaletteOptions []string
+	for _, palette := range pomodoro.ColorPalettes {
+		colorPaletteOptions = append(colorPaletteOptions, string(palette))
+	}
+	colorPaletteSelect := widget.NewSelect(colorPaletteOptions, nil)
+	colorPaletteSelect.SetSelected(string(s.ColorPalette))
+	trayIconCheck := widget.NewCheck("", nil)
+	trayIconCheck.SetChecked(s.TrayIcon)
+	autostartCheck := widget.NewCheck("", nil)
+	autostartCheck.SetChecked(s.Autostart)
+	speakFocusedTimerCheck := widget.NewCheck("", nil)
+	speakFocusedTimerCheck.SetChecked(s.SpeakFocusedTimer)
+	languageOptions := append([]string{l10n.T("(system)")}, l10n.Languages()...)
+	languageSelect := widget.NewSelect(languageOptions, nil)
+	languageSelect.SetSelected(s.Language)
+	if s.Language == "" {
+		languageSelect.SetSelectedIndex(0)
+	}
+	autoContinueCheck := widget.NewCheck("", nil)
+	autoContinueCheck.SetChecked(s.AutoContinue)
+	overtimeCheck := widget.NewCheck("", nil)
+	overtimeCheck.SetChecked(s.Overtime)
+	autoContinueDelayEntry := newUintEntry(uint64(s.AutoContinueDelay / time.Second))
+	endActionChooserCheck := widget.NewCheck("", nil)
+	endActionChooserCheck.SetChecked(s.EndActionChooser)
+	idlePauseAfterEntry := newUintEntry(uint64(s.IdlePauseAfter / time.Minute))
+	var breakComplianceOptions []string
+	for _, policy := range breakCompliancePolicies {
+		breakComplianceOptions = append(breakComplianceOptions, string(policy))
+	}
+	breakComplianceSelect := widget.NewSelect(breakComplianceOptions, nil)
+	breakComplianceSelect.SetSelected(string(s.BreakCompliance))
+	var suspendPolicyOptions []string
+	for _, policy := range suspendPolicies {
+		suspendPolicyOptions = append(suspendPolicyOptions, string(policy))
+	}
+	suspendPolicySelect := widget.NewSelect(suspendPolicyOptions, nil)
+	suspendPolicySelect.SetSelected(string(s.SuspendPolicy))
+	warnBeforeEntry := newUintEntry(uint64(s.WarnBefore / time.Minute))
+	overrunNudgeEveryEntry := newUintEntry(uint64(s.OverrunNudgeEvery / time.Minute))
+	doNotDisturbCheck := widget.NewCheck("", nil)
+	doNotDisturbCheck.SetChecked(s.DoNotDisturb)
+	askSessionNoteCheck := widget.NewCheck("", nil)
+	askSessionNoteCheck.SetChecked(s.AskSessionNote)
+	askFocusRatingCheck := widget.NewCheck("", nil)
+	askFocusRatingCheck.SetChecked(s.AskFocusRating)
+	ritualCheck := widget.NewCheck("", nil)
+	ritualCheck.SetChecked(s.Ritual.Enabled)
+	ritualItemsEntry := widget.NewMultiLineEntry()
+	ritualItemsEntry.SetPlaceHolder(l10n.T("One item per line"))
+	ritualItemsEntry.SetText(strings.Join(s.Ritual.Items, "\n"))
+	var commitmentModeOptions []string
+	for _, mode := range pomodoro.CommitmentModes {
+		commitmentModeOptions = append(commitmentModeOptions, string(mode))
+	}
+	commitmentModeSelect := widget.NewSelect(commitmentModeOptions, nil)
+	commitmentModeSelect.SetSelected(string(s.Commitment.Mode))
+	commitmentPhraseEntry := widget.NewEntry()
+	commitmentPhraseEntry.SetText(s.Commitment.Phrase)
+	commitmentPhraseEntry.Validator = func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return fmt.Errorf("the phrase is empty")
+		}
+		return nil
+	}
+	strictBreakCheck := widget.NewCheck("", nil)
+	strictBreakCheck.SetChecked(s.StrictBreak)
+	strictBreakSkipAfterEntry := newUintEntry(uint64(s.StrictBreakSkipAfter / time.Second))
+	lockScreenCheck := widget.NewCheck("", nil)
+	lockScreenCheck.SetChecked(s.ScreenLock.OnLongBreak)
+	lockScreenWarningEntry := newUintEntry(uint64(s.ScreenLock.Warning / time.Second))
+	breakDebtLimitEntry := newUintEntry(uint64(s.BreakDebt.Limit / time.Minute))
+	breakDebtEnforceCheck := widget.NewCheck("", nil)
+	breakDebtEnforceCheck.SetChecked(s.BreakDebt.Enforce)
+	breakSuggestionsEntry := widget.NewMultiLineEntry()
+	breakSuggestionsEntry.SetPlaceHolder(l10n.T("One suggestion per line"))
+	breakSuggestionsEntry.SetText(strings.Join(s.BreakSuggestions, "\n"))
+	dailyGoalEntry := newUintEntry(uint64(s.DailyGoal))
+	dayBoundaryEntry := newUintEntry(uint64(s.DayBoundary / time.Hour))
+	dayBoundaryEntry.Validator = func(s string) error {
+		hour, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || hour > 23 {
+			return fmt.Errorf("expected an hour between 0 and 23")
+		}
+		return nil
+	}
+
+	dailySummaryCheck := widget.NewCheck("", nil)
+	dailySummaryCheck.SetChecked(s.DailySummary.Enabled)
+	dailySummaryAtEntry := widget.NewEntry()
+	dailySummaryAtEntry.SetText(formatTimeOfDay(s.DailySummary.At))
+	dailySummaryAtEntry.Validator = func(s string) error {
+		_, err := parseTimeOfDay(s)
+		return err
+	}
+
+	workScheduleCheck := widget.NewCheck("", nil)
+	workScheduleCheck.SetChecked(s.WorkSchedule.Enabled)
+	workScheduleStartEntry := widget.NewEntry()
+	workScheduleStartEntry.SetText(formatTimeOfDay(s.WorkSchedule.Start))
+	workScheduleStartEntry.Validator = func(s string) error {
+		_, err := parseTimeOfDay(s)
+		return err
+	}
+	workScheduleEndEntry := widget.NewEntry()
+	workScheduleEndEntry.SetText(formatTimeOfDay(s.WorkSchedule.End))
+	workScheduleEndEntry.Validator = workScheduleStartEntry.Validator
+	var weekdayOptions, selectedWeekdays []string
+	for _, day := range pomodoro.Weekdays {
+		weekdayOptions = append(weekdayOptions, weekdayName(day))
+		if slices.Contains(s.WorkSchedule.Days, day) {
+			selectedWeekdays = append(selectedWeekdays, weekdayName(day))
+		}
+	}
+	workDaysGroup := widget.NewCheckGroup(weekdayOptions, nil)
+	workDaysGroup.SetSelected(selectedWeekdays)
+	startNudgeAfterEntry := newUintEntry(uint64(s.WorkSchedule.StartNudgeAfter / time.Minute))
+	scheduledSessionsEntry := widget.NewMultiLineEntry()
+	scheduledSessionsEntry.SetPlaceHolder(l10n.T("One per line, e.g. 'weekdays 09:00 90m Deep work' or '2024-03-15 14:00'"))
+	scheduledSessionsEntry.SetText(strings.Join(s.ScheduledSessions, "\n"))
+	scheduledSessionsEntry.Validator = func(s string) error {
+		for _, line := range pomodoro.ParseLines(s) {
+			if _, err := pomodoro.ParseScheduleRule(line); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return settingsSection{
+		Title: l10n.T("General"),
+		Items: []*widget.FormItem{
+			widget.NewFormItem(l10n.T("Work (minutes)"), workIntervalEntry),
+			widget.NewFormItem(l10n.T("Rest (minutes)"), restIntervalEntry),
+			widget.NewFormItem(l10n.T("Long rest (minutes)"), longRestIntervalEntry),
+			widget.NewFormItem(l10n.T("Long rest every N sessions"), longBreakEveryEntry),
+			widget.NewFormItem(l10n.T("Preset buttons (F1–F12, 1–8)"), presetsEntry),
+			widget.NewFormItem(l10n.T("Preset buttons per row"), presetColumnsEntry),
+			widget.NewFormItem(l10n.T("Delimiter animation"), delimiterAnimationSelect),
+			widget.NewFormItem(l10n.T("Flash at the end of an interval"), endFlashCheck),
+			widget.NewFormItem(l10n.T("Shift the digits to yellow and red in the last minutes (0: never)"), urgencyColorsEntry),
+			widget.NewFormItem(l10n.T("Power saving (no animation, no seconds while in background)"), powerSavingCheck),
+			widget.NewFormItem(l10n.T("Show tenths of a second in the last 10 seconds"), showTenthsCheck),
+			widget.NewFormItem(l10n.T("Rounding of the time left"), timeRoundingSelect),
+			widget.NewFormItem(l10n.T("Theme"), themeSelect),
+			widget.NewFormItem(l10n.T("Colors of the phases"), colorPaletteSelect),
+			widget.NewFormItem(l10n.T("Show the remaining minutes in the tray and the window icon"), trayIconCheck),
+			widget.NewFormItem(l10n.T("Start minimized to the tray on login"), autostartCheck),
+			widget.NewFormItem(l10n.T("Read the timer aloud when it gets the keyboard focus"), speakFocusedTimerCheck),
+			widget.NewFormItem(l10n.T("Language (applied on restart)"), languageSelect),
+			widget.NewFormItem(l10n.T("Auto-start next phase"), autoContinueCheck),
+			widget.NewFormItem(l10n.T("Count the overtime instead of switching the phase at the end"), overtimeCheck),
+			widget.NewFormItem(l10n.T("Auto-start delay (seconds)"), autoContinueDelayEntry),
+			widget.NewFormItem(l10n.T("Ask what to do next when an interval ends"), endActionChooserCheck),
+			widget.NewFormItem(l10n.T("Pause work when idle for (minutes, 0 to disable)"), idlePauseAfterEntry),
+			widget.NewFormItem(l10n.T("When the computer is used during a break"), breakComplianceSelect),
+			widget.NewFormItem(l10n.T("After the computer wakes up from sleep"), suspendPolicySelect),
+			widget.NewFormItem(l10n.T("Warn before the end (minutes, 0 to disable)"), warnBeforeEntry),
+			widget.NewFormItem(l10n.T("Remind to take a break when working past the end every (minutes, 0 to disable)"), overrunNudgeEveryEntry),
+			widget.NewFormItem(l10n.T("Do-Not-Disturb during work"), doNotDisturbCheck),
+			widget.NewFormItem(l10n.T("Ask what was accomplished after a work session"), askSessionNoteCheck),
+			widget.NewFormItem(l10n.T("Ask to rate the focus after a work session"), askFocusRatingCheck),
+			widget.NewFormItem(l10n.T("Go through a checklist before a work session"), ritualCheck),
+			widget.NewFormItem(l10n.T("Checklist before a work session"), ritualItemsEntry),
+			widget.NewFormItem(l10n.T("Commitment during work (restricts STOP and interval changes)"), commitmentModeSelect),
+			widget.NewFormItem(l10n.T("Phrase to type to stop a work session"), commitmentPhraseEntry),
+			widget.NewFormItem(l10n.T("Full-screen breaks"), strictBreakCheck),
+			widget.NewFormItem(l10n.T("Allow skipping a full-screen break after (seconds)"), strictBreakSkipAfterEntry),
+			widget.NewFormItem(l10n.T("Lock the screen when a long break starts"), lockScreenCheck),
+			widget.NewFormItem(l10n.T("Warn before locking the screen (seconds)"), lockScreenWarningEntry),
+			widget.NewFormItem(l10n.T("Extend the next break after skipping breaks for (minutes, 0 to disable)"), breakDebtLimitEntry),
+			widget.NewFormItem(l10n.T("Make the extended break full-screen and not skippable"), breakDebtEnforceCheck),
+			widget.NewFormItem(l10n.T("Break suggestions"), breakSuggestionsEntry),
+			widget.NewFormItem(l10n.T("Daily goal (sessions, 0 to disable)"), dailyGoalEntry),
+			widget.NewFormItem(l10n.T("Day starts at (hour)"), dayBoundaryEntry),
+			widget.NewFormItem(l10n.T("End-of-day summary"), dailySummaryCheck),
+			widget.NewFormItem(l10n.T("End-of-day summary at"), dailySummaryAtEntry),
+			widget.NewFormItem(l10n.T("Working hours (no auto-start and notifications outside them)"), workScheduleCheck),
+			widget.NewFormItem(l10n.T("Working hours start at"), workScheduleStartEntry),
+			widget.NewFormItem(l10n.T("Working hours end at"), workScheduleEndEntry),
+			widget.NewFormItem(l10n.T("Working days"), workDaysGroup),
+			widget.NewFormItem(l10n.T("Suggest a session after minutes without one (0: never)"), startNudgeAfterEntry),
+			widget.NewFormItem(l10n.T("Scheduled focus sessions"), scheduledSessionsEntry),
+		},
+		Apply: func(s *pomodoro.Settings) {
+			s.WorkInterval = parseMinutes(workIntervalEntry.Text)
+			s.RestInterval = parseMinutes(restIntervalEntry.Text)
+			s.LongRestInterval = parseMinutes(longRestIntervalEntry.Text)
+			s.LongBreakEvery = uint(parseUint(longBreakEveryEntry.Text))
+			s.Presets, _ = pomodoro.ParsePresets(presetsEntry.Text)
+			s.PresetColumns = uint(parseUint(presetColumnsEntry.Text))
+			s.DelimiterAnimation = pomodoro.DelimiterAnimation(delimiterAnimationSelect.Selected)
+			s.EndFlash = endFlashCheck.Checked
+			s.UrgencyColors = time.Duration(parseUint(urgencyColorsEntry.Text)) * time.Minute
+			s.PowerSaving = powerSavingCheck.Checked
+			s.ShowTenths = showTenthsCheck.Checked
+			s.TimeLeftRounding = pomodoro.TimeRounding(timeRoundingSelect.Selected)
+			s.Theme = pomodoro.ThemeVariant(themeSelect.Selected)
+			s.ColorPalette = pomodoro.ColorPalette(colorPaletteSelect.Selected)
+			s.TrayIcon = trayIconCheck.Checked
+			s.Autostart = autostartCheck.Checked
+			s.SpeakFocusedTimer = speakFocusedTimerCheck.Checked
+			s.Language = languageSelect.Selected
+			if languageSelect.SelectedIndex() == 0 {
+				s.Language = ""
+			}
+			s.AutoContinue = autoContinueCheck.Checked
+			s.Overtime = overtimeCheck.Checked
+			s.AutoContinueDelay = time.Duration(parseUint(autoContinueDelayEntry.Text)) * time.Second
+			s.EndActionChooser = endActionChooserCheck.Checked
+			s.IdlePauseAfter = time.Duration(parseUint(idlePauseAfterEntry.Text)) * time.Minute
+			s.BreakCompliance = pomodoro.BreakCompliancePolicy(breakComplianceSelect.Selected)
+			s.SuspendPolicy = pomodoro.SuspendPolicy(suspendPolicySelect.Selected)
+			s.WarnBefore = time.Duration(parseUint(warnBeforeEntry.Text)) * time.Minute
+			s.OverrunNudgeEvery = time.Duration(parseUint(overrunNudgeEveryEntry.Text)) * time.Minute
+			s.DoNotDisturb = doNotDisturbCheck.Checked
+			s.AskSessionNote = askSessionNoteCheck.Checked
+			s.AskFocusRating = askFocusRatingCheck.Checked
+			s.Ritual.Enabled = ritualCheck.Checked
+			s.Ritual.Items = pomodoro.ParseLines(ritualItemsEntry.Text)
+			s.Commitment.Mode = pomodoro.CommitmentMode(commitmentModeSelect.Selected)
+			s.Commitment.Phrase = strings.TrimSpace(commitmentPhraseEntry.Text)
+			s.StrictBreak = strictBreakCheck.Checked
+			s.StrictBreakSkipAfter = time.Duration(parseUint(strictBreakSkipAfterEntry.Text)) * time.Second
+			s.ScreenLock.OnLongBreak = lockScreenCheck.Checked
+			s.ScreenLock.Warning = time.Duration(parseUint(lockScreenWarningEntry.Text)) * time.Second
+			s.BreakDebt.Limit = time.Duration(parseUint(breakDebtLimitEntry.Text)) * time.Minute
+			s.BreakDebt.Enforce = breakDebtEnforceCheck.Checked
+			s.BreakSuggestions = pomodoro.ParseLines(breakSuggestionsEntry.Text)
+			s.ScheduledSessions = pomodoro.ParseLines(scheduledSessionsEntry.Text)
+			s.DailyGoal = uint(parseUint(dailyGoalEntry.Text))
+			s.DayBoundary = time.Duration(parseUint(dayBoundaryEntry.Text)) * time.Hour
+			s.DailySummary.Enabled = dailySummaryCheck.Checked
+			if at, err := parseTimeOfDay(dailySummaryAtEntry.Text); err == nil {
+				s.DailySummary.At = at
+			}
+			s.WorkSchedule.Enabled = workScheduleCheck.Checked
+			if start, err := parseTimeOfDay(workScheduleStartEntry.Text); err == nil {
+				s.WorkSchedule.Start = start
+			}
+			if end, err := parseTimeOfDay(workScheduleEndEntry.Text); err == nil {
+				s.WorkSchedule.End = end
+			}
+			s.WorkSchedule.Days = nil
+			for _, day := range pomodoro.Weekdays {
+				if slices.Contains(workDaysGroup.Selected, weekdayName(day)) {
+					s.WorkSchedule.Days = append(s.WorkSchedule.Days, day)
+				}
+			}
+			s.WorkSchedule.StartNudgeAfter = time.Duration(parseUint(startNudgeAfterEntry.Text)) * time.Minute
+		},
+	}
+}
+
+func (g *GUI) alarmSettingsSection(
+	s pomodoro.Settings,
+	w fyne.Window,
+) settingsSection {
+	alarmCheck := widget.NewCheck("", nil)
+	alarmCheck.SetChecked(s.AlarmEnabled)
+	alarmVolumeSlider := widget.NewSlider(0, 1)
+	alarmVolumeSlider.Step = 0.05
+	alarmVolumeSlider.SetValue(s.AlarmVolume)
+	var alarmSoundOptions []string
+	for _, sound := range pomodoro.AlarmSounds {
+		alarmSoundOptions = append(alarmSoundOptions, string(sound))
+	}
+	alarmSoundSelect := widget.NewSelect(alarmSoundOptions, nil)
+	alarmSoundSelect.SetSelected(string(s.AlarmSound))
+	alarmFileEntry := widget.NewEntry()
+	alarmFileEntry.SetPlaceHolder(l10n.T("(use the sound above)"))
+	alarmFileEntry.SetText(s.AlarmFile)
+	alarmFileBrowseButton := widget.NewButtonWithIcon("", theme.FolderOpenIcon(), func() {
+		d := dialog.NewFileOpen(func(f fyne.URIReadCloser, err error) {
+			if err != nil || f == nil {
+				return
+			}
+			defer f.Close()
+			alarmFileEntry.SetText(f.URI().Path())
+		}, w)
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".ogg", ".oga", ".mp3", ".wav", ".wave"}))
+		d.Show()
+	})
+	alarmDeviceOptions := []string{l10n.T("(default)")}
+	devices, err := audio.OutputDevices()
+	if err != nil {
+		slog.Error("unable to list the audio devices", "error", err)
+	}
+	for _, device := range devices {
+		alarmDeviceOptions = append(alarmDeviceOptions, device.ID)
+	}
+	if s.AlarmDevice != "" && !slices.Contains(alarmDeviceOptions, s.AlarmDevice) {
+		// not connected now
+		alarmDeviceOptions = append(alarmDeviceOptions, s.AlarmDevice)
+	}
+	alarmDeviceSelect := widget.NewSelect(alarmDeviceOptions, nil)
+	alarmDeviceSelect.SetSelectedIndex(0)
+	if s.AlarmDevice != "" {
+		alarmDeviceSelect.SetSelected(s.AlarmDevice)
+	}
+	alarmFadeInEntry := newUintEntry(uint64(s.AlarmFadeIn / time.Second))
+	var countdownOptions []string
+	for _, mode := range countdownModes {
+		countdownOptions = append(countdownOptions, string(mode))
+	}
+	countdownSelect := widget.NewSelect(countdownOptions, nil)
+	countdownSelect.SetSelected(string(s.Countdown))
+	alarmRepeatEntry := newUintEntry(uint64(s.AlarmRepeat))
+	alarmEscalationCheck := widget.NewCheck("", nil)
+	alarmEscalationCheck.SetChecked(s.AlarmEscalation)
+	ambienceCheck := widget.NewCheck("", nil)
+	ambienceCheck.SetChecked(s.Ambience.Enabled)
+	ambienceVolumeSlider := widget.NewSlider(0, 1)
+	ambienceVolumeSlider.Step = 0.05
+	ambienceVolumeSlider.SetValue(s.Ambience.Volume)
+	ambienceFileEntry := widget.NewEntry()
+	ambienceFileEntry.SetPlaceHolder(l10n.T("(ticking clock)"))
+	ambienceFileEntry.SetText(s.Ambience.File)
+	ambienceFileBrowseButton := widget.NewButtonWithIcon("", theme.FolderOpenIcon(), func() {
+		d := dialog.NewFileOpen(func(f fyne.URIReadCloser, err error) {
+			if err != nil || f == nil {
+				return
+			}
+			defer f.Close()
+			ambienceFileEntry.SetText(f.URI().Path())
+		}, w)
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".ogg", ".oga", ".mp3", ".wav", ".wave"}))
+		d.Show()
+	})
+	focusMusicCheck := widget.NewCheck("", nil)
+	focusMusicCheck.SetChecked(s.FocusMusic.Enabled)
+	focusMusicVolumeSlider := widget.NewSlider(0, 1)
+	focusMusicVolumeSlider.Step = 0.05
+	focusMusicVolumeSlider.SetValue(s.FocusMusic.Volume)
+	focusMusicPlaylistEntry := widget.NewMultiLineEntry()
+	focusMusicPlaylistEntry.SetPlaceHolder(l10n.T("One file, M3U playlist or stream URL per line"))
+	focusMusicPlaylistEntry.SetText(strings.Join(s.FocusMusic.Playlist, "\n"))
+	alarmPreviewButton := widget.NewButtonWithIcon(l10n.T("Preview"), theme.MediaPlayIcon(), func() {
+		g.PreviewSound(pomodoro.AlarmSound(alarmSoundSelect.Selected), alarmFileEntry.Text, alarmVolumeSlider.Value)
+	})
+	var audioItems []*widget.FormItem
+	if !g.HasAudio() {
+		alarmPreviewButton.Disable()
+		noAudioLabel := widget.NewLabel(l10n.T("No audio output is available: the sounds are disabled, the alarm flashes the window and shows a notification instead."))
+		noAudioLabel.Wrapping = fyne.TextWrapWord
+		noAudioLabel.Importance = widget.WarningImportance
+		audioItems = append(audioItems, widget.NewFormItem(l10n.T("Sound"), noAudioLabel))
+	}
+
+	var notifyBackendOptions []string
+	for _, name := range notify.BackendNames {
+		notifyBackendOptions = append(notifyBackendOptions, string(name))
+	}
+	notifyBackendsCheckGroup := widget.NewCheckGroup(notifyBackendOptions, nil)
+	notifyBackendsCheckGroup.Horizontal = true
+	notifyBackendsCheckGroup.SetSelected(pomodoro.BackendNamesToStrings(s.Notifications.Backends))
+	notifyWebhookURLEntry := widget.NewEntry()
+	notifyWebhookURLEntry.SetPlaceHolder("https://example.com/hook")
+	notifyWebhookURLEntry.SetText(s.Notifications.WebhookURL)
+	notifyOnPhaseEndCheck := widget.NewCheck("", nil)
+	notifyOnPhaseEndCheck.SetChecked(s.Notifications.OnPhaseEnd)
+
+	announceCheck := widget.NewCheck("", nil)
+	announceCheck.SetChecked(s.Announcements.Enabled)
+	announceWorkEntry := widget.NewEntry()
+	announceWorkEntry.SetText(s.Announcements.Work)
+	announceRestEntry := widget.NewEntry()
+	announceRestEntry.SetText(s.Announcements.Rest)
+	announceLongRestEntry := widget.NewEntry()
+	announceLongRestEntry.SetText(s.Announcements.LongRest)
+
+	return settingsSection{
+		Title: l10n.T("Alarm"),
+		Items: append(audioItems,
+			widget.NewFormItem(l10n.T("Alarm"), alarmCheck),
+			widget.NewFormItem(l10n.T("Maximum alarm volume"), alarmVolumeSlider),
+			widget.NewFormItem(l10n.T("Alarm fade-in (seconds)"), alarmFadeInEntry),
+			widget.NewFormItem(l10n.T("Play the alarm N times (0 until acknowledged)"), alarmRepeatEntry),
+			widget.NewFormItem(l10n.T("Escalate until acknowledged (louder, then full screen)"), alarmEscalationCheck),
+			widget.NewFormItem(l10n.T("Alarm sound"), container.NewBorder(nil, nil, nil, alarmPreviewButton, alarmSoundSelect)),
+			widget.NewFormItem(l10n.T("Custom alarm sound file"), container.NewBorder(nil, nil, nil, alarmFileBrowseButton, alarmFileEntry)),
+			widget.NewFormItem(l10n.T("Output device (applied on restart)"), alarmDeviceSelect),
+			widget.NewFormItem(l10n.T("Countdown in the last 10 seconds"), countdownSelect),
+			widget.NewFormItem(l10n.T("Ambient sound during work"), ambienceCheck),
+			widget.NewFormItem(l10n.T("Ambient sound volume"), ambienceVolumeSlider),
+			widget.NewFormItem(l10n.T("Ambient sound file (looped)"), container.NewBorder(nil, nil, nil, ambienceFileBrowseButton, ambienceFileEntry)),
+			widget.NewFormItem(l10n.T("Focus music (stopped during breaks)"), focusMusicCheck),
+			widget.NewFormItem(l10n.T("Focus music volume"), focusMusicVolumeSlider),
+			widget.NewFormItem(l10n.T("Focus music playlist"), focusMusicPlaylistEntry),
+			widget.NewFormItem(l10n.T("Notifications via"), notifyBackendsCheckGroup),
+			widget.NewFormItem(l10n.T("Notification webhook URL"), notifyWebhookURLEntry),
+			widget.NewFormItem(l10n.T("Notify when an interval ends (with the buttons in Windows toasts)"), notifyOnPhaseEndCheck),
+			widget.NewFormItem(l10n.T("Spoken announcements"), announceCheck),
+			widget.NewFormItem(l10n.T("Work starts"), announceWorkEntry),
+			widget.NewFormItem(l10n.T("Break starts"), announceRestEntry),
+			widget.NewFormItem(l10n.T("Long break starts"), announceLongRestEntry),
+		),
+		Apply: func(s *pomodoro.Settings) {
+			s.AlarmEnabled = alarmCheck.Checked
+			s.AlarmVolume = alarmVolumeSlider.Value
+			s.AlarmSound = pomodoro.AlarmSound(alarmSoundSelect.Selected)
+			s.AlarmFile = alarmFileEntry.Text
+			s.AlarmDevice = alarmDeviceSelect.Selected
+			if alarmDeviceSelect.SelectedIndex() == 0 {
+				s.AlarmDevice = ""
+			}
+			s.AlarmFadeIn = time.Duration(parseUint(alarmFadeInEntry.Text)) * time.Second
+			s.AlarmRepeat = uint(parseUint(alarmRepeatEntry.Text))
+			s.AlarmEscalation = alarmEscalationCheck.Checked
+			s.Countdown = pomodoro.CountdownMode(countdownSelect.Selected)
+			s.Ambience.Enabled = ambienceCheck.Checked
+			s.Ambience.Volume = ambienceVolumeSlider.Value
+			s.Ambience.File = ambienceFileEntry.Text
+			s.FocusMusic.Enabled = focusMusicCheck.Checked
+			s.FocusMusic.Volume = focusMusicVolumeSlider.Value
+			s.FocusMusic.Playlist = pomodoro.ParseLines(focusMusicPlaylistEntry.Text)
+			s.Notifications.Backends = nil
+			for _, name := range notifyBackendsCheckGroup.Selected {
+				s.Notifications.Backends = append(s.Notifications.Backends, notify.BackendName(name))
+			}
+			s.Notifications.WebhookURL = notifyWebhookURLEntry.Text
+			s.Notifications.OnPhaseEnd = notifyOnPhaseEndCheck.Checked
+			s.Announcements.Enabled = announceCheck.Checked
+			s.Announcements.Work = announceWorkEntry.Text
+			s.Announcements.Rest = announceRestEntry.Text
+			s.Announcements.LongRest = announceLongRestEntry.Text
+		},
+	}
+}
+
+func (g *GUI) colorsSettingsSection(
+	s pomodoro.Settings,
+	w fyne.Window,
+) settingsSection {
+	colors := s.Colors
+	var items []*widget.FormItem
+	for _, phase := range []struct {
+		Name   string
+		Colors *pomodoro.PhaseColors
+	}{
+		{Name: l10n.T("Idle"), Colors: &colors.Idle},
+		{Name: l10n.T("Work"), Colors: &colors.Work},
+		{Name: l10n.T("Rest"), Colors: &colors.Rest},
+		{Name: l10n.T("Long rest"), Colors: &colors.LongRest},
+	} {
+		items = append(items,
+			widget.NewFormItem(l10n.T("%s: digits", phase.Name), newColorButton(&phase.Colors.Digits, w)),
+			widget.NewFormItem(l10n.T("%s: delimiter", phase.Name), newColorButton(&phase.Colors.Delimiter, w)),
+			widget.NewFormItem(l10n.T("%s: description", phase.Name), newColorButton(&phase.Colors.Description, w)),
+			widget.NewFormItem(l10n.T("%s: background", phase.Name), newColorButton(&phase.Colors.Background, w)),
+		)
+	}
+
+	return settingsSection{
+		Title: l10n.T("Colors"),
+		Items: items,
+		Apply: func(s *pomodoro.Settings) {
+			s.Colors = colors
+		},
+	}
+}
+
+func newColorButton(
+	target *color.NRGBA,
+	w fyne.Window,
+) fyne.CanvasObject {
+	swatch := canvas.NewRectangle(*target)
+	swatch.SetMinSize(fyne.NewSize(48, 24))
+	swatch.StrokeColor = color.Gray{Y: 128}
+	swatch.StrokeWidth = 1
+	button := widget.NewButtonWithIcon("", theme.ColorPaletteIcon(), func() {
+		picker := dialog.NewColorPicker(l10n.T("Pick a color"), "", func(c color.Color) {
+			*target = color.NRGBAModel.Convert(c).(color.NRGBA)
+			swatch.FillColor = *target
+			swatch.Refresh()
+		}, w)
+		picker.Advanced = true
+		picker.SetColor(*target)
+		picker.Show()
+	})
+	return container.NewHBox(swatch, button)
+}
+
+func (g *GUI) labelsSettingsSection(s pomodoro.Settings) settingsSection {
+	newLabelEntry := func(label string, phase pomodoro.Phase) *widget.Entry {
+		e := widget.NewEntry()
+		e.SetPlaceHolder(pomodoro.DefaultPhaseDescription(phase))
+		e.SetText(label)
+		return e
+	}
+	workEntry := newLabelEntry(s.Labels.Work, pomodoro.PhaseWork)
+	restEntry := newLabelEntry(s.Labels.Rest, pomodoro.PhaseRest)
+	longRestEntry := newLabelEntry(s.Labels.LongRest, pomodoro.PhaseLongRest)
+	stopwatchEntry := newLabelEntry(s.Labels.Stopwatch, pomodoro.PhaseStopwatch)
+
+	return settingsSection{
+		Title: l10n.T("Labels"),
+		Items: []*widget.FormItem{
+			widget.NewFormItem(l10n.T("Work"), workEntry),
+			widget.NewFormItem(l10n.T("Rest"), restEntry),
+			widget.NewFormItem(l10n.T("Long rest"), longRestEntry),
+			widget.NewFormItem(l10n.T("Stopwatch"), stopwatchEntry),
+			widget.NewFormItem("", widget.NewLabel(l10n.T("Shown above the timer (emojis are fine), empty for the default."))),
+		},
+		Apply: func(s *pomodoro.Settings) {
+			s.Labels = pomodoro.PhaseLabels{
+				Work:      workEntry.Text,
+				Rest:      restEntry.Text,
+				LongRest:  longRestEntry.Text,
+				Stopwatch: stopwatchEntry.Text,
+			}
+		},
+	}
+}
+
+func (g *GUI) hotkeysSettingsSection(s pomodoro.Settings) settingsSection {
+	togglePauseEntry := newHotkeyEntry(s.GlobalHotkeys.TogglePause)
+	startWorkEntry := newHotkeyEntry(s.GlobalHotkeys.StartWork)
+
+	return settingsSection{
+		Title: l10n.T("Hotkeys"),
+		Items: []*widget.FormItem{
+			widget.NewFormItem(l10n.T("Pause/resume"), togglePauseEntry),
+			widget.NewFormItem(l10n.T("Start work"), startWorkEntry),
+			widget.NewFormItem("", widget.NewLabel(l10n.T("System-wide shortcuts like 'ctrl+alt+p', empty to disable."))),
+		},
+		Apply: func(s *pomodoro.Settings) {
+			s.GlobalHotkeys.TogglePause = togglePauseEntry.Text
+			s.GlobalHotkeys.StartWork = startWorkEntry.Text
+		},
+	}
+}
+
+func (g *GUI) hooksSettingsSection(s pomodoro.Settings) settingsSection {
+	onWorkStartEntry := widget.NewEntry()
+	onWorkStartEntry.SetText(s.Hooks.OnWorkStart)
+	onWorkEndEntry := widget.NewEntry()
+	onWorkEndEntry.SetText(s.Hooks.OnWorkEnd)
+	onRestEndEntry := widget.NewEntry()
+	onRestEndEntry.SetText(s.Hooks.OnRestEnd)
+	webhookURLsEntry := widget.NewMultiLineEntry()
+	webhookURLsEntry.SetPlaceHolder(l10n.T("One URL per line"))
+	webhookURLsEntry.SetText(strings.Join(s.Webhooks.URLs, "\n"))
+	webhookSecretEntry := widget.NewPasswordEntry()
+	webhookSecretEntry.SetPlaceHolder(l10n.T("(empty to not sign)"))
+	webhookSecretEntry.SetText(s.Webhooks.Secret)
+
+	return settingsSection{
+		Title: l10n.T("Hooks"),
+		Items: []*widget.FormItem{
+			widget.NewFormItem(l10n.T("On work start"), onWorkStartEntry),
+			widget.NewFormItem(l10n.T("On work end"), onWorkEndEntry),
+			widget.NewFormItem(l10n.T("On rest end"), onRestEndEntry),
+			widget.NewFormItem("", widget.NewLabel(l10n.T("Shell commands; the session is described by the POMODORO_* environment variables."))),
+			widget.NewFormItem(l10n.T("Webhooks"), webhookURLsEntry),
+			widget.NewFormItem(l10n.T("Webhook signing secret"), webhookSecretEntry),
+			widget.NewFormItem("", widget.NewLabel(l10n.T("The events are POSTed as JSON, signed with HMAC-SHA256 in the X-Pomodoro-Signature header."))),
+		},
+		Apply: func(s *pomodoro.Settings) {
+			s.Hooks.OnWorkStart = onWorkStartEntry.Text
+			s.Hooks.OnWorkEnd = onWorkEndEntry.Text
+			s.Hooks.OnRestEnd = onRestEndEntry.Text
+			s.Webhooks.URLs = pomodoro.ParseLines(webhookURLsEntry.Text)
+			s.Webhooks.Secret = webhookSecretEntry.Text
+		},
+	}
+}
+
+func (g *GUI) blockerSettingsSection(s pomodoro.Settings) settingsSection {
+	sitesEntry := widget.NewMultiLineEntry()
+	sitesEntry.SetPlaceHolder(l10n.T("youtube.com, one per line"))
+	sitesEntry.SetText(strings.Join(s.Blocker.Sites, "\n"))
+	processesEntry := widget.NewMultiLineEntry()
+	processesEntry.SetPlaceHolder(l10n.T("telegram-desktop, one per line"))
+	processesEntry.SetText(strings.Join(s.Blocker.Processes, "\n"))
+	killProcessesCheck := widget.NewCheck("", nil)
+	killProcessesCheck.SetChecked(s.Blocker.KillProcesses)
+	proxyAddrEntry := widget.NewEntry()
+	proxyAddrEntry.SetPlaceHolder(l10n.T("localhost:8791 (empty to change the hosts file)"))
+	proxyAddrEntry.SetText(s.Blocker.ProxyAddr)
+
+	return settingsSection{
+		Title: l10n.T("Blocker"),
+		Items: []*widget.FormItem{
+			widget.NewFormItem(l10n.T("Sites"), sitesEntry),
+			widget.NewFormItem(l10n.T("Applications"), processesEntry),
+			widget.NewFormItem(l10n.T("Close the applications"), killProcessesCheck),
+			widget.NewFormItem(l10n.T("Proxy address"), proxyAddrEntry),
+			widget.NewFormItem("", widget.NewLabel(l10n.T("Blocked during the work. Changing the hosts file needs the administrator rights;\nthe proxy needs the browser to be configured to use it."))),
+		},
+		Apply: func(s *pomodoro.Settings) {
+			s.Blocker.Sites = pomodoro.ParseLines(sitesEntry.Text)
+			s.Blocker.Processes = pomodoro.ParseLines(processesEntry.Text)
+			s.Blocker.KillProcesses = killProcessesCheck.Checked
+			s.Blocker.ProxyAddr = strings.TrimSpace(proxyAddrEntry.Text)
+		},
+	}
+}
+
+func newHotkeyEntry(binding string) *widget.Entry {
+	e := widget.NewEntry()
+	e.SetPlaceHolder(l10n.T("(disabled)"))
+	e.SetText(binding)
+	e.Validator = func(s string) error {
+		if s == "" {
+			return nil
+		}
+		return globalhotkey.Validate(s)
+	}
+	return e
+}
+
+func (g *GUI) integrationsSettingsSection(s pomodoro.Settings) settingsSection {
+	mqttBrokerURLEntry := widget.NewEntry()
+	mqttBrokerURLEntry.SetPlaceHolder(l10n.T("tcp://localhost:1883 (empty to disable)"))
+	mqttBrokerURLEntry.SetText(s.MQTT.BrokerURL)
+	mqttUsernameEntry := widget.NewEntry()
+	mqttUsernameEntry.SetText(s.MQTT.Username)
+	mqttPasswordEntry := widget.NewPasswordEntry()
+	mqttPasswordEntry.SetText(s.MQTT.Password)
+	mqttTopicPrefixEntry := widget.NewEntry()
+	mqttTopicPrefixEntry.SetText(s.MQTT.TopicPrefix)
+	togglAPITokenEntry := widget.NewPasswordEntry()
+	togglAPITokenEntry.SetPlaceHolder(l10n.T("(empty to disable)"))
+	togglAPITokenEntry.SetText(s.Toggl.APIToken)
+	togglWorkspaceIDEntry := newUintEntry(uint64(s.Toggl.WorkspaceID))
+	calDAVURLEntry := widget.NewEntry()
+	calDAVURLEntry.SetPlaceHolder(l10n.T("https://dav.example.com/calendars/me/work/ (empty to disable)"))
+	calDAVURLEntry.SetText(s.CalDAV.URL)
+	calDAVUsernameEntry := widget.NewEntry()
+	calDAVUsernameEntry.SetText(s.CalDAV.Username)
+	calDAVPasswordEntry := widget.NewPasswordEntry()
+	calDAVPasswordEntry.SetText(s.CalDAV.Password)
+	warnMeetingsCheck := widget.NewCheck("", nil)
+	warnMeetingsCheck.SetChecked(s.CalDAV.WarnMeetings)
+	meetingsFeedURLEntry := widget.NewEntry()
+	meetingsFeedURLEntry.SetPlaceHolder(l10n.T("https://calendar.example.com/me/basic.ics (empty to use CalDAV)"))
+	meetingsFeedURLEntry.SetText(s.CalDAV.MeetingsFeedURL)
+	slackTokenEntry := widget.NewPasswordEntry()
+	slackTokenEntry.SetPlaceHolder(l10n.T("(empty to disable)"))
+	slackTokenEntry.SetText(s.Slack.Token)
+	todoistAPITokenEntry := widget.NewPasswordEntry()
+	todoistAPITokenEntry.SetPlaceHolder(l10n.T("(empty to disable)"))
+	todoistAPITokenEntry.SetText(s.TaskProviders.TodoistAPIToken)
+	gitHubTokenEntry := widget.NewPasswordEntry()
+	gitHubTokenEntry.SetPlaceHolder(l10n.T("(empty to disable)"))
+	gitHubTokenEntry.SetText(s.TaskProviders.GitHubToken)
+	taskCommentCheck := widget.NewCheck("", nil)
+	taskCommentCheck.SetChecked(s.TaskProviders.CommentOnSession)
+	taskCompleteAfterEntry := newUintEntry(uint64(s.TaskProviders.CompleteAfter))
+	dailyNotePathEntry := widget.NewEntry()
+	dailyNotePathEntry.SetPlaceHolder(l10n.T("~/notes/daily/{{date}}.md (empty to disable)"))
+	dailyNotePathEntry.SetText(s.DailyNote.PathTemplate)
+	peerSyncListenEntry := widget.NewEntry()
+	peerSyncListenEntry.SetPlaceHolder(l10n.T(":8788 (empty to not accept the peers)"))
+	peerSyncListenEntry.SetText(s.PeerSync.ListenAddr)
+	peerSyncPeersEntry := widget.NewMultiLineEntry()
+	peerSyncPeersEntry.SetPlaceHolder(l10n.T("ws://laptop.local:8788/sync, one per line"))
+	peerSyncPeersEntry.SetText(strings.Join(s.PeerSync.Peers, "\n"))
+	peerSyncSecretEntry := widget.NewPasswordEntry()
+	peerSyncSecretEntry.SetText(s.PeerSync.Secret)
+	roomHostAddrEntry := widget.NewEntry()
+	roomHostAddrEntry.SetPlaceHolder(l10n.T(":8789 (empty to not host a room)"))
+	roomHostAddrEntry.SetText(s.Room.HostAddr)
+	roomJoinURLEntry := widget.NewEntry()
+	roomJoinURLEntry.SetPlaceHolder(l10n.T("ws://host.example.com:8789/room (empty to not join)"))
+	roomJoinURLEntry.SetText(s.Room.JoinURL)
+	roomNameEntry := widget.NewEntry()
+	roomNameEntry.SetPlaceHolder(l10n.T("(the user name)"))
+	roomNameEntry.SetText(s.Room.Name)
+	pluginsEntry := widget.NewMultiLineEntry()
+	pluginsEntry.SetPlaceHolder(l10n.T("One executable (with the arguments) per line"))
+	pluginsEntry.SetText(strings.Join(s.Plugins, "\n"))
+
+	return settingsSection{
+		Title: l10n.T("Integrations"),
+		Items: []*widget.FormItem{
+			widget.NewFormItem(l10n.T("MQTT broker URL"), mqttBrokerURLEntry),
+			widget.NewFormItem(l10n.T("MQTT username"), mqttUsernameEntry),
+			widget.NewFormItem(l10n.T("MQTT password"), mqttPasswordEntry),
+			widget.NewFormItem(l10n.T("MQTT topic prefix"), mqttTopicPrefixEntry),
+			widget.NewFormItem(l10n.T("Toggl Track API token"), togglAPITokenEntry),
+			widget.NewFormItem(l10n.T("Toggl Track workspace ID"), togglWorkspaceIDEntry),
+			widget.NewFormItem(l10n.T("CalDAV calendar URL"), calDAVURLEntry),
+			widget.NewFormItem(l10n.T("CalDAV username"), calDAVUsernameEntry),
+			widget.NewFormItem(l10n.T("CalDAV password"), calDAVPasswordEntry),
+			widget.NewFormItem(l10n.T("Warn about meetings during work sessions"), warnMeetingsCheck),
+			widget.NewFormItem(l10n.T("Meetings calendar feed URL"), meetingsFeedURLEntry),
+			widget.NewFormItem(l10n.T("Slack user token (for the status)"), slackTokenEntry),
+			widget.NewFormItem(l10n.T("Todoist API token (for the tasks)"), todoistAPITokenEntry),
+			widget.NewFormItem(l10n.T("GitHub token (for the assigned issues)"), gitHubTokenEntry),
+			widget.NewFormItem(l10n.T("Comment the picked task after a session"), taskCommentCheck),
+			widget.NewFormItem(l10n.T("Complete the picked task after N sessions (0 never)"), taskCompleteAfterEntry),
+			widget.NewFormItem(l10n.T("Daily note (Markdown) path"), dailyNotePathEntry),
+			widget.NewFormItem(l10n.T("Sync with devices: listen address"), peerSyncListenEntry),
+			widget.NewFormItem(l10n.T("Sync with devices: peers"), peerSyncPeersEntry),
+			widget.NewFormItem(l10n.T("Sync with devices: shared secret"), peerSyncSecretEntry),
+			widget.NewFormItem(l10n.T("Room: host on address"), roomHostAddrEntry),
+			widget.NewFormItem(l10n.T("Room: join"), roomJoinURLEntry),
+			widget.NewFormItem(l10n.T("Room: your name"), roomNameEntry),
+			widget.NewFormItem(l10n.T("Plugins"), pluginsEntry),
+			widget.NewFormItem("", widget.NewLabel(l10n.T("Integration changes are applied on restart."))),
+		},
+		Apply: func(s *pomodoro.Settings) {
+			s.MQTT.BrokerURL = mqttBrokerURLEntry.Text
+			s.MQTT.Username = mqttUsernameEntry.Text
+			s.MQTT.Password = mqttPasswordEntry.Text
+			s.MQTT.TopicPrefix = mqttTopicPrefixEntry.Text
+			s.Toggl.APIToken = togglAPITokenEntry.Text
+			s.Toggl.WorkspaceID = int64(parseUint(togglWorkspaceIDEntry.Text))
+			s.CalDAV.URL = calDAVURLEntry.Text
+			s.CalDAV.Username = calDAVUsernameEntry.Text
+			s.CalDAV.Password = calDAVPasswordEntry.Text
+			s.CalDAV.WarnMeetings = warnMeetingsCheck.Checked
+			s.CalDAV.MeetingsFeedURL = meetingsFeedURLEntry.Text
+			s.Slack.Token = slackTokenEntry.Text
+			s.TaskProviders.TodoistAPIToken = todoistAPITokenEntry.Text
+			s.TaskProviders.GitHubToken = gitHubTokenEntry.Text
+			s.TaskProviders.CommentOnSession = taskCommentCheck.Checked
+			s.TaskProviders.CompleteAfter = uint(parseUint(taskCompleteAfterEntry.Text))
+			s.DailyNote.PathTemplate = strings.TrimSpace(dailyNotePathEntry.Text)
+			s.PeerSync.ListenAddr = peerSyncListenEntry.Text
+			s.PeerSync.Peers = pomodoro.ParseLines(peerSyncPeersEntry.Text)
+			s.PeerSync.Secret = peerSyncSecretEntry.Text
+			s.Room.HostAddr = roomHostAddrEntry.Text
+			s.Room.JoinURL = roomJoinURLEntry.Text
+			s.Room.Name = roomNameEntry.Text
+			s.Plugins = pomodoro.ParseLines(pluginsEntry.Text)
+		},
+	}
+}
+
+func newMinutesEntry(d time.Duration) *widget.Entry {
+	e := widget.NewEntry()
+	e.SetText(strconv.Itoa(int(d / time.Minute)))
+	e.Validator = func(s string) error {
+		minutes, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("not a number: %w", err)
+		}
+		if minutes <= 0 {
+			return fmt.Errorf("the value should be positive")
+		}
+		return nil
+	}
+	return e
+}
+
+func newUintEntry(v uint64) *widget.Entry {
+	e := widget.NewEntry()
+	e.SetText(strconv.FormatUint(v, 10))
+	e.Validator = func(s string) error {
+		if _, err := strconv.ParseUint(s, 10, 64); err != nil {
+			return fmt.Errorf("not a non-negative number: %w", err)
+		}
+		return nil
+	}
+	return e
+}
+
+func parseMinutes(s string) time.Duration {
+	minutes, _ := strconv.Atoi(s)
+	return time.Duration(minutes) * time.Minute
+}
+
+func parseUint(s string) uint64 {
+	v, _ := strconv.ParseUint(s, 10, 64)
+	return v
+}
+
+var breakCompliancePolicies = []pomodoro.BreakCompliancePolicy{
+	pomodoro.BreakCompliancePolicyOff,
+	pomodoro.BreakCompliancePolicyRecord,
+	pomodoro.BreakCompliancePolicyWarn,
+	pomodoro.BreakCompliancePolicyExtend,
+}
+
+var suspendPolicies = []pomodoro.SuspendPolicy{
+	pomodoro.SuspendPolicyFastForward,
+	pomodoro.SuspendPolicyPause,
+	pomodoro.SuspendPolicyExtend,
+}
+
+var countdownModes = []pomodoro.CountdownMode{
+	pomodoro.CountdownModeOff,
+	pomodoro.CountdownModeTick,
+	pomodoro.CountdownModeVoice,
+}
+
+// formatTimeOfDay formats the time since the midnight as "HH:MM".
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// parseTimeOfDay parses "HH:MM" into the time since the midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("expected a time like '18:30': %w", err)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func weekdayName(day time.Weekday) string {
+	return l10n.T(day.String())
+}
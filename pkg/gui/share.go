@@ -0,0 +1,60 @@
+package gui
+
+import (
+	"log/slog"
+	"net/url"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/skip2/go-qrcode"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// ShowShareDialog shows the link to the live timer page and its
+// QR code, so that the others could watch the countdown from their
+// devices.
+func (g *GUI) ShowShareDialog() {
+	w := g.parentWindow()
+	if w == nil {
+		return
+	}
+	shareURL := g.ShareURL()
+	if shareURL == "" {
+		dialog.ShowInformation(
+			l10n.T("Share the timer"),
+			l10n.T("The timer is not shared, start the app with '--share :8790' to serve a live timer page to the others."),
+			w,
+		)
+		return
+	}
+
+	png, err := qrcode.Encode(shareURL, qrcode.Medium, shareQRSize)
+	if err != nil {
+		slog.Error("unable to render the QR code", "url", shareURL, "error", err)
+		return
+	}
+	qr := canvas.NewImageFromResource(fyne.NewStaticResource("share.png", png))
+	qr.FillMode = canvas.ImageFillContain
+	qr.SetMinSize(fyne.NewSize(shareQRSize, shareQRSize))
+	var link fyne.CanvasObject = widget.NewLabel(shareURL)
+	if u, err := url.Parse(shareURL); err == nil {
+		link = widget.NewHyperlink(shareURL, u)
+	}
+	dialog.ShowCustom(
+		l10n.T("Share the timer"),
+		l10n.T("Close"),
+		container.NewVBox(
+			widget.NewLabel(l10n.T("Scan the code or open the link to watch the countdown:")),
+			qr,
+			container.NewCenter(link),
+		),
+		w,
+	)
+}
+
+const (
+	shareQRSize = 256
+)
@@ -0,0 +1,84 @@
+package gui
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// presetKeys apply the presets by their positions, presetDigitKeys
+// do the same for the first presets.
+var presetKeys = [pomodoro.MaxPresets]fyne.KeyName{
+	fyne.KeyF1,
+	fyne.KeyF2,
+	fyne.KeyF3,
+	fyne.KeyF4,
+	fyne.KeyF5,
+	fyne.KeyF6,
+	fyne.KeyF7,
+	fyne.KeyF8,
+	fyne.KeyF9,
+	fyne.KeyF10,
+	fyne.KeyF11,
+	fyne.KeyF12,
+}
+
+var presetDigitKeys = []fyne.KeyName{
+	fyne.Key1,
+	fyne.Key2,
+	fyne.Key3,
+	fyne.Key4,
+	fyne.Key5,
+	fyne.Key6,
+	fyne.Key7,
+	fyne.Key8,
+}
+
+func (g *GUI) onTypedKey(ev *fyne.KeyEvent) {
+	switch ev.Name {
+	case fyne.KeySpace:
+		g.TogglePause()
+		return
+	case fyne.KeyW:
+		g.afterRitual(func() { g.Start(true) })()
+		return
+	case fyne.KeyR:
+		g.unlessCommitted(func() { g.Start(false) })()
+		return
+	case fyne.KeyS:
+		g.unlessCommitted(g.StopTimer)()
+		return
+	case fyne.KeyC:
+		g.ToggleCompactMode()
+		return
+	case fyne.KeyP:
+		g.TogglePresentationMode()
+		return
+	case fyne.KeyEscape:
+		g.ExitPresentationMode()
+		return
+	case fyne.KeyT:
+		g.unlessCommitted(g.ToggleStopwatch)()
+		return
+	case fyne.KeyI:
+		g.ShowInterruptionDialog()
+		return
+	case fyne.KeyPlus, fyne.KeyEqual:
+		g.Extend(pomodoro.ExtendStep)
+		return
+	case fyne.KeyMinus:
+		g.unlessCommitted(func() { g.Extend(-pomodoro.ExtendStep) })()
+		return
+	}
+
+	g.locker.Lock()
+	presets := g.settings.Presets
+	g.locker.Unlock()
+	for _, keys := range [][]fyne.KeyName{presetKeys[:], presetDigitKeys} {
+		for idx, key := range keys {
+			if ev.Name == key && idx < len(presets) {
+				g.unlessCommitted(func() { g.ApplyPreset(presets[idx]) })()
+				return
+			}
+		}
+	}
+}
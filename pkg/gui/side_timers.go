@@ -0,0 +1,110 @@
+package gui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// sideTimersPanel lists the side timers (see pomodoro.SideTimer).
+type sideTimersPanel struct {
+	labels map[uint64]*widget.Label
+	list   *fyne.Container
+	panel  *fyne.Container
+}
+
+func (g *GUI) newSideTimersPanel() fyne.CanvasObject {
+	s := &g.sideTimers
+	s.list = container.NewVBox()
+	s.panel = container.NewVBox(
+		widget.NewLabelWithStyle(l10n.T("Timers"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		s.list,
+	)
+	s.panel.Hide()
+	return s.panel
+}
+
+// refreshSideTimersPanel shows the time left of the side timers, the list
+// is rebuilt only if the timers changed since prev. The panel is shown
+// only while there are any.
+func (g *GUI) refreshSideTimersPanel(prev []pomodoro.SideTimerView) {
+	s := &g.sideTimers
+	timers := g.view.SideTimers
+	if !sameSideTimers(timers, prev) || len(s.labels) != len(timers) {
+		s.list.RemoveAll()
+		s.labels = make(map[uint64]*widget.Label, len(timers))
+		for _, t := range timers {
+			id := t.ID
+			label := widget.NewLabel("")
+			s.labels[id] = label
+			s.list.Add(container.NewBorder(
+				nil, nil, nil,
+				widget.NewButtonWithIcon("", theme.CancelIcon(), func() { g.RemoveSideTimer(id) }),
+				label,
+			))
+		}
+		if len(timers) == 0 {
+			s.panel.Hide()
+		} else {
+			s.panel.Show()
+		}
+	}
+	for _, t := range timers {
+		text := fmt.Sprintf("%s  %02d:%02d", t.Name, t.Minutes, t.Seconds)
+		if label := s.labels[t.ID]; label.Text != text {
+			label.SetText(text)
+		}
+	}
+}
+
+func sameSideTimers(a, b []pomodoro.SideTimerView) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx].ID != b[idx].ID {
+			return false
+		}
+	}
+	return true
+}
+
+// ShowNewSideTimerDialog asks for the name and the duration
+// of a new side timer.
+func (g *GUI) ShowNewSideTimerDialog() {
+	w := g.parentWindow()
+	if w == nil {
+		return
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder(l10n.T("e.g. 'tea'"))
+	minutesEntry := newUintEntry(5)
+	soundCheck := widget.NewCheck("", nil)
+	soundCheck.SetChecked(true)
+	dialog.ShowForm(
+		l10n.T("New side timer"),
+		l10n.T("Start"),
+		l10n.T("Cancel"),
+		[]*widget.FormItem{
+			widget.NewFormItem(l10n.T("Name"), nameEntry),
+			widget.NewFormItem(l10n.T("Minutes"), minutesEntry),
+			widget.NewFormItem(l10n.T("Play the alarm sound"), soundCheck),
+		},
+		func(confirmed bool) {
+			minutes := parseUint(minutesEntry.Text)
+			if !confirmed || minutes == 0 {
+				return
+			}
+			g.AddSideTimer(nameEntry.Text, time.Duration(minutes)*time.Minute, soundCheck.Checked)
+		},
+		w,
+	)
+}
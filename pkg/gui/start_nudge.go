@@ -0,0 +1,63 @@
+package gui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+func (g *GUI) closeStartNudgeDialog() {
+	if g.startNudgeDialog == nil {
+		return
+	}
+	g.startNudgeDialog.Hide()
+	g.startNudgeDialog = nil
+}
+
+func (g *GUI) handleStartNudgeEvent(ev pomodoro.Event) {
+	if ev.Type != pomodoro.EventTypePhaseStarted {
+		return
+	}
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	g.closeStartNudgeDialog()
+}
+
+// NudgeToStart implements pomodoro.Frontend.
+func (g *GUI) NudgeToStart(message string) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	w := g.window()
+	if w == nil {
+		return
+	}
+	var d *dialog.CustomDialog
+	action := func(fn func()) func() {
+		return func() {
+			d.Hide()
+			fn()
+		}
+	}
+	buttons := []fyne.CanvasObject{
+		widget.NewButtonWithIcon(l10n.T("Start work"), theme.MediaPlayIcon(), action(g.afterRitual(func() { g.Start(true) }))),
+	}
+	for _, snooze := range pomodoro.StartNudgeSnoozes {
+		buttons = append(buttons, widget.NewButtonWithIcon(
+			l10n.T("Remind in %d min", int(snooze/time.Minute)),
+			theme.HistoryIcon(),
+			action(func() { g.SnoozeStartNudge(snooze) }),
+		))
+	}
+	buttons = append(buttons, widget.NewButtonWithIcon(l10n.T("Not today"), theme.CancelIcon(), action(g.SnoozeStartNudgeForToday)))
+	d = dialog.NewCustomWithoutButtons(message, container.NewVBox(buttons...), w)
+
+	g.closeStartNudgeDialog()
+	g.startNudgeDialog = d
+	d.Show()
+}
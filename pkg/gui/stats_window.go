@@ -0,0 +1,263 @@
+package gui
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	statsWeeks = 12
+
+	// ratingLengthStep groups the intervals of close lengths together
+	ratingLengthStep = 5 * time.Minute
+)
+
+func sessionsBars(
+	periods []history.Period,
+	labelLayout string,
+) []BarChartBar {
+	bars := make([]BarChartBar, 0, len(periods))
+	for _, period := range periods {
+		bars = append(bars, BarChartBar{
+			Label: period.Start.Format(labelLayout),
+			Value: float64(period.Sessions),
+		})
+	}
+	return bars
+}
+
+func focusMinutesBars(
+	periods []history.Period,
+	labelLayout string,
+) []BarChartBar {
+	bars := make([]BarChartBar, 0, len(periods))
+	for _, period := range periods {
+		bars = append(bars, BarChartBar{
+			Label: period.Start.Format(labelLayout),
+			Value: float64(period.Duration / time.Minute),
+		})
+	}
+	return bars
+}
+
+func ratingBars(
+	averages []history.RatingAverage,
+	label func(key int) string,
+) []BarChartBar {
+	bars := make([]BarChartBar, 0, len(averages))
+	for _, average := range averages {
+		bars = append(bars, BarChartBar{
+			Label: label(average.Key),
+			Value: average.Average,
+		})
+	}
+	return bars
+}
+
+func tagsBars(totals []history.TagTotal) []BarChartBar {
+	bars := make([]BarChartBar, 0, len(totals))
+	for _, total := range totals {
+		bars = append(bars, BarChartBar{
+			Label: "#" + total.Tag,
+			Value: float64(total.Duration / time.Minute),
+		})
+	}
+	return bars
+}
+
+// ShowStatistics opens a window with the charts of the completed
+// sessions and the streaks, optionally filtered by a tag.
+func (g *GUI) ShowStatistics() {
+	sessions := g.FocusSessions()
+	now := g.Clock().Now()
+	breakDebt := g.BreakDebt()
+	compliance, hasCompliance := g.BreakCompliance()
+	recommendation, hasRecommendation := g.Recommend()
+	g.locker.Lock()
+	boundary := g.settings.DayBoundary
+	schedule := g.settings.WorkSchedule
+	chartColor := g.chartColor()
+	g.locker.Unlock()
+
+	w := g.App.NewWindow(fmt.Sprintf("%s — %s", windowTitle, l10n.T("Statistics")))
+	content := container.NewStack(newStatisticsContent(sessions, now, boundary, schedule, chartColor, w))
+	tagSelect := g.newTagSelect(func(tag string) {
+		content.Objects = []fyne.CanvasObject{
+			newStatisticsContent(history.FilterByTag(sessions, tag), now, boundary, schedule, chartColor, w),
+		}
+		content.Refresh()
+	})
+
+	header := container.NewHBox(
+		widget.NewLabel(l10n.T("Tag")),
+		tagSelect,
+		layout.NewSpacer(),
+	)
+	if hasCompliance {
+		header.Add(widget.NewLabel(l10n.T("Breaks away from the computer (%d days): %d%%", pomodoro.BreakComplianceDays, int(compliance*100))))
+	}
+	if ritual, ok := history.RitualCompletion(sessions); ok {
+		header.Add(widget.NewLabel(l10n.T("Checklist gone through: %d%%", int(ritual*100))))
+	}
+	header.Add(widget.NewLabel(l10n.T("Break debt: %d min", int(breakDebt/time.Minute))))
+	var top fyne.CanvasObject = header
+	if hasRecommendation {
+		recommendationLabel := widget.NewLabel(describeRecommendation(recommendation))
+		recommendationLabel.Wrapping = fyne.TextWrapWord
+		top = container.NewVBox(header, recommendationLabel)
+	}
+
+	w.SetContent(container.NewBorder(
+		top,
+		nil, nil, nil,
+		content,
+	))
+	w.Resize(fyne.NewSize(720, 400))
+	w.Show()
+}
+
+func newStatisticsContent(
+	sessions []history.Session,
+	now time.Time,
+	boundary time.Duration,
+	schedule pomodoro.WorkScheduleSettings,
+	barColor color.Color,
+	w fyne.Window,
+) fyne.CanvasObject {
+	today := history.Daily(sessions, now, 1, boundary)[0]
+	currentStreak, longestStreak := history.Streaks(sessions, now, boundary)
+	summary := widget.NewLabel(l10n.T(
+		"Today: %d sessions, %s of focus. Current streak: %d days, longest: %d days.",
+		today.Sessions, today.Duration.Round(time.Minute), currentStreak, longestStreak,
+	))
+	if today.Overtime >= time.Minute {
+		summary.SetText(summary.Text + " " + l10n.T("Overtime today: %s.", today.Overtime.Round(time.Minute)))
+	}
+	summary.Wrapping = fyne.TextWrapWord
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem(l10n.T("Week"), container.NewVBox(
+			widget.NewLabel(l10n.T("Sessions per day")),
+			NewBarChart(sessionsBars(history.Daily(sessions, now, 7, boundary), "02.01"), barColor),
+		)),
+		container.NewTabItem(l10n.T("Month"), container.NewVBox(
+			widget.NewLabel(l10n.T("Sessions per day")),
+			NewBarChart(sessionsBars(history.Daily(sessions, now, 30, boundary), "02"), barColor),
+		)),
+		container.NewTabItem(l10n.T("Focus per week"), container.NewVBox(
+			widget.NewLabel(l10n.T("Focus minutes per week (weeks start on Monday)")),
+			NewBarChart(focusMinutesBars(history.Weekly(sessions, now, statsWeeks, boundary), "02.01"), barColor),
+		)),
+		container.NewTabItem(l10n.T("Tags"), container.NewVBox(
+			widget.NewLabel(l10n.T("Focus minutes per tag")),
+			NewBarChart(tagsBars(history.ByTag(sessions)), barColor),
+		)),
+		container.NewTabItem(l10n.T("Year"), newYearHeatmapContent(sessions, now, boundary, barColor, w)),
+	)
+	if schedule.Enabled {
+		weekStart := history.DayStart(now, boundary).AddDate(0, 0, -6)
+		var lastWeek []history.Session
+		for _, session := range sessions {
+			if !session.StartedAt.Before(weekStart) {
+				lastWeek = append(lastWeek, session)
+			}
+		}
+		inHours, offHours := splitByWorkSchedule(lastWeek, schedule)
+		tabs.Append(container.NewTabItem(l10n.T("Working hours"), container.NewVBox(
+			widget.NewLabel(l10n.T("Focus minutes during the last 7 days")),
+			NewBarChart([]BarChartBar{
+				{Label: l10n.T("Working hours"), Value: float64(inHours / time.Minute)},
+				{Label: l10n.T("Off hours"), Value: float64(offHours / time.Minute)},
+			}, barColor),
+		)))
+	}
+	if byHour := history.RatingByHour(sessions); len(byHour) > 0 {
+		tabs.Append(container.NewTabItem(l10n.T("Focus quality"), container.NewVBox(
+			widget.NewLabel(l10n.T("Average focus quality by the time of day")),
+			NewBarChart(ratingBars(byHour, func(hour int) string {
+				return fmt.Sprintf("%02d:00", hour)
+			}), barColor),
+			widget.NewLabel(l10n.T("Average focus quality by the interval length")),
+			NewBarChart(ratingBars(history.RatingByLength(sessions, ratingLengthStep), func(minutes int) string {
+				return l10n.T("%d min", minutes)
+			}), barColor),
+		)))
+	}
+	if notes := newNotesList(sessions); notes != nil {
+		tabs.Append(container.NewTabItem(l10n.T("Notes"), notes))
+	}
+	return container.NewBorder(summary, nil, nil, nil, tabs)
+}
+
+// newNotesList lists the notes of the sessions (the newest first),
+// it returns nil if there are none.
+func newNotesList(sessions []history.Session) fyne.CanvasObject {
+	var noted []history.Session
+	for idx := len(sessions) - 1; idx >= 0; idx-- {
+		if sessions[idx].Note != "" {
+			noted = append(noted, sessions[idx])
+		}
+	}
+	if len(noted) == 0 {
+		return nil
+	}
+	return widget.NewList(
+		func() int { return len(noted) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			session := noted[id]
+			header := session.EndedAt.Format("02.01 15:04")
+			if session.Task != "" {
+				header += " " + session.Task
+			}
+			obj.(*widget.Label).SetText(fmt.Sprintf("%s: %s", header, session.Note))
+		},
+	)
+}
+
+// describeRecommendation renders the recommendation like "Your 45 min
+// sessions after 15:00 fail 60% of the time — try 25 min".
+func describeRecommendation(r history.Recommendation) string {
+	var hours string
+	switch {
+	case r.FromHour == 0 && r.ToHour == 24:
+		hours = ""
+	case r.ToHour == 24:
+		hours = " " + l10n.T("after %s", fmt.Sprintf("%02d:00", r.FromHour))
+	default:
+		hours = " " + l10n.T("before %s", fmt.Sprintf("%02d:00", r.ToHour))
+	}
+	return l10n.T(
+		"Your %d min sessions%s fail %d%% of the time — try %d min.",
+		int(r.Length/time.Minute), hours, int(r.FailureRate*100+0.5), int(r.Suggested/time.Minute),
+	)
+}
+
+// splitByWorkSchedule sums the duration of the sessions started
+// within the working hours and outside of them.
+func splitByWorkSchedule(
+	sessions []history.Session,
+	schedule pomodoro.WorkScheduleSettings,
+) (inHours, offHours time.Duration) {
+	for _, session := range sessions {
+		if schedule.Contains(session.StartedAt) {
+			inHours += session.Duration
+		} else {
+			offHours += session.Duration
+		}
+	}
+	return inHours, offHours
+}
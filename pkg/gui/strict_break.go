@@ -0,0 +1,121 @@
+package gui
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/clock"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+type strictBreakOverlay struct {
+	Window        fyne.Window
+	TimeLeftText  *canvas.Text
+	SkipButton    *widget.Button
+	SkipAvailable time.Time
+}
+
+func (g *GUI) handleStrictBreakEvent(ev pomodoro.Event) {
+	enforced := g.IsBreakEnforced()
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	enabled := g.settings.StrictBreak || enforced
+	skipAfter := g.settings.StrictBreakSkipAfter
+	switch ev.Type {
+	case pomodoro.EventTypePhaseStarted:
+		if (ev.Phase != pomodoro.PhaseRest && ev.Phase != pomodoro.PhaseLongRest) || !enabled {
+			g.closeStrictBreak()
+			return
+		}
+		g.openStrictBreak(skipAfter, !enforced)
+		g.refreshStrictBreak(ev.DisplayedTimeLeft)
+	case pomodoro.EventTypeTick, pomodoro.EventTypePaused, pomodoro.EventTypeResumed:
+		g.refreshStrictBreak(ev.DisplayedTimeLeft)
+	case pomodoro.EventTypePhaseEnded, pomodoro.EventTypeStopped:
+		g.closeStrictBreak()
+	}
+}
+
+func (g *GUI) openStrictBreak(
+	skipAfter time.Duration,
+	skippable bool,
+) {
+	if g.strictBreak != nil {
+		return
+	}
+
+	colors := g.timerColors().Rest
+	title := canvas.NewText(l10n.T("BREAK"), colors.Description)
+	title.Alignment = fyne.TextAlignCenter
+	title.TextSize = 60
+	title.TextStyle = fyne.TextStyle{Monospace: true}
+	timeLeftText := canvas.NewText("", colors.Digits)
+	timeLeftText.Alignment = fyne.TextAlignCenter
+	timeLeftText.TextSize = 160
+	timeLeftText.TextStyle = fyne.TextStyle{Monospace: true}
+	hint := canvas.NewText(l10n.T("Step away from the keyboard."), color.Gray{Y: 160})
+	hint.Alignment = fyne.TextAlignCenter
+	hint.TextSize = 24
+	skipButton := widget.NewButtonWithIcon(l10n.T("Skip the break"), theme.MediaSkipNextIcon(), func() {
+		g.Start(true)
+	})
+	skipButton.Disable()
+	if !skippable {
+		skipButton.Hide()
+	}
+
+	w := g.App.NewWindow(l10n.T("Break"))
+	w.SetCloseIntercept(func() {})
+	w.SetContent(container.NewStack(
+		canvas.NewRectangle(colors.Background),
+		container.NewCenter(container.NewVBox(
+			title,
+			timeLeftText,
+			hint,
+			container.NewCenter(skipButton),
+		)),
+	))
+	w.SetFullScreen(true)
+	w.Show()
+	w.RequestFocus()
+
+	g.strictBreak = &strictBreakOverlay{
+		Window:        w,
+		TimeLeftText:  timeLeftText,
+		SkipButton:    skipButton,
+		SkipAvailable: g.Clock().Now().Add(skipAfter),
+	}
+}
+
+func (g *GUI) refreshStrictBreak(timeLeft time.Duration) {
+	overlay := g.strictBreak
+	if overlay == nil {
+		return
+	}
+	minutes, seconds := splitClock(timeLeft)
+	text := fmt.Sprintf("%02d:%02d", minutes, seconds)
+	if skipIn := clock.Until(g.Clock(), overlay.SkipAvailable); skipIn > 0 {
+		overlay.SkipButton.SetText(l10n.T("Skip the break (in %ds)", int(skipIn.Seconds())+1))
+	} else {
+		overlay.SkipButton.SetText(l10n.T("Skip the break"))
+		overlay.SkipButton.Enable()
+	}
+	overlay.TimeLeftText.Text = text
+	overlay.TimeLeftText.Refresh()
+}
+
+func (g *GUI) closeStrictBreak() {
+	overlay := g.strictBreak
+	if overlay == nil {
+		return
+	}
+	g.strictBreak = nil
+	overlay.Window.Close()
+}
@@ -0,0 +1,20 @@
+package gui
+
+import (
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// handleTaskOptionsEvent offers the task of the recorded session
+// as well (see TaskOptions).
+func (g *GUI) handleTaskOptionsEvent(ev pomodoro.Event) {
+	switch ev.Type {
+	case pomodoro.EventTypePhaseEnded, pomodoro.EventTypeStopped:
+	default:
+		return
+	}
+	g.refreshTaskOptions(g.Status().Task)
+}
+
+func (g *GUI) refreshTaskOptions(task string) {
+	g.taskEntry.SetOptions(g.TaskOptions(task))
+}
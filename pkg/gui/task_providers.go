@@ -0,0 +1,63 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// ShowTaskPicker shows the open tasks of the providers
+// (see AddTaskProvider) to pick one of them.
+func (g *GUI) ShowTaskPicker() {
+	w := g.parentWindow()
+	if w == nil {
+		return
+	}
+
+	var items []pomodoro.ProvidedTask
+	status := widget.NewLabel(l10n.T("Loading the tasks..."))
+	status.Wrapping = fyne.TextWrapWord
+	list := widget.NewList(
+		func() int { return len(items) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(fmt.Sprintf("[%s] %s", items[id].Provider.Name(), items[id].Task.Title))
+		},
+	)
+	d := dialog.NewCustom(l10n.T("Pick a task"), l10n.T("Cancel"), container.NewBorder(status, nil, nil, nil, list), w)
+	list.OnSelected = func(id widget.ListItemID) {
+		d.Hide()
+		g.PickTask(items[id].Provider, items[id].Task)
+		g.taskEntry.SetText(items[id].Task.Title)
+	}
+	d.Resize(fyne.NewSize(500, 400))
+	d.Show()
+
+	go func() {
+		ctx, cancelFn := context.WithTimeout(g.ctx, pomodoro.TaskProviderTimeout)
+		defer cancelFn()
+		fetched, err := g.FetchOpenTasks(ctx)
+		items = fetched
+		switch {
+		case err != nil:
+			slog.Error("unable to get the tasks", "error", err)
+			status.SetText(err.Error())
+		case len(items) == 0:
+			status.SetText(l10n.T("No open tasks."))
+		default:
+			status.Hide()
+		}
+		list.Refresh()
+	}()
+}
@@ -0,0 +1,44 @@
+package gui
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	launcherEntryInterface = "com.canonical.Unity.LauncherEntry"
+	launcherEntryPath      = dbus.ObjectPath("/center/dx/fynodoro/launcher")
+	launcherAppURI         = "application://center.dx.fynodoro.desktop"
+)
+
+var (
+	launcherLocker       sync.Mutex
+	launcherLastProgress = -1.0
+)
+
+// setTaskbarProgress uses the Unity LauncherEntry API (supported by
+// KDE Plasma, Dash-to-Dock, Plank and others); a negative progress
+// hides the indicator.
+func setTaskbarProgress(progress float64) error {
+	launcherLocker.Lock()
+	defer launcherLocker.Unlock()
+	if progress == launcherLastProgress {
+		return nil
+	}
+
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return fmt.Errorf("unable to connect to the session bus: %w", err)
+	}
+	err = conn.Emit(launcherEntryPath, launcherEntryInterface+".Update", launcherAppURI, map[string]dbus.Variant{
+		"progress":         dbus.MakeVariant(max(progress, 0)),
+		"progress-visible": dbus.MakeVariant(progress >= 0),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to emit the LauncherEntry update: %w", err)
+	}
+	launcherLastProgress = progress
+	return nil
+}
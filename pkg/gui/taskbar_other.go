@@ -0,0 +1,7 @@
+//go:build !linux
+
+package gui
+
+func setTaskbarProgress(progress float64) error {
+	return nil
+}
@@ -0,0 +1,117 @@
+package gui
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	largeTextScale = 1.5
+)
+
+type variantTheme struct {
+	fyne.Theme
+	Variant fyne.ThemeVariant
+}
+
+var _ fyne.Theme = (*variantTheme)(nil)
+
+func newVariantTheme(v pomodoro.ThemeVariant) fyne.Theme {
+	switch v {
+	case pomodoro.ThemeVariantDark:
+		return &variantTheme{Theme: theme.DefaultTheme(), Variant: theme.VariantDark}
+	case pomodoro.ThemeVariantLight:
+		return &variantTheme{Theme: theme.DefaultTheme(), Variant: theme.VariantLight}
+	case pomodoro.ThemeVariantHighContrast:
+		return &highContrastTheme{Theme: theme.DefaultTheme()}
+	case pomodoro.ThemeVariantLargeText:
+		return &largeTextTheme{Theme: theme.DefaultTheme()}
+	default:
+		return theme.DefaultTheme()
+	}
+}
+
+func (t *variantTheme) Color(
+	name fyne.ThemeColorName,
+	_ fyne.ThemeVariant,
+) color.Color {
+	return t.Theme.Color(name, t.Variant)
+}
+
+var (
+	highContrastBackground = color.Black
+	highContrastForeground = color.White
+	highContrastAccent     = color.NRGBA{R: 255, G: 255, A: 255}
+)
+
+type highContrastTheme struct {
+	fyne.Theme
+}
+
+var _ fyne.Theme = (*highContrastTheme)(nil)
+
+func (t *highContrastTheme) Color(
+	name fyne.ThemeColorName,
+	_ fyne.ThemeVariant,
+) color.Color {
+	switch name {
+	case theme.ColorNameBackground,
+		theme.ColorNameInputBackground,
+		theme.ColorNameMenuBackground,
+		theme.ColorNameOverlayBackground,
+		theme.ColorNameHeaderBackground,
+		theme.ColorNameButton,
+		theme.ColorNameForegroundOnPrimary:
+		return highContrastBackground
+	case theme.ColorNameForeground,
+		theme.ColorNamePlaceHolder,
+		theme.ColorNameInputBorder,
+		theme.ColorNameSeparator,
+		theme.ColorNameScrollBar,
+		theme.ColorNameForegroundOnError,
+		theme.ColorNameForegroundOnSuccess:
+		return highContrastForeground
+	case theme.ColorNamePrimary,
+		theme.ColorNameFocus,
+		theme.ColorNameHyperlink,
+		theme.ColorNameWarning:
+		return highContrastAccent
+	case theme.ColorNameDisabled,
+		theme.ColorNameDisabledButton:
+		return color.Gray{Y: 128}
+	case theme.ColorNameHover,
+		theme.ColorNamePressed,
+		theme.ColorNameSelection:
+		return color.NRGBA{R: 255, G: 255, A: 96}
+	}
+	return t.Theme.Color(name, theme.VariantDark)
+}
+
+func (t *highContrastTheme) Size(name fyne.ThemeSizeName) float32 {
+	switch name {
+	case theme.SizeNameInputBorder, theme.SizeNameSeparatorThickness:
+		return 2 * t.Theme.Size(name)
+	}
+	return t.Theme.Size(name)
+}
+
+type largeTextTheme struct {
+	fyne.Theme
+}
+
+var _ fyne.Theme = (*largeTextTheme)(nil)
+
+func (t *largeTextTheme) Size(name fyne.ThemeSizeName) float32 {
+	switch name {
+	case theme.SizeNameText,
+		theme.SizeNameCaptionText,
+		theme.SizeNameHeadingText,
+		theme.SizeNameSubHeadingText,
+		theme.SizeNameInlineIcon:
+		return largeTextScale * t.Theme.Size(name)
+	}
+	return t.Theme.Size(name)
+}
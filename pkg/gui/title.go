@@ -0,0 +1,65 @@
+package gui
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	windowTitle = "Pomodoro (DX)"
+
+	// taskbarProgressSteps is how finely the progress is shown in the
+	// taskbar, so that it is not updated on every tick (see
+	// setTaskbarProgress).
+	taskbarProgressSteps = 100
+)
+
+// handleTitleEvent shows the countdown in the window title and
+// the taskbar/dock (where supported), so it is visible even when
+// the window is minimized.
+func (g *GUI) handleTitleEvent(ev pomodoro.Event) {
+	title := windowTitle
+	progress := -1.0
+	switch ev.Type {
+	case pomodoro.EventTypePhaseStarted, pomodoro.EventTypeTick, pomodoro.EventTypeResumed, pomodoro.EventTypePaused:
+		minutes, seconds := splitClock(ev.DisplayedTimeLeft)
+		if ev.Phase == pomodoro.PhaseStopwatch {
+			minutes, seconds = splitClock(ev.Elapsed)
+		}
+		g.locker.Lock()
+		passed := g.view.Progress
+		labels := g.settings.Labels
+		g.locker.Unlock()
+		title = fmt.Sprintf("%02d:%02d — %s", minutes, seconds, labels.Title(ev.Phase))
+		if ev.Type == pomodoro.EventTypePaused {
+			title += " (paused)"
+		}
+		if ev.Phase != pomodoro.PhaseStopwatch {
+			progress = math.Round(passed*taskbarProgressSteps) / taskbarProgressSteps
+		}
+	case pomodoro.EventTypePhaseEnded, pomodoro.EventTypeStopped:
+	default:
+		return
+	}
+
+	g.Window.SetTitle(title)
+	err := setTaskbarProgress(progress)
+	switch {
+	case err == nil:
+		g.taskbarLastError = ""
+	case err.Error() != g.taskbarLastError:
+		g.taskbarLastError = err.Error()
+		slog.Error("unable to update the taskbar progress", "error", err)
+	}
+}
+
+// splitClock returns the minutes and the seconds of d as they are
+// shown, negative durations are shown as zero.
+func splitClock(d time.Duration) (minutes, seconds uint) {
+	d = max(d, 0)
+	return uint(d / time.Minute), uint((d % time.Minute) / time.Second)
+}
@@ -0,0 +1,190 @@
+package gui
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"log/slog"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	trayIconSize    = 64
+	trayIconPadding = 4
+
+	// trayIconMaxMinutes is the most digits fitting the icon legibly.
+	trayIconMaxMinutes = 999
+)
+
+// trayIconGlyphs is a 3x5 pixel font of the digits, scaled up when drawn.
+var trayIconGlyphs = [10][5]string{
+	{"###", "#.#", "#.#", "#.#", "###"},
+	{".#.", "##.", ".#.", ".#.", "###"},
+	{"###", "..#", "###", "#..", "###"},
+	{"###", "..#", "###", "..#", "###"},
+	{"#.#", "#.#", "###", "..#", "..#"},
+	{"###", "#..", "###", "..#", "###"},
+	{"###", "#..", "###", "#.#", "###"},
+	{"###", "..#", ".#.", ".#.", ".#."},
+	{"###", "#.#", "###", "#.#", "###"},
+	{"###", "#.#", "###", "..#", "###"},
+}
+
+// trayIconState is accessed under GUI.locker.
+type trayIconState struct {
+	menuSet bool
+
+	// shown identifies the rendered icon to skip re-rendering it
+	// every second, empty if the static icon is shown.
+	shown string
+}
+
+// desktopApp returns the application as desktop.App (to access the system
+// tray), unwrapping appWithPreferences.
+func (g *GUI) desktopApp() (desktop.App, bool) {
+	a := g.App
+	if wrapper, ok := a.(*appWithPreferences); ok {
+		a = wrapper.App
+	}
+	desk, ok := a.(desktop.App)
+	return desk, ok
+}
+
+// handleTrayIconEvent draws the remaining minutes into the tray and
+// the window icons, so the timer is readable from the taskbar alone.
+func (g *GUI) handleTrayIconEvent(ev pomodoro.Event) {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	switch ev.Type {
+	case pomodoro.EventTypePhaseStarted, pomodoro.EventTypeTick, pomodoro.EventTypeResumed, pomodoro.EventTypePaused:
+		minutes, _ := splitClock(ev.DisplayedTimeLeft)
+		if ev.Phase == pomodoro.PhaseStopwatch {
+			minutes, _ = splitClock(ev.Elapsed)
+		}
+		g.refreshTrayIcon(ev.Phase, minutes, ev.Type == pomodoro.EventTypePaused)
+	case pomodoro.EventTypePhaseEnded, pomodoro.EventTypeStopped:
+		g.showStaticTrayIcon()
+	}
+}
+
+func (g *GUI) refreshTrayIcon(
+	phase pomodoro.Phase,
+	minutes uint,
+	isPaused bool,
+) {
+	if !g.settings.TrayIcon {
+		g.showStaticTrayIcon()
+		return
+	}
+	g.setupTrayMenu()
+	minutes = min(minutes, trayIconMaxMinutes)
+	// the icon has its own (dark) background regardless of the theme
+	colors := timerColors(g.settings, theme.VariantDark).ForPhase(phase)
+	if isPaused {
+		colors.Digits.A /= 2
+	}
+	key := fmt.Sprintf("%d/%v/%v", minutes, colors, isPaused)
+	if key == g.trayIcon.shown {
+		return
+	}
+	icon, err := renderTrayIcon(strconv.FormatUint(uint64(minutes), 10), colors)
+	if err != nil {
+		slog.Error("unable to render the tray icon", "error", err)
+		return
+	}
+	g.trayIcon.shown = key
+	g.setIcon(fyne.NewStaticResource(fmt.Sprintf("pomodoro-%d.png", minutes), icon))
+}
+
+func (g *GUI) showStaticTrayIcon() {
+	if g.trayIcon.shown == "" {
+		return
+	}
+	g.trayIcon.shown = ""
+	g.setIcon(g.App.Icon())
+}
+
+func (g *GUI) setIcon(icon fyne.Resource) {
+	g.Window.SetIcon(icon)
+	if desk, ok := g.desktopApp(); ok && g.trayIcon.menuSet {
+		desk.SetSystemTrayIcon(icon)
+	}
+}
+
+// setupTrayMenu adds the icon to the system tray on the first use
+// (it cannot be removed, so it stays until the exit).
+func (g *GUI) setupTrayMenu() {
+	if g.trayIcon.menuSet {
+		return
+	}
+	desk, ok := g.desktopApp()
+	if !ok {
+		return
+	}
+	g.trayIcon.menuSet = true
+	desk.SetSystemTrayMenu(fyne.NewMenu("Pomodoro",
+		fyne.NewMenuItem(l10n.T("Show"), func() {
+			g.Window.Show()
+			g.Window.RequestFocus()
+		}),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(l10n.T("Start work"), g.afterRitual(func() { g.Start(true) })),
+		fyne.NewMenuItem(l10n.T("Start break"), g.unlessCommitted(func() { g.Start(false) })),
+		fyne.NewMenuItem(l10n.T("Pause/resume"), g.TogglePause),
+		fyne.NewMenuItem(l10n.T("Stop"), g.unlessCommitted(g.StopTimer)),
+	))
+}
+
+// renderTrayIcon draws the digits of the text over the background
+// as a PNG image.
+func renderTrayIcon(
+	text string,
+	colors pomodoro.PhaseColors,
+) ([]byte, error) {
+	img := image.NewNRGBA(image.Rect(0, 0, trayIconSize, trayIconSize))
+	draw.Draw(img, img.Bounds(), image.NewUniform(colors.Background), image.Point{}, draw.Src)
+
+	const glyphWidth, glyphHeight, spacing = 3, 5, 1
+	textWidth := len(text)*(glyphWidth+spacing) - spacing
+	scale := min(
+		(trayIconSize-2*trayIconPadding)/textWidth,
+		(trayIconSize-2*trayIconPadding)/glyphHeight,
+	)
+	left := (trayIconSize - textWidth*scale) / 2
+	top := (trayIconSize - glyphHeight*scale) / 2
+	fg := image.NewUniform(color.NRGBA(colors.Digits))
+	for idx, ch := range text {
+		if ch < '0' || ch > '9' {
+			return nil, fmt.Errorf("unable to draw '%c', only digits are supported", ch)
+		}
+		glyph := trayIconGlyphs[ch-'0']
+		glyphLeft := left + idx*(glyphWidth+spacing)*scale
+		for y, row := range glyph {
+			for x, pixel := range row {
+				if pixel != '#' {
+					continue
+				}
+				rect := image.Rect(
+					glyphLeft+x*scale, top+y*scale,
+					glyphLeft+(x+1)*scale, top+(y+1)*scale,
+				)
+				draw.Draw(img, rect, fg, image.Point{}, draw.Over)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("unable to encode the icon: %w", err)
+	}
+	return buf.Bytes(), nil
+}
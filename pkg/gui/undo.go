@@ -0,0 +1,54 @@
+package gui
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+var undoShortcut = &desktop.CustomShortcut{
+	KeyName:  fyne.KeyZ,
+	Modifier: fyne.KeyModifierShortcutDefault,
+}
+
+// newUndoToast creates the bar shown at the bottom of the timer
+// after a Stop/Start action.
+func (g *GUI) newUndoToast() fyne.CanvasObject {
+	g.undoToastText = widget.NewLabel("")
+	toast := container.NewVBox(
+		layout.NewSpacer(),
+		container.NewCenter(container.NewStack(
+			canvas.NewRectangle(color.NRGBA{A: 192}),
+			container.NewHBox(
+				g.undoToastText,
+				widget.NewButtonWithIcon(l10n.T("Undo"), theme.ContentUndoIcon(), func() { g.Undo() }),
+			),
+		)),
+	)
+	toast.Hide()
+	return toast
+}
+
+// refreshUndoToast shows the toast while the undo is offered
+// (see pomodoro.View.UndoOffer).
+func (g *GUI) refreshUndoToast() {
+	if g.view.UndoOffer == "" {
+		g.undoToast.Hide()
+		return
+	}
+	g.undoToastText.SetText(g.view.UndoOffer)
+	g.undoToast.Show()
+}
+
+func (g *GUI) addUndoShortcut(c fyne.Canvas) {
+	c.AddShortcut(undoShortcut, func(fyne.Shortcut) {
+		g.Undo()
+	})
+}
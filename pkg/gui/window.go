@@ -0,0 +1,47 @@
+package gui
+
+import (
+	"fyne.io/fyne/v2"
+)
+
+// parentWindow returns the window the timer UI is shown in,
+// or nil if it is not shown.
+func (g *GUI) parentWindow() fyne.Window {
+	g.locker.Lock()
+	defer g.locker.Unlock()
+	return g.window()
+}
+
+// window is parentWindow for the callers, which have locked the GUI.
+func (g *GUI) window() fyne.Window {
+	if g.Window != nil {
+		return g.Window
+	}
+	if !g.isShown {
+		return nil
+	}
+	c := g.App.Driver().CanvasForObject(g.Content)
+	if c == nil {
+		return nil
+	}
+	for _, w := range g.App.Driver().AllWindows() {
+		if w.Canvas() == c {
+			return w
+		}
+	}
+	return nil
+}
+
+func (g *GUI) canvas() fyne.Canvas {
+	w := g.window()
+	if w == nil {
+		return nil
+	}
+	return w.Canvas()
+}
+
+func (g *GUI) unfocus() {
+	if w := g.parentWindow(); w != nil {
+		w.Canvas().Unfocus()
+	}
+}
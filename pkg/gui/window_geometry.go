@@ -0,0 +1,77 @@
+package gui
+
+import (
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+)
+
+const (
+	prefKeyWindowX       = "window_x"
+	prefKeyWindowY       = "window_y"
+	prefKeyWindowWidth   = "window_width"
+	prefKeyWindowHeight  = "window_height"
+	prefKeyWindowCompact = "window_compact"
+)
+
+// restoreWindowSize applies the size saved by saveWindowGeometry,
+// the rest is restored once the window is shown (see restoreWindowPlacement).
+func (g *GUI) restoreWindowSize(w fyne.Window) {
+	prefs := g.App.Preferences()
+	width := prefs.Float(prefKeyWindowWidth)
+	height := prefs.Float(prefKeyWindowHeight)
+	if width <= 0 || height <= 0 {
+		return
+	}
+	w.Resize(fyne.NewSize(float32(width), float32(height)))
+}
+
+// restoreWindowPlacement moves the window to the saved position (which
+// may be on another monitor) and restores the compact mode, it should be
+// called after the window is shown. It returns false if nothing was saved.
+func (g *GUI) restoreWindowPlacement() bool {
+	prefs := g.App.Preferences()
+	if prefs.Bool(prefKeyWindowCompact) {
+		g.SetCompactMode(true)
+	}
+	x := prefs.IntWithFallback(prefKeyWindowX, -1)
+	y := prefs.IntWithFallback(prefKeyWindowY, -1)
+	if x < 0 || y < 0 {
+		return false
+	}
+	if err := moveWindow(g.Window, x, y); err != nil {
+		slog.Warn("unable to restore the window position", "error", err)
+	}
+	return true
+}
+
+// saveWindowGeometry remembers the position, the size and the compact
+// mode of the main window. It should not be called from the main thread
+// (see driver.NativeWindow).
+func (g *GUI) saveWindowGeometry() {
+	if g.Window == nil {
+		return
+	}
+	g.locker.Lock()
+	isCompact, isPresenting := g.isCompact, g.isPresenting
+	g.locker.Unlock()
+
+	prefs := g.App.Preferences()
+	prefs.SetBool(prefKeyWindowCompact, isCompact)
+	if isPresenting {
+		return
+	}
+	if !isCompact {
+		// the compact window is always of its minimal size
+		size := g.Window.Canvas().Size()
+		prefs.SetFloat(prefKeyWindowWidth, float64(size.Width))
+		prefs.SetFloat(prefKeyWindowHeight, float64(size.Height))
+	}
+	x, y, err := windowPosition(g.Window)
+	if err != nil {
+		slog.Debug("unable to get the window position", "error", err)
+		return
+	}
+	prefs.SetInt(prefKeyWindowX, x)
+	prefs.SetInt(prefKeyWindowY, y)
+}
@@ -0,0 +1,78 @@
+package gui
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver"
+)
+
+func x11WindowHandle(w fyne.Window) (uintptr, error) {
+	nativeWindow, ok := w.(driver.NativeWindow)
+	if !ok {
+		return 0, fmt.Errorf("the window does not provide access to the native window")
+	}
+	var (
+		handle uintptr
+		err    error
+	)
+	nativeWindow.RunNative(func(ctx any) {
+		x11Ctx, ok := ctx.(driver.X11WindowContext)
+		if !ok {
+			err = fmt.Errorf("the window position is supported only on X11, but the context is %T", ctx)
+			return
+		}
+		handle = x11Ctx.WindowHandle
+	})
+	return handle, err
+}
+
+func windowPosition(w fyne.Window) (x, y int, err error) {
+	handle, err := x11WindowHandle(w)
+	if err != nil {
+		return 0, 0, err
+	}
+	output, err := exec.Command("wmctrl", "-l", "-G").Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to execute wmctrl: %w", err)
+	}
+	// <id> <desktop> <x> <y> <width> <height> <host> <title>
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimPrefix(fields[0], "0x"), 16, 64)
+		if err != nil || uintptr(id) != handle {
+			continue
+		}
+		x, errX := strconv.Atoi(fields[2])
+		y, errY := strconv.Atoi(fields[3])
+		if errX != nil || errY != nil {
+			return 0, 0, fmt.Errorf("unable to parse the geometry reported by wmctrl: '%s'", scanner.Text())
+		}
+		return x, y, nil
+	}
+	return 0, 0, fmt.Errorf("the window 0x%x is not found in the output of wmctrl", handle)
+}
+
+func moveWindow(
+	w fyne.Window,
+	x, y int,
+) error {
+	handle, err := x11WindowHandle(w)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("wmctrl", "-i", "-r", fmt.Sprintf("0x%x", handle), "-e", fmt.Sprintf("0,%d,%d,-1,-1", x, y))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to execute wmctrl: %w (output: '%s')", err, output)
+	}
+	return nil
+}
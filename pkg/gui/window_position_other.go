@@ -0,0 +1,21 @@
+//go:build !linux && !windows
+
+package gui
+
+import (
+	"fmt"
+	"runtime"
+
+	"fyne.io/fyne/v2"
+)
+
+func windowPosition(w fyne.Window) (x, y int, err error) {
+	return 0, 0, fmt.Errorf("the window position is not supported on %s", runtime.GOOS)
+}
+
+func moveWindow(
+	w fyne.Window,
+	x, y int,
+) error {
+	return fmt.Errorf("the window position is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,67 @@
+package gui
+
+import (
+	"fmt"
+	"unsafe"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver"
+)
+
+const (
+	swpNoZOrder = uintptr(0x0004) // SWP_NOZORDER
+)
+
+var procGetWindowRect = user32.NewProc("GetWindowRect")
+
+type winRect struct {
+	Left, Top, Right, Bottom int32
+}
+
+func windowHWND(w fyne.Window) (uintptr, error) {
+	nativeWindow, ok := w.(driver.NativeWindow)
+	if !ok {
+		return 0, fmt.Errorf("the window does not provide access to the native window")
+	}
+	var (
+		hwnd uintptr
+		err  error
+	)
+	nativeWindow.RunNative(func(ctx any) {
+		winCtx, ok := ctx.(driver.WindowsWindowContext)
+		if !ok {
+			err = fmt.Errorf("unexpected native context type %T", ctx)
+			return
+		}
+		hwnd = winCtx.HWND
+	})
+	return hwnd, err
+}
+
+func windowPosition(w fyne.Window) (x, y int, err error) {
+	hwnd, err := windowHWND(w)
+	if err != nil {
+		return 0, 0, err
+	}
+	var rect winRect
+	r, _, _err := procGetWindowRect.Call(hwnd, uintptr(unsafe.Pointer(&rect)))
+	if r == 0 {
+		return 0, 0, fmt.Errorf("GetWindowRect failed: %w", _err)
+	}
+	return int(rect.Left), int(rect.Top), nil
+}
+
+func moveWindow(
+	w fyne.Window,
+	x, y int,
+) error {
+	hwnd, err := windowHWND(w)
+	if err != nil {
+		return err
+	}
+	r, _, _err := procSetWindowPos.Call(hwnd, 0, uintptr(x), uintptr(y), 0, 0, swpNoSize|swpNoZOrder)
+	if r == 0 {
+		return fmt.Errorf("SetWindowPos failed: %w", _err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package history
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatUnknown = Format("")
+	FormatCSV     = Format("csv")
+	FormatJSON    = Format("json")
+	FormatICS     = Format("ics")
+)
+
+var Formats = []Format{
+	FormatCSV,
+	FormatJSON,
+	FormatICS,
+}
+
+// Export writes all the sessions to w in the given format.
+func (s *Store) Export(
+	w io.Writer,
+	format Format,
+) error {
+	return Export(w, format, s.Sessions())
+}
+
+// Export writes the sessions to w in the given format.
+func Export(
+	w io.Writer,
+	format Format,
+	sessions []Session,
+) error {
+	switch format {
+	case FormatCSV:
+		return exportCSV(w, sessions)
+	case FormatJSON:
+		return exportJSON(w, sessions)
+	case FormatICS:
+		return exportICS(w, sessions)
+	default:
+		return fmt.Errorf("unknown export format '%s'", format)
+	}
+}
+
+func exportCSV(
+	w io.Writer,
+	sessions []Session,
+) error {
+	csvWriter := csv.NewWriter(w)
+	err := csvWriter.Write([]string{
+		"started_at",
+		"ended_at",
+		"phase",
+		"planned_seconds",
+		"duration_seconds",
+		"task",
+		"tags",
+		"completed",
+		"interruptions",
+		"note",
+		"overtime_seconds",
+		"ritual",
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write the CSV header: %w", err)
+	}
+	for _, session := range sessions {
+		err := csvWriter.Write([]string{
+			session.StartedAt.Format(time.RFC3339),
+			session.EndedAt.Format(time.RFC3339),
+			session.Phase,
+			strconv.FormatInt(int64(session.Planned/time.Second), 10),
+			strconv.FormatInt(int64(session.Duration/time.Second), 10),
+			session.Task,
+			strings.Join(session.AllTags(), " "),
+			strconv.FormatBool(session.Completed),
+			strconv.Itoa(len(session.Interruptions)),
+			session.Note,
+			strconv.FormatInt(int64(session.Overtime/time.Second), 10),
+			formatRitual(session.Ritual),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to write a CSV record: %w", err)
+		}
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("unable to write the CSV: %w", err)
+	}
+	return nil
+}
+
+// formatRitual is empty if there was no ritual (see Session.Ritual).
+func formatRitual(ritual *bool) string {
+	if ritual == nil {
+		return ""
+	}
+	return strconv.FormatBool(*ritual)
+}
+
+func exportJSON(
+	w io.Writer,
+	sessions []Session,
+) error {
+	if sessions == nil {
+		sessions = []Session{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(sessions); err != nil {
+		return fmt.Errorf("unable to write the JSON: %w", err)
+	}
+	return nil
+}
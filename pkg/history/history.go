@@ -0,0 +1,291 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+type Session struct {
+	StartedAt time.Time     `json:"started_at"`
+	EndedAt   time.Time     `json:"ended_at"`
+	Phase     string        `json:"phase"`
+	Planned   time.Duration `json:"planned"`
+	Duration  time.Duration `json:"duration"`
+	Task      string        `json:"task,omitempty"`
+	Tags      []string      `json:"tags,omitempty"`
+	Completed bool          `json:"completed"`
+	Note      string        `json:"note,omitempty"`
+
+	// Overtime is how long the session lasted past its end
+	// (is included into Duration).
+	Overtime time.Duration `json:"overtime,omitempty"`
+
+	// Rating is the focus quality from 1 to 5, 0 if not rated.
+	Rating uint8 `json:"rating,omitempty"`
+
+	// Ritual is true if the checklist was gone through before the work
+	// session, false if it was skipped and nil if there was none.
+	Ritual *bool `json:"ritual,omitempty"`
+
+	// Active is how long the computer was used during a break,
+	// nil if it was not monitored.
+	Active *time.Duration `json:"active,omitempty"`
+
+	Interruptions []Interruption `json:"interruptions,omitempty"`
+}
+
+type InterruptionKind string
+
+const (
+	InterruptionKindInternal = InterruptionKind("internal")
+	InterruptionKindExternal = InterruptionKind("external")
+
+	// InterruptionKindCrash marks the time the app was not running
+	// within a session recovered from the Journal.
+	InterruptionKindCrash = InterruptionKind("crash")
+)
+
+var InterruptionKinds = []InterruptionKind{
+	InterruptionKindInternal,
+	InterruptionKindExternal,
+}
+
+// Interruption is a moment when the focus was broken, either by
+// oneself (internal) or by somebody/something else (external).
+type Interruption struct {
+	At     time.Time        `json:"at"`
+	Kind   InterruptionKind `json:"kind"`
+	Reason string           `json:"reason,omitempty"`
+}
+
+// Store is an append-only session history persisted as JSON lines.
+type Store struct {
+	locker   sync.Mutex
+	path     string
+	sessions []Session
+
+	// skipped are the malformed lines of the file, they are kept
+	// by rewrite.
+	skipped [][]byte
+
+	// unterminated is true if the last line of the file has no line
+	// break, so the next one should start with it.
+	unterminated bool
+}
+
+// Open loads the history from the file. An empty path means
+// an in-memory history.
+func Open(path string) (*Store, error) {
+	s := &Store{
+		path: path,
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read '%s': %w", path, err)
+	}
+
+	// a malformed line (including the last one written partially
+	// because of a crash) does not make the whole history unavailable
+	// (then the new sessions would not be saved either): it is skipped
+	for lineNum, offset := 1, 0; offset < len(content); lineNum++ {
+		line, rest, terminated := bytes.Cut(content[offset:], []byte("\n"))
+		offset = len(content) - len(rest)
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		s.unterminated = !terminated
+		var session Session
+		if err := json.Unmarshal(line, &session); err != nil {
+			slog.Warn("skipping a malformed line of the history", "path", path, "line", lineNum, "error", err)
+			s.skipped = append(s.skipped, line)
+			continue
+		}
+		s.sessions = append(s.sessions, session)
+	}
+	return s, nil
+}
+
+func (s *Store) Add(session Session) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	s.sessions = append(s.sessions, session)
+	if s.path == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("unable to serialize the session: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("unable to create the directory for '%s': %w", s.path, err)
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("unable to open '%s': %w", s.path, err)
+	}
+	defer f.Close()
+	if s.unterminated {
+		line = append([]byte{'\n'}, line...)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("unable to write to '%s': %w", s.path, err)
+	}
+	s.unterminated = false
+	return nil
+}
+
+// SetNote attaches the note to the session started at the given time
+// (the history file is rewritten).
+func (s *Store) SetNote(
+	startedAt time.Time,
+	note string,
+) error {
+	return s.update(startedAt, func(session *Session) {
+		session.Note = note
+	})
+}
+
+// SetRating sets the focus quality (from 1 to 5) of the session
+// started at the given time (the history file is rewritten).
+func (s *Store) SetRating(
+	startedAt time.Time,
+	rating uint8,
+) error {
+	if rating < MinRating || rating > MaxRating {
+		return fmt.Errorf("the rating %d is out of range [%d, %d]", rating, MinRating, MaxRating)
+	}
+	return s.update(startedAt, func(session *Session) {
+		session.Rating = rating
+	})
+}
+
+func (s *Store) update(
+	startedAt time.Time,
+	fn func(*Session),
+) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	idx := slices.IndexFunc(s.sessions, func(session Session) bool {
+		return session.StartedAt.Equal(startedAt)
+	})
+	if idx < 0 {
+		return fmt.Errorf("no session started at %s", startedAt.Format(time.RFC3339))
+	}
+	fn(&s.sessions[idx])
+	if s.path == "" {
+		return nil
+	}
+	return s.rewrite()
+}
+
+// Remove removes the session started at the given time (if any).
+func (s *Store) Remove(startedAt time.Time) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	idx := slices.IndexFunc(s.sessions, func(session Session) bool {
+		return session.StartedAt.Equal(startedAt)
+	})
+	if idx < 0 {
+		return nil
+	}
+	s.sessions = slices.Delete(s.sessions, idx, idx+1)
+	if s.path == "" {
+		return nil
+	}
+	return s.rewrite()
+}
+
+// rewrite writes the sessions over the file, the malformed lines
+// (see Open) are put first as they were, so nothing is lost.
+func (s *Store) rewrite() error {
+	var buf bytes.Buffer
+	for _, line := range s.skipped {
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	encoder := json.NewEncoder(&buf)
+	for _, session := range s.sessions {
+		if err := encoder.Encode(session); err != nil {
+			return fmt.Errorf("unable to serialize the session: %w", err)
+		}
+	}
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("unable to write '%s': %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("unable to replace '%s': %w", s.path, err)
+	}
+	s.unterminated = false
+	return nil
+}
+
+func (s *Store) Sessions() []Session {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	return append([]Session(nil), s.sessions...)
+}
+
+// Tasks returns the distinct task labels, the most recently used first.
+func (s *Store) Tasks() []string {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	var tasks []string
+	seen := map[string]struct{}{}
+	for idx := len(s.sessions) - 1; idx >= 0; idx-- {
+		task := s.sessions[idx].Task
+		if task == "" {
+			continue
+		}
+		if _, ok := seen[task]; ok {
+			continue
+		}
+		seen[task] = struct{}{}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+func (s *Store) SessionsSince(t time.Time) []Session {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	var result []Session
+	for _, session := range s.sessions {
+		if session.EndedAt.Before(t) {
+			continue
+		}
+		result = append(result, session)
+	}
+	return result
+}
+
+// DayStart returns the beginning of the "day" containing t, where days
+// are shifted by the boundary (for example, with a 4h boundary a session
+// at 2am belongs to the previous day).
+func DayStart(
+	t time.Time,
+	boundary time.Duration,
+) time.Time {
+	year, month, day := t.Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, t.Location()).Add(boundary)
+	if t.Before(start) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
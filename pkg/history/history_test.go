@@ -0,0 +1,141 @@
+package history
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sessionLine(t *testing.T, task string) string {
+	t.Helper()
+	b, err := json.Marshal(Session{
+		StartedAt: time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC),
+		Phase:     "work",
+		Task:      task,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestOpenMalformed(t *testing.T) {
+	for _, tc := range []struct {
+		Name    string
+		Content func(t *testing.T) string
+		Tasks   []string
+	}{
+		{
+			Name: "valid",
+			Content: func(t *testing.T) string {
+				return sessionLine(t, "a") + "\n\n" + sessionLine(t, "b") + "\n"
+			},
+			Tasks: []string{"a", "b"},
+		},
+		{
+			Name: "malformed line in the middle",
+			Content: func(t *testing.T) string {
+				return sessionLine(t, "a") + "\n{garbage\n" + sessionLine(t, "b") + "\n"
+			},
+			Tasks: []string{"a", "b"},
+		},
+		{
+			Name: "unfinished last line",
+			Content: func(t *testing.T) string {
+				line := sessionLine(t, "b")
+				return sessionLine(t, "a") + "\n" + line[:len(line)/2]
+			},
+			Tasks: []string{"a"},
+		},
+		{
+			Name: "no line break at the end",
+			Content: func(t *testing.T) string {
+				return sessionLine(t, "a") + "\n" + sessionLine(t, "b")
+			},
+			Tasks: []string{"a", "b"},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "history.jsonl")
+			if err := os.WriteFile(path, []byte(tc.Content(t)), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			s, err := Open(path)
+			if err != nil {
+				t.Fatalf("unable to open the history: %v", err)
+			}
+			if tasks := sessionTasks(s.Sessions()); !slices.Equal(tasks, tc.Tasks) {
+				t.Fatalf("expected the sessions %v, got %v", tc.Tasks, tasks)
+			}
+
+			// the new session is saved into the same file, and
+			// is read back along with the old ones
+			if err := s.Add(Session{Phase: "work", Task: "new"}); err != nil {
+				t.Fatalf("unable to add a session: %v", err)
+			}
+			reopened, err := Open(path)
+			if err != nil {
+				t.Fatalf("unable to reopen the history: %v", err)
+			}
+			expected := slices.Concat(tc.Tasks, []string{"new"})
+			if tasks := sessionTasks(reopened.Sessions()); !slices.Equal(tasks, expected) {
+				t.Fatalf("expected the sessions %v after reopening, got %v", expected, tasks)
+			}
+		})
+	}
+}
+
+func TestRewriteKeepsMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	unfinished := sessionLine(t, "c")[:20]
+	content := sessionLine(t, "a") + "\n{garbage\n" + sessionLine(t, "b") + "\n" + unfinished
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("unable to open the history: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Fatalf("expected the file to be kept intact by Open, got %q", data)
+	}
+
+	startedAt := s.Sessions()[0].StartedAt
+	if err := s.SetNote(startedAt, "a note"); err != nil {
+		t.Fatalf("unable to set the note: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for _, malformed := range []string{"{garbage", unfinished} {
+		if !slices.Contains(lines, malformed) {
+			t.Fatalf("the malformed line %q is lost after the rewrite:\n%s", malformed, data)
+		}
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("unable to reopen the history: %v", err)
+	}
+	sessions := reopened.Sessions()
+	if tasks := sessionTasks(sessions); !slices.Equal(tasks, []string{"a", "b"}) {
+		t.Fatalf("expected the sessions [a b] after the rewrite, got %v", tasks)
+	}
+	if sessions[0].Note != "a note" {
+		t.Fatalf("expected the note to be saved, got %+v", sessions[0])
+	}
+}
+
+func sessionTasks(sessions []Session) []string {
+	var tasks []string
+	for _, session := range sessions {
+		tasks = append(tasks, session.Task)
+	}
+	return tasks
+}
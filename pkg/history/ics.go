@@ -0,0 +1,101 @@
+package history
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	icsTimeLayout = "20060102T150405Z"
+
+	// icsLineLimit is the maximal length of a line in octets (RFC 5545,
+	// section 3.1), the longer ones are folded.
+	icsLineLimit = 75
+)
+
+// exportICS writes the sessions as the events of an iCalendar file
+// (RFC 5545), titled by their tasks.
+func exportICS(
+	w io.Writer,
+	sessions []Session,
+) error {
+	bw := bufio.NewWriter(w)
+	writeLine := func(line string) {
+		writeICSLine(bw, line)
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//xaionaro-go//pomodoro//EN")
+	writeLine("CALSCALE:GREGORIAN")
+	for _, session := range sessions {
+		title := session.Task
+		if title == "" {
+			title = "Pomodoro"
+		}
+		writeLine("BEGIN:VEVENT")
+		writeLine(fmt.Sprintf("UID:%d-%s@pomodoro", session.StartedAt.UnixNano(), session.Phase))
+		writeLine("DTSTAMP:" + session.EndedAt.UTC().Format(icsTimeLayout))
+		writeLine("DTSTART:" + session.StartedAt.UTC().Format(icsTimeLayout))
+		writeLine("DTEND:" + session.EndedAt.UTC().Format(icsTimeLayout))
+		writeLine("SUMMARY:" + escapeICSText(title))
+		if session.Note != "" {
+			writeLine("DESCRIPTION:" + escapeICSText(session.Note))
+		}
+		if tags := session.AllTags(); len(tags) > 0 {
+			escaped := make([]string, 0, len(tags))
+			for _, tag := range tags {
+				escaped = append(escaped, escapeICSText(tag))
+			}
+			writeLine("CATEGORIES:" + strings.Join(escaped, ","))
+		}
+		writeLine("TRANSP:OPAQUE")
+		writeLine("END:VEVENT")
+	}
+	writeLine("END:VCALENDAR")
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("unable to write the iCalendar: %w", err)
+	}
+	return nil
+}
+
+// writeICSLine writes the content line terminated by CRLF, folding it
+// into the continuation lines (starting with a space) if it is too long;
+// multi-byte characters are not split.
+func writeICSLine(
+	w *bufio.Writer,
+	line string,
+) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isICSRuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space of the continuation line counts
+		limit = icsLineLimit - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+func isICSRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+var icsTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func escapeICSText(s string) string {
+	return icsTextEscaper.Replace(s)
+}
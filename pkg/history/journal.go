@@ -0,0 +1,139 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// JournalRecord is a checkpoint of the running session.
+type JournalRecord struct {
+	// Time is when the checkpoint was written, the app is known
+	// to be alive at this moment.
+	Time      time.Time     `json:"time"`
+	Phase     string        `json:"phase"`
+	StartedAt time.Time     `json:"started_at"`
+	Planned   time.Duration `json:"planned"`
+	Elapsed   time.Duration `json:"elapsed"`
+	TimeLeft  time.Duration `json:"time_left"`
+	Paused    bool          `json:"paused,omitempty"`
+	Task      string        `json:"task,omitempty"`
+}
+
+// Journal is an append-only log of checkpoints of the running session,
+// every write is synced to the disk, so that the session could be
+// recovered after a crash. It is cleared when the session ends normally.
+type Journal struct {
+	locker sync.Mutex
+	path   string
+	file   *os.File
+}
+
+// OpenJournal opens (or creates) the journal file. An empty path
+// means a journal which keeps nothing.
+func OpenJournal(path string) (*Journal, error) {
+	j := &Journal{
+		path: path,
+	}
+	if path == "" {
+		return j, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create the directory for '%s': %w", path, err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open '%s': %w", path, err)
+	}
+	j.file = f
+	return j, nil
+}
+
+// Last returns the last checkpoint, ok is false if the journal is empty
+// (no session was interrupted). A partially written last line (if
+// the crash happened during the write) is skipped.
+func (j *Journal) Last() (record JournalRecord, ok bool, err error) {
+	j.locker.Lock()
+	defer j.locker.Unlock()
+	if j.path == "" {
+		return JournalRecord{}, false, nil
+	}
+
+	f, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return JournalRecord{}, false, nil
+	}
+	if err != nil {
+		return JournalRecord{}, false, fmt.Errorf("unable to open '%s': %w", j.path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var candidate JournalRecord
+		if err := json.Unmarshal(scanner.Bytes(), &candidate); err != nil {
+			continue
+		}
+		record, ok = candidate, true
+	}
+	if err := scanner.Err(); err != nil {
+		return JournalRecord{}, false, fmt.Errorf("unable to read '%s': %w", j.path, err)
+	}
+	return record, ok, nil
+}
+
+// Write appends the checkpoint and waits until it is on the disk.
+func (j *Journal) Write(record JournalRecord) error {
+	j.locker.Lock()
+	defer j.locker.Unlock()
+	if j.file == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("unable to serialize the checkpoint: %w", err)
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("unable to write to '%s': %w", j.path, err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("unable to sync '%s': %w", j.path, err)
+	}
+	return nil
+}
+
+// Clear forgets all the checkpoints.
+func (j *Journal) Clear() error {
+	j.locker.Lock()
+	defer j.locker.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	if err := j.file.Truncate(0); err != nil {
+		return fmt.Errorf("unable to truncate '%s': %w", j.path, err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("unable to sync '%s': %w", j.path, err)
+	}
+	return nil
+}
+
+func (j *Journal) Close() error {
+	j.locker.Lock()
+	defer j.locker.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	if err != nil {
+		return fmt.Errorf("unable to close '%s': %w", j.path, err)
+	}
+	return nil
+}
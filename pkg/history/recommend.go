@@ -0,0 +1,197 @@
+package history
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// RecommendMinSessions is how many sessions of a length within
+	// a part of the day it takes to draw a conclusion about them.
+	RecommendMinSessions = 5
+
+	// RecommendFailureRate is the share of not completed sessions
+	// since which another length is recommended.
+	RecommendFailureRate = 0.5
+)
+
+// Recommendation suggests another interval length for the sessions
+// started within a part of the day, where the current length
+// is not completed too often (see Recommend).
+type Recommendation struct {
+	// Length is the planned length (rounded to the step)
+	// of the failing sessions.
+	Length time.Duration
+
+	// FromHour and ToHour (exclusive) are the part of the day
+	// the sessions were started within, 0 and 24 for the whole day.
+	FromHour int
+	ToHour   int
+
+	Sessions    uint
+	FailureRate float64
+
+	// Suggested is the shorter length to try instead.
+	Suggested time.Duration
+}
+
+type completionTotal struct {
+	sessions uint
+	failed   uint
+}
+
+func (t completionTotal) failureRate() float64 {
+	return float64(t.failed) / float64(t.sessions)
+}
+
+// Recommend analyzes the completion rate of the sessions (both completed
+// and not, the caller picks the phases) against their planned length and
+// the hour they were started at. For each length which fails at least
+// RecommendFailureRate of the time (either within the whole day, or since
+// or before some hour, whichever is the worst) it suggests a shorter one:
+// the best completed shorter length within the same hours, or half of it if
+// there is no data. The most failing lengths go first.
+func Recommend(
+	sessions []Session,
+	step time.Duration,
+) []Recommendation {
+	// totals[length][hour]
+	totals := map[time.Duration]*[24]completionTotal{}
+	for _, session := range sessions {
+		if session.Planned <= 0 {
+			continue
+		}
+		length := max(session.Planned.Round(step), step)
+		byHour := totals[length]
+		if byHour == nil {
+			byHour = &[24]completionTotal{}
+			totals[length] = byHour
+		}
+		total := &byHour[session.StartedAt.Hour()]
+		total.sessions++
+		if !session.Completed {
+			total.failed++
+		}
+	}
+
+	var result []Recommendation
+	for length, byHour := range totals {
+		worst, ok := worstHours(byHour)
+		if !ok {
+			continue
+		}
+		worst.Length = length
+		worst.Suggested = suggestShorter(totals, length, worst.FromHour, worst.ToHour, step)
+		if worst.Suggested >= length {
+			// already the shortest
+			continue
+		}
+		result = append(result, worst)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].FailureRate != result[j].FailureRate {
+			return result[i].FailureRate > result[j].FailureRate
+		}
+		return result[i].Length > result[j].Length
+	})
+	return result
+}
+
+// worstHours finds the part of the day with the highest failure rate
+// among the ones having enough sessions.
+func worstHours(byHour *[24]completionTotal) (Recommendation, bool) {
+	var (
+		worst Recommendation
+		found bool
+	)
+	consider := func(from, to int) {
+		total := sumHours(byHour, from, to)
+		if total.sessions < RecommendMinSessions {
+			return
+		}
+		rate := total.failureRate()
+		if rate < RecommendFailureRate {
+			return
+		}
+		if found && !isWorse(rate, total.sessions, to-from, worst) {
+			return
+		}
+		worst = Recommendation{
+			FromHour:    from,
+			ToHour:      to,
+			Sessions:    total.sessions,
+			FailureRate: rate,
+		}
+		found = true
+	}
+	consider(0, 24)
+	for hour := 1; hour < 24; hour++ {
+		consider(hour, 24)
+		consider(0, hour)
+	}
+	return worst, found
+}
+
+// isWorse compares the failure rates, and then prefers more sessions
+// and the narrower parts of the day (to point at the failing hours).
+func isWorse(
+	rate float64,
+	sessions uint,
+	hours int,
+	than Recommendation,
+) bool {
+	switch {
+	case rate != than.FailureRate:
+		return rate > than.FailureRate
+	case sessions != than.Sessions:
+		return sessions > than.Sessions
+	default:
+		return hours < than.ToHour-than.FromHour
+	}
+}
+
+func sumHours(
+	byHour *[24]completionTotal,
+	from int,
+	to int,
+) completionTotal {
+	var total completionTotal
+	for hour := from; hour < to; hour++ {
+		total.sessions += byHour[hour].sessions
+		total.failed += byHour[hour].failed
+	}
+	return total
+}
+
+func suggestShorter(
+	totals map[time.Duration]*[24]completionTotal,
+	length time.Duration,
+	from int,
+	to int,
+	step time.Duration,
+) time.Duration {
+	var (
+		best     time.Duration
+		bestRate float64
+	)
+	for other, byHour := range totals {
+		if other >= length {
+			continue
+		}
+		total := sumHours(byHour, from, to)
+		if total.sessions < RecommendMinSessions {
+			continue
+		}
+		rate := total.failureRate()
+		if rate >= RecommendFailureRate {
+			continue
+		}
+		if best == 0 || rate < bestRate || (rate == bestRate && other > best) {
+			best, bestRate = other, rate
+		}
+	}
+	if best != 0 {
+		return best
+	}
+	return max((length / 2).Round(step), step)
+}
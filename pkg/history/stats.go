@@ -0,0 +1,220 @@
+package history
+
+import (
+	"sort"
+	"time"
+)
+
+// Period aggregates the sessions started within [Start, End).
+type Period struct {
+	Start    time.Time
+	End      time.Time
+	Sessions uint
+	Duration time.Duration
+	Overtime time.Duration
+}
+
+// Daily splits the last `days` days (the last one contains now) into periods
+// and aggregates the sessions into them; see DayStart for the boundary.
+func Daily(
+	sessions []Session,
+	now time.Time,
+	days int,
+	boundary time.Duration,
+) []Period {
+	today := DayStart(now, boundary)
+	periods := make([]Period, days)
+	for idx := range periods {
+		start := today.AddDate(0, 0, idx-days+1)
+		periods[idx] = Period{
+			Start: start,
+			End:   start.AddDate(0, 0, 1),
+		}
+	}
+	aggregate(sessions, periods)
+	return periods
+}
+
+// Weekly is the same as Daily, but for weeks (starting on Monday).
+func Weekly(
+	sessions []Session,
+	now time.Time,
+	weeks int,
+	boundary time.Duration,
+) []Period {
+	today := DayStart(now, boundary)
+	thisWeek := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	periods := make([]Period, weeks)
+	for idx := range periods {
+		start := thisWeek.AddDate(0, 0, 7*(idx-weeks+1))
+		periods[idx] = Period{
+			Start: start,
+			End:   start.AddDate(0, 0, 7),
+		}
+	}
+	aggregate(sessions, periods)
+	return periods
+}
+
+func aggregate(
+	sessions []Session,
+	periods []Period,
+) {
+	for _, session := range sessions {
+		for idx := range periods {
+			period := &periods[idx]
+			if session.StartedAt.Before(period.Start) || !session.StartedAt.Before(period.End) {
+				continue
+			}
+			period.Sessions++
+			period.Duration += session.Duration
+			period.Overtime += session.Overtime
+			break
+		}
+	}
+}
+
+// Streaks returns the number of consecutive days with at least one session
+// ending today (or yesterday, if there were no sessions today yet),
+// and the longest such run ever.
+func Streaks(
+	sessions []Session,
+	now time.Time,
+	boundary time.Duration,
+) (current, longest uint) {
+	const dayLayout = "2006-01-02"
+	daySet := map[string]struct{}{}
+	for _, session := range sessions {
+		daySet[DayStart(session.StartedAt, boundary).Format(dayLayout)] = struct{}{}
+	}
+	days := make([]string, 0, len(daySet))
+	for day := range daySet {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	var run uint
+	var prev time.Time
+	for _, day := range days {
+		t, _ := time.Parse(dayLayout, day)
+		if run > 0 && prev.AddDate(0, 0, 1).Equal(t) {
+			run++
+		} else {
+			run = 1
+		}
+		prev = t
+		longest = max(longest, run)
+	}
+
+	day := DayStart(now, boundary)
+	if _, ok := daySet[day.Format(dayLayout)]; !ok {
+		day = day.AddDate(0, 0, -1)
+	}
+	for {
+		if _, ok := daySet[day.Format(dayLayout)]; !ok {
+			break
+		}
+		current++
+		day = day.AddDate(0, 0, -1)
+	}
+	return current, longest
+}
+
+// BreakCompliance returns the share of the breaks' time the computer
+// was not used (see Session.Active); ok is false if none of the sessions
+// was monitored.
+func BreakCompliance(sessions []Session) (compliance float64, ok bool) {
+	var total, active time.Duration
+	for _, session := range sessions {
+		if session.Active == nil || session.Duration <= 0 {
+			continue
+		}
+		total += session.Duration
+		active += *session.Active
+	}
+	if total <= 0 {
+		return 0, false
+	}
+	return 1 - float64(active)/float64(total), true
+}
+
+// RitualCompletion returns the share of the sessions started with
+// the checklist gone through rather than skipped (see Session.Ritual);
+// ok is false if none of the sessions had it.
+func RitualCompletion(sessions []Session) (completion float64, ok bool) {
+	var total, completed uint
+	for _, session := range sessions {
+		if session.Ritual == nil {
+			continue
+		}
+		total++
+		if *session.Ritual {
+			completed++
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(completed) / float64(total), true
+}
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// RatingAverage is the average focus quality of the rated sessions
+// within a group (see RatingByHour and RatingByLength).
+type RatingAverage struct {
+	Key      int
+	Sessions uint
+	Average  float64
+}
+
+// RatingByHour groups the rated sessions by the hour of the day
+// they were started at (Key); the hours without ratings are omitted.
+func RatingByHour(sessions []Session) []RatingAverage {
+	return averageRating(sessions, func(session Session) int {
+		return session.StartedAt.Hour()
+	})
+}
+
+// RatingByLength groups the rated sessions by the planned interval
+// length in minutes (Key) rounded to the step.
+func RatingByLength(
+	sessions []Session,
+	step time.Duration,
+) []RatingAverage {
+	return averageRating(sessions, func(session Session) int {
+		return int(session.Planned.Round(step) / time.Minute)
+	})
+}
+
+func averageRating(
+	sessions []Session,
+	key func(Session) int,
+) []RatingAverage {
+	totals := map[int]*RatingAverage{}
+	for _, session := range sessions {
+		if session.Rating == 0 {
+			continue
+		}
+		k := key(session)
+		total := totals[k]
+		if total == nil {
+			total = &RatingAverage{Key: k}
+			totals[k] = total
+		}
+		total.Sessions++
+		total.Average += float64(session.Rating)
+	}
+	result := make([]RatingAverage, 0, len(totals))
+	for _, total := range totals {
+		total.Average /= float64(total.Sessions)
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
@@ -0,0 +1,101 @@
+package history
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	tagPrefix = "#"
+)
+
+// ParseTags returns the distinct words of the task starting with '#'
+// (without the '#'), like "project-x" for "review #project-x".
+func ParseTags(task string) []string {
+	var tags []string
+	for _, word := range strings.Fields(task) {
+		tag, ok := strings.CutPrefix(word, tagPrefix)
+		if !ok || tag == "" || slices.Contains(tags, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// AllTags returns the tags of the session; the sessions recorded
+// before tagging was introduced have their tags parsed from the task.
+func (s Session) AllTags() []string {
+	if len(s.Tags) > 0 {
+		return s.Tags
+	}
+	return ParseTags(s.Task)
+}
+
+func (s Session) HasTag(tag string) bool {
+	return slices.Contains(s.AllTags(), tag)
+}
+
+// FilterByTag returns the sessions having the tag, an empty tag means
+// all the sessions.
+func FilterByTag(
+	sessions []Session,
+	tag string,
+) []Session {
+	if tag == "" {
+		return sessions
+	}
+	var result []Session
+	for _, session := range sessions {
+		if session.HasTag(tag) {
+			result = append(result, session)
+		}
+	}
+	return result
+}
+
+// Tags returns the distinct tags, the most recently used first.
+func (s *Store) Tags() []string {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	var tags []string
+	for idx := len(s.sessions) - 1; idx >= 0; idx-- {
+		for _, tag := range s.sessions[idx].AllTags() {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+type TagTotal struct {
+	Tag      string
+	Sessions uint
+	Duration time.Duration
+}
+
+// ByTag aggregates the sessions by their tags (a session with several
+// tags is accounted in each of them), the largest duration first.
+func ByTag(sessions []Session) []TagTotal {
+	var totals []TagTotal
+	index := map[string]int{}
+	for _, session := range sessions {
+		for _, tag := range session.AllTags() {
+			idx, ok := index[tag]
+			if !ok {
+				idx = len(totals)
+				index[tag] = idx
+				totals = append(totals, TagTotal{Tag: tag})
+			}
+			totals[idx].Sessions++
+			totals[idx].Duration += session.Duration
+		}
+	}
+	sort.SliceStable(totals, func(i, j int) bool {
+		return totals[i].Duration > totals[j].Duration
+	})
+	return totals
+}
@@ -0,0 +1,14 @@
+package httpapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:embed resources/overlay.html
+var overlayPage []byte
+
+func handleOverlay(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(overlayPage)
+}
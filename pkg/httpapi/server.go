@@ -0,0 +1,218 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+type Timer interface {
+	Start(isWork bool)
+	Pause()
+	Resume()
+	StopTimer()
+	Extend(d time.Duration)
+	Status() pomodoro.Status
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+}
+
+type Server struct {
+	Timer Timer
+	Mux   *http.ServeMux
+
+	// AllowedHosts are the names (with or without the port) the API may
+	// be requested by, besides the IP addresses and "localhost"
+	// (see serveLocal); usually it is the listen address.
+	AllowedHosts []string
+
+	httpServer *http.Server
+	upgrader   websocket.Upgrader
+	spectator  bool
+}
+
+func New(timer Timer) *Server {
+	s := newServer(timer)
+	s.httpServer.Handler = http.HandlerFunc(s.serveLocal)
+	s.Mux.HandleFunc("/status", s.handleStatus)
+	s.Mux.HandleFunc("/ws", s.handleWebSocket)
+	s.Mux.HandleFunc("/overlay", handleOverlay)
+	s.Mux.HandleFunc("/start", s.action(func(r *http.Request) error {
+		switch phase := r.URL.Query().Get("phase"); phase {
+		case "", "work":
+			s.Timer.Start(true)
+		case "rest":
+			s.Timer.Start(false)
+		default:
+			return fmt.Errorf("unknown phase '%s', expected 'work' or 'rest'", phase)
+		}
+		return nil
+	}))
+	s.Mux.HandleFunc("/pause", s.action(func(*http.Request) error {
+		s.Timer.Pause()
+		return nil
+	}))
+	s.Mux.HandleFunc("/resume", s.action(func(*http.Request) error {
+		s.Timer.Resume()
+		return nil
+	}))
+	s.Mux.HandleFunc("/stop", s.action(func(*http.Request) error {
+		s.Timer.StopTimer()
+		return nil
+	}))
+	s.Mux.HandleFunc("/extend", s.action(func(r *http.Request) error {
+		d, err := time.ParseDuration(r.URL.Query().Get("by"))
+		if err != nil {
+			return fmt.Errorf("unable to parse parameter 'by' (expected a duration, e.g. '5m' or '-5m'): %w", err)
+		}
+		s.Timer.Extend(d)
+		return nil
+	}))
+	return s
+}
+
+func newServer(timer Timer) *Server {
+	s := &Server{
+		Timer: timer,
+		Mux:   http.NewServeMux(),
+	}
+	s.httpServer = &http.Server{
+		Handler:           s.Mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// Serve serves the API on the listener until Close is called.
+func (s *Server) Serve(listener net.Listener) error {
+	err := s.httpServer.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (s *Server) Close() error {
+	ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFn()
+	return s.httpServer.Shutdown(ctx)
+}
+
+// status returns the status of the timer, without the task
+// for the spectators (see NewSpectator).
+func (s *Server) status() pomodoro.StatusJSON {
+	status := s.Timer.Status().JSON()
+	if s.spectator {
+		status.Task = ""
+	}
+	return status
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSON(w, http.StatusMethodNotAllowed, ErrorResponse{Error: "only GET is allowed"})
+		return
+	}
+	writeJSON(w, http.StatusOK, s.status())
+}
+
+// action handles a request changing the state of the timer. Only POST is
+// allowed, so that a link or an image could not trigger it; and the
+// requests from the pages of the other sites are refused (a browser may
+// send them, but always with their origin).
+func (s *Server) action(
+	fn func(r *http.Request) error,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeJSON(w, http.StatusMethodNotAllowed, ErrorResponse{Error: "only POST is allowed"})
+			return
+		}
+		if isCrossOrigin(r) {
+			writeJSON(w, http.StatusForbidden, ErrorResponse{Error: "cross-origin requests are not allowed"})
+			return
+		}
+		if err := fn(r); err != nil {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, s.status())
+	}
+}
+
+// serveLocal refuses the requests to the names not known to be of this
+// host: otherwise a page of another site could resolve its name to
+// 127.0.0.1 (DNS rebinding) and use the API as a page of the same origin.
+func (s *Server) serveLocal(w http.ResponseWriter, r *http.Request) {
+	if !s.isAllowedHost(r.Host) {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: fmt.Sprintf("the host '%s' is not allowed", r.Host)})
+		return
+	}
+	s.Mux.ServeHTTP(w, r)
+}
+
+func (s *Server) isAllowedHost(host string) bool {
+	name := hostName(host)
+	if net.ParseIP(name) != nil {
+		// an address could not be rebound to another one
+		return true
+	}
+	if strings.EqualFold(name, "localhost") || strings.HasSuffix(strings.ToLower(name), ".localhost") {
+		return true
+	}
+	for _, allowed := range s.AllowedHosts {
+		if allowedName := hostName(allowed); allowedName != "" && strings.EqualFold(name, allowedName) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostName returns the host of "host[:port]" without the port,
+// the brackets of IPv6 and the trailing dot.
+func hostName(host string) string {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	host = strings.TrimPrefix(strings.TrimSuffix(host, "]"), "[")
+	return strings.TrimSuffix(host, ".")
+}
+
+// isCrossOrigin returns true if the request is made by a page of
+// another host (the same check as of websocket.Upgrader by default).
+func isCrossOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return true
+	}
+	return !strings.EqualFold(u.Host, r.Host)
+}
+
+func writeJSON(
+	w http.ResponseWriter,
+	statusCode int,
+	value any,
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		slog.Error("unable to write the response", "error", err)
+	}
+}
@@ -0,0 +1,114 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+type fakeTimer struct {
+	calls []string
+}
+
+func (t *fakeTimer) Start(isWork bool) {
+	if isWork {
+		t.calls = append(t.calls, "start work")
+	} else {
+		t.calls = append(t.calls, "start rest")
+	}
+}
+
+func (t *fakeTimer) Pause()                 { t.calls = append(t.calls, "pause") }
+func (t *fakeTimer) Resume()                { t.calls = append(t.calls, "resume") }
+func (t *fakeTimer) StopTimer()             { t.calls = append(t.calls, "stop") }
+func (t *fakeTimer) Extend(d time.Duration) { t.calls = append(t.calls, "extend "+d.String()) }
+func (t *fakeTimer) Status() pomodoro.Status {
+	return pomodoro.Status{}
+}
+
+func (t *fakeTimer) OnEvent(func(pomodoro.Event)) func() {
+	return func() {}
+}
+
+func TestActions(t *testing.T) {
+	for _, tc := range []struct {
+		Method     string
+		Target     string
+		Origin     string
+		StatusCode int
+		Call       string
+	}{
+		{Method: http.MethodPost, Target: "/start", StatusCode: http.StatusOK, Call: "start work"},
+		{Method: http.MethodPost, Target: "/start?phase=rest", StatusCode: http.StatusOK, Call: "start rest"},
+		{Method: http.MethodPost, Target: "/start?phase=nap", StatusCode: http.StatusBadRequest},
+		{Method: http.MethodPost, Target: "/pause", StatusCode: http.StatusOK, Call: "pause"},
+		{Method: http.MethodPost, Target: "/resume", StatusCode: http.StatusOK, Call: "resume"},
+		{Method: http.MethodPost, Target: "/stop", StatusCode: http.StatusOK, Call: "stop"},
+		{Method: http.MethodPost, Target: "/extend?by=-5m", StatusCode: http.StatusOK, Call: "extend -5m0s"},
+		{Method: http.MethodPost, Target: "/extend?by=5", StatusCode: http.StatusBadRequest},
+		{Method: http.MethodGet, Target: "/start", StatusCode: http.StatusMethodNotAllowed},
+		{Method: http.MethodGet, Target: "/stop", StatusCode: http.StatusMethodNotAllowed},
+		{Method: http.MethodPost, Target: "/stop", Origin: "http://127.0.0.1:8787", StatusCode: http.StatusOK, Call: "stop"},
+		{Method: http.MethodPost, Target: "/stop", Origin: "https://evil.example", StatusCode: http.StatusForbidden},
+		{Method: http.MethodPost, Target: "/stop", Origin: "null", StatusCode: http.StatusForbidden},
+		{Method: http.MethodPost, Target: "/status", StatusCode: http.StatusMethodNotAllowed},
+	} {
+		t.Run(tc.Method+" "+tc.Target+" "+tc.Origin, func(t *testing.T) {
+			timer := &fakeTimer{}
+			r := httptest.NewRequest(tc.Method, "http://127.0.0.1:8787"+tc.Target, nil)
+			if tc.Origin != "" {
+				r.Header.Set("Origin", tc.Origin)
+			}
+			w := httptest.NewRecorder()
+			New(timer).Mux.ServeHTTP(w, r)
+			if w.Code != tc.StatusCode {
+				t.Fatalf("expected the status %d, got %d: %s", tc.StatusCode, w.Code, w.Body)
+			}
+			var expectedCalls []string
+			if tc.Call != "" {
+				expectedCalls = []string{tc.Call}
+			}
+			if !slices.Equal(timer.calls, expectedCalls) {
+				t.Fatalf("expected the calls %v, got %v", expectedCalls, timer.calls)
+			}
+		})
+	}
+}
+
+func TestAllowedHosts(t *testing.T) {
+	for _, tc := range []struct {
+		Host       string
+		StatusCode int
+	}{
+		{Host: "127.0.0.1:8787", StatusCode: http.StatusOK},
+		{Host: "[::1]:8787", StatusCode: http.StatusOK},
+		{Host: "192.168.1.10:8787", StatusCode: http.StatusOK},
+		{Host: "localhost:8787", StatusCode: http.StatusOK},
+		{Host: "LOCALHOST.:8787", StatusCode: http.StatusOK},
+		{Host: "pomodoro.localhost", StatusCode: http.StatusOK},
+		{Host: "desktop.lan:8787", StatusCode: http.StatusOK},
+		{Host: "evil.example:8787", StatusCode: http.StatusForbidden},
+		{Host: "evil.example", StatusCode: http.StatusForbidden},
+		{Host: "localhost.evil.example", StatusCode: http.StatusForbidden},
+	} {
+		t.Run(tc.Host, func(t *testing.T) {
+			timer := &fakeTimer{}
+			s := New(timer)
+			s.AllowedHosts = []string{"desktop.lan:8787"}
+			r := httptest.NewRequest(http.MethodPost, "/stop", nil)
+			r.Host = tc.Host
+			w := httptest.NewRecorder()
+			s.httpServer.Handler.ServeHTTP(w, r)
+			if w.Code != tc.StatusCode {
+				t.Fatalf("expected the status %d, got %d: %s", tc.StatusCode, w.Code, w.Body)
+			}
+			if called := len(timer.calls) != 0; called != (tc.StatusCode == http.StatusOK) {
+				t.Fatalf("unexpected calls %v", timer.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package httpapi
+
+import (
+	_ "embed"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+//go:embed resources/spectator.html
+var spectatorPage []byte
+
+// NewSpectator creates a read-only server for the spectators (like
+// the participants of a workshop): the live timer page on "/", the status
+// and the updates via WebSocket, but none of the actions.
+func NewSpectator(timer Timer) *Server {
+	s := newServer(timer)
+	s.spectator = true
+	s.Mux.HandleFunc("/{$}", handleSpectator)
+	s.Mux.HandleFunc("/status", s.handleStatus)
+	s.Mux.HandleFunc("/ws", s.handleWebSocket)
+	return s
+}
+
+func handleSpectator(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(spectatorPage)
+}
+
+// SpectatorURL returns the URL of the live timer page served on
+// the address, if it listens on all the interfaces, the address of
+// the first non-loopback interface is used (to be opened from the other
+// devices of the local network).
+func SpectatorURL(addr net.Addr) string {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return "http://" + addr.String() + "/"
+	}
+	ip := tcpAddr.IP
+	if ip.IsUnspecified() {
+		ip = localIP()
+	}
+	return fmt.Sprintf("http://%s/", net.JoinHostPort(ip.String(), fmt.Sprint(tcpAddr.Port)))
+}
+
+func localIP() net.IP {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return net.IPv4(127, 0, 0, 1)
+	}
+	var fallback net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		if ip := ipNet.IP.To4(); ip != nil {
+			return ip
+		}
+		if fallback == nil {
+			fallback = ipNet.IP
+		}
+	}
+	if fallback != nil {
+		return fallback
+	}
+	return net.IPv4(127, 0, 0, 1)
+}
@@ -0,0 +1,68 @@
+package httpapi
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	websocketWriteTimeout = 5 * time.Second
+)
+
+type UpdateMessage struct {
+	Event string `json:"event,omitempty"`
+	pomodoro.StatusJSON
+}
+
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		slog.Error("unable to upgrade the connection to WebSocket", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	events := make(chan pomodoro.Event, 16)
+	unsubscribe := s.Timer.OnEvent(func(ev pomodoro.Event) {
+		select {
+		case events <- ev:
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(eventType string) error {
+		conn.SetWriteDeadline(time.Now().Add(websocketWriteTimeout))
+		return conn.WriteJSON(UpdateMessage{
+			Event:      eventType,
+			StatusJSON: s.status(),
+		})
+	}
+
+	if err := send(""); err != nil {
+		return
+	}
+	for {
+		select {
+		case <-closed:
+			return
+		case ev := <-events:
+			if err := send(ev.Type.String()); err != nil {
+				return
+			}
+		}
+	}
+}
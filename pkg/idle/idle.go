@@ -0,0 +1,33 @@
+package idle
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Detector reports how long the user has not touched the keyboard or mouse.
+type Detector interface {
+	IdleTime() (time.Duration, error)
+}
+
+type DetectorFunc func() (time.Duration, error)
+
+func (fn DetectorFunc) IdleTime() (time.Duration, error) {
+	return fn()
+}
+
+// Chain tries the detectors in order and returns the result of the first one that works.
+type Chain []Detector
+
+func (c Chain) IdleTime() (time.Duration, error) {
+	var errs []error
+	for _, d := range c {
+		idleTime, err := d.IdleTime()
+		if err == nil {
+			return idleTime, nil
+		}
+		errs = append(errs, err)
+	}
+	return 0, fmt.Errorf("no idle detector succeeded: %w", errors.Join(errs...))
+}
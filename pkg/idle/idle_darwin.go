@@ -0,0 +1,31 @@
+package idle
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var hidIdleTimeRegexp = regexp.MustCompile(`"HIDIdleTime" = (\d+)`)
+
+func NewDefaultDetector() Detector {
+	return DetectorFunc(ioregIdleTime)
+}
+
+func ioregIdleTime() (time.Duration, error) {
+	output, err := exec.Command("ioreg", "-c", "IOHIDSystem", "-d", "4").Output()
+	if err != nil {
+		return 0, fmt.Errorf("unable to execute ioreg: %w", err)
+	}
+	match := hidIdleTimeRegexp.FindSubmatch(output)
+	if match == nil {
+		return 0, fmt.Errorf("HIDIdleTime is not found in the output of ioreg")
+	}
+	idleNS, err := strconv.ParseUint(string(match[1]), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse HIDIdleTime '%s': %w", match[1], err)
+	}
+	return time.Duration(idleNS), nil
+}
@@ -0,0 +1,61 @@
+package idle
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func NewDefaultDetector() Detector {
+	return Chain{
+		DetectorFunc(mutterIdleTime),
+		DetectorFunc(screenSaverIdleTime),
+		DetectorFunc(xprintidleIdleTime),
+	}
+}
+
+func mutterIdleTime() (time.Duration, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return 0, fmt.Errorf("unable to connect to the session bus: %w", err)
+	}
+	var idleMS uint64
+	err = conn.Object("org.gnome.Mutter.IdleMonitor", "/org/gnome/Mutter/IdleMonitor/Core").
+		Call("org.gnome.Mutter.IdleMonitor.GetIdletime", 0).
+		Store(&idleMS)
+	if err != nil {
+		return 0, fmt.Errorf("unable to query the GNOME idle monitor: %w", err)
+	}
+	return time.Duration(idleMS) * time.Millisecond, nil
+}
+
+func screenSaverIdleTime() (time.Duration, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return 0, fmt.Errorf("unable to connect to the session bus: %w", err)
+	}
+	var idleMS uint32
+	err = conn.Object("org.freedesktop.ScreenSaver", "/org/freedesktop/ScreenSaver").
+		Call("org.freedesktop.ScreenSaver.GetSessionIdleTime", 0).
+		Store(&idleMS)
+	if err != nil {
+		return 0, fmt.Errorf("unable to query the freedesktop screensaver: %w", err)
+	}
+	return time.Duration(idleMS) * time.Millisecond, nil
+}
+
+func xprintidleIdleTime() (time.Duration, error) {
+	output, err := exec.Command("xprintidle").Output()
+	if err != nil {
+		return 0, fmt.Errorf("unable to execute xprintidle: %w", err)
+	}
+	idleMS, err := strconv.ParseUint(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse the output of xprintidle '%s': %w", output, err)
+	}
+	return time.Duration(idleMS) * time.Millisecond, nil
+}
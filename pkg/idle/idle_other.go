@@ -0,0 +1,15 @@
+//go:build !linux && !windows && !darwin
+
+package idle
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+func NewDefaultDetector() Detector {
+	return DetectorFunc(func() (time.Duration, error) {
+		return 0, fmt.Errorf("idle detection is not supported on %s", runtime.GOOS)
+	})
+}
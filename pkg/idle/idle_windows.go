@@ -0,0 +1,32 @@
+package idle
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var (
+	procGetLastInputInfo = syscall.NewLazyDLL("user32.dll").NewProc("GetLastInputInfo")
+	procGetTickCount     = syscall.NewLazyDLL("kernel32.dll").NewProc("GetTickCount")
+)
+
+type lastInputInfo struct {
+	CbSize uint32
+	DwTime uint32
+}
+
+func NewDefaultDetector() Detector {
+	return DetectorFunc(lastInputIdleTime)
+}
+
+func lastInputIdleTime() (time.Duration, error) {
+	info := lastInputInfo{CbSize: uint32(unsafe.Sizeof(lastInputInfo{}))}
+	r, _, err := procGetLastInputInfo.Call(uintptr(unsafe.Pointer(&info)))
+	if r == 0 {
+		return 0, fmt.Errorf("GetLastInputInfo failed: %w", err)
+	}
+	tickCount, _, _ := procGetTickCount.Call()
+	return time.Duration(uint32(tickCount)-info.DwTime) * time.Millisecond, nil
+}
@@ -0,0 +1,46 @@
+// Package jsonrpc serves the timer over JSON-RPC 2.0 on a pair of streams
+// (the standard input and output with '--rpc-stdio'), for the editor
+// plugins to control the sessions and to show the time left in the status
+// line. Like the terminal UI, it needs only the timer engine (see
+// pomodoro.New), so '--rpc-stdio' works without a display.
+//
+// The messages are newline-delimited: every request, response and
+// notification is a single line of JSON. Requests are processed in order.
+//
+// Methods (the result of all of them, except "version", is a Status):
+//
+//	version                           {"protocol": 1}, see ProtocolVersion
+//	status
+//	start      {"phase": "work"}      the phase is "work" (default) or "rest"
+//	pause
+//	resume
+//	toggle                            pause/resume, or start if stopped
+//	stop
+//	extend     {"seconds": 300}       negative to shorten
+//	set_task   {"task": "Write report #project-x"}
+//	subscribe                         start sending the "event" notifications
+//	unsubscribe
+//
+// Status is:
+//
+//	{
+//	  "phase": "work",                "work", "rest", "long_rest" or "stopwatch"
+//	  "running": true,
+//	  "paused": false,
+//	  "time_left_seconds": 1234,
+//	  "deadline": "2024-01-02T10:25:00Z",   only while running
+//	  "task": "Write report",         only for work
+//	  "completed_work_sessions": 3
+//	}
+//
+// After "subscribe", a notification is sent on every change of the timer
+// (and once a second while it runs):
+//
+//	{"jsonrpc": "2.0", "method": "event", "params": {"event": "tick", "status": {...}}}
+//
+// Errors use the standard JSON-RPC codes (-32700 for a malformed line,
+// -32601 for an unknown method, -32602 for invalid params).
+//
+// Incompatible changes of the protocol increase ProtocolVersion,
+// the new methods and fields are added without increasing it.
+package jsonrpc
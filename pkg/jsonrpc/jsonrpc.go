@@ -0,0 +1,248 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// ProtocolVersion is increased on every incompatible change
+// of the protocol (see the package documentation).
+const ProtocolVersion = 1
+
+const (
+	maxLineSize = 1 << 20
+)
+
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeServerError    = -32000
+)
+
+type Timer interface {
+	Start(isWork bool)
+	Pause()
+	Resume()
+	TogglePause()
+	StopTimer()
+	Extend(d time.Duration)
+	SetTask(task string)
+	Status() pomodoro.Status
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+}
+
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+type Notification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+type EventParams struct {
+	Event  string          `json:"event"`
+	Status pomodoro.Status `json:"status"`
+}
+
+// Server serves the requests of one client.
+type Server struct {
+	timer Timer
+
+	writeLocker sync.Mutex
+	encoder     *json.Encoder
+
+	unsubscribe func()
+}
+
+func New(timer Timer) *Server {
+	return &Server{
+		timer: timer,
+	}
+}
+
+// Serve processes the requests from r until it is closed, writing
+// the responses and the notifications to w.
+func (s *Server) Serve(
+	r io.Reader,
+	w io.Writer,
+) error {
+	s.encoder = json.NewEncoder(w)
+	defer s.unsubscribeEvents()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := s.handleLine(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read a request: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) handleLine(line []byte) error {
+	var req Request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return s.write(Response{
+			JSONRPC: "2.0",
+			ID:      json.RawMessage("null"),
+			Error:   &Error{Code: codeParseError, Message: err.Error()},
+		})
+	}
+	result, rpcErr := s.call(req)
+	if req.ID == nil {
+		// a notification from the client, no response is expected
+		return nil
+	}
+	resp := Response{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  result,
+		Error:   rpcErr,
+	}
+	return s.write(resp)
+}
+
+func (s *Server) call(req Request) (any, *Error) {
+	if req.JSONRPC != "2.0" {
+		return nil, &Error{Code: codeInvalidRequest, Message: "expected 'jsonrpc': '2.0'"}
+	}
+	switch req.Method {
+	case "version":
+		return map[string]int{"protocol": ProtocolVersion}, nil
+	case "status":
+	case "start":
+		var params struct {
+			Phase string `json:"phase"`
+		}
+		if err := parseParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		switch params.Phase {
+		case "", "work":
+			s.timer.Start(true)
+		case "rest":
+			s.timer.Start(false)
+		default:
+			return nil, &Error{Code: codeInvalidParams, Message: fmt.Sprintf("unknown phase '%s', expected 'work' or 'rest'", params.Phase)}
+		}
+	case "pause":
+		s.timer.Pause()
+	case "resume":
+		s.timer.Resume()
+	case "toggle":
+		s.timer.TogglePause()
+	case "stop":
+		s.timer.StopTimer()
+	case "extend":
+		var params struct {
+			Seconds int64 `json:"seconds"`
+		}
+		if err := parseParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		s.timer.Extend(time.Duration(params.Seconds) * time.Second)
+	case "set_task":
+		var params struct {
+			Task string `json:"task"`
+		}
+		if err := parseParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		s.timer.SetTask(params.Task)
+	case "subscribe":
+		s.subscribeEvents()
+	case "unsubscribe":
+		s.unsubscribeEvents()
+	default:
+		return nil, &Error{Code: codeMethodNotFound, Message: fmt.Sprintf("unknown method '%s'", req.Method)}
+	}
+	return s.timer.Status(), nil
+}
+
+func parseParams(
+	raw json.RawMessage,
+	params any,
+) *Error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, params); err != nil {
+		return &Error{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) subscribeEvents() {
+	s.writeLocker.Lock()
+	defer s.writeLocker.Unlock()
+	if s.unsubscribe != nil {
+		return
+	}
+	s.unsubscribe = s.timer.OnEvent(func(ev pomodoro.Event) {
+		err := s.write(Notification{
+			JSONRPC: "2.0",
+			Method:  "event",
+			Params: EventParams{
+				Event:  ev.Type.String(),
+				Status: s.timer.Status(),
+			},
+		})
+		if err != nil {
+			s.unsubscribeEvents()
+		}
+	})
+}
+
+func (s *Server) unsubscribeEvents() {
+	s.writeLocker.Lock()
+	unsubscribe := s.unsubscribe
+	s.unsubscribe = nil
+	s.writeLocker.Unlock()
+	if unsubscribe != nil {
+		unsubscribe()
+	}
+}
+
+func (s *Server) write(msg any) error {
+	s.writeLocker.Lock()
+	defer s.writeLocker.Unlock()
+	if err := s.encoder.Encode(msg); err != nil {
+		return fmt.Errorf("unable to write the message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Package l10n translates the UI strings. The English text is used
+// as the message ID, so untranslated messages are shown in English.
+package l10n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jeandeaual/go-locale"
+	"golang.org/x/text/language"
+)
+
+const (
+	DefaultLanguage = "en"
+)
+
+//go:embed translations/*.json
+var translations embed.FS
+
+var (
+	locker          sync.RWMutex
+	currentLanguage = DefaultLanguage
+	currentMessages map[string]string
+)
+
+// Languages returns the codes of the available translations.
+func Languages() []string {
+	entries, err := translations.ReadDir("translations")
+	if err != nil {
+		return []string{DefaultLanguage}
+	}
+	var languages []string
+	for _, entry := range entries {
+		languages = append(languages, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// systemLocale returns the locale of the OS, English if it is unknown.
+func systemLocale() language.Tag {
+	loc, err := locale.GetLocale()
+	if err != nil || loc == "" {
+		return language.English
+	}
+	tag, err := language.Parse(loc)
+	if err != nil {
+		return language.English
+	}
+	return tag
+}
+
+// SystemLanguage returns the language of the OS (which may have no translation).
+func SystemLanguage() string {
+	base, _ := systemLocale().Base()
+	return strings.ToLower(base.String())
+}
+
+// SetLanguage switches the translation, an empty value means the language
+// of the OS. Unknown languages fall back to English (an error is returned
+// only if the language was requested explicitly).
+func SetLanguage(language string) error {
+	isExplicit := language != ""
+	if !isExplicit {
+		language = SystemLanguage()
+	}
+	messages, err := loadMessages(language)
+	if err != nil {
+		messages = nil
+		language = DefaultLanguage
+		if !isExplicit {
+			err = nil
+		}
+	}
+
+	locker.Lock()
+	defer locker.Unlock()
+	currentLanguage = language
+	currentMessages = messages
+	return err
+}
+
+func Language() string {
+	locker.RLock()
+	defer locker.RUnlock()
+	return currentLanguage
+}
+
+func loadMessages(language string) (map[string]string, error) {
+	fileName := path.Join("translations", language+".json")
+	b, err := translations.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("no translation for language '%s': %w", language, err)
+	}
+	var messages map[string]string
+	if err := json.Unmarshal(b, &messages); err != nil {
+		return nil, fmt.Errorf("unable to parse '%s': %w", fileName, err)
+	}
+	return messages, nil
+}
+
+// T translates the message; if args are given the translated message
+// is used as a format string for them.
+func T(message string, args ...any) string {
+	locker.RLock()
+	translated, ok := currentMessages[message]
+	locker.RUnlock()
+	if !ok || translated == "" {
+		translated = message
+	}
+	if len(args) == 0 {
+		return translated
+	}
+	return fmt.Sprintf(translated, args...)
+}
+
+// twelveHourRegions are the regions where the 12-hour clock is
+// customary (see ClockLayout).
+var twelveHourRegions = map[string]struct{}{
+	"US": {}, "CA": {}, "AU": {}, "NZ": {}, "IN": {}, "PH": {},
+	"PK": {}, "BD": {}, "EG": {}, "SA": {}, "MY": {}, "CO": {},
+}
+
+// ClockLayout returns the time.Format layout of the wall clock time
+// customary for the OS locale: "3:04 PM" or "15:04".
+var ClockLayout = sync.OnceValue(func() string {
+	// the region is guessed if the locale has none (like "US" for "en")
+	region, _ := systemLocale().Region()
+	if _, ok := twelveHourRegions[region.String()]; ok {
+		return "3:04 PM"
+	}
+	return "15:04"
+})
+
+// FormatClock formats the wall clock time of t (see ClockLayout).
+func FormatClock(t time.Time) string {
+	return t.Format(ClockLayout())
+}
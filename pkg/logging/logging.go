@@ -0,0 +1,107 @@
+// Package logging configures the structured logger (log/slog) of the
+// application and keeps the recent records for the in-app log viewer.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	recentLinesLimit = 1000
+)
+
+type Config struct {
+	Level slog.Level
+
+	// File is where to write the log to (in addition to the log viewer),
+	// empty means the standard error.
+	File string
+}
+
+var (
+	output = &switchableWriter{w: os.Stderr}
+	recent = &ringBuffer{limit: recentLinesLimit}
+)
+
+// ParseLevel parses the level name: "debug", "info", "warn" or "error".
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("unknown log level '%s', expected 'debug', 'info', 'warn' or 'error': %w", s, err)
+	}
+	return level, nil
+}
+
+// Init makes the configured logger the default one (including
+// for the standard package "log"). The returned Closer closes the file.
+func Init(config Config) (io.Closer, error) {
+	var closer io.Closer = io.NopCloser(nil)
+	if config.File != "" {
+		f, err := os.OpenFile(config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open the log file '%s': %w", config.File, err)
+		}
+		SetOutput(f)
+		closer = f
+	}
+	handler := slog.NewTextHandler(io.MultiWriter(output, recent), &slog.HandlerOptions{
+		Level: config.Level,
+	})
+	slog.SetDefault(slog.New(handler))
+	return closer, nil
+}
+
+// SetOutput redirects the log (but not the log viewer), it returns
+// the previous output.
+func SetOutput(w io.Writer) io.Writer {
+	output.locker.Lock()
+	defer output.locker.Unlock()
+	prev := output.w
+	output.w = w
+	return prev
+}
+
+// Recent returns the last lines of the log.
+func Recent() []string {
+	return recent.lines()
+}
+
+type switchableWriter struct {
+	locker sync.Mutex
+	w      io.Writer
+}
+
+func (w *switchableWriter) Write(p []byte) (int, error) {
+	w.locker.Lock()
+	defer w.locker.Unlock()
+	return w.w.Write(p)
+}
+
+type ringBuffer struct {
+	locker sync.Mutex
+	limit  int
+	buf    []string
+}
+
+func (b *ringBuffer) Write(p []byte) (int, error) {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		b.buf = append(b.buf, line)
+	}
+	if extra := len(b.buf) - b.limit; extra > 0 {
+		b.buf = append(b.buf[:0], b.buf[extra:]...)
+	}
+	return len(p), nil
+}
+
+func (b *ringBuffer) lines() []string {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+	return append([]string{}, b.buf...)
+}
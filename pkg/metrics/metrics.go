@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	namespace = "pomodoro"
+)
+
+type Timer interface {
+	Status() pomodoro.Status
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+}
+
+// Exporter exposes the timer statistics in the Prometheus format.
+type Exporter struct {
+	Registry *prometheus.Registry
+
+	completedSessions *prometheus.CounterVec
+	abortedSessions   *prometheus.CounterVec
+	focusSeconds      prometheus.Counter
+	unsubscribe       func()
+}
+
+func New(timer Timer) *Exporter {
+	e := &Exporter{
+		Registry: prometheus.NewRegistry(),
+		completedSessions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "completed_sessions_total",
+			Help:      "The amount of phases which ran until the end.",
+		}, []string{"phase"}),
+		abortedSessions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "aborted_sessions_total",
+			Help:      "The amount of phases which were stopped before the end.",
+		}, []string{"phase"}),
+		focusSeconds: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "focus_seconds_total",
+			Help:      "The total time spent in work phases (including the stopwatch).",
+		}),
+	}
+	timeLeft := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "time_left_seconds",
+		Help:      "The time left until the end of the current phase (as it is shown).",
+	}, func() float64 {
+		return timer.Status().DisplayedTimeLeft.Seconds()
+	})
+	running := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "running",
+		Help:      "1 if the timer is running, 0 otherwise.",
+	}, func() float64 {
+		if timer.Status().IsRunning {
+			return 1
+		}
+		return 0
+	})
+	e.Registry.MustRegister(
+		e.completedSessions,
+		e.abortedSessions,
+		e.focusSeconds,
+		timeLeft,
+		running,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	e.unsubscribe = timer.OnEvent(e.onEvent)
+	return e
+}
+
+func (e *Exporter) onEvent(ev pomodoro.Event) {
+	if ev.Restored {
+		return
+	}
+	switch ev.Type {
+	case pomodoro.EventTypePhaseEnded:
+		e.completedSessions.WithLabelValues(ev.Phase.String()).Inc()
+	case pomodoro.EventTypeStopped:
+		e.abortedSessions.WithLabelValues(ev.Phase.String()).Inc()
+	default:
+		return
+	}
+	if ev.Phase == pomodoro.PhaseWork || ev.Phase == pomodoro.PhaseStopwatch {
+		e.focusSeconds.Add(ev.Elapsed.Seconds())
+	}
+}
+
+func (e *Exporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.Registry, promhttp.HandlerOpts{})
+}
+
+func (e *Exporter) Close() {
+	e.unsubscribe()
+}
@@ -0,0 +1,295 @@
+// Package mpris publishes the timer as an MPRIS media player, so that
+// the media applets of the desktop environments (GNOME Shell, KDE Plasma,
+// Waybar, etc) show it and control it with their play/pause buttons.
+//
+// See https://specifications.freedesktop.org/mpris-spec/latest/
+package mpris
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/godbus/dbus/v5/prop"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	ServiceName     = "org.mpris.MediaPlayer2.pomodoro"
+	ObjectPath      = dbus.ObjectPath("/org/mpris/MediaPlayer2")
+	RootInterface   = "org.mpris.MediaPlayer2"
+	PlayerInterface = "org.mpris.MediaPlayer2.Player"
+
+	identity = "Pomodoro"
+)
+
+type PlaybackStatus string
+
+const (
+	PlaybackStatusPlaying = PlaybackStatus("Playing")
+	PlaybackStatusPaused  = PlaybackStatus("Paused")
+	PlaybackStatusStopped = PlaybackStatus("Stopped")
+)
+
+type Timer interface {
+	Start(isWork bool)
+	TogglePause()
+	Pause()
+	Resume()
+	StopTimer()
+	Status() pomodoro.Status
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+}
+
+// Player publishes the timer on the session bus: the phase is the title
+// of the "track", the elapsed time is the position in it.
+type Player struct {
+	conn        *dbus.Conn
+	timer       Timer
+	props       *prop.Properties
+	unsubscribe func()
+}
+
+func New(timer Timer) (*Player, error) {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the session bus: %w", err)
+	}
+	p := &Player{
+		conn:  conn,
+		timer: timer,
+	}
+	if err := p.init(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Player) init() error {
+	if err := p.conn.Export(rootMethods{}, ObjectPath, RootInterface); err != nil {
+		return fmt.Errorf("unable to export the %s methods: %w", RootInterface, err)
+	}
+	player := playerMethods{timer: p.timer}
+	if err := p.conn.ExportWithMap(player, playerMethodNames, ObjectPath, PlayerInterface); err != nil {
+		return fmt.Errorf("unable to export the %s methods: %w", PlayerInterface, err)
+	}
+
+	status := p.timer.Status()
+	props, err := prop.Export(p.conn, ObjectPath, prop.Map{
+		RootInterface: {
+			"Identity":            {Value: identity, Emit: prop.EmitConst},
+			"CanQuit":             {Value: false, Emit: prop.EmitConst},
+			"CanRaise":            {Value: false, Emit: prop.EmitConst},
+			"HasTrackList":        {Value: false, Emit: prop.EmitConst},
+			"SupportedUriSchemes": {Value: []string{}, Emit: prop.EmitConst},
+			"SupportedMimeTypes":  {Value: []string{}, Emit: prop.EmitConst},
+		},
+		PlayerInterface: {
+			"PlaybackStatus": {Value: string(playbackStatus(status)), Emit: prop.EmitTrue},
+			"Metadata":       {Value: metadata(status), Emit: prop.EmitTrue},
+			"Position":       {Value: position(status), Emit: prop.EmitFalse},
+			"Rate":           {Value: 1.0, Emit: prop.EmitConst},
+			"MinimumRate":    {Value: 1.0, Emit: prop.EmitConst},
+			"MaximumRate":    {Value: 1.0, Emit: prop.EmitConst},
+			"CanGoNext":      {Value: true, Emit: prop.EmitConst},
+			"CanGoPrevious":  {Value: false, Emit: prop.EmitConst},
+			"CanPlay":        {Value: true, Emit: prop.EmitConst},
+			"CanPause":       {Value: true, Emit: prop.EmitConst},
+			"CanSeek":        {Value: false, Emit: prop.EmitConst},
+			"CanControl":     {Value: true, Emit: prop.EmitConst},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to export the properties: %w", err)
+	}
+	p.props = props
+
+	node := &introspect.Node{
+		Name: string(ObjectPath),
+		Interfaces: []introspect.Interface{
+			introspect.IntrospectData,
+			prop.IntrospectData,
+			{
+				Name:       RootInterface,
+				Methods:    introspect.Methods(rootMethods{}),
+				Properties: props.Introspection(RootInterface),
+			},
+			{
+				Name:       PlayerInterface,
+				Methods:    renameMethods(introspect.Methods(player), playerMethodNames),
+				Properties: props.Introspection(PlayerInterface),
+				Signals: []introspect.Signal{{
+					Name: "Seeked",
+					Args: []introspect.Arg{{Name: "Position", Type: "x"}},
+				}},
+			},
+		},
+	}
+	if err := p.conn.Export(introspect.NewIntrospectable(node), ObjectPath, "org.freedesktop.DBus.Introspectable"); err != nil {
+		return fmt.Errorf("unable to export the introspection data: %w", err)
+	}
+
+	reply, err := p.conn.RequestName(ServiceName, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		return fmt.Errorf("unable to request name '%s': %w", ServiceName, err)
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		return fmt.Errorf("name '%s' is already taken", ServiceName)
+	}
+
+	p.unsubscribe = p.timer.OnEvent(p.onEvent)
+	return nil
+}
+
+func playbackStatus(status pomodoro.Status) PlaybackStatus {
+	switch {
+	case status.IsPaused:
+		return PlaybackStatusPaused
+	case status.IsRunning:
+		return PlaybackStatusPlaying
+	default:
+		return PlaybackStatusStopped
+	}
+}
+
+func phaseTitle(phase pomodoro.Phase) string {
+	switch phase {
+	case pomodoro.PhaseWork:
+		return l10n.T("Work")
+	case pomodoro.PhaseRest:
+		return l10n.T("Rest")
+	case pomodoro.PhaseLongRest:
+		return l10n.T("Long rest")
+	case pomodoro.PhaseStopwatch:
+		return l10n.T("Stopwatch")
+	default:
+		return identity
+	}
+}
+
+func microseconds(d time.Duration) int64 {
+	return int64(d / time.Microsecond)
+}
+
+func position(status pomodoro.Status) int64 {
+	return microseconds(status.Elapsed)
+}
+
+func metadata(status pomodoro.Status) map[string]dbus.Variant {
+	m := map[string]dbus.Variant{
+		"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/center/dx/pomodoro/phase/" + status.Phase.String())),
+		"xesam:title":   dbus.MakeVariant(phaseTitle(status.Phase)),
+		"xesam:artist":  dbus.MakeVariant([]string{identity}),
+	}
+	if status.Phase != pomodoro.PhaseStopwatch {
+		m["mpris:length"] = dbus.MakeVariant(microseconds(status.Elapsed + status.TimeLeft))
+	}
+	return m
+}
+
+func (p *Player) onEvent(ev pomodoro.Event) {
+	status := p.timer.Status()
+	p.props.SetMust(PlayerInterface, "Position", position(status))
+	switch ev.Type {
+	case pomodoro.EventTypeTick:
+		// the position is not supposed to be signalled
+		return
+	case pomodoro.EventTypeWokeUp:
+		if err := p.conn.Emit(ObjectPath, PlayerInterface+".Seeked", position(status)); err != nil {
+			slog.Error("unable to emit the Seeked signal", "error", err)
+		}
+	}
+	p.props.SetMust(PlayerInterface, "PlaybackStatus", string(playbackStatus(status)))
+	p.props.SetMust(PlayerInterface, "Metadata", metadata(status))
+}
+
+func (p *Player) Close() error {
+	if p.unsubscribe != nil {
+		p.unsubscribe()
+	}
+	return p.conn.Close()
+}
+
+type rootMethods struct{}
+
+func (rootMethods) Raise() *dbus.Error {
+	return nil
+}
+
+func (rootMethods) Quit() *dbus.Error {
+	return nil
+}
+
+type playerMethods struct {
+	timer Timer
+}
+
+// playerMethodNames maps the methods which cannot be named as in
+// the specification (because of the conventions of Go).
+var playerMethodNames = map[string]string{
+	"SeekBy": "Seek",
+}
+
+func renameMethods(
+	methods []introspect.Method,
+	names map[string]string,
+) []introspect.Method {
+	for idx := range methods {
+		if name, ok := names[methods[idx].Name]; ok {
+			methods[idx].Name = name
+		}
+	}
+	return methods
+}
+
+// Next starts the next phase: a break after the work and vice versa.
+func (m playerMethods) Next() *dbus.Error {
+	m.timer.Start(m.timer.Status().Phase != pomodoro.PhaseWork)
+	return nil
+}
+
+func (m playerMethods) Previous() *dbus.Error {
+	return nil
+}
+
+func (m playerMethods) Pause() *dbus.Error {
+	m.timer.Pause()
+	return nil
+}
+
+func (m playerMethods) PlayPause() *dbus.Error {
+	m.timer.TogglePause()
+	return nil
+}
+
+func (m playerMethods) Stop() *dbus.Error {
+	m.timer.StopTimer()
+	return nil
+}
+
+func (m playerMethods) Play() *dbus.Error {
+	status := m.timer.Status()
+	switch {
+	case status.IsPaused:
+		m.timer.Resume()
+	case !status.IsRunning:
+		m.timer.TogglePause()
+	}
+	return nil
+}
+
+func (m playerMethods) SeekBy(offset int64) *dbus.Error {
+	return nil
+}
+
+func (m playerMethods) SetPosition(trackID dbus.ObjectPath, position int64) *dbus.Error {
+	return nil
+}
+
+func (m playerMethods) OpenUri(uri string) *dbus.Error {
+	return nil
+}
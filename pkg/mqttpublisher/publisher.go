@@ -0,0 +1,116 @@
+package mqttpublisher
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	publishTimeout = 5 * time.Second
+)
+
+type Config struct {
+	BrokerURL   string
+	ClientID    string
+	Username    string
+	Password    string
+	TopicPrefix string
+}
+
+type Timer interface {
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+}
+
+// Publisher publishes timer events to an MQTT broker:
+//
+//	<prefix>/event     -- the type of every non-tick event
+//	<prefix>/phase     -- the current phase (retained)
+//	<prefix>/state     -- running/paused/stopped (retained)
+//	<prefix>/remaining -- the remaining time in seconds (retained)
+type Publisher struct {
+	config      Config
+	client      mqtt.Client
+	unsubscribe func()
+}
+
+func New(
+	config Config,
+	timer Timer,
+) (*Publisher, error) {
+	if config.ClientID == "" {
+		hostname, _ := os.Hostname()
+		config.ClientID = fmt.Sprintf("pomodoro-%s-%d", hostname, os.Getpid())
+	}
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(config.BrokerURL).
+		SetClientID(config.ClientID).
+		SetUsername(config.Username).
+		SetPassword(config.Password).
+		SetAutoReconnect(true)
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(publishTimeout) {
+		return nil, fmt.Errorf("timed out connecting to '%s'", config.BrokerURL)
+	}
+	if err := token.Error(); err != nil {
+		return nil, fmt.Errorf("unable to connect to '%s': %w", config.BrokerURL, err)
+	}
+
+	p := &Publisher{
+		config: config,
+		client: client,
+	}
+	p.unsubscribe = timer.OnEvent(p.onEvent)
+	return p, nil
+}
+
+func (p *Publisher) onEvent(ev pomodoro.Event) {
+	p.publish("remaining", true, strconv.FormatInt(int64(ev.DisplayedTimeLeft.Seconds()), 10))
+	switch ev.Type {
+	case pomodoro.EventTypeTick:
+		return
+	case pomodoro.EventTypePhaseStarted, pomodoro.EventTypeResumed:
+		p.publish("state", true, "running")
+	case pomodoro.EventTypePaused:
+		p.publish("state", true, "paused")
+	case pomodoro.EventTypePhaseEnded, pomodoro.EventTypeStopped:
+		p.publish("state", true, "stopped")
+	}
+	p.publish("phase", true, ev.Phase.String())
+	if ev.Restored {
+		// the state is updated, but it is not a new transition
+		return
+	}
+	p.publish("event", false, ev.Type.String())
+}
+
+func (p *Publisher) publish(
+	subTopic string,
+	retained bool,
+	payload string,
+) {
+	topic := p.config.TopicPrefix + "/" + subTopic
+	token := p.client.Publish(topic, 0, retained, payload)
+	go func() {
+		if !token.WaitTimeout(publishTimeout) {
+			slog.Error("timed out publishing to MQTT", "topic", topic)
+			return
+		}
+		if err := token.Error(); err != nil {
+			slog.Error("unable to publish to MQTT", "topic", topic, "error", err)
+		}
+	}()
+}
+
+func (p *Publisher) Close() error {
+	p.unsubscribe()
+	p.client.Disconnect(uint(publishTimeout / time.Millisecond))
+	return nil
+}
@@ -0,0 +1,23 @@
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Bell rings the terminal bell and prints the notification.
+type Bell struct {
+	Writer io.Writer
+}
+
+func NewBell() Bell {
+	return Bell{Writer: os.Stderr}
+}
+
+func (b Bell) Notify(n Notification) error {
+	if _, err := fmt.Fprintf(b.Writer, "\a%s: %s\n", n.Title, n.Body); err != nil {
+		return fmt.Errorf("unable to ring the bell: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Libnotify shows the notification via 'notify-send'.
+type Libnotify struct{}
+
+func (Libnotify) Notify(n Notification) error {
+	return run(exec.Command("notify-send", "--app-name=Pomodoro", "--", n.Title, n.Body))
+}
+
+// MacOS shows the notification in the notification center via 'osascript'
+// (the texts are passed as the arguments to avoid escaping them).
+type MacOS struct{}
+
+func (MacOS) Notify(n Notification) error {
+	return run(exec.Command(
+		"osascript",
+		"-e", "on run argv",
+		"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+		"-e", "end run",
+		n.Title, n.Body,
+	))
+}
+
+const windowsToastScript = `$n = [Console]::In.ReadToEnd() | ConvertFrom-Json; ` +
+	`[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null; ` +
+	`[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null; ` +
+	`$t = [Windows.Data.Xml.Dom.XmlDocument]::new(); ` +
+	`$t.LoadXml($n.xml); ` +
+	`[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('Pomodoro').Show([Windows.UI.Notifications.ToastNotification]::new($t))`
+
+type toastXML struct {
+	XMLName xml.Name         `xml:"toast"`
+	Binding toastBindingXML  `xml:"visual>binding"`
+	Actions *toastActionsXML `xml:"actions"`
+}
+
+type toastBindingXML struct {
+	Template string   `xml:"template,attr"`
+	Texts    []string `xml:"text"`
+}
+
+type toastActionsXML struct {
+	Actions []toastActionXML `xml:"action"`
+}
+
+type toastActionXML struct {
+	Content        string `xml:"content,attr"`
+	ActivationType string `xml:"activationType,attr"`
+	Arguments      string `xml:"arguments,attr"`
+}
+
+// WindowsToast shows a toast through PowerShell; the toast XML is passed
+// via stdin to avoid escaping it. The actions are protocol-activated,
+// so the URL scheme should be registered (see pkg/urlscheme).
+type WindowsToast struct{}
+
+func (WindowsToast) Notify(n Notification) error {
+	toast := toastXML{
+		Binding: toastBindingXML{
+			Template: "ToastGeneric",
+			Texts:    []string{n.Title, n.Body},
+		},
+	}
+	if len(n.Actions) > 0 {
+		toast.Actions = &toastActionsXML{}
+	}
+	for _, action := range n.Actions {
+		toast.Actions.Actions = append(toast.Actions.Actions, toastActionXML{
+			Content:        action.Label,
+			ActivationType: "protocol",
+			Arguments:      action.URL,
+		})
+	}
+	toastBytes, err := xml.Marshal(toast)
+	if err != nil {
+		return fmt.Errorf("unable to serialize the toast: %w", err)
+	}
+	payload, err := json.Marshal(map[string]string{
+		"xml": string(toastBytes),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to serialize the notification: %w", err)
+	}
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", windowsToastScript)
+	cmd.Stdin = strings.NewReader(string(payload))
+	return run(cmd)
+}
+
+func run(cmd *exec.Cmd) error {
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to run '%s': %w (output: '%s')", cmd.Path, err, output)
+	}
+	return nil
+}
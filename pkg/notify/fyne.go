@@ -0,0 +1,19 @@
+package notify
+
+import (
+	"runtime"
+)
+
+// Native returns the backend using the native mechanism of the OS,
+// it replaces BackendNameFyne without the Fyne application
+// (see Config.Fyne).
+func Native() Backend {
+	switch runtime.GOOS {
+	case "windows":
+		return WindowsToast{}
+	case "darwin":
+		return MacOS{}
+	default:
+		return Libnotify{}
+	}
+}
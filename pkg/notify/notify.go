@@ -0,0 +1,123 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Notification struct {
+	Title string
+	Body  string
+
+	// Actions are shown as the buttons by the backends supporting them
+	// (see WindowsToast), the others ignore them.
+	Actions []Action
+}
+
+// Action is a button of a notification, pressing it opens the URL
+// (see pkg/urlscheme).
+type Action struct {
+	Label string
+	URL   string
+}
+
+// Backend shows a notification to the user.
+type Backend interface {
+	Notify(n Notification) error
+}
+
+type BackendFunc func(n Notification) error
+
+func (fn BackendFunc) Notify(n Notification) error {
+	return fn(n)
+}
+
+// Multi sends the notification through all the backends.
+type Multi []Backend
+
+func (m Multi) Notify(n Notification) error {
+	var errs []error
+	for _, b := range m {
+		if err := b.Notify(n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type BackendName string
+
+const (
+	BackendNameFyne         = BackendName("fyne")
+	BackendNameLibnotify    = BackendName("libnotify")
+	BackendNameWindowsToast = BackendName("windows-toast")
+	BackendNameMacOS        = BackendName("macos")
+	BackendNameBell         = BackendName("bell")
+	BackendNameWebhook      = BackendName("webhook")
+)
+
+var BackendNames = []BackendName{
+	BackendNameFyne,
+	BackendNameLibnotify,
+	BackendNameWindowsToast,
+	BackendNameMacOS,
+	BackendNameBell,
+	BackendNameWebhook,
+}
+
+// Config contains the parameters of the backends which need them.
+type Config struct {
+	// Fyne sends the notifications through the Fyne application (which
+	// uses the native mechanism of the OS), Native is used if it is nil.
+	Fyne       Backend
+	WebhookURL string
+}
+
+func New(
+	name BackendName,
+	config Config,
+) (Backend, error) {
+	switch name {
+	case BackendNameFyne:
+		if config.Fyne == nil {
+			return Native(), nil
+		}
+		return config.Fyne, nil
+	case BackendNameLibnotify:
+		return Libnotify{}, nil
+	case BackendNameWindowsToast:
+		return WindowsToast{}, nil
+	case BackendNameMacOS:
+		return MacOS{}, nil
+	case BackendNameBell:
+		return NewBell(), nil
+	case BackendNameWebhook:
+		if config.WebhookURL == "" {
+			return nil, fmt.Errorf("the webhook URL is not set")
+		}
+		return NewWebhook(config.WebhookURL), nil
+	default:
+		return nil, fmt.Errorf("unknown notification backend '%s'", name)
+	}
+}
+
+// NewMulti creates the backends by their names, skipping (and reporting)
+// the ones which could not be created.
+func NewMulti(
+	names []BackendName,
+	config Config,
+) (Multi, error) {
+	var (
+		m    Multi
+		errs []error
+	)
+	for _, name := range names {
+		b, err := New(name, config)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		m = append(m, b)
+	}
+	return m, errors.Join(errs...)
+}
@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Webhook POSTs the notification as JSON ({"title": ..., "body": ...}).
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhook(url string) Webhook {
+	return Webhook{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (b Webhook) Notify(n Notification) error {
+	payload, err := json.Marshal(map[string]string{
+		"title": n.Title,
+		"body":  n.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to serialize the notification: %w", err)
+	}
+	resp, err := b.Client.Post(b.URL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("unable to POST the notification to '%s': %w", b.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("the webhook '%s' responded with status %d", b.URL, resp.StatusCode)
+	}
+	return nil
+}
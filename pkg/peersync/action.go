@@ -0,0 +1,65 @@
+package peersync
+
+import (
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+type ActionKind string
+
+const (
+	ActionKindStartWork      = ActionKind("start_work")
+	ActionKindStartRest      = ActionKind("start_rest")
+	ActionKindStartStopwatch = ActionKind("start_stopwatch")
+	ActionKindPause          = ActionKind("pause")
+	ActionKindResume         = ActionKind("resume")
+	ActionKindStop           = ActionKind("stop")
+)
+
+// Action is a user action on one of the devices, it is mirrored
+// on the others.
+type Action struct {
+	Origin   string        `json:"origin"`
+	At       time.Time     `json:"at"`
+	Kind     ActionKind    `json:"kind"`
+	Deadline time.Time     `json:"deadline,omitempty"`
+	TimeLeft time.Duration `json:"time_left,omitempty"`
+}
+
+// newerThan defines the conflict resolution: the latest action wins
+// (the ties are broken by the origin to be resolved the same way
+// on all the devices).
+func (a Action) newerThan(b Action) bool {
+	if !a.At.Equal(b.At) {
+		return a.At.After(b.At)
+	}
+	return a.Origin > b.Origin
+}
+
+// actionKindFromEvent returns the action that caused the event, or an empty
+// string if the event should not be mirrored (ticks, phases ending by
+// themselves, the states restored by Undo, etc).
+func actionKindFromEvent(ev pomodoro.Event) ActionKind {
+	if ev.Restored {
+		return ""
+	}
+	switch ev.Type {
+	case pomodoro.EventTypePhaseStarted:
+		switch ev.Phase {
+		case pomodoro.PhaseWork:
+			return ActionKindStartWork
+		case pomodoro.PhaseRest, pomodoro.PhaseLongRest:
+			return ActionKindStartRest
+		case pomodoro.PhaseStopwatch:
+			return ActionKindStartStopwatch
+		}
+	case pomodoro.EventTypePaused:
+		return ActionKindPause
+	case pomodoro.EventTypeResumed:
+		return ActionKindResume
+	case pomodoro.EventTypeStopped:
+		return ActionKindStop
+	}
+	return ""
+}
@@ -0,0 +1,329 @@
+package peersync
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	syncPath       = "/sync"
+	writeTimeout   = 5 * time.Second
+	reconnectDelay = 5 * time.Second
+
+	// echoTimeout is how long an event caused by applying a remote action
+	// is expected to arrive (such events are not sent back to the peers).
+	echoTimeout = 5 * time.Second
+)
+
+type Config struct {
+	// ListenAddr is the address to accept the peers on (for example ':8788'),
+	// empty to only connect to the peers.
+	ListenAddr string
+
+	// Peers are the WebSocket URLs of the other devices,
+	// like 'ws://laptop.local:8788/sync'.
+	Peers []string
+
+	// Secret is required from the peers if non-empty (and sent to them).
+	Secret string
+}
+
+type Timer interface {
+	Start(isWork bool)
+	StartStopwatch()
+	Pause()
+	Resume()
+	StopTimer()
+	Extend(d time.Duration)
+	Status() pomodoro.Status
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+}
+
+// Syncer mirrors the starts, pauses, resumes and stops of the timer between
+// devices (the clocks of the devices are supposed to be synchronized).
+// The peers relay the actions to each other, so it is enough for every
+// device to be connected to any other one.
+type Syncer struct {
+	config      Config
+	timer       Timer
+	nodeID      string
+	upgrader    websocket.Upgrader
+	httpServer  *http.Server
+	unsubscribe func()
+	ctx         context.Context
+	cancelFn    context.CancelFunc
+	goroutines  sync.WaitGroup
+
+	locker   sync.Mutex
+	conns    map[*peerConn]struct{}
+	last     *Action
+	expected map[ActionKind]time.Time
+}
+
+type peerConn struct {
+	conn        *websocket.Conn
+	writeLocker sync.Mutex
+}
+
+func (c *peerConn) send(action Action) error {
+	c.writeLocker.Lock()
+	defer c.writeLocker.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return c.conn.WriteJSON(action)
+}
+
+func New(
+	config Config,
+	timer Timer,
+) (*Syncer, error) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+	s := &Syncer{
+		config:   config,
+		timer:    timer,
+		nodeID:   newNodeID(),
+		ctx:      ctx,
+		cancelFn: cancelFn,
+		conns:    map[*peerConn]struct{}{},
+		expected: map[ActionKind]time.Time{},
+	}
+
+	if config.ListenAddr != "" {
+		listener, err := net.Listen("tcp", config.ListenAddr)
+		if err != nil {
+			cancelFn()
+			return nil, fmt.Errorf("unable to listen on '%s': %w", config.ListenAddr, err)
+		}
+		mux := http.NewServeMux()
+		mux.HandleFunc(syncPath, s.handleSync)
+		s.httpServer = &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		s.goroutines.Add(1)
+		go func() {
+			defer s.goroutines.Done()
+			err := s.httpServer.Serve(listener)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slog.Error("the peer sync server stopped", "error", err)
+			}
+		}()
+	}
+
+	s.unsubscribe = timer.OnEvent(s.onEvent)
+	for _, peer := range config.Peers {
+		s.goroutines.Add(1)
+		go func() {
+			defer s.goroutines.Done()
+			s.dialLoop(peer)
+		}()
+	}
+	return s, nil
+}
+
+func newNodeID() string {
+	hostname, _ := os.Hostname()
+	random := make([]byte, 4)
+	_, _ = rand.Read(random)
+	return fmt.Sprintf("%s-%s", hostname, hex.EncodeToString(random))
+}
+
+func (s *Syncer) authHeader() http.Header {
+	header := http.Header{}
+	if s.config.Secret != "" {
+		header.Set("Authorization", "Bearer "+s.config.Secret)
+	}
+	return header
+}
+
+func (s *Syncer) handleSync(w http.ResponseWriter, r *http.Request) {
+	if s.config.Secret != "" {
+		expected := []byte("Bearer " + s.config.Secret)
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			http.Error(w, "invalid secret", http.StatusUnauthorized)
+			return
+		}
+	}
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		slog.Error("unable to upgrade the peer connection to WebSocket", "error", err)
+		return
+	}
+	s.serveConn(conn)
+}
+
+func (s *Syncer) dialLoop(peer string) {
+	for {
+		conn, _, err := websocket.DefaultDialer.DialContext(s.ctx, peer, s.authHeader())
+		if err == nil {
+			s.serveConn(conn)
+		} else if s.ctx.Err() == nil {
+			slog.Error("unable to connect to the peer", "peer", peer, "error", err)
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// serveConn receives the actions from the peer until the connection is closed.
+func (s *Syncer) serveConn(conn *websocket.Conn) {
+	c := &peerConn{conn: conn}
+	s.locker.Lock()
+	if s.ctx.Err() != nil {
+		s.locker.Unlock()
+		conn.Close()
+		return
+	}
+	s.conns[c] = struct{}{}
+	last := s.last
+	s.locker.Unlock()
+	defer func() {
+		s.locker.Lock()
+		delete(s.conns, c)
+		s.locker.Unlock()
+		conn.Close()
+	}()
+
+	if last != nil {
+		// let the peer catch up if it missed the latest action
+		if err := c.send(*last); err != nil {
+			return
+		}
+	}
+
+	for {
+		var action Action
+		if err := conn.ReadJSON(&action); err != nil {
+			return
+		}
+		s.receive(c, action)
+	}
+}
+
+func (s *Syncer) receive(
+	from *peerConn,
+	action Action,
+) {
+	s.locker.Lock()
+	if action.Origin == s.nodeID || (s.last != nil && !action.newerThan(*s.last)) {
+		s.locker.Unlock()
+		return
+	}
+	s.last = &action
+	s.expected[action.Kind] = time.Now().Add(echoTimeout)
+	var others []*peerConn
+	for c := range s.conns {
+		if c != from {
+			others = append(others, c)
+		}
+	}
+	s.locker.Unlock()
+
+	s.apply(action)
+	for _, c := range others {
+		if err := c.send(action); err != nil {
+			slog.Error("unable to relay the action to a peer", "error", err)
+		}
+	}
+}
+
+func (s *Syncer) apply(action Action) {
+	switch action.Kind {
+	case ActionKindStartWork:
+		s.timer.Start(true)
+	case ActionKindStartRest:
+		s.timer.Start(false)
+	case ActionKindStartStopwatch:
+		s.timer.StartStopwatch()
+		return
+	case ActionKindPause:
+		s.timer.Pause()
+	case ActionKindResume:
+		s.timer.Resume()
+	case ActionKindStop:
+		s.timer.StopTimer()
+		return
+	default:
+		slog.Warn("received an unknown action", "action", action.Kind, "peer", action.Origin)
+		return
+	}
+
+	// align the time left with the origin
+	status := s.timer.Status()
+	switch {
+	case status.IsRunning && !action.Deadline.IsZero():
+		s.timer.Extend(action.Deadline.Sub(status.Deadline))
+	case status.IsPaused:
+		s.timer.Extend(action.TimeLeft - status.TimeLeft)
+	}
+}
+
+func (s *Syncer) onEvent(ev pomodoro.Event) {
+	kind := actionKindFromEvent(ev)
+	if kind == "" {
+		return
+	}
+
+	s.locker.Lock()
+	if deadline, ok := s.expected[kind]; ok {
+		delete(s.expected, kind)
+		if time.Now().Before(deadline) {
+			// caused by a remote action
+			s.locker.Unlock()
+			return
+		}
+	}
+	action := Action{
+		Origin:   s.nodeID,
+		At:       ev.Time,
+		Kind:     kind,
+		TimeLeft: ev.TimeLeft,
+	}
+	if ev.Type != pomodoro.EventTypePaused && ev.Phase != pomodoro.PhaseStopwatch {
+		action.Deadline = ev.Deadline
+	}
+	s.last = &action
+	conns := make([]*peerConn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.locker.Unlock()
+
+	for _, c := range conns {
+		if err := c.send(action); err != nil {
+			slog.Error("unable to send the action to a peer", "error", err)
+		}
+	}
+}
+
+func (s *Syncer) Close() error {
+	s.unsubscribe()
+	s.locker.Lock()
+	s.cancelFn()
+	for c := range s.conns {
+		c.conn.Close()
+	}
+	s.locker.Unlock()
+	var err error
+	if s.httpServer != nil {
+		err = s.httpServer.Close()
+	}
+	s.goroutines.Wait()
+	return err
+}
@@ -0,0 +1,40 @@
+// Package plugin runs the external executables extending the timer
+// (lights, journals, chat bots and so on) without patching the core.
+//
+// Every plugin is started once and kept running (it is restarted with
+// a growing delay if it exits). The timer writes the events to its
+// standard input as newline-delimited JSON:
+//
+//	{"protocol": 1, "event": "init", "status": {...}}          right after the start
+//	{"protocol": 1, "event": "phase_started", "status": {...}}
+//	{"protocol": 1, "event": "tick", "status": {...}}           once a second while running
+//
+// where the events are the ones of pomodoro.EventType ("phase_started",
+// "tick", "phase_ending", "phase_ended", "paused", "resumed", "stopped",
+// "woke_up") and Status is:
+//
+//	{
+//	  "phase": "work",                "work", "rest", "long_rest" or "stopwatch"
+//	  "running": true,
+//	  "paused": false,
+//	  "time_left_seconds": 1234,
+//	  "elapsed_seconds": 266,
+//	  "deadline": "2024-01-02T10:25:00Z",   only while running
+//	  "task": "Write report",         only for work
+//	  "completed_work_sessions": 3,
+//	  "cycle_work_sessions": 1
+//	}
+//
+// A slow plugin misses the events (the status is sent in full every time).
+// A plugin may control the timer by writing the commands to its standard
+// output, also as newline-delimited JSON:
+//
+//	{"command": "pause"}
+//	{"command": "pomodoro://start?minutes=25"}
+//
+// where the command is one of pomodoro.Commands or a "pomodoro://" URL
+// (see Pomodoro.OpenURL). The standard error is logged.
+//
+// Incompatible changes of the protocol increase ProtocolVersion,
+// the new events and fields are added without increasing it.
+package plugin
@@ -0,0 +1,233 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+// ProtocolVersion is increased on every incompatible change
+// of the protocol (see the package documentation).
+const ProtocolVersion = 1
+
+const (
+	eventQueueSize   = 16
+	maxLineSize      = 1 << 20
+	minRestartDelay  = time.Second
+	maxRestartDelay  = 5 * time.Minute
+	stableRunningFor = time.Minute
+)
+
+type Timer interface {
+	Status() pomodoro.Status
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+	RunCommand(command string) error
+}
+
+// Message is sent to the plugins.
+type Message struct {
+	Protocol int             `json:"protocol"`
+	Event    string          `json:"event"`
+	Status   pomodoro.Status `json:"status"`
+}
+
+// Request is received from the plugins.
+type Request struct {
+	Command string `json:"command"`
+}
+
+// Manager runs the plugins and forwards the events of the timer to them.
+type Manager struct {
+	timer       Timer
+	plugins     []*plugin
+	unsubscribe func()
+	cancelFn    context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+type plugin struct {
+	command []string
+	events  chan Message
+}
+
+// Start runs the plugins, each command is the path to the executable
+// followed by the arguments separated by spaces.
+func Start(
+	timer Timer,
+	commands []string,
+) *Manager {
+	ctx, cancelFn := context.WithCancel(context.Background())
+	m := &Manager{
+		timer:    timer,
+		cancelFn: cancelFn,
+	}
+	for _, command := range commands {
+		fields := strings.Fields(command)
+		if len(fields) == 0 {
+			continue
+		}
+		p := &plugin{
+			command: fields,
+			events:  make(chan Message, eventQueueSize),
+		}
+		m.plugins = append(m.plugins, p)
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			m.keepRunning(ctx, p)
+		}()
+	}
+	m.unsubscribe = timer.OnEvent(m.onEvent)
+	return m
+}
+
+func (m *Manager) onEvent(ev pomodoro.Event) {
+	msg := Message{
+		Protocol: ProtocolVersion,
+		Event:    ev.Type.String(),
+		Status:   m.timer.Status(),
+	}
+	for _, p := range m.plugins {
+		select {
+		case p.events <- msg:
+		default:
+		}
+	}
+}
+
+// keepRunning restarts the plugin when it exits, with the delay growing
+// while it keeps failing right after the start.
+func (m *Manager) keepRunning(
+	ctx context.Context,
+	p *plugin,
+) {
+	delay := minRestartDelay
+	for {
+		startedAt := time.Now()
+		err := m.run(ctx, p)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(startedAt) > stableRunningFor {
+			delay = minRestartDelay
+		}
+		slog.Error("the plugin exited, restarting it", "plugin", p.command[0], "error", err, "delay", delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRestartDelay)
+	}
+}
+
+func (m *Manager) run(
+	ctx context.Context,
+	p *plugin,
+) error {
+	cmd := exec.CommandContext(ctx, p.command[0], p.command[1:]...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("unable to get the standard input: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("unable to get the standard output: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("unable to get the standard error: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("unable to start '%s': %w", p.command[0], err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		m.readRequests(p, stdout)
+	}()
+	go func() {
+		defer wg.Done()
+		logStderr(p, stderr)
+	}()
+
+	exited := make(chan struct{})
+	go func() {
+		defer stdin.Close()
+		encoder := json.NewEncoder(stdin)
+		msg := Message{
+			Protocol: ProtocolVersion,
+			Event:    "init",
+			Status:   m.timer.Status(),
+		}
+		for {
+			if err := encoder.Encode(msg); err != nil {
+				slog.Debug("unable to send the event to the plugin", "plugin", p.command[0], "error", err)
+				return
+			}
+			select {
+			case <-exited:
+				return
+			case msg = <-p.events:
+			}
+		}
+	}()
+
+	wg.Wait()
+	err = cmd.Wait()
+	close(exited)
+	if err != nil {
+		return fmt.Errorf("'%s' failed: %w", p.command[0], err)
+	}
+	return fmt.Errorf("'%s' exited", p.command[0])
+}
+
+func (m *Manager) readRequests(
+	p *plugin,
+	stdout io.Reader,
+) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(nil, maxLineSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var req Request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			slog.Warn("unable to parse the request of the plugin", "plugin", p.command[0], "request", scanner.Text(), "error", err)
+			continue
+		}
+		if err := m.timer.RunCommand(req.Command); err != nil {
+			slog.Warn("unable to run the command of the plugin", "plugin", p.command[0], "command", req.Command, "error", err)
+		}
+	}
+}
+
+func logStderr(
+	p *plugin,
+	stderr io.Reader,
+) {
+	scanner := bufio.NewScanner(stderr)
+	scanner.Buffer(nil, maxLineSize)
+	for scanner.Scan() {
+		slog.Info("plugin output", "plugin", p.command[0], "line", scanner.Text())
+	}
+}
+
+// Close stops the plugins.
+func (m *Manager) Close() {
+	m.unsubscribe()
+	m.cancelFn()
+	m.wg.Wait()
+}
@@ -0,0 +1,53 @@
+package pomodoro
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// describeStatus returns the status of the timer as a sentence for
+// reading it aloud.
+func describeStatus(
+	s Status,
+	labels PhaseLabels,
+) string {
+	var text string
+	switch {
+	case !s.IsRunning && !s.IsPaused:
+		return l10n.T("The timer is stopped, the next interval is %s.", spokenDuration(s.DisplayedTimeLeft))
+	case s.Phase == PhaseStopwatch:
+		text = l10n.T("%s: %s elapsed", labels.Title(s.Phase), spokenDuration(s.Elapsed))
+	default:
+		text = l10n.T("%s: %s left", labels.Title(s.Phase), spokenDuration(s.TimeLeft))
+	}
+	if s.IsPaused {
+		text = l10n.T("%s, paused", text)
+	}
+	return text + "."
+}
+
+func spokenDuration(d time.Duration) string {
+	minutes, seconds := splitDuration(max(d, 0))
+	return l10n.T("%d min %d sec", minutes, seconds)
+}
+
+// ReadTimerAloud speaks the status of the timer (if enabled in
+// the settings), it is used instead of a screen reader which
+// cannot see the drawn digits.
+func (p *Pomodoro) ReadTimerAloud() {
+	p.locker.Lock()
+	enabled := p.settings.SpeakFocusedTimer
+	speaker := p.Speaker
+	text := describeStatus(p.status(), p.settings.Labels)
+	p.locker.Unlock()
+	if !enabled || speaker == nil {
+		return
+	}
+	p.lifecycle.launch(func() {
+		if err := speaker.Speak(text); err != nil {
+			slog.Error("unable to read the timer aloud", "error", err)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package pomodoro
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/xaionaro-go/pomodoro/pkg/audio"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+const (
+	// alarmEscalationSteps is the number of the repeats during which
+	// the volume rises to the maximum (see Settings.AlarmEscalation).
+	alarmEscalationSteps = 4
+)
+
+// AcknowledgeAlarm silences the alarm.
+func (p *Pomodoro) AcknowledgeAlarm() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.stopAlarm()
+}
+
+func (p *Pomodoro) stopAlarm() {
+	if p.alarmCancel != nil {
+		p.alarmCancel()
+		p.alarmCancel = nil
+	}
+	p.view.IsAlarmEscalated = false
+	p.refresh()
+}
+
+// escalateAlarm asks the frontend for the full-screen banner, which
+// stays until the alarm is acknowledged (see Settings.AlarmEscalation).
+func (p *Pomodoro) escalateAlarm(ctx context.Context) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if ctx.Err() != nil {
+		return
+	}
+	p.view.IsAlarmEscalated = true
+	p.refresh()
+}
+
+// startAlarm plays the alarm sound Settings.AlarmRepeat times (or until
+// acknowledged), fading the volume in from zero to Settings.AlarmVolume
+// (the volume of the alarm channel of the mixer) during Settings.AlarmFadeIn.
+//
+// With Settings.AlarmEscalation it repeats until acknowledged, starting
+// quieter and getting louder with each repeat, and then also shows
+// a full-screen banner.
+//
+// Without the audio output it falls back to startSilentAlarm.
+func (p *Pomodoro) startAlarm() {
+	p.stopAlarm()
+	if !p.HasAudio() {
+		p.startSilentAlarm()
+		return
+	}
+	sound, file := p.settings.AlarmSound, p.settings.AlarmFile
+	fadeIn, repeat := p.settings.AlarmFadeIn, p.settings.AlarmRepeat
+	escalate := p.settings.AlarmEscalation
+	if escalate {
+		repeat = 0
+	}
+	ctx, cancelFn := context.WithCancel(p.lifecycle.ctx)
+	p.alarmCancel = cancelFn
+	startedAt := p.clock.Now()
+	volumeFn := func() float64 {
+		if fadeIn <= 0 {
+			return 1
+		}
+		return min(1, float64(p.since(startedAt))/float64(fadeIn))
+	}
+
+	launched := p.lifecycle.launch(func() {
+		for count := uint(0); repeat == 0 || count < repeat; count++ {
+			stream, err := openSound(sound, file)
+			if err != nil {
+				slog.Error("unable to open the alarm sound", "error", err)
+				break
+			}
+			level := 1.0
+			if escalate {
+				level = min(1, float64(count+1)/alarmEscalationSteps)
+			}
+			err = p.Player.PlayWithVolume(ctx, audio.ChannelAlarm, stream, func() float64 {
+				return volumeFn() * level
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				slog.Error("unable to play the alarm sound", "error", err)
+				break
+			}
+			if escalate && count+1 == alarmEscalationSteps {
+				p.escalateAlarm(ctx)
+			}
+		}
+
+		p.locker.Lock()
+		defer p.locker.Unlock()
+		if ctx.Err() == nil {
+			p.stopAlarm()
+		}
+	})
+	if !launched {
+		p.alarmCancel = nil
+		cancelFn()
+		return
+	}
+	p.refresh()
+}
+
+// startSilentAlarm replaces the alarm sound if there is no audio output
+// (see detectAudio): the overlay stays until acknowledged, the window
+// flashes and a notification is shown; with Settings.AlarmEscalation the
+// full-screen banner is shown right away.
+func (p *Pomodoro) startSilentAlarm() {
+	_, p.alarmCancel = context.WithCancel(p.lifecycle.ctx)
+	if !p.settings.Notifications.OnPhaseEnd {
+		p.notify(l10n.T("Time is up!"))
+	}
+	p.view.IsAlarmEscalated = p.settings.AlarmEscalation
+	p.refresh()
+	if !p.settings.EndFlash {
+		p.frontend.Flash()
+	}
+}
@@ -0,0 +1,96 @@
+package pomodoro
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/audio"
+)
+
+const (
+	ambienceTickFreq     = 900
+	ambienceTickDuration = 25 * time.Millisecond
+	ambienceRetryDelay   = 5 * time.Second
+)
+
+// AmbienceSettings configure the sound looped during the work
+// (a soft ticking of a clock by default).
+type AmbienceSettings struct {
+	Enabled bool
+	File    string // empty for the ticking
+	Volume  float64
+}
+
+// handleAmbienceEvent starts and stops the ambience together
+// with the work (see refreshAmbience).
+func (p *Pomodoro) handleAmbienceEvent(ev Event) {
+	switch ev.Type {
+	case EventTypePhaseStarted, EventTypePhaseEnded, EventTypePaused, EventTypeResumed, EventTypeStopped:
+	default:
+		return
+	}
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.refreshAmbience()
+}
+
+// refreshAmbience plays the ambience if it is enabled and the work
+// (or a stopwatch) is running, and stops it otherwise.
+func (p *Pomodoro) refreshAmbience() {
+	s := p.settings.Ambience
+	shouldPlay := s.Enabled && p.HasAudio() && p.isRunning() && (p.isWork || p.isStopwatch)
+	if p.ambienceCancel != nil && (!shouldPlay || p.ambienceFile != s.File) {
+		p.ambienceCancel()
+		p.ambienceCancel = nil
+	}
+	if !shouldPlay || p.ambienceCancel != nil {
+		return
+	}
+
+	ctx, cancelFn := context.WithCancel(p.lifecycle.ctx)
+	p.ambienceCancel = cancelFn
+	p.ambienceFile = s.File
+	file := s.File
+	p.lifecycle.launch(func() {
+		p.loopAmbience(ctx, file)
+	})
+}
+
+func (p *Pomodoro) loopAmbience(
+	ctx context.Context,
+	file string,
+) {
+	var tick *audio.Sample
+	for ctx.Err() == nil {
+		var stream audio.Stream
+		if file != "" {
+			var err error
+			stream, err = audio.OpenFile(file)
+			if err != nil {
+				slog.Warn("unable to open the ambience file, falling back to the ticking", "file", file, "error", err)
+				file = ""
+			}
+		}
+		if file == "" {
+			if tick == nil {
+				tick = audio.NewToneSample(ambienceTickFreq, ambienceTickDuration).Padded(time.Second)
+			}
+			stream = tick.Stream()
+		}
+
+		err := p.Player.Play(ctx, audio.ChannelAmbience, stream, 1)
+		switch {
+		case err == nil:
+		case errors.Is(err, context.Canceled):
+			return
+		default:
+			slog.Error("unable to play the ambience", "error", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(ambienceRetryDelay):
+			}
+		}
+	}
+}
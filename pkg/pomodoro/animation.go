@@ -0,0 +1,18 @@
+package pomodoro
+
+type DelimiterAnimation string
+
+const (
+	DelimiterAnimationNone  = DelimiterAnimation("none")
+	DelimiterAnimationBlink = DelimiterAnimation("blink")
+	DelimiterAnimationFade  = DelimiterAnimation("fade")
+	DelimiterAnimationPulse = DelimiterAnimation("pulse")
+)
+
+// DelimiterAnimations are the choices of Settings.DelimiterAnimation.
+var DelimiterAnimations = []DelimiterAnimation{
+	DelimiterAnimationNone,
+	DelimiterAnimationBlink,
+	DelimiterAnimationFade,
+	DelimiterAnimationPulse,
+}
@@ -0,0 +1,27 @@
+package pomodoro
+
+import (
+	"log/slog"
+)
+
+func (p *Pomodoro) handleAnnouncementEvent(ev Event) {
+	if ev.Type != EventTypePhaseStarted || ev.Restored {
+		return
+	}
+
+	p.locker.Lock()
+	s := p.settings.Announcements
+	speaker := p.Speaker
+	p.locker.Unlock()
+	if !s.Enabled || speaker == nil {
+		return
+	}
+
+	phrase := s.Phrase(ev.Phase)
+	if phrase == "" {
+		return
+	}
+	if err := speaker.Speak(phrase); err != nil {
+		slog.Error("unable to announce", "phrase", phrase, "error", err)
+	}
+}
@@ -0,0 +1,32 @@
+package pomodoro
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/xaionaro-go/pomodoro/pkg/audio"
+)
+
+// detectAudio waits for the audio output to be initialized (see
+// audio.Player.Prepare): without it (in a container, on a headless
+// machine) the sounds are disabled and the alarm is only shown and
+// notified (see startSilentAlarm).
+func (p *Pomodoro) detectAudio() {
+	err := p.Player.Err(p.lifecycle.ctx)
+	if !errors.Is(err, audio.ErrUnavailable) {
+		return
+	}
+	slog.Warn("no audio output, the sounds are disabled", "error", err)
+	p.noAudio.Store(true)
+
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.refreshAmbience()
+	p.refreshFocusMusic()
+}
+
+// HasAudio returns false if there is no audio output (see detectAudio),
+// it does not require p.locker.
+func (p *Pomodoro) HasAudio() bool {
+	return !p.noAudio.Load()
+}
@@ -0,0 +1,63 @@
+package pomodoro
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+func (p *Pomodoro) CancelAutoContinue() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.cancelAutoContinue()
+}
+
+func (p *Pomodoro) cancelAutoContinue() {
+	if p.autoContinueCancel == nil {
+		return
+	}
+	p.autoContinueCancel()
+	p.autoContinueCancel = nil
+	p.view.AutoContinue = ""
+	p.refresh()
+}
+
+func (p *Pomodoro) scheduleAutoContinue() {
+	p.cancelAutoContinue()
+	ctx, cancelFn := context.WithCancel(p.lifecycle.ctx)
+	p.autoContinueCancel = cancelFn
+
+	isWork := p.isWork
+	phaseName := l10n.T("WORK")
+	if !isWork {
+		phaseName = l10n.T("BREAK")
+	}
+	delay := p.settings.AutoContinueDelay
+
+	p.lifecycle.launch(func() {
+		for left := delay; left > 0; left -= time.Second {
+			p.locker.Lock()
+			if ctx.Err() == nil {
+				p.view.AutoContinue = fmt.Sprintf("%s starts in %d...", phaseName, left/time.Second)
+				p.refresh()
+			}
+			p.locker.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.clock.After(time.Second):
+			}
+		}
+
+		p.locker.Lock()
+		defer p.locker.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		p.autoContinueCancel = nil
+		p.view.AutoContinue = ""
+		p.start(isWork)
+	})
+}
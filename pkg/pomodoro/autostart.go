@@ -0,0 +1,39 @@
+package pomodoro
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/xaionaro-go/pomodoro/pkg/autostart"
+)
+
+const (
+	// minimizedFlag is the flag of cmd/pomodoro to start hidden
+	// in the system tray (see gui.GUI.SetStartMinimized).
+	minimizedFlag = "--minimized"
+)
+
+// setAutostart installs (or removes) the entry making the OS start this
+// executable minimized to the tray on login (see Settings.Autostart).
+func (p *Pomodoro) setAutostart(enable bool) {
+	if !enable {
+		if err := autostart.Disable(); err != nil {
+			slog.Error("unable to disable the autostart", "error", err)
+		}
+		return
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		slog.Error("unable to get the path of the executable", "error", err)
+		return
+	}
+	args := []string{minimizedFlag}
+	if p.Dirs.IsPortable {
+		args = append(args, "--data-dir", filepath.Dir(p.Dirs.Data))
+	}
+	if err := autostart.Enable(executable, args...); err != nil {
+		slog.Error("unable to enable the autostart", "error", err)
+	}
+}
@@ -0,0 +1,373 @@
+package pomodoro
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/datadir"
+)
+
+const (
+	// BackupFileExtension is the extension of the backup files
+	// (see Backup).
+	BackupFileExtension = ".zip"
+
+	backupVersion         = 1
+	backupManifestName    = "manifest.json"
+	backupPreferencesName = "preferences.json"
+	backupDataDirName     = "data"
+)
+
+// backupDataFiles are the files and the directories of the data directory
+// put into the backups. The journal is not: it makes sense only for
+// the session running on this computer.
+var backupDataFiles = []string{historyFileName, soundsDirName}
+
+type backupManifest struct {
+	Version   int
+	CreatedAt time.Time
+
+	// DataDir is the data directory the backup was made of, the paths
+	// of the sounds within it are moved to the one restored to.
+	DataDir string
+}
+
+// DefaultBackupFileName is the suggested name of the backup made at t.
+func DefaultBackupFileName(t time.Time) string {
+	return "pomodoro-backup-" + t.Format(time.DateOnly) + BackupFileExtension
+}
+
+// Backup writes the settings kept in dirs (including the profiles and
+// the credentials of the integrations), the history and the imported
+// sounds as a zip archive, without starting the timer.
+func Backup(
+	w io.Writer,
+	dirs datadir.Dirs,
+) error {
+	return writeBackup(w, LoadSettings(openPreferences(dirs)), dirs.Data, time.Now())
+}
+
+// Backup writes the backup of the user data (see the function Backup).
+func (p *Pomodoro) Backup(w io.Writer) error {
+	p.locker.Lock()
+	s := p.settings
+	now := p.clock.Now()
+	p.locker.Unlock()
+	return writeBackup(w, s, p.dataDir(), now)
+}
+
+func writeBackup(
+	w io.Writer,
+	s Settings,
+	dataDir string,
+	now time.Time,
+) error {
+	zw := zip.NewWriter(w)
+	mw, err := zw.Create(backupManifestName)
+	if err != nil {
+		return fmt.Errorf("unable to add the manifest: %w", err)
+	}
+	encoder := json.NewEncoder(mw)
+	encoder.SetIndent("", "\t")
+	err = encoder.Encode(backupManifest{
+		Version:   backupVersion,
+		CreatedAt: now,
+		DataDir:   dataDir,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write the manifest: %w", err)
+	}
+
+	prefs := datadir.NewPreferences()
+	s.Save(prefs)
+	pw, err := zw.Create(backupPreferencesName)
+	if err != nil {
+		return fmt.Errorf("unable to add the preferences: %w", err)
+	}
+	if err := prefs.Encode(pw); err != nil {
+		return err
+	}
+
+	if dataDir != "" {
+		for _, name := range backupDataFiles {
+			if err := addBackupFiles(zw, dataDir, name); err != nil {
+				return err
+			}
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("unable to finish the backup: %w", err)
+	}
+	return nil
+}
+
+// addBackupFiles adds the file (or the directory recursively) of the data
+// directory into the "data" directory of the archive, it is a no-op
+// if there is no such file.
+func addBackupFiles(
+	zw *zip.Writer,
+	dataDir string,
+	name string,
+) error {
+	root := filepath.Join(dataDir, name)
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dataDir, filePath)
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(backupDataDirName, filepath.ToSlash(rel))
+		header.Method = zip.Deflate
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(fw, f)
+		return err
+	})
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
+		return fmt.Errorf("unable to back up '%s': %w", root, err)
+	}
+	return nil
+}
+
+// Restore replaces the settings, the history and the imported sounds
+// with the ones from the backup (see Backup); the history or the sounds
+// missing in the backup are kept. The data files are either all restored
+// or, on an error, all left intact.
+func (p *Pomodoro) Restore(
+	r io.ReaderAt,
+	size int64,
+) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("unable to open the backup: %w", err)
+	}
+	var manifest backupManifest
+	if err := readBackupJSON(zr, backupManifestName, &manifest); err != nil {
+		return err
+	}
+	if manifest.Version != backupVersion {
+		return fmt.Errorf("unsupported backup version %d, expected %d", manifest.Version, backupVersion)
+	}
+	pf, err := zr.Open(backupPreferencesName)
+	if err != nil {
+		return fmt.Errorf("unable to open the preferences in the backup: %w", err)
+	}
+	prefs, err := datadir.DecodePreferences(pf)
+	pf.Close()
+	if err != nil {
+		return err
+	}
+
+	dataDir := p.dataDir()
+	if dataDir == "" {
+		return fmt.Errorf("no storage to restore the data to")
+	}
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return fmt.Errorf("unable to create the directory '%s': %w", dataDir, err)
+	}
+	// the staging directory is within the data directory, so the restored
+	// files are moved into place without copying
+	stagingDir, err := os.MkdirTemp(dataDir, ".restore-")
+	if err != nil {
+		return fmt.Errorf("unable to create a staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+	restoredDir := filepath.Join(stagingDir, backupDataDirName)
+	for _, f := range zr.File {
+		name, ok := strings.CutPrefix(f.Name, backupDataDirName+"/")
+		if !ok || f.FileInfo().IsDir() {
+			continue
+		}
+		if !isBackupDataFile(name) {
+			slog.Warn("skipping an unexpected file in the backup", "file", f.Name)
+			continue
+		}
+		if err := extractBackupFile(f, filepath.Join(restoredDir, filepath.FromSlash(name))); err != nil {
+			return err
+		}
+	}
+	if err := swapBackupFiles(restoredDir, dataDir, filepath.Join(stagingDir, "replaced")); err != nil {
+		return err
+	}
+
+	s := LoadSettings(prefs)
+	s.rebaseSoundPaths(manifest.DataDir, dataDir)
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.applySettings(s)
+	s.Save(p.prefs)
+	p.openHistory()
+	return nil
+}
+
+func readBackupJSON(
+	zr *zip.Reader,
+	name string,
+	value any,
+) error {
+	f, err := zr.Open(name)
+	if err != nil {
+		return fmt.Errorf("unable to open '%s' in the backup: %w", name, err)
+	}
+	defer f.Close()
+	if err := json.NewDecoder(f).Decode(value); err != nil {
+		return fmt.Errorf("unable to parse '%s' in the backup: %w", name, err)
+	}
+	return nil
+}
+
+// isBackupDataFile reports whether the path (relative to the "data"
+// directory of the archive) is one of backupDataFiles or is within them.
+func isBackupDataFile(name string) bool {
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		return false
+	}
+	for _, dataFile := range backupDataFiles {
+		if name == dataFile || strings.HasPrefix(name, dataFile+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// swapBackupFiles moves backupDataFiles from restoredDir into dataDir,
+// the replaced ones are moved to replacedDir. If a file cannot be moved,
+// the already moved ones are put back.
+func swapBackupFiles(
+	restoredDir string,
+	dataDir string,
+	replacedDir string,
+) error {
+	if err := os.MkdirAll(replacedDir, 0o755); err != nil {
+		return fmt.Errorf("unable to create the directory '%s': %w", replacedDir, err)
+	}
+	var swapped []string
+	rollback := func() {
+		for idx := len(swapped) - 1; idx >= 0; idx-- {
+			name := swapped[idx]
+			if err := restoreReplacedFile(filepath.Join(dataDir, name), filepath.Join(replacedDir, name)); err != nil {
+				slog.Error("unable to put back the file replaced by the restoration", "file", name, "error", err)
+			}
+		}
+	}
+	for _, name := range backupDataFiles {
+		restoredPath := filepath.Join(restoredDir, name)
+		if _, err := os.Lstat(restoredPath); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		dataPath := filepath.Join(dataDir, name)
+		switch err := os.Rename(dataPath, filepath.Join(replacedDir, name)); {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			rollback()
+			return fmt.Errorf("unable to move away '%s': %w", dataPath, err)
+		}
+		swapped = append(swapped, name)
+		if err := os.Rename(restoredPath, dataPath); err != nil {
+			rollback()
+			return fmt.Errorf("unable to replace '%s': %w", dataPath, err)
+		}
+	}
+	return nil
+}
+
+// restoreReplacedFile puts the file moved away by swapBackupFiles
+// (if there was one) back to dataPath.
+func restoreReplacedFile(
+	dataPath string,
+	replacedPath string,
+) error {
+	if _, err := os.Lstat(replacedPath); errors.Is(err, fs.ErrNotExist) {
+		return os.RemoveAll(dataPath)
+	}
+	if err := os.RemoveAll(dataPath); err != nil {
+		return err
+	}
+	return os.Rename(replacedPath, dataPath)
+}
+
+// extractBackupFile writes the file from the backup to dstPath.
+func extractBackupFile(
+	f *zip.File,
+	dstPath string,
+) error {
+	src, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("unable to open '%s' in the backup: %w", f.Name, err)
+	}
+	defer src.Close()
+	if err := os.MkdirAll(filepath.Dir(dstPath), 0o755); err != nil {
+		return fmt.Errorf("unable to create the directory '%s': %w", filepath.Dir(dstPath), err)
+	}
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return fmt.Errorf("unable to create '%s': %w", dstPath, err)
+	}
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to extract '%s' to '%s': %w", f.Name, dstPath, err)
+	}
+	return nil
+}
+
+// rebaseSoundPaths moves the paths of the sounds within the directory from
+// (the data directory of a backup) to the directory to.
+func (s *Settings) rebaseSoundPaths(
+	from string,
+	to string,
+) {
+	if from == "" || from == to {
+		return
+	}
+	rebase := func(soundPath *string) {
+		if *soundPath == "" {
+			return
+		}
+		rel, err := filepath.Rel(from, *soundPath)
+		if err != nil || !filepath.IsLocal(rel) {
+			return
+		}
+		*soundPath = filepath.Join(to, rel)
+	}
+	rebase(&s.AlarmFile)
+	rebase(&s.Ambience.File)
+	for idx := range s.Profiles {
+		rebase(&s.Profiles[idx].AlarmFile)
+	}
+}
@@ -0,0 +1,180 @@
+package pomodoro
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/datadir"
+)
+
+type testBackupFile struct {
+	Name    string
+	Content string
+}
+
+// newTestBackup builds a backup archive with the files, the content
+// "corrupted" is damaged after the archive is written.
+func newTestBackup(t *testing.T, files ...testBackupFile) *bytes.Reader {
+	t.Helper()
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	mw, err := zw.Create(backupManifestName)
+	if err != nil {
+		t.Fatalf("unable to add the manifest: %v", err)
+	}
+	if err := json.NewEncoder(mw).Encode(backupManifest{Version: backupVersion, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("unable to write the manifest: %v", err)
+	}
+	pw, err := zw.Create(backupPreferencesName)
+	if err != nil {
+		t.Fatalf("unable to add the preferences: %v", err)
+	}
+	if err := datadir.NewPreferences().Encode(pw); err != nil {
+		t.Fatalf("unable to write the preferences: %v", err)
+	}
+	for _, file := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: file.Name, Method: zip.Store})
+		if err != nil {
+			t.Fatalf("unable to add '%s': %v", file.Name, err)
+		}
+		if _, err := fw.Write([]byte(file.Content)); err != nil {
+			t.Fatalf("unable to write '%s': %v", file.Name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("unable to finish the archive: %v", err)
+	}
+	return bytes.NewReader(bytes.ReplaceAll(b.Bytes(), []byte("corrupted"), []byte("CORRUPTED")))
+}
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			t.Fatalf("unable to create the directory: %v", err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatalf("unable to write '%s': %v", name, err)
+		}
+	}
+}
+
+// readTestFiles reads all the files within dir.
+func readTestFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to read '%s': %v", dir, err)
+	}
+	return files
+}
+
+func TestRestore(t *testing.T) {
+	existing := map[string]string{
+		historyFileName:                 `{"task":"old"}` + "\n",
+		soundsDirName + "/old.wav":      "old sound",
+		soundsDirName + "/replaced.wav": "old sound",
+	}
+	for _, tc := range []struct {
+		Name          string
+		Files         []testBackupFile
+		ExpectedError bool
+		Expected      map[string]string
+	}{
+		{
+			Name: "history and sounds",
+			Files: []testBackupFile{
+				{Name: "data/" + historyFileName, Content: `{"task":"restored"}` + "\n"},
+				{Name: "data/" + soundsDirName + "/replaced.wav", Content: "restored sound"},
+				{Name: "data/" + soundsDirName + "/new.wav", Content: "restored sound"},
+			},
+			Expected: map[string]string{
+				historyFileName:                 `{"task":"restored"}` + "\n",
+				soundsDirName + "/replaced.wav": "restored sound",
+				soundsDirName + "/new.wav":      "restored sound",
+			},
+		},
+		{
+			Name: "no sounds",
+			Files: []testBackupFile{
+				{Name: "data/" + historyFileName, Content: `{"task":"restored"}` + "\n"},
+			},
+			Expected: map[string]string{
+				historyFileName:                 `{"task":"restored"}` + "\n",
+				soundsDirName + "/old.wav":      "old sound",
+				soundsDirName + "/replaced.wav": "old sound",
+			},
+		},
+		{
+			Name: "not whitelisted",
+			Files: []testBackupFile{
+				{Name: "data/" + journalFileName, Content: `{"phase":"rest"}` + "\n"},
+				{Name: "data/settings.json", Content: "{}"},
+				{Name: "data/" + historyFileName + ".bak", Content: "{}"},
+				{Name: "data/../escaped", Content: "{}"},
+			},
+			Expected: existing,
+		},
+		{
+			Name: "corrupted",
+			Files: []testBackupFile{
+				{Name: "data/" + historyFileName, Content: `{"task":"restored"}` + "\n"},
+				{Name: "data/" + soundsDirName + "/new.wav", Content: "corrupted"},
+			},
+			ExpectedError: true,
+			Expected:      existing,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			dataDir := filepath.Join(dir, "data")
+			writeTestFiles(t, dataDir, existing)
+			p := New(datadir.Dirs{Data: dataDir})
+			t.Cleanup(func() { p.Close() })
+
+			backup := newTestBackup(t, tc.Files...)
+			err := p.Restore(backup, backup.Size())
+			if tc.ExpectedError != (err != nil) {
+				t.Fatalf("expected an error: %v, got %v", tc.ExpectedError, err)
+			}
+			files := readTestFiles(t, dataDir)
+			// the journal is up to the timer, it is never restored
+			if files[journalFileName] == `{"phase":"rest"}`+"\n" {
+				t.Fatalf("the journal is restored")
+			}
+			delete(files, journalFileName)
+			if len(files) != len(tc.Expected) {
+				t.Fatalf("expected the files %v, got %v", tc.Expected, files)
+			}
+			for name, content := range tc.Expected {
+				if files[name] != content {
+					t.Fatalf("expected '%s' to be %q, got %q", name, content, files[name])
+				}
+			}
+			if _, err := os.Stat(filepath.Join(dir, "escaped")); err == nil {
+				t.Fatalf("a file is extracted outside of the data directory")
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package pomodoro
+
+import (
+	"log/slog"
+	"net"
+
+	"github.com/xaionaro-go/pomodoro/pkg/blocker"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// BlockerSettings configure keeping the distractions away during the work
+// (see pkg/blocker), it is disabled if both Sites and Processes are empty.
+type BlockerSettings struct {
+	Sites         []string
+	Processes     []string
+	KillProcesses bool
+
+	// ProxyAddr is where to serve the proxy refusing the sites,
+	// the hosts file is changed instead if it is empty.
+	ProxyAddr string
+}
+
+func (s BlockerSettings) isEnabled() bool {
+	return len(s.Sites) > 0 || len(s.Processes) > 0
+}
+
+type blockerState struct {
+	blocker   *blocker.Blocker
+	proxy     *blocker.Proxy
+	proxyAddr string
+
+	// overridden is set by the user (see UnblockDistractions)
+	// until the end of the phase.
+	overridden bool
+}
+
+// handleBlockerEvent blocks the distractions together with the work
+// (see refreshBlocker), an override ends with the phase.
+func (p *Pomodoro) handleBlockerEvent(ev Event) {
+	switch ev.Type {
+	case EventTypePhaseStarted, EventTypePhaseEnded, EventTypeStopped:
+	default:
+		return
+	}
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.blocking.overridden = false
+	p.refreshBlocker()
+}
+
+// refreshBlocker blocks the distractions if the blocker is enabled and
+// the work (or a stopwatch) is going on, and unblocks them otherwise.
+func (p *Pomodoro) refreshBlocker() {
+	if p.lifecycle.ctx.Err() != nil {
+		// closed, see closeBlocker
+		return
+	}
+	s := p.settings.Blocker
+	b := &p.blocking
+	if b.blocker == nil || b.proxyAddr != s.ProxyAddr {
+		p.closeBlocker()
+		p.openBlocker(s)
+	}
+
+	shouldBlock := s.isEnabled() && !b.overridden &&
+		(p.isRunning() || p.isPaused) && (p.isWork || p.isStopwatch)
+	if !shouldBlock {
+		if !b.blocker.IsBlocking() {
+			return
+		}
+		if err := b.blocker.Unblock(); err != nil {
+			slog.Error("unable to unblock the distractions", "error", err)
+		}
+		return
+	}
+	err := b.blocker.Block(blocker.Config{
+		Sites:         s.Sites,
+		Processes:     s.Processes,
+		KillProcesses: s.KillProcesses,
+	})
+	if err != nil {
+		slog.Error("unable to block the distractions", "error", err)
+	}
+}
+
+func (p *Pomodoro) openBlocker(s BlockerSettings) {
+	b := &p.blocking
+	b.proxyAddr = s.ProxyAddr
+	if s.ProxyAddr == "" {
+		b.blocker = blocker.New(blocker.NewHostsFile(""), p.onBlockedProcess)
+		if len(s.Sites) > 0 {
+			// the sites stay blocked in the hosts file after a crash
+			if err := b.blocker.Unblock(); err != nil {
+				slog.Error("unable to clean up the hosts file", "error", err)
+			}
+		}
+		return
+	}
+
+	b.proxy = blocker.NewProxy()
+	b.blocker = blocker.New(b.proxy, p.onBlockedProcess)
+	listener, err := net.Listen("tcp", s.ProxyAddr)
+	if err != nil {
+		slog.Error("unable to listen for the blocking proxy", "address", s.ProxyAddr, "error", err)
+		return
+	}
+	proxy := b.proxy
+	go func() {
+		if err := proxy.Serve(listener); err != nil {
+			slog.Error("the blocking proxy stopped", "error", err)
+		}
+	}()
+}
+
+func (p *Pomodoro) closeBlocker() {
+	b := &p.blocking
+	if b.blocker != nil {
+		if err := b.blocker.Unblock(); err != nil {
+			slog.Error("unable to unblock the distractions", "error", err)
+		}
+		b.blocker = nil
+	}
+	if b.proxy != nil {
+		if err := b.proxy.Close(); err != nil {
+			slog.Error("unable to close the blocking proxy", "error", err)
+		}
+		b.proxy = nil
+	}
+}
+
+// onBlockedProcess is called by the blocker, so it should not lock
+// p.locker (see blocker.New).
+func (p *Pomodoro) onBlockedProcess(
+	process blocker.Process,
+	killed bool,
+) {
+	if killed {
+		p.deliverNotification(l10n.T("%s was closed: it is blocked during the work.", process.Name))
+		return
+	}
+	p.deliverNotification(l10n.T("%s is blocked during the work, please close it.", process.Name))
+}
+
+// IsBlockingDistractions returns true if the sites and the applications
+// are blocked right now.
+func (p *Pomodoro) IsBlockingDistractions() bool {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.blocking.blocker != nil && p.blocking.blocker.IsBlocking()
+}
+
+// UnblockDistractions lifts the blocking until the end of the phase,
+// it is refused during a committed work session.
+func (p *Pomodoro) UnblockDistractions() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.refuseIfCommitted("unblock the distractions") {
+		return
+	}
+	p.blocking.overridden = true
+	p.refreshBlocker()
+}
@@ -0,0 +1,97 @@
+package pomodoro
+
+import (
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+const (
+	// breakActivityWarnEvery limits how often the user is warned about
+	// using the computer during a break (see BreakCompliancePolicyWarn).
+	breakActivityWarnEvery = time.Minute
+
+	// maxBreakExtension limits how much a single break may be extended
+	// because of the activity (see BreakCompliancePolicyExtend).
+	maxBreakExtension = 15 * time.Minute
+
+	// BreakComplianceDays is how many last days BreakCompliance covers.
+	BreakComplianceDays = 7
+)
+
+// BreakCompliancePolicy defines what happens if the keyboard or
+// the mouse is used during a break (see IdleDetector).
+type BreakCompliancePolicy string
+
+const (
+	// BreakCompliancePolicyOff does not monitor the breaks.
+	BreakCompliancePolicyOff = BreakCompliancePolicy("off")
+
+	// BreakCompliancePolicyRecord only records the activity
+	// into the history (see Session.Active).
+	BreakCompliancePolicyRecord = BreakCompliancePolicy("record")
+
+	// BreakCompliancePolicyWarn also notifies the user.
+	BreakCompliancePolicyWarn = BreakCompliancePolicy("warn")
+
+	// BreakCompliancePolicyExtend also extends the break by the time
+	// the user was active.
+	BreakCompliancePolicyExtend = BreakCompliancePolicy("extend")
+)
+
+func (p *Pomodoro) isMonitoringBreak() bool {
+	return p.settings.BreakCompliance != BreakCompliancePolicyOff &&
+		p.IdleDetector != nil &&
+		p.isRunning() && !p.isWork && !p.isStopwatch
+}
+
+// checkBreakActivity is called every idleCheckInterval; the user is
+// considered active during the whole interval if there was any input
+// within it.
+func (p *Pomodoro) checkBreakActivity(idleTime time.Duration) {
+	if !p.isMonitoringBreak() || idleTime >= idleCheckInterval {
+		return
+	}
+	p.phaseActive += idleCheckInterval
+
+	switch p.settings.BreakCompliance {
+	case BreakCompliancePolicyWarn:
+		if !p.breakWarnedAt.IsZero() && p.since(p.breakWarnedAt) < breakActivityWarnEvery {
+			return
+		}
+		p.breakWarnedAt = p.clock.Now()
+		p.notify(l10n.T("It's a break! Step away from the keyboard."))
+	case BreakCompliancePolicyExtend:
+		if p.phaseActive > maxBreakExtension {
+			return
+		}
+		p.extend(idleCheckInterval)
+	}
+}
+
+// BreakCompliance returns the share of the breaks of the last days
+// spent away from the computer, ok is false if no break was monitored.
+func (p *Pomodoro) BreakCompliance() (compliance float64, ok bool) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.breakCompliance()
+}
+
+func (p *Pomodoro) breakCompliance() (float64, bool) {
+	if p.history == nil {
+		return 0, false
+	}
+	since := history.DayStart(p.clock.Now(), p.settings.DayBoundary).AddDate(0, 0, -BreakComplianceDays+1)
+	var breaks []history.Session
+	for _, session := range p.history.Sessions() {
+		if session.StartedAt.Before(since) {
+			continue
+		}
+		switch session.Phase {
+		case PhaseRest.String(), PhaseLongRest.String():
+			breaks = append(breaks, session)
+		}
+	}
+	return history.BreakCompliance(breaks)
+}
@@ -0,0 +1,81 @@
+package pomodoro
+
+import (
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+const (
+	prefKeyBreakDebt = "break_debt"
+)
+
+// BreakDebtSettings configure tracking the skipped breaks: the untaken
+// break time is accumulated, and after it reaches Limit the next break
+// is extended by the whole debt.
+type BreakDebtSettings struct {
+	// Limit is the debt to extend the next break after, 0 disables tracking.
+	Limit time.Duration
+
+	// Enforce makes the extended break full-screen without
+	// the possibility to skip it.
+	Enforce bool
+}
+
+// BreakDebt returns the accumulated untaken break time.
+func (p *Pomodoro) BreakDebt() time.Duration {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.breakDebt
+}
+
+// includedBreakDebt returns the debt to extend the next break by.
+func (p *Pomodoro) includedBreakDebt() time.Duration {
+	limit := p.settings.BreakDebt.Limit
+	if limit == 0 || p.breakDebt < limit {
+		return 0
+	}
+	return p.breakDebt
+}
+
+func (p *Pomodoro) baseRestInterval() time.Duration {
+	if p.isLongBreak {
+		return p.nextLongRestInterval
+	}
+	return p.nextRestInterval
+}
+
+// skipBreak accumulates the untaken time of the current or the pending
+// break, since the work is started instead of it.
+func (p *Pomodoro) skipBreak() {
+	if p.settings.BreakDebt.Limit == 0 {
+		return
+	}
+	switch {
+	case p.isRunning():
+		p.addBreakDebt(p.until(p.deadline) - p.phaseBreakDebt)
+	case p.isPaused:
+		p.addBreakDebt(p.pausedTimeLeft - p.phaseBreakDebt)
+	default:
+		p.addBreakDebt(p.baseRestInterval())
+	}
+}
+
+func (p *Pomodoro) addBreakDebt(d time.Duration) {
+	limit := p.settings.BreakDebt.Limit
+	wasBelowLimit := p.breakDebt < limit
+	p.breakDebt = max(p.breakDebt+d, 0)
+	setDuration(p.prefs, prefKeyBreakDebt, p.breakDebt)
+	if wasBelowLimit && limit > 0 && p.breakDebt >= limit {
+		p.notify(l10n.T("You skipped %d minutes of breaks, the next break is extended by them.", int(p.breakDebt/time.Minute)))
+	}
+	p.refresh()
+}
+
+// IsBreakEnforced returns true if the current break pays the break debt
+// back, so it cannot be skipped (see BreakDebtSettings.Enforce).
+func (p *Pomodoro) IsBreakEnforced() bool {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.settings.BreakDebt.Enforce && p.phaseBreakDebt > 0
+}
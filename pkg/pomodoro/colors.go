@@ -0,0 +1,110 @@
+package pomodoro
+
+import (
+	"encoding/json"
+	"image/color"
+	"log/slog"
+)
+
+const (
+	prefKeyColors = "colors"
+)
+
+type PhaseColors struct {
+	Digits      color.NRGBA
+	Delimiter   color.NRGBA
+	Description color.NRGBA
+	Background  color.NRGBA
+}
+
+// ColorPalette is the set of the colors of the timer, picked for the
+// light or the dark theme (see Settings.ColorPalette).
+type ColorPalette string
+
+const (
+	// ColorPaletteDefault is red for the work and green for the breaks,
+	// or Settings.Colors if they are customized.
+	ColorPaletteDefault = ColorPalette("default")
+
+	// ColorPaletteColorBlind distinguishes the phases by orange and
+	// blue (of the Okabe-Ito palette) instead of red and green.
+	ColorPaletteColorBlind = ColorPalette("color-blind")
+)
+
+// ColorPalettes are the choices of Settings.ColorPalette.
+var ColorPalettes = []ColorPalette{
+	ColorPaletteDefault,
+	ColorPaletteColorBlind,
+}
+
+// Theme defines the colors of the timer per phase. Idle colors are used
+// when no timer is running.
+type Theme struct {
+	Idle     PhaseColors
+	Work     PhaseColors
+	Rest     PhaseColors
+	LongRest PhaseColors
+}
+
+func DefaultTheme() Theme {
+	return Theme{
+		Idle: PhaseColors{
+			Digits:      color.NRGBA{R: 255, G: 255, B: 255, A: 255},
+			Delimiter:   color.NRGBA{R: 128, G: 128, B: 128, A: 255},
+			Description: color.NRGBA{R: 224, G: 224, B: 224, A: 255},
+		},
+		Work: PhaseColors{
+			Digits:      color.NRGBA{R: 255, G: 224, B: 224, A: 255},
+			Delimiter:   color.NRGBA{R: 160, G: 112, B: 112, A: 255},
+			Description: color.NRGBA{R: 255, G: 192, B: 192, A: 255},
+			Background:  color.NRGBA{R: 64, G: 0, B: 0, A: 64},
+		},
+		Rest: PhaseColors{
+			Digits:      color.NRGBA{R: 224, G: 255, B: 224, A: 255},
+			Delimiter:   color.NRGBA{R: 112, G: 160, B: 112, A: 255},
+			Description: color.NRGBA{R: 192, G: 255, B: 192, A: 255},
+			Background:  color.NRGBA{R: 0, G: 64, B: 0, A: 64},
+		},
+		LongRest: PhaseColors{
+			Digits:      color.NRGBA{R: 224, G: 240, B: 255, A: 255},
+			Delimiter:   color.NRGBA{R: 112, G: 136, B: 160, A: 255},
+			Description: color.NRGBA{R: 192, G: 224, B: 255, A: 255},
+			Background:  color.NRGBA{R: 0, G: 32, B: 64, A: 64},
+		},
+	}
+}
+
+func (t Theme) ForPhase(phase Phase) PhaseColors {
+	switch phase {
+	case PhaseWork, PhaseStopwatch:
+		return t.Work
+	case PhaseRest:
+		return t.Rest
+	case PhaseLongRest:
+		return t.LongRest
+	default:
+		return t.Idle
+	}
+}
+
+func loadTheme(prefs Preferences) Theme {
+	t := DefaultTheme()
+	serialized := prefs.String(prefKeyColors)
+	if serialized == "" {
+		return t
+	}
+	if err := json.Unmarshal([]byte(serialized), &t); err != nil {
+		slog.Warn("unable to parse the colors preference, using the defaults", "error", err)
+		return DefaultTheme()
+	}
+	return t
+}
+
+func saveTheme(prefs Preferences, t Theme) {
+	serialized, err := json.Marshal(t)
+	if err != nil {
+		slog.Error("unable to serialize the colors", "error", err)
+		return
+	}
+	prefs.SetString(prefKeyColors, string(serialized))
+}
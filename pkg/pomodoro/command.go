@@ -0,0 +1,66 @@
+package pomodoro
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/xaionaro-go/pomodoro/pkg/urlscheme"
+)
+
+// Commands are the actions which could be passed as the command line
+// arguments (also to an already running instance).
+var Commands = []string{
+	"work",
+	"rest",
+	"pause",
+	"resume",
+	"toggle",
+	"stop",
+	"stopwatch",
+	"acknowledge",
+	"undo",
+}
+
+// RunCommand runs one of Commands or the action of a URL (see OpenURL).
+func (p *Pomodoro) RunCommand(command string) error {
+	if urlscheme.IsURL(command) {
+		return p.OpenURL(command)
+	}
+	switch command {
+	case "work":
+		p.Start(true)
+	case "rest":
+		p.Start(false)
+	case "pause":
+		p.Pause()
+	case "resume":
+		p.Resume()
+	case "toggle":
+		p.TogglePause()
+	case "stop":
+		p.StopTimer()
+	case "stopwatch":
+		p.StartStopwatch()
+	case "acknowledge":
+		p.AcknowledgeAlarm()
+	case "undo":
+		p.Undo()
+	default:
+		return fmt.Errorf("unknown command '%s', expected one of: %s", command, strings.Join(Commands, ", "))
+	}
+	return nil
+}
+
+// Activate brings the window to the front and runs the commands
+// passed (for example, by another started instance).
+func (p *Pomodoro) Activate(commands []string) {
+	p.locker.Lock()
+	p.frontend.Raise()
+	p.locker.Unlock()
+	for _, command := range commands {
+		if err := p.RunCommand(command); err != nil {
+			slog.Error("unable to run the command", "command", command, "error", err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package pomodoro
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// CommitmentMode defines what happens on an attempt to bail out of
+// a running work session: to stop it, to switch to a rest or
+// a stopwatch, or to change (shorten) the interval.
+type CommitmentMode string
+
+const (
+	CommitmentModeOff = CommitmentMode("off")
+
+	// CommitmentModeLocked refuses such actions until the session ends.
+	CommitmentModeLocked = CommitmentMode("locked")
+
+	// CommitmentModePhrase allows such actions after typing
+	// CommitmentSettings.Phrase.
+	CommitmentModePhrase = CommitmentMode("phrase")
+)
+
+// CommitmentModes are the choices of CommitmentSettings.Mode.
+var CommitmentModes = []CommitmentMode{
+	CommitmentModeOff,
+	CommitmentModeLocked,
+	CommitmentModePhrase,
+}
+
+type CommitmentSettings struct {
+	Mode   CommitmentMode
+	Phrase string
+}
+
+// IsCommitted returns true if bailing out of the current work session
+// is restricted (see CommitmentMode).
+func (p *Pomodoro) IsCommitted() bool {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.isCommitted()
+}
+
+func (p *Pomodoro) isCommitted() bool {
+	switch p.settings.Commitment.Mode {
+	case CommitmentModeLocked, CommitmentModePhrase:
+	default:
+		return false
+	}
+	if !p.isWork || p.isStopwatch || p.commitmentReleased {
+		return false
+	}
+	return p.isRunning() || p.isPaused
+}
+
+// refuseIfCommitted is the guard of the bail-out actions,
+// it returns true if the action should not be done.
+func (p *Pomodoro) refuseIfCommitted(action string) bool {
+	if !p.isCommitted() {
+		return false
+	}
+	slog.Info("refused during a committed work session", "action", action)
+	return true
+}
+
+// ReleaseCommitment lifts the restrictions for the rest of the current
+// work session if the phrase matches (in CommitmentModePhrase only).
+func (p *Pomodoro) ReleaseCommitment(phrase string) error {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if !p.isCommitted() {
+		return nil
+	}
+	if p.settings.Commitment.Mode != CommitmentModePhrase {
+		return fmt.Errorf("the work session cannot be interrupted until it ends")
+	}
+	if !strings.EqualFold(strings.TrimSpace(phrase), strings.TrimSpace(p.settings.Commitment.Phrase)) {
+		return fmt.Errorf("the phrase does not match")
+	}
+	p.commitmentReleased = true
+	p.refresh()
+	return nil
+}
@@ -0,0 +1,123 @@
+package pomodoro
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/audio"
+)
+
+const (
+	countdownSeconds      = 10
+	countdownTickFreq     = 1760
+	countdownTickDuration = 60 * time.Millisecond
+)
+
+// CountdownMode defines what is played during the last seconds
+// of an interval (see countdownSeconds).
+type CountdownMode string
+
+const (
+	CountdownModeOff   = CountdownMode("off")
+	CountdownModeTick  = CountdownMode("tick")
+	CountdownModeVoice = CountdownMode("voice")
+)
+
+// countdownState is accessed only from handleCountdownEvent
+// (except voiceSamples, see prepareVoiceSamples).
+type countdownState struct {
+	lastSecond   int
+	tickSample   *audio.Sample
+	voicePrepare sync.Once
+	voiceSamples atomic.Pointer[[]*audio.Sample]
+}
+
+func (p *Pomodoro) handleCountdownEvent(ev Event) {
+	p.locker.Lock()
+	mode := p.settings.Countdown
+	p.locker.Unlock()
+	if mode != CountdownModeTick && mode != CountdownModeVoice || !p.HasAudio() {
+		return
+	}
+
+	c := &p.countdown
+	switch ev.Type {
+	case EventTypePhaseStarted, EventTypeResumed:
+		if mode == CountdownModeVoice {
+			c.voicePrepare.Do(func() {
+				p.lifecycle.launch(p.prepareVoiceSamples)
+			})
+		}
+		return
+	case EventTypeTick:
+	default:
+		return
+	}
+	if ev.Phase == PhaseStopwatch || ev.TimeLeft <= 0 || ev.TimeLeft > countdownSeconds*time.Second {
+		c.lastSecond = 0
+		return
+	}
+	second := int((ev.TimeLeft + time.Second - 1) / time.Second)
+	if second == c.lastSecond {
+		return
+	}
+	c.lastSecond = second
+
+	sample := p.countdownSample(mode, second)
+	p.Player.Start(p.lifecycle.ctx, audio.ChannelCountdown, sample.Stream(), func() float64 { return 1 }, func(err error) {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			slog.Error("unable to play the countdown", "second", second, "error", err)
+		}
+	})
+}
+
+// countdownSample returns the spoken number if it is ready,
+// and the tick otherwise.
+func (p *Pomodoro) countdownSample(
+	mode CountdownMode,
+	second int,
+) *audio.Sample {
+	c := &p.countdown
+	if mode == CountdownModeVoice {
+		if samples := c.voiceSamples.Load(); samples != nil && (*samples)[second] != nil {
+			return (*samples)[second]
+		}
+	}
+	if c.tickSample == nil {
+		c.tickSample = audio.NewToneSample(countdownTickFreq, countdownTickDuration)
+	}
+	return c.tickSample
+}
+
+// prepareVoiceSamples synthesizes the numbers in advance, since
+// the synthesis takes too long to be done each second.
+func (p *Pomodoro) prepareVoiceSamples() {
+	if p.Synthesizer == nil {
+		return
+	}
+	samples := make([]*audio.Sample, countdownSeconds+1)
+	for second := 1; second <= countdownSeconds; second++ {
+		wav, err := p.Synthesizer.Synthesize(strconv.Itoa(second))
+		if err != nil {
+			slog.Warn("unable to synthesize the countdown, falling back to the ticks", "error", err)
+			return
+		}
+		stream, err := audio.NewWAVStream(bytes.NewReader(wav))
+		if err != nil {
+			slog.Warn("unable to decode the synthesized countdown, falling back to the ticks", "error", err)
+			return
+		}
+		samples[second], err = audio.Decode(stream)
+		if err != nil {
+			slog.Warn("unable to decode the synthesized countdown, falling back to the ticks", "error", err)
+			return
+		}
+	}
+	p.countdown.voiceSamples.Store(&samples)
+}
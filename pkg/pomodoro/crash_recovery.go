@@ -0,0 +1,186 @@
+package pomodoro
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+const (
+	journalFileName = "journal.jsonl"
+
+	// journalHeartbeat is how often the running session is checkpointed,
+	// it bounds the time lost by a crash.
+	journalHeartbeat = 15 * time.Second
+)
+
+// openJournal opens the journal of the running session and picks
+// the session interrupted by a crash of the previous run, if any
+// (see offerRecovery).
+func (p *Pomodoro) openJournal() {
+	path, err := p.dataFilePath(journalFileName)
+	if err != nil {
+		slog.Warn("unable to prepare the data directory", "error", err)
+	}
+	j, err := history.OpenJournal(path)
+	if err != nil {
+		slog.Warn("unable to open the session journal, the sessions will not be recovered after a crash", "error", err)
+		j, _ = history.OpenJournal("")
+	}
+	p.journal = j
+
+	record, ok, err := j.Last()
+	if err != nil {
+		slog.Error("unable to read the session journal", "error", err)
+		return
+	}
+	if ok {
+		p.recovered = &record
+	}
+}
+
+func (p *Pomodoro) handleJournalEvent(ev Event) {
+	switch ev.Type {
+	case EventTypePhaseStarted, EventTypePaused, EventTypeResumed, EventTypeWokeUp, EventTypeOvertimeStarted:
+	case EventTypeTick:
+		if ev.Time.Sub(p.journalWrittenAt) < journalHeartbeat {
+			return
+		}
+	case EventTypePhaseEnded, EventTypeStopped:
+		if err := p.journal.Clear(); err != nil {
+			slog.Error("unable to clear the session journal", "error", err)
+		}
+		return
+	default:
+		return
+	}
+
+	p.locker.Lock()
+	if p.isStopwatch || (!p.isRunning() && !p.isPaused) {
+		p.locker.Unlock()
+		return
+	}
+	status := p.status()
+	record := history.JournalRecord{
+		Time:      p.clock.Now(),
+		Phase:     status.Phase.String(),
+		StartedAt: p.phaseStartedAt,
+		Planned:   p.phasePlanned,
+		Elapsed:   status.Elapsed,
+		TimeLeft:  status.TimeLeft,
+		Paused:    status.IsPaused,
+		Task:      p.task,
+	}
+	p.locker.Unlock()
+
+	p.journalWrittenAt = ev.Time
+	if err := p.journal.Write(record); err != nil {
+		slog.Error("unable to write to the session journal", "error", err)
+	}
+}
+
+// offerRecovery asks whether to resume the session interrupted by
+// a crash of the previous run; without the UI it is resumed right away.
+func (p *Pomodoro) offerRecovery() {
+	p.locker.Lock()
+	record := p.recovered
+	p.locker.Unlock()
+	if record == nil {
+		return
+	}
+
+	slog.Warn("the previous run ended unexpectedly during a session",
+		"phase", record.Phase, "started_at", record.StartedAt, "last_checkpoint", record.Time)
+	message := l10n.T(
+		"The app was closed unexpectedly %s ago during the interval started at %s, %s were left.",
+		p.since(record.Time).Round(time.Minute), l10n.FormatClock(record.StartedAt), record.TimeLeft.Round(time.Second),
+	)
+	p.locker.Lock()
+	offered := p.frontend.OfferRecovery(message)
+	p.locker.Unlock()
+	if offered {
+		return
+	}
+	p.notify(message + " " + l10n.T("The interval is resumed."))
+	p.RecoverSession(true)
+}
+
+// RecoverSession resumes the session interrupted by a crash (the time
+// the app was not running is not counted) or, if resume is false or
+// another session is already running, records it as interrupted.
+// The crash is logged as an interruption of the session.
+func (p *Pomodoro) RecoverSession(resume bool) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	record := p.recovered
+	if record == nil {
+		return
+	}
+	p.recovered = nil
+	crash := history.Interruption{
+		At:     record.Time,
+		Kind:   history.InterruptionKindCrash,
+		Reason: l10n.T("the app was not running for %s", p.since(record.Time).Round(time.Second)),
+	}
+
+	if !resume || p.isRunning() || p.isPaused {
+		p.recordCrashedSession(*record, crash)
+		return
+	}
+
+	isWork := record.Phase == PhaseWork.String()
+	if isWork {
+		p.task = record.Task
+	}
+	p.start(isWork)
+	if !p.isRunning() {
+		p.recordCrashedSession(*record, crash)
+		return
+	}
+	if !isWork {
+		p.isLongBreak = record.Phase == PhaseLongRest.String()
+		p.setDescription(p.settings.Labels.Description(p.phase()))
+		p.refresh()
+	}
+	p.overridePhaseDuration(record.TimeLeft)
+	p.phaseStartedAt = record.StartedAt
+	p.phasePlanned = record.Planned
+	p.phaseElapsed = record.Elapsed
+	p.phaseInterruptions = []history.Interruption{crash}
+	p.refreshProgress()
+	if record.Paused {
+		p.pause()
+	}
+}
+
+func (p *Pomodoro) recordCrashedSession(
+	record history.JournalRecord,
+	crash history.Interruption,
+) {
+	if !p.isRunning() && !p.isPaused {
+		// otherwise the journal is already of the new session
+		if err := p.journal.Clear(); err != nil {
+			slog.Error("unable to clear the session journal", "error", err)
+		}
+	}
+	if p.history == nil {
+		return
+	}
+	session := history.Session{
+		StartedAt:     record.StartedAt,
+		EndedAt:       record.Time,
+		Phase:         record.Phase,
+		Planned:       record.Planned,
+		Duration:      record.Elapsed,
+		Interruptions: []history.Interruption{crash},
+	}
+	if record.Phase == PhaseWork.String() {
+		session.Task = record.Task
+		session.Tags = history.ParseTags(record.Task)
+	}
+	if err := p.history.Add(session); err != nil {
+		slog.Error("unable to record the interrupted session", "error", err)
+	}
+}
@@ -0,0 +1,128 @@
+package pomodoro
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+const (
+	dailySummaryCheckInterval = time.Minute
+)
+
+// DailySummarySettings configure the end-of-day summary sent at the time
+// At (since the midnight).
+type DailySummarySettings struct {
+	Enabled bool
+	At      time.Duration
+}
+
+type TaskFocus struct {
+	Task  string
+	Focus time.Duration
+}
+
+type DailySummary struct {
+	Sessions      uint
+	Focus         time.Duration
+	Interruptions uint
+	Goal          uint
+	Tasks         []TaskFocus
+}
+
+func (p *Pomodoro) DailySummary() DailySummary {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.dailySummary()
+}
+
+func (p *Pomodoro) dailySummary() DailySummary {
+	summary := DailySummary{
+		Goal: p.settings.DailyGoal,
+	}
+	if p.history == nil {
+		return summary
+	}
+
+	taskFocus := map[string]time.Duration{}
+	dayStart := history.DayStart(p.clock.Now(), p.settings.DayBoundary)
+	for _, session := range p.history.SessionsSince(dayStart) {
+		summary.Interruptions += uint(len(session.Interruptions))
+		if !session.Completed {
+			continue
+		}
+		switch session.Phase {
+		case PhaseWork.String(), PhaseStopwatch.String():
+		default:
+			continue
+		}
+		summary.Sessions++
+		summary.Focus += session.Duration
+		taskFocus[session.Task] += session.Duration
+	}
+	for task, focus := range taskFocus {
+		summary.Tasks = append(summary.Tasks, TaskFocus{Task: task, Focus: focus})
+	}
+	slices.SortFunc(summary.Tasks, func(a, b TaskFocus) int {
+		return cmp.Compare(b.Focus, a.Focus)
+	})
+	return summary
+}
+
+func (s DailySummary) String() string {
+	text := l10n.T(
+		"Today: %d sessions, %s of focus, %d interruptions.",
+		s.Sessions, s.Focus.Round(time.Minute), s.Interruptions,
+	)
+	if s.Goal > 0 {
+		text += " " + l10n.T("Daily goal: %d/%d.", s.Sessions, s.Goal)
+	}
+	return text
+}
+
+// watchDailySummary sends the end-of-day summary once a day at the
+// configured time (the check is periodic to survive the suspends and
+// the changes of the settings).
+func (p *Pomodoro) watchDailySummary() {
+	p.locker.Lock()
+	sentDay := p.dailySummaryDay()
+	p.locker.Unlock()
+
+	ticker := p.clock.NewTicker(dailySummaryCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.lifecycle.ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		p.locker.Lock()
+		day := p.dailySummaryDay()
+		if !p.settings.DailySummary.Enabled || day.IsZero() || day.Equal(sentDay) {
+			p.locker.Unlock()
+			continue
+		}
+		sentDay = day
+		summary := p.dailySummary()
+		p.frontend.DailySummaryDue(summary)
+		p.locker.Unlock()
+
+		// the summary is usually sent after the working hours
+		p.deliverNotification(summary.String())
+	}
+}
+
+// dailySummaryDay returns the (calendar) day the summary is due for,
+// or zero time if it is not due yet today.
+func (p *Pomodoro) dailySummaryDay() time.Time {
+	now := p.clock.Now()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if now.Before(day.Add(p.settings.DailySummary.At)) {
+		return time.Time{}
+	}
+	return day
+}
@@ -0,0 +1,64 @@
+package pomodoro
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/xaionaro-go/pomodoro/pkg/datadir"
+)
+
+// openPreferences opens the preferences kept in dirs, they are kept only
+// in memory if there is no directory for them (see WithPreferences).
+func openPreferences(dirs datadir.Dirs) Preferences {
+	if dirs.Config == "" {
+		return datadir.NewPreferences()
+	}
+	prefs, err := datadir.OpenPreferences(dirs.Config)
+	if err != nil {
+		slog.Error("unable to open the preferences, using the default ones", "dir", dirs.Config, "error", err)
+		return datadir.NewPreferences()
+	}
+	return prefs
+}
+
+// dataDir returns the directory for the history and the sounds,
+// empty if there is no storage.
+func (p *Pomodoro) dataDir() string {
+	return p.Dirs.Data
+}
+
+// dataFilePath returns the path of the file in the data directory, the file
+// is moved there from the storage of the Fyne application (where it used
+// to be kept before the XDG data directory was respected) if needed.
+func (p *Pomodoro) dataFilePath(fileName string) (string, error) {
+	dir := p.dataDir()
+	if dir == "" {
+		return "", nil
+	}
+	path := filepath.Join(dir, fileName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return path, fmt.Errorf("unable to create the directory '%s': %w", dir, err)
+	}
+	legacyDir := datadir.FyneStorageDir()
+	if p.Dirs.IsPortable || legacyDir == "" {
+		return path, nil
+	}
+	legacyPath := filepath.Join(legacyDir, fileName)
+	if legacyPath == path {
+		return path, nil
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		return path, nil
+	}
+	if _, err := os.Stat(legacyPath); err != nil {
+		return path, nil
+	}
+	if err := os.Rename(legacyPath, path); err != nil {
+		return legacyPath, fmt.Errorf("unable to move '%s' to '%s': %w", legacyPath, path, err)
+	}
+	slog.Info("moved the file to the data directory", "from", legacyPath, "to", path)
+	return path, nil
+}
@@ -0,0 +1,29 @@
+package pomodoro
+
+import (
+	"log/slog"
+)
+
+func (p *Pomodoro) handleDNDEvent(ev Event) {
+	p.locker.Lock()
+	enabled := p.settings.DoNotDisturb
+	controller := p.DND
+	p.locker.Unlock()
+	if controller == nil {
+		return
+	}
+
+	switch {
+	case ev.Type == EventTypePhaseStarted && (ev.Phase == PhaseWork || ev.Phase == PhaseStopwatch):
+		if !enabled {
+			return
+		}
+		if err := controller.Enable(); err != nil {
+			slog.Error("unable to enable Do-Not-Disturb", "error", err)
+		}
+	case ev.Type == EventTypePhaseStarted, ev.Type == EventTypePhaseEnded, ev.Type == EventTypeStopped:
+		if err := controller.Disable(); err != nil {
+			slog.Error("unable to disable Do-Not-Disturb", "error", err)
+		}
+	}
+}
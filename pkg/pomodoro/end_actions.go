@@ -0,0 +1,39 @@
+package pomodoro
+
+import (
+	"time"
+)
+
+// ContinueEnded runs the interval which has just ended for d more
+// (it is recorded as a separate session).
+func (p *Pomodoro) ContinueEnded(d time.Duration) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.isRunning() || p.isPaused || p.isStopwatch {
+		return
+	}
+	wasWork := !p.isWork
+	p.start(wasWork)
+	p.overridePhaseDuration(d)
+}
+
+// overridePhaseDuration makes the just started interval last d.
+func (p *Pomodoro) overridePhaseDuration(d time.Duration) {
+	if !p.isRunning() {
+		return
+	}
+	p.deadline = p.clock.Now().Add(d)
+	p.phasePlanned = d
+	p.setTimeLeft(d)
+	p.refreshProgress()
+	p.emitEvent(EventTypeTick, d)
+}
+
+// DoneForNow silences the alarm and cancels the automatic start
+// of the next interval.
+func (p *Pomodoro) DoneForNow() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.stopAlarm()
+	p.cancelAutoContinue()
+}
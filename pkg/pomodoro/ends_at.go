@@ -0,0 +1,20 @@
+package pomodoro
+
+import (
+	"time"
+)
+
+// PhaseEndsAt returns the moment the current interval ends; ok is false
+// if the timer is not counting down (stopped, paused or a stopwatch).
+func (p *Pomodoro) PhaseEndsAt() (endsAt time.Time, ok bool) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.phaseEndsAt()
+}
+
+func (p *Pomodoro) phaseEndsAt() (time.Time, bool) {
+	if !p.isRunning() || p.isStopwatch {
+		return time.Time{}, false
+	}
+	return p.wallDeadline(), true
+}
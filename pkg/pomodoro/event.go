@@ -0,0 +1,194 @@
+package pomodoro
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	eventQueueSize = 256
+)
+
+type Phase int
+
+const (
+	PhaseUndefined Phase = iota
+	PhaseWork
+	PhaseRest
+	PhaseLongRest
+	PhaseStopwatch
+)
+
+func (phase Phase) String() string {
+	switch phase {
+	case PhaseUndefined:
+		return "undefined"
+	case PhaseWork:
+		return "work"
+	case PhaseRest:
+		return "rest"
+	case PhaseLongRest:
+		return "long_rest"
+	case PhaseStopwatch:
+		return "stopwatch"
+	default:
+		return fmt.Sprintf("unknown_phase_%d", int(phase))
+	}
+}
+
+type EventType int
+
+const (
+	EventTypeUndefined EventType = iota
+	EventTypePhaseStarted
+	EventTypeTick
+	EventTypePhaseEnded
+	EventTypePaused
+	EventTypeResumed
+	EventTypeStopped
+	EventTypePhaseEnding
+	EventTypeWokeUp
+	EventTypeOvertimeStarted
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventTypeUndefined:
+		return "undefined"
+	case EventTypePhaseStarted:
+		return "phase_started"
+	case EventTypeTick:
+		return "tick"
+	case EventTypePhaseEnded:
+		return "phase_ended"
+	case EventTypePaused:
+		return "paused"
+	case EventTypeResumed:
+		return "resumed"
+	case EventTypeStopped:
+		return "stopped"
+	case EventTypePhaseEnding:
+		return "phase_ending"
+	case EventTypeWokeUp:
+		return "woke_up"
+	case EventTypeOvertimeStarted:
+		return "overtime_started"
+	default:
+		return fmt.Sprintf("unknown_event_type_%d", int(t))
+	}
+}
+
+type Event struct {
+	Type     EventType
+	Phase    Phase
+	TimeLeft time.Duration
+	Elapsed  time.Duration
+	Deadline time.Time
+	Time     time.Time
+
+	// DisplayedTimeLeft is TimeLeft rounded to the seconds as it is
+	// shown (see TimeRounding).
+	DisplayedTimeLeft time.Duration
+
+	// Suspended is how long the system was suspended (for EventTypeWokeUp).
+	Suspended time.Duration
+
+	// Task is the task at the moment of the event (see SetTask).
+	Task string
+
+	// Restored is true if the event reports the state restored by Undo
+	// rather than a new transition: the side-effecting subscribers
+	// (hooks, webhooks, calendar, etc) should skip it.
+	Restored bool
+}
+
+// OnEvent registers a handler that is called for every timer event.
+//
+// Handlers are called sequentially from a dedicated goroutine (one per handler),
+// so it is safe to call methods of Pomodoro from a handler. The returned function
+// unsubscribes the handler.
+func (p *Pomodoro) OnEvent(
+	handler func(Event),
+) (unsubscribe func()) {
+	queue := make(chan Event, eventQueueSize)
+
+	p.eventSubscribersLocker.Lock()
+	launched := p.lifecycle.launch(func() {
+		for ev := range queue {
+			handler(ev)
+		}
+	})
+	if !launched {
+		p.eventSubscribersLocker.Unlock()
+		return func() {}
+	}
+	if p.eventSubscribers == nil {
+		p.eventSubscribers = map[uint64]chan Event{}
+	}
+	p.eventSubscriberNextID++
+	id := p.eventSubscriberNextID
+	p.eventSubscribers[id] = queue
+	p.eventSubscribersLocker.Unlock()
+
+	return func() {
+		p.eventSubscribersLocker.Lock()
+		defer p.eventSubscribersLocker.Unlock()
+		if _, ok := p.eventSubscribers[id]; !ok {
+			return
+		}
+		delete(p.eventSubscribers, id)
+		close(queue)
+	}
+}
+
+func (p *Pomodoro) phase() Phase {
+	switch {
+	case p.isStopwatch:
+		return PhaseStopwatch
+	case p.isWork:
+		return PhaseWork
+	case p.isLongBreak:
+		return PhaseLongRest
+	default:
+		return PhaseRest
+	}
+}
+
+func (p *Pomodoro) emitEvent(
+	eventType EventType,
+	timeLeft time.Duration,
+) {
+	p.emit(p.newEvent(eventType, timeLeft))
+}
+
+func (p *Pomodoro) newEvent(
+	eventType EventType,
+	timeLeft time.Duration,
+) Event {
+	if p.isStopwatch {
+		timeLeft = 0
+	}
+	return Event{
+		Type:              eventType,
+		Phase:             p.phase(),
+		TimeLeft:          timeLeft,
+		Elapsed:           p.elapsed(),
+		Deadline:          p.wallDeadline(),
+		Time:              p.clock.Now(),
+		DisplayedTimeLeft: p.displayedTimeLeft(timeLeft),
+		Task:              p.task,
+	}
+}
+
+func (p *Pomodoro) emit(ev Event) {
+	p.eventSubscribersLocker.Lock()
+	defer p.eventSubscribersLocker.Unlock()
+	for _, queue := range p.eventSubscribers {
+		select {
+		case queue <- ev:
+		default:
+			slog.Warn("the event queue is full, dropping the event", "event", ev.Type)
+		}
+	}
+}
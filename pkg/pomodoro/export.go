@@ -0,0 +1,60 @@
+package pomodoro
+
+import (
+	"io"
+
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+)
+
+// ExportHistory writes the sessions having the tag (all of them
+// if the tag is empty) to w. The calendar (history.FormatICS) gets
+// only the completed focus sessions.
+func (p *Pomodoro) ExportHistory(
+	w io.Writer,
+	format history.Format,
+	tag string,
+) error {
+	p.locker.Lock()
+	var sessions []history.Session
+	if format == history.FormatICS {
+		sessions = p.focusSessions()
+	} else if p.history != nil {
+		sessions = p.history.Sessions()
+	}
+	p.locker.Unlock()
+	return history.Export(w, format, history.FilterByTag(sessions, tag))
+}
+
+// Tags returns the tags of the recorded sessions.
+func (p *Pomodoro) Tags() []string {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.history == nil {
+		return nil
+	}
+	return p.history.Tags()
+}
+
+// FocusSessions returns the completed work (and stopwatch) sessions.
+func (p *Pomodoro) FocusSessions() []history.Session {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.focusSessions()
+}
+
+func (p *Pomodoro) focusSessions() []history.Session {
+	if p.history == nil {
+		return nil
+	}
+	var result []history.Session
+	for _, session := range p.history.Sessions() {
+		if !session.Completed {
+			continue
+		}
+		switch session.Phase {
+		case PhaseWork.String(), PhaseStopwatch.String():
+			result = append(result, session)
+		}
+	}
+	return result
+}
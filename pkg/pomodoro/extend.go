@@ -0,0 +1,48 @@
+package pomodoro
+
+import (
+	"time"
+)
+
+const (
+	// ExtendStep is the step of extending (or shortening)
+	// the interval from the UI.
+	ExtendStep = 5 * time.Minute
+)
+
+// Extend moves the deadline of the current interval by d (which may be
+// negative to shorten it). If the interval is shortened past the current
+// moment, it ends on the next tick.
+func (p *Pomodoro) Extend(d time.Duration) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.extend(d)
+}
+
+func (p *Pomodoro) extend(d time.Duration) {
+	if p.isStopwatch {
+		return
+	}
+	if d < 0 && p.refuseIfCommitted("shorten the interval") {
+		return
+	}
+
+	var timeLeft time.Duration
+	switch {
+	case p.isPaused:
+		p.pausedTimeLeft = max(p.pausedTimeLeft+d, 0)
+		timeLeft = p.pausedTimeLeft
+	case p.isRunning():
+		timeLeft = max(p.until(p.deadline)+d, 0)
+		p.deadline = p.clock.Now().Add(timeLeft)
+	default:
+		return
+	}
+	p.phasePlanned = max(p.phasePlanned+d, 0)
+	if timeLeft > p.settings.WarnBefore {
+		p.phaseEndingEmitted = false
+	}
+	p.setTimeLeft(timeLeft)
+	p.refreshProgress()
+	p.emitEvent(EventTypeTick, timeLeft)
+}
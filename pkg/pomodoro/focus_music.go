@@ -0,0 +1,209 @@
+package pomodoro
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/audio"
+)
+
+const (
+	focusMusicRetryDelay = 30 * time.Second
+)
+
+// FocusMusicSettings configure the music played during the work
+// and stopped during the breaks.
+type FocusMusicSettings struct {
+	Enabled bool
+
+	// Playlist are the local files, M3U playlists and the URLs
+	// of the streams (for example, an internet radio).
+	Playlist []string
+	Volume   float64
+}
+
+// focusMusicState is accessed under Pomodoro.locker.
+type focusMusicState struct {
+	cancel     context.CancelFunc
+	skip       context.CancelFunc
+	skipped    bool
+	playlist   []string
+	index      int
+	userPaused bool
+	nowPlaying string
+}
+
+// handleFocusMusicEvent starts and stops the music together
+// with the work (see refreshFocusMusic).
+func (p *Pomodoro) handleFocusMusicEvent(ev Event) {
+	switch ev.Type {
+	case EventTypePhaseStarted, EventTypePhaseEnded, EventTypePaused, EventTypeResumed, EventTypeStopped:
+	default:
+		return
+	}
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.refreshFocusMusic()
+}
+
+// refreshFocusMusic plays the playlist if it is enabled and the work
+// (or a stopwatch) is running, and stops it otherwise.
+func (p *Pomodoro) refreshFocusMusic() {
+	m := &p.focusMusic
+	s := p.settings.FocusMusic
+	playlist := audio.ExpandPlaylist(s.Playlist)
+	if !slices.Equal(playlist, m.playlist) {
+		if m.cancel != nil {
+			m.cancel()
+			m.cancel = nil
+		}
+		m.playlist = playlist
+		m.index = 0
+	}
+	shouldPlay := s.Enabled && len(playlist) > 0 && !m.userPaused && p.HasAudio() &&
+		p.isRunning() && (p.isWork || p.isStopwatch)
+	if m.cancel != nil && !shouldPlay {
+		m.cancel()
+		m.cancel = nil
+	}
+	if shouldPlay && m.cancel == nil {
+		ctx, cancelFn := context.WithCancel(p.lifecycle.ctx)
+		m.cancel = cancelFn
+		p.lifecycle.launch(func() {
+			p.loopFocusMusic(ctx, playlist)
+		})
+	}
+	p.refreshFocusMusicPanel()
+}
+
+// refreshFocusMusicPanel shows the player of the focus music
+// (see View.FocusMusic).
+func (p *Pomodoro) refreshFocusMusicPanel() {
+	m := &p.focusMusic
+	s := p.settings.FocusMusic
+	if !s.Enabled || len(m.playlist) == 0 {
+		p.view.FocusMusic = FocusMusicView{}
+		p.refresh()
+		return
+	}
+	track := m.nowPlaying
+	if track == "" {
+		track = m.playlist[m.index%len(m.playlist)]
+	}
+	if !audio.IsURL(track) {
+		track = filepath.Base(track)
+	}
+	p.view.FocusMusic = FocusMusicView{
+		Track:    track,
+		IsPaused: m.userPaused,
+		Volume:   s.Volume,
+	}
+	p.refresh()
+}
+
+func (p *Pomodoro) loopFocusMusic(
+	ctx context.Context,
+	playlist []string,
+) {
+	m := &p.focusMusic
+	failures := 0
+	for ctx.Err() == nil {
+		p.locker.Lock()
+		idx := m.index % len(playlist)
+		trackCtx, skip := context.WithCancel(ctx)
+		m.skip = skip
+		m.skipped = false
+		m.nowPlaying = playlist[idx]
+		p.refreshFocusMusicPanel()
+		p.locker.Unlock()
+
+		err := p.playFocusTrack(trackCtx, playlist[idx])
+		skip()
+		if ctx.Err() != nil {
+			return
+		}
+
+		p.locker.Lock()
+		if !m.skipped {
+			m.index = (idx + 1) % len(playlist)
+		}
+		p.locker.Unlock()
+		if err == nil || errors.Is(err, context.Canceled) {
+			failures = 0
+			continue
+		}
+		slog.Error("unable to play the focus music", "track", playlist[idx], "error", err)
+		failures++
+		if failures < len(playlist) {
+			continue
+		}
+		failures = 0
+		select {
+		case <-ctx.Done():
+		case <-time.After(focusMusicRetryDelay):
+		}
+	}
+}
+
+func (p *Pomodoro) playFocusTrack(
+	ctx context.Context,
+	location string,
+) error {
+	stream, closer, err := audio.Open(ctx, location)
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+	return p.Player.Play(ctx, audio.ChannelMusic, stream, 1)
+}
+
+// ToggleFocusMusic pauses or resumes the focus music
+// (it is still stopped during the breaks).
+func (p *Pomodoro) ToggleFocusMusic() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.focusMusic.userPaused = !p.focusMusic.userPaused
+	p.refreshFocusMusic()
+}
+
+func (p *Pomodoro) NextFocusTrack() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.skipFocusTrack(1)
+}
+
+func (p *Pomodoro) PreviousFocusTrack() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.skipFocusTrack(-1)
+}
+
+func (p *Pomodoro) skipFocusTrack(delta int) {
+	m := &p.focusMusic
+	if len(m.playlist) == 0 {
+		return
+	}
+	m.index = ((m.index+delta)%len(m.playlist) + len(m.playlist)) % len(m.playlist)
+	m.nowPlaying = ""
+	if m.skip != nil && m.cancel != nil {
+		m.skipped = true
+		m.skip()
+	}
+	p.refreshFocusMusicPanel()
+}
+
+// SetFocusMusicVolume changes the volume of the focus music
+// (it is saved right away, without the settings window).
+func (p *Pomodoro) SetFocusMusicVolume(volume float64) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.settings.FocusMusic.Volume = volume
+	p.Player.Mixer.SetVolume(audio.ChannelMusic, volume)
+	p.prefs.SetFloat(prefKeyFocusMusicVolume, volume)
+	p.view.FocusMusic.Volume = volume
+	p.refresh()
+}
@@ -0,0 +1,23 @@
+package pomodoro
+
+import (
+	"fmt"
+)
+
+// RateSession sets the focus quality (from 1 to 5)
+// of the last completed work session.
+func (p *Pomodoro) RateSession(rating uint8) error {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.rateSession(rating)
+}
+
+func (p *Pomodoro) rateSession(rating uint8) error {
+	if p.history == nil || p.lastWorkStartedAt.IsZero() {
+		return fmt.Errorf("no completed work session")
+	}
+	if err := p.history.SetRating(p.lastWorkStartedAt, rating); err != nil {
+		return fmt.Errorf("unable to save the rating: %w", err)
+	}
+	return nil
+}
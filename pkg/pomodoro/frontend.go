@@ -0,0 +1,166 @@
+package pomodoro
+
+import (
+	"time"
+)
+
+// Frontend shows the timer to the user (see WithFrontend and pkg/gui).
+//
+// It is called with the state of the timer locked, so its methods must
+// not call the methods of Pomodoro (but they may launch goroutines doing
+// so). The methods returning bool return false if there is nothing to
+// show the offer in, then the timer falls back to a notification.
+type Frontend interface {
+	// Refresh shows the state of the timer. It is called on every change,
+	// including the ticks, so only the changed parts should be redrawn.
+	Refresh(v View)
+
+	// SettingsApplied adapts the UI to the changed settings.
+	SettingsApplied(s Settings)
+
+	// Flash draws attention to the end of an interval (see Settings.EndFlash).
+	Flash()
+
+	// Raise brings the window to the front (see Pomodoro.Activate).
+	Raise()
+
+	// OfferRecovery asks whether to resume the session interrupted
+	// by a crash (see Pomodoro.RecoverSession).
+	OfferRecovery(message string) bool
+
+	// OfferToEndAt asks whether to end the work session at endAt,
+	// before a meeting (see Pomodoro.EndAt).
+	OfferToEndAt(message string, endAt time.Time) bool
+
+	// NudgeToStart suggests starting a work session
+	// (see WorkScheduleSettings.StartNudgeAfter).
+	NudgeToStart(message string)
+
+	// NudgeOverrun reminds to take a break, the level rises with every
+	// reminder (see Settings.OverrunNudgeEvery). CloseOverrunNudge hides
+	// the reminder once the break is taken.
+	NudgeOverrun(message string, level int) bool
+	CloseOverrunNudge()
+
+	// DailySummaryDue shows the summary of the day once it is due
+	// (see DailySummarySettings).
+	DailySummaryDue(s DailySummary)
+}
+
+// View is the state of the timer as it is shown (see Frontend.Refresh).
+type View struct {
+	Phase      Phase
+	IsRunning  bool
+	IsPaused   bool
+	IsOvertime bool
+
+	// TimeLeft is the shown time: the time left (negative in the overtime)
+	// or the elapsed time of the stopwatch.
+	TimeLeft time.Duration
+	Digits   Digits
+
+	// Progress is the passed part of the interval, from 0 to 1.
+	Progress float64
+
+	// Description names the phase, it is empty while stopped.
+	Description string
+
+	// Caption is the task of the work session or a suggestion
+	// for the break (see Settings.BreakSuggestions).
+	Caption string
+
+	CycleWorkSessions uint
+
+	// CompletedToday is counted when a session is recorded
+	// (see Settings.DailyGoal).
+	CompletedToday uint
+
+	BreakDebt   time.Duration
+	IsOffHours  bool     // see Settings.WorkSchedule
+	RoomMembers []string // see Pomodoro.SetRoomMembers
+	IsCommitted bool     // see Settings.Commitment
+	CanPickTask bool     // see Pomodoro.AddTaskProvider
+
+	IsAlarmRinging bool
+
+	// IsAlarmEscalated asks for the full-screen banner
+	// (see Settings.AlarmEscalation).
+	IsAlarmEscalated bool
+
+	// UndoOffer is the text of the toast offering to undo the last
+	// Stop/Start action (see Pomodoro.Undo), empty if not offered.
+	UndoOffer string
+
+	// AutoContinue is the countdown to the automatic start of the next
+	// interval (see Settings.AutoContinue), empty if none.
+	AutoContinue string
+
+	// ScreenLockIn is the countdown to locking the screen
+	// (see ScreenLockSettings), zero if none.
+	ScreenLockIn time.Duration
+
+	SideTimers []SideTimerView
+	FocusMusic FocusMusicView
+}
+
+// Digits are the shown minutes and seconds.
+type Digits struct {
+	Minutes uint
+	Seconds uint
+	Tenths  int  // -1 if not shown (see Settings.ShowTenths)
+	Minus   bool // the overtime is shown (see Settings.Overtime)
+}
+
+// SideTimerView is a side timer with the time left to show.
+type SideTimerView struct {
+	SideTimer
+	Minutes uint
+	Seconds uint
+}
+
+// FocusMusicView is the state of the focus music player
+// (see FocusMusicSettings).
+type FocusMusicView struct {
+	// Track is the file name or the URL of the current track,
+	// empty if there is no player to show.
+	Track    string
+	IsPaused bool
+	Volume   float64
+}
+
+// nopFrontend is the frontend of the timer without the UI.
+type nopFrontend struct{}
+
+var _ Frontend = nopFrontend{}
+
+func (nopFrontend) Refresh(View)                        {}
+func (nopFrontend) SettingsApplied(Settings)            {}
+func (nopFrontend) Flash()                              {}
+func (nopFrontend) Raise()                              {}
+func (nopFrontend) OfferRecovery(string) bool           { return false }
+func (nopFrontend) OfferToEndAt(string, time.Time) bool { return false }
+func (nopFrontend) NudgeToStart(string)                 {}
+func (nopFrontend) NudgeOverrun(string, int) bool       { return false }
+func (nopFrontend) CloseOverrunNudge()                  {}
+func (nopFrontend) DailySummaryDue(DailySummary)        {}
+
+// refresh shows the current state of the timer in the frontend
+// (see Frontend.Refresh).
+func (p *Pomodoro) refresh() {
+	if p.headless {
+		return
+	}
+	v := p.view
+	v.Phase = p.phase()
+	v.IsRunning = p.isRunning()
+	v.IsPaused = p.isPaused
+	v.IsOvertime = p.isOvertime
+	v.CycleWorkSessions = p.cycleWorkSessions
+	v.BreakDebt = p.breakDebt
+	v.IsOffHours = p.IsOffHours()
+	v.IsCommitted = p.isCommitted()
+	v.CanPickTask = len(p.taskProviders) > 0
+	v.IsAlarmRinging = p.alarmCancel != nil
+	v.SideTimers = p.sideTimerViews()
+	p.frontend.Refresh(v)
+}
@@ -0,0 +1,35 @@
+package pomodoro
+
+import (
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+)
+
+func (p *Pomodoro) CompletedToday() uint {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.completedToday()
+}
+
+func (p *Pomodoro) completedToday() uint {
+	if p.history == nil {
+		return 0
+	}
+	var count uint
+	dayStart := history.DayStart(p.clock.Now(), p.settings.DayBoundary)
+	for _, session := range p.history.SessionsSince(dayStart) {
+		if session.Completed && session.Phase == PhaseWork.String() {
+			count++
+		}
+	}
+	return count
+}
+
+// refreshGoal counts the sessions done today (see View.CompletedToday),
+// it is done on a change only, since it goes through the history.
+func (p *Pomodoro) refreshGoal() {
+	p.view.CompletedToday = 0
+	if p.settings.DailyGoal > 0 {
+		p.view.CompletedToday = p.completedToday()
+	}
+	p.refresh()
+}
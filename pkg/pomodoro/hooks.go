@@ -0,0 +1,70 @@
+package pomodoro
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	hookTimeout = time.Minute
+
+	// hookWaitDelay is how long the output of a killed hook is waited for,
+	// the processes started by the shell may keep it open.
+	hookWaitDelay = time.Second
+)
+
+// HookSettings contains shell commands executed on phase changes;
+// the session is described by the POMODORO_* environment variables.
+type HookSettings struct {
+	OnWorkStart string
+	OnWorkEnd   string
+	OnRestEnd   string
+}
+
+func (s HookSettings) command(ev Event) (string, string) {
+	switch {
+	case ev.Type == EventTypePhaseStarted && ev.Phase == PhaseWork:
+		return "on_work_start", s.OnWorkStart
+	case ev.Type == EventTypePhaseEnded && ev.Phase == PhaseWork:
+		return "on_work_end", s.OnWorkEnd
+	case ev.Type == EventTypePhaseEnded && (ev.Phase == PhaseRest || ev.Phase == PhaseLongRest):
+		return "on_rest_end", s.OnRestEnd
+	default:
+		return "", ""
+	}
+}
+
+func (p *Pomodoro) handleHookEvent(ev Event) {
+	if ev.Restored {
+		return
+	}
+	p.locker.Lock()
+	hookName, command := p.settings.Hooks.command(ev)
+	status := p.status()
+	p.locker.Unlock()
+	if command == "" {
+		return
+	}
+
+	ctx, cancelFn := context.WithTimeout(p.lifecycle.ctx, hookTimeout)
+	defer cancelFn()
+	cmd := shellCommand(ctx, command)
+	cmd.WaitDelay = hookWaitDelay
+	cmd.Env = append(os.Environ(),
+		"POMODORO_HOOK="+hookName,
+		"POMODORO_EVENT="+ev.Type.String(),
+		"POMODORO_PHASE="+ev.Phase.String(),
+		"POMODORO_TIME_LEFT="+strconv.FormatInt(int64(ev.DisplayedTimeLeft/time.Second), 10),
+		"POMODORO_ELAPSED="+strconv.FormatInt(int64(ev.Elapsed/time.Second), 10),
+		"POMODORO_DEADLINE="+ev.Deadline.Format(time.RFC3339),
+		"POMODORO_TASK="+ev.Task,
+		"POMODORO_COMPLETED_WORK_SESSIONS="+strconv.FormatUint(uint64(status.CompletedWorkSessions), 10),
+		"POMODORO_CYCLE_WORK_SESSIONS="+strconv.FormatUint(uint64(status.CycleWorkSessions), 10),
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		slog.Error("the hook failed", "hook", hookName, "command", command, "error", err, "output", string(output))
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package pomodoro
+
+import (
+	"context"
+	"os/exec"
+)
+
+func shellCommand(
+	ctx context.Context,
+	command string,
+) *exec.Cmd {
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,80 @@
+package pomodoro
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+const (
+	idleCheckInterval = 5 * time.Second
+)
+
+func (p *Pomodoro) monitorIdle() {
+	ticker := p.clock.NewTicker(idleCheckInterval)
+	defer ticker.Stop()
+	var lastErr string
+	for {
+		select {
+		case <-p.lifecycle.ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		err := p.checkIdle()
+		switch {
+		case err == nil:
+			lastErr = ""
+		case err.Error() != lastErr:
+			lastErr = err.Error()
+			slog.Error("unable to detect the idle time", "error", err)
+		}
+	}
+}
+
+func (p *Pomodoro) checkIdle() error {
+	p.locker.Lock()
+	threshold := p.settings.IdlePauseAfter
+	detector := p.IdleDetector
+	shouldCheck := threshold > 0 && detector != nil && ((p.isRunning() && p.isWork) || p.pausedByIdle)
+	shouldCheck = shouldCheck || p.isMonitoringBreak()
+	p.locker.Unlock()
+	if !shouldCheck {
+		return nil
+	}
+
+	idleTime, err := detector.IdleTime()
+	if err != nil {
+		return err
+	}
+
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.checkBreakActivity(idleTime)
+	switch {
+	case threshold <= 0:
+	case p.pausedByIdle:
+		if idleTime >= idleCheckInterval {
+			return nil
+		}
+		p.pausedByIdle = false
+		p.notify(l10n.T(
+			"Welcome back! The work session was paused while you were away for %s.",
+			p.since(p.idleSince).Round(time.Minute),
+		))
+	case p.isRunning() && p.isWork && idleTime >= threshold:
+		p.pause()
+		giveBack := min(idleTime, p.phaseElapsed)
+		p.phaseElapsed -= giveBack
+		p.pausedTimeLeft += giveBack
+		if p.isStopwatch {
+			p.setTimeLeft(p.phaseElapsed)
+		} else {
+			p.setTimeLeft(p.pausedTimeLeft)
+		}
+		p.pausedByIdle = true
+		p.idleSince = p.clock.Now().Add(-idleTime)
+	}
+	return nil
+}
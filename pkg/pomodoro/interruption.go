@@ -0,0 +1,31 @@
+package pomodoro
+
+import (
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+)
+
+// RecordInterruption pauses the work session and stores the interruption,
+// it will be saved to the history together with the session.
+func (p *Pomodoro) RecordInterruption(
+	kind history.InterruptionKind,
+	reason string,
+) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.recordInterruption(kind, reason)
+}
+
+func (p *Pomodoro) recordInterruption(
+	kind history.InterruptionKind,
+	reason string,
+) {
+	if !p.isWork || (!p.isRunning() && !p.isPaused) {
+		return
+	}
+	p.pause()
+	p.phaseInterruptions = append(p.phaseInterruptions, history.Interruption{
+		At:     p.clock.Now(),
+		Kind:   kind,
+		Reason: reason,
+	})
+}
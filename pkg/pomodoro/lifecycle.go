@@ -0,0 +1,110 @@
+package pomodoro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type lifecycle struct {
+	ctx      context.Context
+	cancelFn context.CancelFunc
+
+	locker     sync.Mutex
+	closed     bool
+	goroutines sync.WaitGroup
+	closeOnce  sync.Once
+	closeErr   error
+}
+
+func (l *lifecycle) init() {
+	l.ctx, l.cancelFn = context.WithCancel(context.Background())
+}
+
+// launch runs fn in a goroutine which Close waits for. It returns false
+// (and does not run fn) if the Pomodoro is already closed.
+func (l *lifecycle) launch(fn func()) bool {
+	l.locker.Lock()
+	defer l.locker.Unlock()
+	if l.closed {
+		return false
+	}
+	l.goroutines.Add(1)
+	go func() {
+		defer l.goroutines.Done()
+		fn()
+	}()
+	return true
+}
+
+// Run runs the timer until the context is cancelled, then it releases
+// the resources (see Close). The windows are run by gui.GUI.Run.
+func (p *Pomodoro) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return p.Close()
+}
+
+// Close records the unfinished session, stops all the background goroutines
+// (waiting for the event handlers to process the already emitted events)
+// and releases the audio context. It is safe to call Close multiple times.
+func (p *Pomodoro) Close() error {
+	p.lifecycle.closeOnce.Do(func() {
+		p.lifecycle.closeErr = p.close()
+	})
+	return p.lifecycle.closeErr
+}
+
+func (p *Pomodoro) close() error {
+	p.lifecycle.locker.Lock()
+	p.lifecycle.closed = true
+	p.lifecycle.locker.Unlock()
+
+	p.locker.Lock()
+	p.cancelAutoContinue()
+	if p.isRunning() || p.isPaused {
+		p.recordSession(p.isStopwatch)
+		p.emitEvent(EventTypeStopped, p.until(p.deadline))
+	}
+	if p.tickerCancel != nil {
+		p.tickerCancel()
+		p.tickerCancel = nil
+	}
+	p.isPaused = false
+	p.locker.Unlock()
+
+	p.lifecycle.cancelFn()
+
+	p.eventSubscribersLocker.Lock()
+	for id, queue := range p.eventSubscribers {
+		delete(p.eventSubscribers, id)
+		close(queue)
+	}
+	p.eventSubscribersLocker.Unlock()
+
+	var result error
+	if err := p.Player.Close(); err != nil {
+		result = errors.Join(result, fmt.Errorf("unable to close the audio player: %w", err))
+	}
+
+	p.locker.Lock()
+	p.closeBlocker()
+	p.locker.Unlock()
+
+	p.lifecycle.goroutines.Wait()
+
+	// the session was recorded above, unless the recovery of the
+	// previous one was not decided yet
+	p.locker.Lock()
+	undecided := p.recovered != nil
+	p.locker.Unlock()
+	if !undecided {
+		if err := p.journal.Clear(); err != nil {
+			result = errors.Join(result, err)
+		}
+	}
+	if err := p.journal.Close(); err != nil {
+		result = errors.Join(result, err)
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package pomodoro
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCloseWaitsForGoroutines(t *testing.T) {
+	p := newTestPomodoro(t)
+	var finished atomic.Bool
+	launched := p.lifecycle.launch(func() {
+		<-p.lifecycle.ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		finished.Store(true)
+	})
+	if !launched {
+		t.Fatalf("unable to launch a goroutine before Close")
+	}
+	p.Close()
+	if !finished.Load() {
+		t.Fatalf("Close returned before the launched goroutine finished")
+	}
+}
+
+func TestCloseWaitsForEventHandlers(t *testing.T) {
+	p := newTestPomodoro(t)
+	var stopped atomic.Bool
+	p.OnEvent(func(ev Event) {
+		if ev.Type == EventTypeStopped {
+			time.Sleep(10 * time.Millisecond)
+			stopped.Store(true)
+		}
+	})
+	p.Start(true)
+	p.Close()
+	if !stopped.Load() {
+		t.Fatalf("Close returned before the handler processed the stop of the running session")
+	}
+}
+
+func TestCloseInterruptsHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hook is a POSIX shell command")
+	}
+	p := newTestPomodoro(t)
+	taskFile := filepath.Join(t.TempDir(), "task")
+	s := p.CurrentSettings()
+	s.Hooks.OnWorkStart = `echo "$POMODORO_TASK" > '` + taskFile + `'; sleep 60`
+	p.ApplySettings(s)
+	p.SetTask("Write the tests")
+	p.Start(true)
+	p.SetTask("Review the tests")
+
+	for {
+		content, err := os.ReadFile(taskFile)
+		if err == nil && len(content) > 0 {
+			if task := string(content); task != "Write the tests\n" {
+				t.Fatalf("expected the hook to get the task of the event, got %q", task)
+			}
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	startedAt := time.Now()
+	p.Close()
+	if elapsed := time.Since(startedAt); elapsed > hookTimeout/2 {
+		t.Fatalf("Close waited for the hook for %v", elapsed)
+	}
+}
+
+func TestLaunchAfterClose(t *testing.T) {
+	p := newTestPomodoro(t)
+	firstErr := p.Close()
+	if err := p.Close(); err != firstErr {
+		t.Fatalf("expected the second Close to return the same error '%v', got '%v'", firstErr, err)
+	}
+
+	var called atomic.Bool
+	if p.lifecycle.launch(func() { called.Store(true) }) {
+		t.Fatalf("launch returned true after Close")
+	}
+	p.OnEvent(func(Event) { called.Store(true) })()
+	p.Start(true)
+	p.StopTimer()
+	time.Sleep(10 * time.Millisecond)
+	if called.Load() {
+		t.Fatalf("a function was run after Close")
+	}
+}
@@ -0,0 +1,43 @@
+package pomodoro
+
+import (
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+const (
+	// meetingLead is the time left between the end of a shortened
+	// session and the meeting.
+	meetingLead = 5 * time.Minute
+)
+
+// OfferToEndBefore warns that the meeting starts before the current
+// work session ends and offers to shorten the session, so that it ends
+// meetingLead before the meeting (see calendar.MeetingGuard).
+func (p *Pomodoro) OfferToEndBefore(
+	meeting string,
+	startsAt time.Time,
+) {
+	endAt := startsAt.Add(-meetingLead)
+	message := l10n.T("'%s' starts at %s, before this session ends.", meeting, l10n.FormatClock(startsAt))
+
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if !p.isRunning() || !p.isWork || !p.wallDeadline().After(startsAt) {
+		return
+	}
+	if !endAt.After(p.clock.Now()) || !p.frontend.OfferToEndAt(message, endAt) {
+		p.notify(message)
+	}
+}
+
+// EndAt moves the deadline of the running interval to t.
+func (p *Pomodoro) EndAt(t time.Time) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if !p.isRunning() {
+		return
+	}
+	p.extend(t.Sub(p.wallDeadline()))
+}
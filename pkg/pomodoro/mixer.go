@@ -0,0 +1,74 @@
+package pomodoro
+
+import (
+	"slices"
+
+	"github.com/xaionaro-go/pomodoro/pkg/audio"
+)
+
+// MixerSettings complement the volumes of the sounds (Settings.AlarmVolume,
+// AmbienceSettings and FocusMusicSettings are the volumes of their channels)
+// in the mixer (see audio.Mixer).
+type MixerSettings struct {
+	CountdownVolume float64
+	Muted           []audio.Channel
+
+	// Ducking is the level the ambience and the focus music are lowered
+	// to while the alarm plays, 1 to not lower them.
+	Ducking float64
+}
+
+func channelsToStrings(channels []audio.Channel) []string {
+	result := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		result = append(result, string(channel))
+	}
+	return result
+}
+
+func stringsToChannels(s []string) []audio.Channel {
+	result := make([]audio.Channel, 0, len(s))
+	for _, channel := range s {
+		result = append(result, audio.Channel(channel))
+	}
+	return result
+}
+
+// ChannelVolume returns the setting of the volume of the channel.
+func (s *Settings) ChannelVolume(channel audio.Channel) *float64 {
+	switch channel {
+	case audio.ChannelAlarm:
+		return &s.AlarmVolume
+	case audio.ChannelCountdown:
+		return &s.Mixer.CountdownVolume
+	case audio.ChannelAmbience:
+		return &s.Ambience.Volume
+	case audio.ChannelMusic:
+		return &s.FocusMusic.Volume
+	default:
+		return nil
+	}
+}
+
+func (p *Pomodoro) refreshMixer() {
+	m := p.Player.Mixer
+	for _, channel := range audio.Channels {
+		m.SetVolume(channel, *p.settings.ChannelVolume(channel))
+		m.SetMuted(channel, slices.Contains(p.settings.Mixer.Muted, channel))
+	}
+	m.SetDucking(p.settings.Mixer.Ducking)
+}
+
+// ChangeMixer applies the change of the mixer settings right away
+// (without the settings window) and saves it.
+func (p *Pomodoro) ChangeMixer(change func(s *Settings)) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	s := p.settings
+	s.Mixer.Muted = slices.Clone(s.Mixer.Muted)
+	change(&s)
+	p.settings = s
+	p.refreshMixer()
+	p.refreshFocusMusicPanel()
+	s.Save(p.prefs)
+}
@@ -0,0 +1,67 @@
+package pomodoro
+
+import (
+	"log/slog"
+
+	"github.com/xaionaro-go/pomodoro/pkg/notify"
+)
+
+// NotificationSettings select the backends (see pkg/notify) used to notify
+// the user, all of them are used.
+type NotificationSettings struct {
+	Backends   []notify.BackendName
+	WebhookURL string
+	OnPhaseEnd bool
+}
+
+func BackendNamesToStrings(names []notify.BackendName) []string {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		result = append(result, string(name))
+	}
+	return result
+}
+
+func (p *Pomodoro) refreshNotifier() {
+	notifier, err := notify.NewMulti(p.settings.Notifications.Backends, notify.Config{
+		Fyne:       p.appNotifications,
+		WebhookURL: p.settings.Notifications.WebhookURL,
+	})
+	if err != nil {
+		slog.Error("unable to initialize some of the notification backends", "error", err)
+	}
+	p.notifier.Store(&notifier)
+}
+
+// notify sends the notification unless it is off hours (see IsOffHours).
+func (p *Pomodoro) notify(
+	body string,
+	actions ...notify.Action,
+) {
+	if p.IsOffHours() {
+		return
+	}
+	p.deliverNotification(body, actions...)
+}
+
+// deliverNotification sends the notification in the background (some
+// backends run external commands or make network requests).
+func (p *Pomodoro) deliverNotification(
+	body string,
+	actions ...notify.Action,
+) {
+	notifier := p.notifier.Load()
+	if notifier == nil {
+		return
+	}
+	p.lifecycle.launch(func() {
+		err := notifier.Notify(notify.Notification{
+			Title:   "Pomodoro",
+			Body:    body,
+			Actions: actions,
+		})
+		if err != nil {
+			slog.Error("unable to send the notification", "error", err)
+		}
+	})
+}
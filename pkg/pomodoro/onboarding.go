@@ -0,0 +1,20 @@
+package pomodoro
+
+// NeedsOnboarding returns true on the first launch: neither the first-run
+// wizard was gone through, nor the settings were ever saved (so the users
+// who configured the timer before the wizard appeared do not get it).
+func (p *Pomodoro) NeedsOnboarding() bool {
+	if p.prefs.BoolWithFallback(prefKeyOnboarded, false) {
+		return false
+	}
+	return p.prefs.IntWithFallback(prefKeyWorkInterval, -1) < 0
+}
+
+// FinishOnboarding applies the settings chosen in the first-run wizard
+// (nil if it was skipped) and marks the wizard as gone through.
+func (p *Pomodoro) FinishOnboarding(s *Settings) {
+	if s != nil {
+		p.ApplySettings(*s)
+	}
+	p.prefs.SetBool(prefKeyOnboarded, true)
+}
@@ -0,0 +1,94 @@
+package pomodoro
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+const (
+	// overrunNudgeLevelWindow is the first level shown by the frontend
+	// instead of a notification (see Frontend.NudgeOverrun).
+	overrunNudgeLevelWindow = 2
+)
+
+// handleOverrunEvent watches for the keyboard/mouse activity after a work
+// session ended and the break was not started, escalating the reminder:
+// a notification, then a small window, then a darkening full-screen overlay.
+func (p *Pomodoro) handleOverrunEvent(ev Event) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	switch ev.Type {
+	case EventTypePhaseEnded:
+		if ev.Phase != PhaseWork {
+			return
+		}
+		step := p.settings.OverrunNudgeEvery
+		if step <= 0 || p.IdleDetector == nil {
+			return
+		}
+		p.stopOverrunWatch()
+		ctx, cancelFn := context.WithCancel(p.lifecycle.ctx)
+		p.overrunCancel = cancelFn
+		endedAt := ev.Time
+		p.lifecycle.launch(func() {
+			p.watchOverrun(ctx, endedAt, step)
+		})
+	case EventTypePhaseStarted, EventTypeStopped:
+		p.stopOverrunWatch()
+	}
+}
+
+func (p *Pomodoro) stopOverrunWatch() {
+	if p.overrunCancel != nil {
+		p.overrunCancel()
+		p.overrunCancel = nil
+	}
+	p.frontend.CloseOverrunNudge()
+}
+
+func (p *Pomodoro) watchOverrun(
+	ctx context.Context,
+	endedAt time.Time,
+	step time.Duration,
+) {
+	ticker := p.clock.NewTicker(step)
+	defer ticker.Stop()
+	level := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		idleTime, err := p.IdleDetector.IdleTime()
+		if err != nil {
+			slog.Error("unable to detect the idle time", "error", err)
+			return
+		}
+		if idleTime >= step {
+			// the user is away, so the break is taken anyway
+			continue
+		}
+
+		level++
+		p.locker.Lock()
+		if ctx.Err() == nil {
+			p.nudgeOverrun(level, p.since(endedAt))
+		}
+		p.locker.Unlock()
+	}
+}
+
+func (p *Pomodoro) nudgeOverrun(
+	level int,
+	overrun time.Duration,
+) {
+	message := l10n.T("You are working %s past the end of the focus session, take a break.", overrun.Round(time.Minute))
+	if level < overrunNudgeLevelWindow || !p.frontend.NudgeOverrun(message, level) {
+		p.notify(message)
+	}
+}
@@ -0,0 +1,46 @@
+package pomodoro
+
+import (
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// startOvertime is called instead of endTimer when the deadline passes
+// with Settings.Overtime: the phase goes on (the time is shown as
+// negative) until the user switches it explicitly (see endOvertime).
+func (p *Pomodoro) startOvertime() {
+	p.isOvertime = true
+	p.emitEvent(EventTypeOvertimeStarted, 0)
+	p.notify(l10n.T("Time is up, the overtime is counted until you switch the phase."))
+	if p.settings.AlarmEnabled {
+		p.startAlarm()
+	}
+	p.refresh()
+	if p.settings.EndFlash {
+		p.frontend.Flash()
+	}
+}
+
+// endOvertime ends the phase which is in the overtime, so that it is
+// recorded as completed before the user switches to another one.
+func (p *Pomodoro) endOvertime() {
+	if !p.isOvertime {
+		return
+	}
+	p.isPaused = false
+	p.pausedByIdle = false
+	p.endTimer()
+}
+
+// overtime returns how long the current phase lasts past its end.
+func (p *Pomodoro) overtime() time.Duration {
+	if !p.isOvertime {
+		return 0
+	}
+	timeLeft := p.pausedTimeLeft
+	if !p.isPaused {
+		timeLeft = p.until(p.deadline)
+	}
+	return max(-timeLeft, 0)
+}
@@ -0,0 +1,14 @@
+package pomodoro
+
+import (
+	"time"
+)
+
+// StartWorkFor starts a work session of the given duration
+// (it also becomes the next work interval).
+func (p *Pomodoro) StartWorkFor(d time.Duration) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.nextWorkInterval = d
+	p.start(true)
+}
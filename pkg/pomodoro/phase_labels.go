@@ -0,0 +1,83 @@
+package pomodoro
+
+import (
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// PhaseLabels replace the descriptions shown above the timer (like
+// "DEEP WORK 🧠" instead of "UNTIL BREAK"), the empty ones keep the defaults.
+type PhaseLabels struct {
+	Work      string `json:",omitempty"`
+	Rest      string `json:",omitempty"`
+	LongRest  string `json:",omitempty"`
+	Stopwatch string `json:",omitempty"`
+}
+
+func (l PhaseLabels) custom(phase Phase) string {
+	switch phase {
+	case PhaseWork:
+		return l.Work
+	case PhaseRest:
+		return l.Rest
+	case PhaseLongRest:
+		return l.LongRest
+	case PhaseStopwatch:
+		return l.Stopwatch
+	default:
+		return ""
+	}
+}
+
+// Description returns the text shown above the timer during the phase.
+func (l PhaseLabels) Description(phase Phase) string {
+	if label := l.custom(phase); label != "" {
+		return label
+	}
+	return DefaultPhaseDescription(phase)
+}
+
+// DefaultPhaseDescription is the description of the phase
+// without a custom label (see PhaseLabels).
+func DefaultPhaseDescription(phase Phase) string {
+	switch phase {
+	case PhaseWork:
+		return l10n.T("UNTIL BREAK")
+	case PhaseLongRest:
+		return l10n.T("LONG BREAK")
+	case PhaseStopwatch:
+		return l10n.T("STOPWATCH")
+	default:
+		return l10n.T("BREAK")
+	}
+}
+
+func (p *Pomodoro) refreshDescription() {
+	if p.view.Description == "" {
+		// stopped
+		return
+	}
+	p.setDescription(p.settings.Labels.Description(p.phase()))
+}
+
+// Title returns the short name of the phase (for the window title
+// and for reading the timer aloud).
+func (l PhaseLabels) Title(phase Phase) string {
+	if label := l.custom(phase); label != "" {
+		return label
+	}
+	switch phase {
+	case PhaseWork:
+		return l10n.T("FOCUS")
+	case PhaseLongRest:
+		return l10n.T("LONG BREAK")
+	case PhaseStopwatch:
+		return l10n.T("STOPWATCH")
+	default:
+		return l10n.T("BREAK")
+	}
+}
+
+func (p *Pomodoro) setDescription(text string) {
+	p.view.Description = text
+	p.refresh()
+}
@@ -1,288 +1,643 @@
 package pomodoro
 
 import (
-	"bytes"
 	"context"
-	"errors"
-	"fmt"
-	"image/color"
-	"io"
-	"log"
-	"reflect"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
-	"unsafe"
-
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/app"
-	"fyne.io/fyne/v2/canvas"
-	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/theme"
-	"fyne.io/fyne/v2/widget"
-	"github.com/ebitengine/oto/v3"
-	"github.com/jfreymuth/oggvorbis"
-)
 
-const (
-	audioEnabled = false
+	"github.com/xaionaro-go/pomodoro/pkg/audio"
+	"github.com/xaionaro-go/pomodoro/pkg/clock"
+	"github.com/xaionaro-go/pomodoro/pkg/datadir"
+	"github.com/xaionaro-go/pomodoro/pkg/dnd"
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/idle"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/notify"
+	"github.com/xaionaro-go/pomodoro/pkg/screenlock"
+	"github.com/xaionaro-go/pomodoro/pkg/tasks"
+	"github.com/xaionaro-go/pomodoro/pkg/tracker"
+	"github.com/xaionaro-go/pomodoro/pkg/tts"
 )
 
 type Pomodoro struct {
-	fyne.App
-	fyne.Window
-	Description      *canvas.Text
-	MinutesText      *canvas.Text
-	Delimiter        *canvas.Text
-	SecondsText      *canvas.Text
-	Deadline         time.Time
-	NextWorkInterval time.Duration
-	NextRestInterval time.Duration
-	IsWork           bool
-
-	Locker       sync.Mutex
-	TickerCancel context.CancelFunc
-}
-
-func New() *Pomodoro {
-	a := app.NewWithID("center.dx.fynodoro")
-	w := a.NewWindow("Pomodoro (DX)")
-	w.CenterOnScreen()
-	w.SetMaster()
+	// Dirs and the integrations are set by New
+	// and are not changed afterwards.
+	Dirs         datadir.Dirs
+	IdleDetector idle.Detector
+	DND          dnd.Controller
+	ScreenLocker screenlock.Locker
+	Speaker      tts.Speaker
+	Synthesizer  tts.Synthesizer
+	Player       *audio.Player
+
+	clock clock.Clock // see WithClock
+
+	// locker guards state, the exported methods lock it and the unexported
+	// ones expect it to be locked. The others get its snapshots (see Status,
+	// CurrentSettings and View).
+	locker sync.Mutex
+	state
+
+	notifier atomic.Pointer[notify.Multi]
+	offHours atomic.Bool
+	noAudio  atomic.Bool // see detectAudio
+
+	lifecycle lifecycle
+	headless  bool
+	mobile    bool // see IsMobile
+
+	// frontend shows view, the state of the timer (see refresh)
+	frontend Frontend
+
+	// prefs keep the settings and the state surviving the restarts,
+	// appNotifications is the notification backend of the frontend
+	// (see notify.Config.Fyne)
+	prefs            Preferences
+	appNotifications notify.Backend
+
+	countdown countdownState
+
+	eventSubscribersLocker sync.Mutex
+	eventSubscribers       map[uint64]chan Event
+	eventSubscriberNextID  uint64
+}
+
+// state is the mutable state of the timer, it is guarded by Pomodoro.locker.
+type state struct {
+	deadline             time.Time
+	nextWorkInterval     time.Duration
+	nextRestInterval     time.Duration
+	nextLongRestInterval time.Duration
+	isWork               bool
+	isLongBreak          bool
+	isPaused             bool
+	pausedTimeLeft       time.Duration
+	isStopwatch          bool
+	settings             Settings
+	task                 string
+	history              *history.Store
+	trackers             []tracker.Tracker
+	taskProviders        []tasks.Provider
+	suggestionProviders  []SuggestionProvider
+
+	completedWorkSessions uint
+	cycleWorkSessions     uint
+
+	tickerCancel       context.CancelFunc
+	autoContinueCancel context.CancelFunc
+	alarmCancel        context.CancelFunc
+
+	view     View
+	shareURL string // see SetShareURL
+
+	phaseStartedAt     time.Time
+	lastTickAt         time.Time
+	lastTickBoot       time.Duration
+	phaseBreakDebt     time.Duration
+	breakDebt          time.Duration
+	inBackground       bool
+	phaseRunningSince  time.Time
+	phaseElapsed       time.Duration
+	phasePlanned       time.Duration
+	pausedByIdle       bool
+	suggestionIndex    int
+	suggestionShownAt  time.Time
+	phaseEndingEmitted bool
+	isOvertime         bool
+	phaseInterruptions []history.Interruption
+	idleSince          time.Time
+
+	// journal checkpoints the running session (see handleJournalEvent),
+	// recovered is the session interrupted by a crash of the previous
+	// run until it is resumed or recorded (see RecoverSession)
+	journal          *history.Journal
+	journalWrittenAt time.Time
+	recovered        *history.JournalRecord
+
+	// startNudgeBase is since when the sessions are awaited
+	// (see checkStartNudge)
+	startNudgeBase         time.Time
+	startNudgeSnoozedUntil time.Time
+
+	// phaseActive is how long the user was using the computer
+	// during the current break (see BreakCompliancePolicy)
+	phaseActive   time.Duration
+	breakWarnedAt time.Time
+
+	// lastWorkStartedAt identifies the last completed work session
+	// in the history (see SetSessionNote)
+	lastWorkStartedAt time.Time
+
+	// commitmentReleased is set if the phrase was typed during
+	// the current work session (see CommitmentModePhrase)
+	commitmentReleased bool
+
+	// pickedTask is the task from a provider the work is done on
+	// (see PickTask), it is unlinked when the task is changed
+	pickedTask *pickedTask
+
+	ambienceCancel context.CancelFunc
+	ambienceFile   string
+	focusMusic     focusMusicState
+	sideTimers     sideTimersState
+	blocking       blockerState
+	ritual         ritualState
+
+	screenLockCancel context.CancelFunc
+	screenLockNow    chan struct{}
+
+	undoSnapshot *timerSnapshot
+
+	overrunCancel context.CancelFunc
+}
+
+// Option configures the timer created by New.
+type Option func(*Pomodoro)
+
+// WithFrontend shows the timer in the frontend (see pkg/gui), without it
+// the timer has no UI.
+func WithFrontend(frontend Frontend) Option {
+	return func(p *Pomodoro) {
+		p.frontend = frontend
+	}
+}
+
+// WithPreferences keeps the settings in prefs instead of the preferences
+// file in datadir.Dirs.Config.
+func WithPreferences(prefs Preferences) Option {
+	return func(p *Pomodoro) {
+		p.prefs = prefs
+	}
+}
+
+// WithAppNotifications sends the notifications of BackendNameFyne
+// through the backend (see notify.Config.Fyne).
+func WithAppNotifications(backend notify.Backend) Option {
+	return func(p *Pomodoro) {
+		p.appNotifications = backend
+	}
+}
+
+// WithClock makes the timer use the clock instead of the real one
+// (for example, clock.Fake in the tests). The clock cannot be replaced
+// after New: the background goroutines use it since the start.
+func WithClock(c clock.Clock) Option {
+	return func(p *Pomodoro) {
+		p.clock = c
+	}
+}
+
+// WithMobile adapts the timer to a phone or a tablet (see IsMobile).
+func WithMobile() Option {
+	return func(p *Pomodoro) {
+		p.mobile = true
+	}
+}
+
+// New creates the timer keeping its files in dirs (see datadir.Default).
+// Without WithFrontend only the timer itself, the notifications and the
+// integrations work: it is supposed to be controlled via DBus, the HTTP
+// API or the commands of other instances then.
+func New(
+	dirs datadir.Dirs,
+	opts ...Option,
+) *Pomodoro {
 	p := &Pomodoro{
-		App:              a,
-		Window:           w,
-		IsWork:           true,
-		NextRestInterval: 15 * time.Minute,
-	}
-	textStyle := fyne.TextStyle{Monospace: true}
-	p.Description = canvas.NewText("", color.Gray{Y: 224})
-	p.Description.Alignment = fyne.TextAlignCenter
-	p.Description.TextSize = 45
-	p.Description.TextStyle = textStyle
-	descriptionContainer := container.NewHBox(p.Description)
-	p.MinutesText = canvas.NewText("", color.White)
-	p.MinutesText.TextSize = 90
-	p.MinutesText.TextStyle = textStyle
-	p.Delimiter = canvas.NewText(":", color.Gray{Y: 128})
-	p.Delimiter.TextSize = 90
-	p.Delimiter.TextStyle = textStyle
-	p.SecondsText = canvas.NewText("", color.White)
-	p.SecondsText.TextSize = 90
-	p.SecondsText.TextStyle = textStyle
-	timerContainer := container.NewStack(container.NewHBox(
-		p.MinutesText,
-		p.Delimiter,
-		p.SecondsText,
-	))
-	set5MinsButton := widget.NewButton("  5  ", func() { p.SetNextInterval(5 * time.Minute) })
-	set15MinsButton := widget.NewButton(" 15 ", func() { p.SetNextInterval(15 * time.Minute) })
-	set30MinsButton := widget.NewButton(" 30 ", func() { p.SetNextInterval(30 * time.Minute) })
-	set45MinsButton := widget.NewButton(" 45 ", func() { p.SetNextInterval(45 * time.Minute) })
-	set60MinsButton := widget.NewButton(" 60 ", func() { p.SetNextInterval(60 * time.Minute) })
-	set75MinsButton := widget.NewButton(" 75 ", func() { p.SetNextInterval(75 * time.Minute) })
-	set90MinsButton := widget.NewButton(" 90 ", func() { p.SetNextInterval(90 * time.Minute) })
-	set105MinsButton := widget.NewButton("105", func() { p.SetNextInterval(105 * time.Minute) })
-	setIsWorkButton := widget.NewButtonWithIcon("WORK", theme.MediaPlayIcon(), func() { p.Start(true) })
-	setIsRestButton := widget.NewButtonWithIcon("REST", theme.MediaPlayIcon(), func() { p.Start(false) })
-	stopButton := widget.NewButtonWithIcon("STOP", theme.MediaStopIcon(), p.StopTimer)
-	controlsLine0Container := container.NewHBox(
-		set5MinsButton,
-		set15MinsButton,
-		set30MinsButton,
-		set45MinsButton,
-		setIsWorkButton,
-	)
-	controlsLine1Container := container.NewHBox(
-		set60MinsButton,
-		set75MinsButton,
-		set90MinsButton,
-		set105MinsButton,
-		setIsRestButton,
-		stopButton,
-	)
-	w.Canvas().SetContent(container.NewVBox(
-		descriptionContainer,
-		timerContainer,
-		controlsLine0Container,
-		controlsLine1Container,
-	))
-	p.SetNextInterval(60 * time.Minute)
+		Dirs:         dirs,
+		state:        state{isWork: true},
+		IdleDetector: idle.NewDefaultDetector(),
+		DND:          dnd.NewDefaultController(),
+		ScreenLocker: screenlock.NewDefaultLocker(),
+		Speaker:      tts.NewDefaultSpeaker(),
+		Synthesizer:  tts.NewDefaultSynthesizer(),
+		Player:       audio.NewPlayer(),
+		clock:        clock.Real{},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.frontend == nil {
+		p.headless = true
+		p.frontend = nopFrontend{}
+	}
+	if p.prefs == nil {
+		p.prefs = openPreferences(dirs)
+	}
+	if err := l10n.SetLanguage(p.prefs.String(prefKeyLanguage)); err != nil {
+		slog.Error("unable to set the language", "error", err)
+	}
+	p.view.Digits.Tenths = -1
+	p.lifecycle.init()
+	// the launched goroutines use the state right away
+	p.locker.Lock()
+	p.startNudgeBase = p.clock.Now()
+	p.breakDebt = durationWithFallback(p.prefs, prefKeyBreakDebt, 0)
+	p.applySettings(LoadSettings(p.prefs))
+	p.Player.Prepare()
+	p.lifecycle.launch(p.detectAudio)
+	p.openHistory()
+	p.openJournal()
+	p.refreshGoal()
+	p.lifecycle.launch(p.monitorIdle)
+	p.lifecycle.launch(p.watchDailySummary)
+	p.lifecycle.launch(p.watchWorkSchedule)
+	p.lifecycle.launch(p.watchScheduledSessions)
+	p.locker.Unlock()
+	p.OnEvent(p.handleDNDEvent)
+	p.OnEvent(p.handleBlockerEvent)
+	p.OnEvent(p.handleAnnouncementEvent)
+	p.OnEvent(p.handleWarningEvent)
+	p.OnEvent(p.handleHookEvent)
+	p.OnEvent(p.handleWebhookEvent)
+	p.OnEvent(p.handleOverrunEvent)
+	p.OnEvent(p.handlePhaseEndNotificationEvent)
+	p.OnEvent(p.handleAmbienceEvent)
+	p.OnEvent(p.handleFocusMusicEvent)
+	p.OnEvent(p.handleScreenLockEvent)
+	p.OnEvent(p.handleCountdownEvent)
+	p.OnEvent(p.handleJournalEvent)
+	p.locker.Lock()
+	p.refresh()
+	p.locker.Unlock()
+	p.offerRecovery()
 	return p
 }
 
+// IsHeadless returns true if the timer has no UI (see WithFrontend).
+func (p *Pomodoro) IsHeadless() bool {
+	return p.headless
+}
+
+// Clock returns the clock the timer uses (see WithClock).
+func (p *Pomodoro) Clock() clock.Clock {
+	return p.clock
+}
+
 func (p *Pomodoro) SetNextInterval(
 	nextInterval time.Duration,
 ) {
-	p.Locker.Lock()
-	defer p.Locker.Unlock()
-	if p.IsWork {
-		p.NextWorkInterval = nextInterval
-	} else {
-		p.NextRestInterval = nextInterval
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.refuseIfCommitted("change the interval") {
+		return
+	}
+	p.setNextInterval(nextInterval)
+}
+
+func (p *Pomodoro) setNextInterval(
+	nextInterval time.Duration,
+) {
+	switch {
+	case p.isWork:
+		p.nextWorkInterval = nextInterval
+	case p.isLongBreak:
+		p.nextLongRestInterval = nextInterval
+	default:
+		p.nextRestInterval = nextInterval
 	}
-	p.Deadline = time.Now().Add(nextInterval)
+	p.deadline = p.clock.Now().Add(nextInterval)
+	p.phaseEndingEmitted = false
 	p.setTimeLeft(nextInterval)
 }
 
 func (p *Pomodoro) SetTimeLeft(
 	timeLeft time.Duration,
 ) {
-	p.Locker.Lock()
-	defer p.Locker.Unlock()
+	p.locker.Lock()
+	defer p.locker.Unlock()
 	p.setTimeLeft(timeLeft)
 }
 
 func (p *Pomodoro) setTimeLeft(
 	timeLeft time.Duration,
 ) {
-	timeLeft += 200 * time.Millisecond
-	minutes := uint(timeLeft / time.Minute)
-	seconds := uint((timeLeft % time.Minute) / time.Second)
-	p.MinutesText.Text = fmt.Sprintf("%2d", minutes)
-	p.SecondsText.Text = fmt.Sprintf("%02d", seconds)
-	p.MinutesText.Refresh()
-	p.SecondsText.Refresh()
+	overtime := timeLeft < 0 && p.isOvertime
+	var minutes, seconds uint
+	tenths := -1
+	switch {
+	case overtime:
+		minutes, seconds = splitElapsed(-timeLeft)
+	case p.showsTenths(timeLeft):
+		var t uint
+		minutes, seconds, t = splitTenths(timeLeft, p.settings.TimeLeftRounding)
+		tenths = int(t)
+	default:
+		minutes, seconds = p.split(timeLeft)
+	}
+	p.view.TimeLeft = timeLeft
+	p.view.Digits = Digits{
+		Minutes: minutes,
+		Seconds: seconds,
+		Tenths:  tenths,
+		Minus:   overtime,
+	}
+	p.refresh()
 }
 
 func (p *Pomodoro) Start(
 	isWork bool,
 ) {
-	p.Locker.Lock()
-	p.setIsWork(isWork)
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.start(isWork)
+}
 
-	ctx, cancelFn := context.WithCancel(context.Background())
-	if p.TickerCancel != nil {
-		p.TickerCancel()
+func (p *Pomodoro) start(
+	isWork bool,
+) {
+	if !isWork && p.refuseIfCommitted("start a rest") {
+		return
 	}
-	p.TickerCancel = cancelFn
-	if p.IsWork {
-		p.Deadline = time.Now().Add(p.NextWorkInterval)
+	p.endOvertime()
+	if p.isRunning() || p.isPaused {
+		p.saveUndoSnapshot(l10n.T("The interval was interrupted"))
 	} else {
-		p.Deadline = time.Now().Add(p.NextRestInterval)
+		p.saveUndoSnapshot("")
 	}
-	p.Locker.Unlock()
+	p.cancelAutoContinue()
+	p.stopAlarm()
+	p.interruptPhase()
+	if isWork && !p.isWork && !p.isStopwatch {
+		p.skipBreak()
+	}
+	p.isStopwatch = false
+	p.setIsWork(isWork)
+	p.isPaused = false
+	p.pausedByIdle = false
+	p.deadline = p.clock.Now().Add(p.nextInterval())
+	p.phaseStartedAt = p.clock.Now()
+	p.phaseRunningSince = p.phaseStartedAt
+	p.phaseElapsed = 0
+	p.phaseInterruptions = nil
+	p.phaseActive = 0
+	p.breakWarnedAt = time.Time{}
+	p.commitmentReleased = false
+	p.ritual.phase = nil
+	if isWork {
+		p.ritual.phase = p.ritual.pending
+	}
+	p.ritual.pending = nil
+	p.phasePlanned = p.nextInterval()
+	p.phaseBreakDebt = 0
+	if !isWork {
+		p.phaseBreakDebt = p.includedBreakDebt()
+	}
+	p.phaseEndingEmitted = false
+	p.refreshTask()
+	p.refreshGoal()
+	p.startTicker()
+	p.refreshProgress()
+	p.emitEvent(EventTypePhaseStarted, p.until(p.deadline))
+}
 
-	ticker := time.NewTicker(time.Second)
-	go func() {
-		defer func() {
-			ticker.Stop()
-			ticker = nil
-		}()
-		p.Tick()
+// interruptPhase records the running (or paused) phase, which is replaced
+// by another one, as not completed (unless it is the stopwatch)
+// and reports that it is stopped.
+func (p *Pomodoro) interruptPhase() {
+	if !p.isRunning() && !p.isPaused {
+		return
+	}
+	p.recordSession(p.isStopwatch)
+	p.emitEvent(EventTypeStopped, p.until(p.deadline))
+}
+
+func (p *Pomodoro) startTicker() {
+	ctx, cancelFn := context.WithCancel(p.lifecycle.ctx)
+	if p.tickerCancel != nil {
+		p.tickerCancel()
+	}
+	p.tickerCancel = cancelFn
+	p.lastTickAt = time.Time{}
+
+	p.lifecycle.launch(func() {
+		p.tickUnlessCancelled(ctx, true)
 		for {
+			delay, isTick := p.nextTickDelay()
 			select {
 			case <-ctx.Done():
 				return
-			case <-ticker.C:
+			case <-p.clock.After(delay):
 			}
-
-			p.Tick()
+			p.tickUnlessCancelled(ctx, isTick)
 		}
-	}()
+	})
+}
+
+func (p *Pomodoro) Pause() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.pause()
+}
+
+func (p *Pomodoro) isRunning() bool {
+	return p.tickerCancel != nil
+}
+
+func (p *Pomodoro) pause() {
+	if !p.isRunning() {
+		return
+	}
+	p.tickerCancel()
+	p.tickerCancel = nil
+	p.phaseElapsed += p.since(p.phaseRunningSince)
+	p.isPaused = true
+	p.pausedTimeLeft = p.until(p.deadline)
+	p.refresh()
+	p.emitEvent(EventTypePaused, p.pausedTimeLeft)
+}
+
+func (p *Pomodoro) Resume() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.resume()
+}
+
+func (p *Pomodoro) resume() {
+	if !p.isPaused {
+		return
+	}
+	p.isPaused = false
+	p.pausedByIdle = false
+	p.deadline = p.clock.Now().Add(p.pausedTimeLeft)
+	p.phaseRunningSince = p.clock.Now()
+	p.startTicker()
+	p.refresh()
+	p.emitEvent(EventTypeResumed, p.pausedTimeLeft)
+}
+
+func (p *Pomodoro) TogglePause() {
+	p.locker.Lock()
+	switch {
+	case p.isPaused:
+		p.resume()
+	case p.isRunning():
+		p.pause()
+	default:
+		isWork := p.isWork
+		p.locker.Unlock()
+		p.Start(isWork)
+		return
+	}
+	p.locker.Unlock()
 }
 
 func (p *Pomodoro) StopTimer() {
-	p.Locker.Lock()
-	defer p.Locker.Unlock()
-	p.Description.Text = ""
-	if p.TickerCancel != nil {
-		p.TickerCancel()
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.refuseIfCommitted("stop") {
+		return
+	}
+	if p.isRunning() || p.isPaused {
+		p.saveUndoSnapshot(l10n.T("The timer is stopped"))
 	}
-	p.TickerCancel = nil
-	p.Delimiter.Color = color.Gray{Y: 128}
-	p.Delimiter.Refresh()
+	p.endOvertime()
+	p.setDescription("")
+	p.cancelAutoContinue()
+	p.stopAlarm()
+	if p.tickerCancel != nil || p.isPaused {
+		p.recordSession(p.isStopwatch)
+		if p.tickerCancel != nil {
+			p.tickerCancel()
+		}
+		p.emitEvent(EventTypeStopped, p.until(p.deadline))
+	}
+	p.tickerCancel = nil
+	p.isPaused = false
+	p.pausedByIdle = false
+	if p.isStopwatch {
+		p.isStopwatch = false
+		p.setTimeLeft(p.nextInterval())
+	}
+	p.view.Caption = ""
+	p.refreshProgress()
 }
 
 func (p *Pomodoro) Tick() {
-	p.Locker.Lock()
-	defer p.Locker.Unlock()
-	if r, _, _, _ := p.Delimiter.Color.RGBA(); r > 10000 {
-		p.Delimiter.Color = color.Gray{Y: 22}
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.tick()
+}
+
+// tickUnlessCancelled ticks (or renders the tenths) only if the ticker
+// was not cancelled: it could be stopped or restarted while this
+// goroutine was waiting for locker (the cancellation happens under it).
+func (p *Pomodoro) tickUnlessCancelled(
+	ctx context.Context,
+	isTick bool,
+) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if ctx.Err() != nil {
+		return
+	}
+	if isTick {
+		p.tick()
 	} else {
-		p.Delimiter.Color = color.Gray{Y: 128}
+		p.renderTenths()
 	}
-	p.Delimiter.Refresh()
+}
 
-	timeLeft := time.Until(p.Deadline)
-	if timeLeft <= 0 {
+func (p *Pomodoro) tick() {
+	if p.handleSuspend() {
+		return
+	}
+	if p.isStopwatch {
+		p.renderTick(p.elapsed())
+		p.emitEvent(EventTypeTick, 0)
+		return
+	}
+
+	timeLeft := p.until(p.deadline)
+	switch {
+	case timeLeft <= 0 && !p.settings.Overtime:
 		p.endTimer()
 		return
+	case timeLeft <= 0 && !p.isOvertime:
+		p.startOvertime()
+	case timeLeft > 0 && p.isOvertime:
+		// extended back from the overtime
+		p.isOvertime = false
+		p.refresh()
 	}
-	p.setTimeLeft(timeLeft)
+	p.renderTick(timeLeft)
+	if !p.isWork {
+		p.rotateSuggestion(false)
+	}
+	p.emitEvent(EventTypeTick, timeLeft)
+	p.checkPhaseEnding(timeLeft)
 }
 
 func (p *Pomodoro) EndTimer() {
-	p.Locker.Lock()
-	defer p.Locker.Unlock()
+	p.locker.Lock()
+	defer p.locker.Unlock()
 	p.endTimer()
 }
 
 func (p *Pomodoro) setIsWork(isWork bool) {
-	if isWork {
-		p.Description.Text = "UNTIL BREAK"
-		p.setTimeLeft(p.NextWorkInterval)
-	} else {
-		p.Description.Text = "BREAK"
-		p.setTimeLeft(p.NextRestInterval)
-	}
-	p.IsWork = isWork
+	p.isWork = isWork
+	p.isLongBreak = !isWork && p.settings.LongBreakEvery > 0 && p.cycleWorkSessions >= p.settings.LongBreakEvery
+	p.setDescription(p.settings.Labels.Description(p.phase()))
+	p.setTimeLeft(p.nextInterval())
 }
 
-func (p *Pomodoro) endTimer() {
-	if p.TickerCancel != nil {
-		p.TickerCancel()
-		p.TickerCancel = nil
-	}
-	if audioEnabled {
-		go func() {
-			err := p.playAlarm()
-			if err != nil {
-				log.Printf("%v", fmt.Errorf("unable to play the alarm sound: %w", err))
-			}
-		}()
-	}
-	p.setIsWork(!p.IsWork)
+func (p *Pomodoro) until(t time.Time) time.Duration {
+	return clock.Until(p.clock, t)
 }
 
-func (p *Pomodoro) playAlarm() error {
-	oggDecoder, err := oggvorbis.NewReader(bytes.NewReader(alarmSoundFile))
-	if err != nil {
-		return fmt.Errorf("unable to initialize a decoder of the ogg vorbis audio: %w", err)
+// wallDeadline returns the deadline on the wall clock as of now. The timer
+// counts down by the monotonic clock (see until), while p.deadline keeps
+// the wall clock reading of the moment it was set: after a change of
+// the wall clock they disagree, so the wall one is projected anew.
+func (p *Pomodoro) wallDeadline() time.Time {
+	if p.deadline.IsZero() {
+		return time.Time{}
 	}
+	return p.clock.Now().Add(p.until(p.deadline)).Round(0)
+}
+
+func (p *Pomodoro) since(t time.Time) time.Duration {
+	return clock.Since(p.clock, t)
+}
 
-	buffer := make([]float32, 671558)
-	n, err := oggDecoder.Read(buffer)
-	if err != nil && !errors.Is(err, io.EOF) {
-		return fmt.Errorf("unable to decode the ogg vorbis file: %w", err)
+func (p *Pomodoro) nextInterval() time.Duration {
+	if p.isWork {
+		return p.nextWorkInterval
 	}
-	buffer = buffer[:n]
+	return p.baseRestInterval() + p.includedBreakDebt()
+}
 
-	op := &oto.NewContextOptions{
-		SampleRate:   oggDecoder.SampleRate(),
-		ChannelCount: oggDecoder.Channels(),
-		Format:       oto.FormatFloat32LE,
-		BufferSize:   0,
+func (p *Pomodoro) endTimer() {
+	p.recordSession(true)
+	wasOvertime := p.isOvertime
+	p.isOvertime = false
+	if !p.isWork && p.phaseBreakDebt > 0 {
+		// the debt is paid off
+		p.addBreakDebt(-p.phaseBreakDebt)
+		p.phaseBreakDebt = 0
 	}
-	otoCtx, readyChan, err := oto.NewContext(op)
-	if err != nil {
-		return fmt.Errorf("unable to initialize an oto context: %w", err)
+	if p.tickerCancel != nil {
+		p.tickerCancel()
+		p.tickerCancel = nil
 	}
-	<-readyChan
-
-	hdr := (*reflect.SliceHeader)(unsafe.Pointer(&buffer))
-	hdr.Cap *= 4
-	hdr.Len *= 4
-
-	player := otoCtx.NewPlayer(bytes.NewReader(*(*[]byte)(unsafe.Pointer(hdr))))
-	player.Play()
-	for player.IsPlaying() {
-		time.Sleep(100 * time.Millisecond)
+	p.emitEvent(EventTypePhaseEnded, 0)
+	switch {
+	case p.isWork:
+		p.completedWorkSessions++
+		p.cycleWorkSessions++
+	case p.isLongBreak:
+		p.cycleWorkSessions = 0
 	}
-
-	err = player.Close()
-	if err != nil {
-		return fmt.Errorf("unable to close the player: %w", err)
+	if p.settings.AlarmEnabled && !wasOvertime {
+		p.startAlarm()
+	}
+	p.setIsWork(!p.isWork)
+	p.refreshProgress()
+	if p.settings.EndFlash && !wasOvertime {
+		p.frontend.Flash()
+	}
+	if p.settings.AutoContinue && !p.IsOffHours() {
+		p.scheduleAutoContinue()
 	}
-
-	return nil
 }
@@ -0,0 +1,87 @@
+package pomodoro
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/clock"
+	"github.com/xaionaro-go/pomodoro/pkg/datadir"
+)
+
+func TestMain(m *testing.M) {
+	// the timer logs the failures of the integrations missing in the tests
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	os.Exit(m.Run())
+}
+
+func newTestPomodoro(t testing.TB, opts ...Option) *Pomodoro {
+	t.Helper()
+	p := New(datadir.Dirs{}, opts...)
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+// TestConcurrentControl is supposed to be run with -race.
+func TestConcurrentControl(t *testing.T) {
+	p := newTestPomodoro(t)
+	unsubscribe := p.OnEvent(func(Event) {
+		p.Status()
+	})
+	defer unsubscribe()
+
+	actions := []func(){
+		func() { p.Start(true) },
+		func() { p.Start(false) },
+		p.Pause,
+		p.Resume,
+		p.TogglePause,
+		func() { p.Extend(ExtendStep) },
+		func() { p.Extend(-ExtendStep) },
+		p.StopTimer,
+		p.Undo,
+		func() { p.Status() },
+		func() { p.CurrentSettings() },
+		func() { p.SetTask("Write the tests #testing") },
+		func() { p.ApplySettings(p.CurrentSettings()) },
+	}
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := 0; idx < 200; idx++ {
+				actions[(worker+idx)%len(actions)]()
+			}
+		}()
+	}
+	wg.Wait()
+
+	p.StopTimer()
+	if s := p.Status(); s.IsRunning || s.IsPaused {
+		t.Fatalf("expected the timer to be stopped, got %+v", s)
+	}
+}
+
+func TestStatusIsSnapshot(t *testing.T) {
+	p := newTestPomodoro(t, WithClock(clock.NewFake(time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC))))
+	p.Start(true)
+	s := p.Status()
+	if !s.IsRunning || s.Phase != PhaseWork {
+		t.Fatalf("expected a running work session, got %+v", s)
+	}
+	p.Extend(ExtendStep)
+	if got := p.Status().Deadline.Sub(s.Deadline); got != ExtendStep {
+		t.Fatalf("expected the deadline to move by %v, got %v", ExtendStep, got)
+	}
+	p.StopTimer()
+	if !s.IsRunning {
+		t.Fatalf("the snapshot changed after StopTimer: %+v", s)
+	}
+	if p.Status().IsRunning {
+		t.Fatalf("expected the timer to be stopped")
+	}
+}
@@ -0,0 +1,86 @@
+package pomodoro
+
+import (
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// EnteredForeground and ExitedForeground are called by the frontend to
+// tell if the window is hidden (see Settings.PowerSaving).
+func (p *Pomodoro) EnteredForeground() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.mobile && p.isRunning() {
+		// the app was frozen in the background, so the interval
+		// could have ended meanwhile (see handleSuspend)
+		p.tick()
+	}
+	p.inBackground = false
+	p.refreshTimeLeft()
+	p.refreshProgress()
+}
+
+func (p *Pomodoro) ExitedForeground() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.inBackground = true
+	if p.mobile && p.isRunning() && !p.isStopwatch {
+		// the system may not let the app notify at the end
+		p.notify(l10n.T("The interval goes on in the background and ends at %s.", l10n.FormatClock(p.wallDeadline())))
+	}
+}
+
+// IsMobile returns true if the timer runs on a phone or a tablet
+// (the layout is adapted to the touch screen then).
+func (p *Pomodoro) IsMobile() bool {
+	return p.mobile
+}
+
+// isRenderingReduced returns true if only the minutes should be refreshed
+// on the ticks (see Settings.PowerSaving).
+func (p *Pomodoro) isRenderingReduced() bool {
+	return p.settings.PowerSaving && (p.inBackground || p.IsHeadless())
+}
+
+// renderTick shows the time on a tick, the seconds and the progress
+// are not refreshed while the window is hidden in the power-saving mode.
+func (p *Pomodoro) renderTick(timeLeft time.Duration) {
+	if p.isRenderingReduced() {
+		p.view.Digits.Minutes, _ = p.split(timeLeft)
+		p.view.Digits.Minus = timeLeft < 0 && p.isOvertime
+		p.refresh()
+		return
+	}
+	p.setTimeLeft(timeLeft)
+	p.refreshProgress()
+}
+
+func (p *Pomodoro) refreshTimeLeft() {
+	switch {
+	case p.isStopwatch && (p.isRunning() || p.isPaused):
+		p.setTimeLeft(p.elapsed())
+	case p.isRunning():
+		p.setTimeLeft(p.until(p.deadline))
+	case p.isPaused:
+		p.setTimeLeft(p.pausedTimeLeft)
+	default:
+		p.setTimeLeft(p.nextInterval())
+	}
+}
+
+// refreshProgress updates View.Progress.
+func (p *Pomodoro) refreshProgress() {
+	var timeLeft time.Duration
+	switch {
+	case p.isPaused:
+		timeLeft = p.pausedTimeLeft
+	case p.isRunning():
+		timeLeft = p.until(p.deadline)
+	}
+	p.view.Progress = 0
+	if (p.isPaused || p.isRunning()) && p.phasePlanned > 0 {
+		p.view.Progress = min(max(1-float64(timeLeft)/float64(p.phasePlanned), 0), 1)
+	}
+	p.refresh()
+}
@@ -0,0 +1,175 @@
+package pomodoro
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Preset is a button of the presets grid, it sets the interval
+// of the phase (see ApplyPreset).
+type Preset struct {
+	// Label is the text of the button, empty means the minutes.
+	Label    string
+	Duration time.Duration
+	Phase    Phase
+}
+
+const (
+	// MaxPresets is the number of the presets, each of them has
+	// a keyboard shortcut (F1-F12).
+	MaxPresets = 12
+
+	maxPresetDuration = 24 * time.Hour
+)
+
+var presetPhases = []Phase{
+	PhaseWork,
+	PhaseRest,
+	PhaseLongRest,
+}
+
+func DefaultPresets() []Preset {
+	return []Preset{
+		{Duration: 15 * time.Minute, Phase: PhaseWork},
+		{Duration: 25 * time.Minute, Phase: PhaseWork},
+		{Duration: 45 * time.Minute, Phase: PhaseWork},
+		{Duration: 60 * time.Minute, Phase: PhaseWork},
+		{Duration: 90 * time.Minute, Phase: PhaseWork},
+		{Duration: 5 * time.Minute, Phase: PhaseRest},
+		{Duration: 10 * time.Minute, Phase: PhaseRest},
+		{Duration: 30 * time.Minute, Phase: PhaseLongRest},
+	}
+}
+
+// FormatPreset serializes the preset as "label, minutes, phase"
+// (the label is omitted if empty), like "Deep work, 90, work".
+func FormatPreset(preset Preset) string {
+	fields := []string{
+		strconv.Itoa(int(preset.Duration / time.Minute)),
+		preset.Phase.String(),
+	}
+	if preset.Label != "" {
+		fields = append([]string{preset.Label}, fields...)
+	}
+	return strings.Join(fields, ", ")
+}
+
+// ParsePreset parses the result of FormatPreset, the phase may be omitted
+// as well (then it is "work").
+func ParsePreset(s string) (Preset, error) {
+	fields := strings.Split(s, ",")
+	for idx := range fields {
+		fields[idx] = strings.TrimSpace(fields[idx])
+	}
+	var preset Preset
+	switch len(fields) {
+	case 1:
+		fields = append(fields, PhaseWork.String())
+	case 2:
+	case 3:
+		preset.Label = fields[0]
+		fields = fields[1:]
+	default:
+		return Preset{}, fmt.Errorf("expected 'label, minutes, phase', but got '%s'", s)
+	}
+	minutes, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return Preset{}, fmt.Errorf("'%s' is not a number: %w", fields[0], err)
+	}
+	if minutes <= 0 {
+		return Preset{}, fmt.Errorf("the preset %d should be positive", minutes)
+	}
+	if time.Duration(minutes) > maxPresetDuration/time.Minute {
+		return Preset{}, fmt.Errorf("the preset %d should be at most %d minutes", minutes, maxPresetDuration/time.Minute)
+	}
+	preset.Duration = time.Duration(minutes) * time.Minute
+	preset.Phase = PhaseUndefined
+	for _, phase := range presetPhases {
+		if fields[1] == phase.String() {
+			preset.Phase = phase
+		}
+	}
+	if preset.Phase == PhaseUndefined {
+		return Preset{}, fmt.Errorf("unknown phase '%s', expected one of: work, rest, long_rest", fields[1])
+	}
+	return preset, nil
+}
+
+// FormatPresets serializes the presets one per line (see FormatPreset).
+func FormatPresets(presets []Preset) string {
+	lines := make([]string, 0, len(presets))
+	for _, preset := range presets {
+		lines = append(lines, FormatPreset(preset))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func ParsePresets(s string) ([]Preset, error) {
+	var presets []Preset
+	for _, line := range ParseLines(s) {
+		preset, err := ParsePreset(line)
+		if err != nil {
+			return nil, err
+		}
+		presets = append(presets, preset)
+	}
+	if len(presets) > MaxPresets {
+		return nil, fmt.Errorf("at most %d presets are supported, but %d given", MaxPresets, len(presets))
+	}
+	return presets, nil
+}
+
+// parseLegacyPresets parses the presets of the older versions:
+// comma-separated minutes of work, like "5, 25, 50".
+func parseLegacyPresets(s string) ([]Preset, error) {
+	var presets []Preset
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		preset, err := ParsePreset(part)
+		if err != nil {
+			return nil, err
+		}
+		presets = append(presets, preset)
+	}
+	return presets, nil
+}
+
+// ApplyPreset sets the interval of the preset's phase. If it is the current
+// phase, the deadline is moved (as with SetNextInterval); if the timer is
+// stopped, it also switches to the phase.
+func (p *Pomodoro) ApplyPreset(preset Preset) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.refuseIfCommitted("change the interval") {
+		return
+	}
+
+	if p.phase() == preset.Phase {
+		p.setNextInterval(preset.Duration)
+		return
+	}
+	switch preset.Phase {
+	case PhaseWork:
+		p.nextWorkInterval = preset.Duration
+	case PhaseLongRest:
+		p.nextLongRestInterval = preset.Duration
+	default:
+		p.nextRestInterval = preset.Duration
+	}
+	if p.isRunning() || p.isPaused {
+		return
+	}
+	p.isStopwatch = false
+	p.setIsWork(preset.Phase == PhaseWork)
+	if preset.Phase == PhaseLongRest && !p.isLongBreak {
+		p.isLongBreak = true
+		p.setDescription(p.settings.Labels.Description(PhaseLongRest))
+		p.setTimeLeft(p.nextInterval())
+	}
+	p.refresh()
+}
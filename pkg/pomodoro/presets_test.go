@@ -0,0 +1,72 @@
+package pomodoro
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func FuzzParsePreset(f *testing.F) {
+	for _, preset := range DefaultPresets() {
+		f.Add(FormatPreset(preset))
+	}
+	for _, s := range []string{
+		"Deep work, 90, work",
+		"25",
+		", 5, rest",
+		"0, work",
+		"-5, rest",
+		"9223372036854775807",
+		"5, nap",
+		"a, b, c, d",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		preset, err := ParsePreset(s)
+		if err != nil {
+			return
+		}
+		if preset.Duration <= 0 || preset.Duration > maxPresetDuration {
+			t.Fatalf("'%s' is parsed into the duration %v", s, preset.Duration)
+		}
+		if preset.Duration%time.Minute != 0 {
+			t.Fatalf("'%s' is parsed into %v, which is not in minutes", s, preset.Duration)
+		}
+		if !slices.Contains(presetPhases, preset.Phase) {
+			t.Fatalf("'%s' is parsed into the phase %s", s, preset.Phase)
+		}
+		formatted := FormatPreset(preset)
+		reparsed, err := ParsePreset(formatted)
+		if err != nil {
+			t.Fatalf("unable to parse '%s' formatted from '%s': %v", formatted, s, err)
+		}
+		if reparsed != preset {
+			t.Fatalf("'%s' is parsed into %+v, but after formatting into %+v", s, preset, reparsed)
+		}
+	})
+}
+
+func FuzzParsePresets(f *testing.F) {
+	f.Add(FormatPresets(DefaultPresets()))
+	f.Add(strings.Repeat("5\n", MaxPresets+1))
+	f.Add("\n 25, work \n\n5, rest")
+	f.Fuzz(func(t *testing.T, s string) {
+		presets, err := ParsePresets(s)
+		if err != nil {
+			return
+		}
+		if len(presets) > MaxPresets {
+			t.Fatalf("'%s' is parsed into %d presets", s, len(presets))
+		}
+		reparsed, err := ParsePresets(FormatPresets(presets))
+		if err != nil {
+			t.Fatalf("unable to parse the formatted presets of '%s': %v", s, err)
+		}
+		if !reflect.DeepEqual(reparsed, presets) {
+			t.Fatalf("'%s' is parsed into %+v, but after formatting into %+v", s, presets, reparsed)
+		}
+	})
+}
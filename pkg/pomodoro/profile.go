@@ -0,0 +1,170 @@
+package pomodoro
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type Profile struct {
+	Name             string
+	WorkInterval     time.Duration
+	RestInterval     time.Duration
+	LongRestInterval time.Duration
+	LongBreakEvery   uint
+	AlarmEnabled     bool
+	AlarmSound       AlarmSound
+	AlarmFile        string
+	AutoContinue     bool
+
+	// Hooks, Colors and Labels are not changed when selecting the profile if nil.
+	Hooks  *HookSettings `json:",omitempty"`
+	Colors *Theme        `json:",omitempty"`
+	Labels *PhaseLabels  `json:",omitempty"`
+}
+
+func DefaultProfiles() []Profile {
+	return []Profile{
+		{
+			Name:             "standard",
+			WorkInterval:     25 * time.Minute,
+			RestInterval:     5 * time.Minute,
+			LongRestInterval: 15 * time.Minute,
+			LongBreakEvery:   4,
+		},
+		{
+			Name:             "long focus",
+			WorkInterval:     50 * time.Minute,
+			RestInterval:     10 * time.Minute,
+			LongRestInterval: 30 * time.Minute,
+			LongBreakEvery:   3,
+		},
+		{
+			Name:             "sprint",
+			WorkInterval:     45 * time.Minute,
+			RestInterval:     15 * time.Minute,
+			LongRestInterval: 30 * time.Minute,
+			LongBreakEvery:   3,
+		},
+		{
+			Name:             "deep work",
+			WorkInterval:     90 * time.Minute,
+			RestInterval:     20 * time.Minute,
+			LongRestInterval: 40 * time.Minute,
+			LongBreakEvery:   2,
+		},
+	}
+}
+
+func (s Settings) ProfileNames() []string {
+	names := make([]string, 0, len(s.Profiles))
+	for _, profile := range s.Profiles {
+		names = append(names, profile.Name)
+	}
+	return names
+}
+
+func (s Settings) profileIndex(name string) int {
+	for idx, profile := range s.Profiles {
+		if profile.Name == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+// UseProfile copies the values of the profile into the settings
+// and makes it active.
+func (s *Settings) UseProfile(name string) error {
+	idx := s.profileIndex(name)
+	if idx < 0 {
+		return fmt.Errorf("profile '%s' not found", name)
+	}
+	profile := s.Profiles[idx]
+	s.WorkInterval = profile.WorkInterval
+	s.RestInterval = profile.RestInterval
+	s.LongRestInterval = profile.LongRestInterval
+	s.LongBreakEvery = profile.LongBreakEvery
+	s.AlarmEnabled = profile.AlarmEnabled
+	if profile.AlarmSound != "" {
+		s.AlarmSound = profile.AlarmSound
+	}
+	s.AlarmFile = profile.AlarmFile
+	s.AutoContinue = profile.AutoContinue
+	if profile.Hooks != nil {
+		s.Hooks = *profile.Hooks
+	}
+	if profile.Colors != nil {
+		s.Colors = *profile.Colors
+	}
+	if profile.Labels != nil {
+		s.Labels = *profile.Labels
+	}
+	s.ActiveProfile = name
+	return nil
+}
+
+// StoreProfile saves the current values of the settings as the profile
+// (creating it if needed) and makes it active.
+func (s *Settings) StoreProfile(name string) {
+	hooks, colors, labels := s.Hooks, s.Colors, s.Labels
+	profile := Profile{
+		Name:             name,
+		WorkInterval:     s.WorkInterval,
+		RestInterval:     s.RestInterval,
+		LongRestInterval: s.LongRestInterval,
+		LongBreakEvery:   s.LongBreakEvery,
+		AlarmEnabled:     s.AlarmEnabled,
+		AlarmSound:       s.AlarmSound,
+		AlarmFile:        s.AlarmFile,
+		AutoContinue:     s.AutoContinue,
+		Hooks:            &hooks,
+		Colors:           &colors,
+		Labels:           &labels,
+	}
+	s.Profiles = append([]Profile{}, s.Profiles...)
+	if idx := s.profileIndex(name); idx >= 0 {
+		s.Profiles[idx] = profile
+	} else {
+		s.Profiles = append(s.Profiles, profile)
+	}
+	s.ActiveProfile = name
+}
+
+func loadProfiles(prefs Preferences) []Profile {
+	serialized := prefs.String(prefKeyProfiles)
+	if serialized == "" {
+		return DefaultProfiles()
+	}
+	var profiles []Profile
+	if err := json.Unmarshal([]byte(serialized), &profiles); err != nil {
+		slog.Warn("unable to parse the profiles preference, using the defaults", "error", err)
+		return DefaultProfiles()
+	}
+	return profiles
+}
+
+func saveProfiles(prefs Preferences, profiles []Profile) {
+	serialized, err := json.Marshal(profiles)
+	if err != nil {
+		slog.Error("unable to serialize the profiles", "error", err)
+		return
+	}
+	prefs.SetString(prefKeyProfiles, string(serialized))
+}
+
+func (p *Pomodoro) SelectProfile(name string) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if name == p.settings.ActiveProfile {
+		return
+	}
+	s := p.settings
+	if err := s.UseProfile(name); err != nil {
+		slog.Error("unable to select the profile", "error", err)
+		return
+	}
+	p.applySettings(s)
+	s.Save(p.prefs)
+}
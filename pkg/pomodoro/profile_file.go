@@ -0,0 +1,181 @@
+package pomodoro
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ProfileFileExtension is the extension of the profile files
+	// (see ProfileFile).
+	ProfileFileExtension = ".pomodoro"
+	profileFileVersion   = 1
+	soundsDirName        = "sounds"
+)
+
+// ProfileFile is the format of the files to share the profiles with.
+// A custom alarm sound is embedded into the file, since its path
+// makes no sense on another computer.
+type ProfileFile struct {
+	Version       int
+	Profile       Profile
+	AlarmFileName string `json:",omitempty"`
+	AlarmFileData []byte `json:",omitempty"`
+}
+
+func ReadProfileFile(r io.Reader) (ProfileFile, error) {
+	var f ProfileFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return ProfileFile{}, fmt.Errorf("unable to parse the profile file: %w", err)
+	}
+	if f.Version != profileFileVersion {
+		return ProfileFile{}, fmt.Errorf("unsupported profile file version %d, expected %d", f.Version, profileFileVersion)
+	}
+	if strings.TrimSpace(f.Profile.Name) == "" {
+		return ProfileFile{}, fmt.Errorf("the profile in the file has no name")
+	}
+	return f, nil
+}
+
+// ExportProfile writes the profile as a file (see ProfileFile).
+func (p *Pomodoro) ExportProfile(
+	w io.Writer,
+	name string,
+) error {
+	p.locker.Lock()
+	idx := p.settings.profileIndex(name)
+	var profile Profile
+	if idx >= 0 {
+		profile = p.settings.Profiles[idx]
+	}
+	p.locker.Unlock()
+	if idx < 0 {
+		return fmt.Errorf("profile '%s' not found", name)
+	}
+
+	f := ProfileFile{
+		Version: profileFileVersion,
+		Profile: profile,
+	}
+	if profile.AlarmFile != "" {
+		data, err := os.ReadFile(profile.AlarmFile)
+		if err != nil {
+			return fmt.Errorf("unable to read the alarm sound '%s': %w", profile.AlarmFile, err)
+		}
+		f.AlarmFileName = filepath.Base(profile.AlarmFile)
+		f.AlarmFileData = data
+		f.Profile.AlarmFile = ""
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	if err := encoder.Encode(f); err != nil {
+		return fmt.Errorf("unable to write the profile file: %w", err)
+	}
+	return nil
+}
+
+// ImportProfile adds the profile from the file (replacing the one with
+// the same name) and selects it.
+func (p *Pomodoro) ImportProfile(f ProfileFile) error {
+	profile := f.Profile
+	if len(f.AlarmFileData) > 0 {
+		path, err := p.storeSound(f.AlarmFileName, f.AlarmFileData)
+		if err != nil {
+			return err
+		}
+		profile.AlarmFile = path
+	}
+
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	s := p.settings
+	s.Profiles = append([]Profile{}, s.Profiles...)
+	if idx := s.profileIndex(profile.Name); idx >= 0 {
+		s.Profiles[idx] = profile
+	} else {
+		s.Profiles = append(s.Profiles, profile)
+	}
+	if err := s.UseProfile(profile.Name); err != nil {
+		return err
+	}
+	p.applySettings(s)
+	s.Save(p.prefs)
+	return nil
+}
+
+// storeSound saves the sound into the application storage (see
+// writeUniqueFile), it returns the path to it.
+func (p *Pomodoro) storeSound(
+	fileName string,
+	data []byte,
+) (string, error) {
+	dataDir := p.dataDir()
+	if dataDir == "" {
+		return "", fmt.Errorf("no storage to save the alarm sound to")
+	}
+	fileName = filepath.Base(fileName)
+	if fileName == "." || fileName == string(filepath.Separator) {
+		fileName = "alarm"
+	}
+	dir := filepath.Join(dataDir, soundsDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("unable to create the directory '%s': %w", dir, err)
+	}
+	path, err := writeUniqueFile(dir, fileName, data)
+	if err != nil {
+		return "", fmt.Errorf("unable to save the alarm sound: %w", err)
+	}
+	return path, nil
+}
+
+// writeUniqueFile writes data into the file in dir, "name (2).ext" and so on
+// are used if the name is taken by another file; the file with the same
+// content is reused. It returns the path to the file.
+func writeUniqueFile(
+	dir string,
+	fileName string,
+	data []byte,
+) (string, error) {
+	ext := filepath.Ext(fileName)
+	base := strings.TrimSuffix(fileName, ext)
+	for idx := 1; ; idx++ {
+		path := filepath.Join(dir, fileName)
+		if idx > 1 {
+			path = filepath.Join(dir, fmt.Sprintf("%s (%d)%s", base, idx, ext))
+		}
+		existing, err := os.ReadFile(path)
+		switch {
+		case err == nil && bytes.Equal(existing, data):
+			return path, nil
+		case err == nil:
+			continue
+		case !errors.Is(err, os.ErrNotExist):
+			return "", fmt.Errorf("unable to read '%s': %w", path, err)
+		}
+
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if errors.Is(err, os.ErrExist) {
+			// created in the meantime
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("unable to create '%s': %w", path, err)
+		}
+		_, err = f.Write(data)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(path)
+			return "", fmt.Errorf("unable to write '%s': %w", path, err)
+		}
+		return path, nil
+	}
+}
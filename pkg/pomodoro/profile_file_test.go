@@ -0,0 +1,41 @@
+package pomodoro
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteUniqueFile(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"alarm.wav":     "old sound",
+		"alarm (2).wav": "another sound",
+		"bell.wav":      "new sound",
+	})
+	for _, tc := range []struct {
+		FileName string
+		Data     string
+		Expected string
+	}{
+		{FileName: "alarm.wav", Data: "new sound", Expected: "alarm (3).wav"},
+		{FileName: "alarm.wav", Data: "new sound", Expected: "alarm (3).wav"},
+		{FileName: "alarm.wav", Data: "another sound", Expected: "alarm (2).wav"},
+		{FileName: "bell.wav", Data: "new sound", Expected: "bell.wav"},
+		{FileName: "gong", Data: "new sound", Expected: "gong"},
+	} {
+		path, err := writeUniqueFile(dir, tc.FileName, []byte(tc.Data))
+		if err != nil {
+			t.Fatalf("unable to write '%s': %v", tc.FileName, err)
+		}
+		if expected := filepath.Join(dir, tc.Expected); path != expected {
+			t.Fatalf("expected '%s' to be written to '%s', got '%s'", tc.FileName, expected, path)
+		}
+		if data, err := os.ReadFile(path); err != nil || string(data) != tc.Data {
+			t.Fatalf("expected '%s' to contain %q, got %q (%v)", path, tc.Data, data, err)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "alarm.wav")); string(data) != "old sound" {
+		t.Fatalf("the existing sound is overwritten with %q", data)
+	}
+}
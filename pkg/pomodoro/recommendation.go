@@ -0,0 +1,39 @@
+package pomodoro
+
+import (
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+)
+
+const (
+	// recommendLengthStep groups the intervals of close lengths together
+	// (see history.Recommend).
+	recommendLengthStep = 5 * time.Minute
+)
+
+// Recommend returns the most important suggestion of another work
+// interval length derived from the history (see history.Recommend);
+// ok is false if there is nothing to suggest.
+func (p *Pomodoro) Recommend() (recommendation history.Recommendation, ok bool) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.recommend()
+}
+
+func (p *Pomodoro) recommend() (history.Recommendation, bool) {
+	if p.history == nil {
+		return history.Recommendation{}, false
+	}
+	var work []history.Session
+	for _, session := range p.history.Sessions() {
+		if session.Phase == PhaseWork.String() {
+			work = append(work, session)
+		}
+	}
+	recommendations := history.Recommend(work, recommendLengthStep)
+	if len(recommendations) == 0 {
+		return history.Recommendation{}, false
+	}
+	return recommendations[0], true
+}
@@ -4,3 +4,12 @@ import _ "embed"
 
 //go:embed resources/alarm.ogg
 var alarmSoundFile []byte
+
+//go:embed resources/bell.wav
+var bellSoundFile []byte
+
+//go:embed resources/chime.wav
+var chimeSoundFile []byte
+
+//go:embed resources/gong.wav
+var gongSoundFile []byte
@@ -0,0 +1,46 @@
+package pomodoro
+
+import (
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// RitualSettings configure the checklist to go through before
+// a work session starts (see SetRitualResult).
+type RitualSettings struct {
+	Enabled bool
+	Items   []string
+}
+
+// IsEnabled returns true if there is a checklist to go through.
+func (s RitualSettings) IsEnabled() bool {
+	return s.Enabled && len(s.Items) > 0
+}
+
+func DefaultRitualItems() []string {
+	return []string{
+		l10n.T("Put the phone away"),
+		l10n.T("Get some water"),
+		l10n.T("Pick the task"),
+	}
+}
+
+type ritualState struct {
+	// pending is the result of the ritual for the work session
+	// being started, it becomes phase in start.
+	pending *bool
+
+	// phase is true if the ritual was completed before the current
+	// work session, false if it was skipped and nil if there was none
+	// (see history.Session.Ritual).
+	phase *bool
+}
+
+// SetRitualResult records whether the ritual checklist was completed
+// (or skipped) before the work session the user is about to start.
+// The frontend calls it right before Start, the automatic starts (and
+// the remote control) do not wait for anybody to go through the checklist.
+func (p *Pomodoro) SetRitualResult(completed bool) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.ritual.pending = &completed
+}
@@ -0,0 +1,10 @@
+package pomodoro
+
+// SetRoomMembers shows who is in the shared room (see pkg/room),
+// an empty list hides it.
+func (p *Pomodoro) SetRoomMembers(members []string) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.view.RoomMembers = members
+	p.refresh()
+}
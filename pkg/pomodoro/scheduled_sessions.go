@@ -0,0 +1,186 @@
+package pomodoro
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+const (
+	scheduledSessionsCheckInterval = 30 * time.Second
+
+	prefKeyScheduledSessionsCheckedAt = "scheduled_sessions_checked_at"
+)
+
+// ScheduleRule starts a focus session at a time of the day, either on
+// the days of the week or once on a date. The syntax is
+//
+//	<days> <HH:MM> [<duration>] [<task>]
+//
+// where the days are "daily", "weekdays", "weekends", a comma-separated
+// list of the days ("mon,wed,fri") or a date ("2024-03-15"), for example
+// "weekdays 09:00 90m Deep work".
+type ScheduleRule struct {
+	Date     time.Time // the zero value for a recurring rule
+	Days     []time.Weekday
+	At       time.Duration // since the midnight
+	Duration time.Duration // zero for the configured work interval
+	Task     string
+}
+
+var scheduleDayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+func ParseScheduleRule(s string) (ScheduleRule, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return ScheduleRule{}, fmt.Errorf("expected '<days> <HH:MM> [<duration>] [<task>]', got '%s'", s)
+	}
+
+	var r ScheduleRule
+	switch days := strings.ToLower(fields[0]); days {
+	case "daily":
+		r.Days = Weekdays
+	case "weekdays":
+		r.Days = DefaultWorkDays()
+	case "weekends":
+		r.Days = []time.Weekday{time.Saturday, time.Sunday}
+	default:
+		if date, err := time.ParseInLocation(time.DateOnly, days, time.Local); err == nil {
+			r.Date = date
+			break
+		}
+		for _, name := range strings.Split(days, ",") {
+			day, ok := scheduleDayNames[name]
+			if !ok {
+				return ScheduleRule{}, fmt.Errorf("unknown day '%s', expected 'daily', 'weekdays', 'weekends', a date (YYYY-MM-DD) or a list like 'mon,wed,fri'", name)
+			}
+			r.Days = append(r.Days, day)
+		}
+	}
+
+	at, err := time.Parse("15:04", fields[1])
+	if err != nil {
+		return ScheduleRule{}, fmt.Errorf("unable to parse the time '%s', expected HH:MM: %w", fields[1], err)
+	}
+	r.At = time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute
+
+	rest := fields[2:]
+	if len(rest) > 0 {
+		if d, err := time.ParseDuration(rest[0]); err == nil {
+			if d <= 0 {
+				return ScheduleRule{}, fmt.Errorf("the duration '%s' is not positive", rest[0])
+			}
+			r.Duration = d
+			rest = rest[1:]
+		}
+	}
+	r.Task = strings.Join(rest, " ")
+	return r, nil
+}
+
+// LastOccurrence returns the latest start of the session not after t.
+func (r ScheduleRule) LastOccurrence(t time.Time) (time.Time, bool) {
+	if !r.Date.IsZero() {
+		occurrence := r.occurrenceOn(r.Date)
+		return occurrence, !occurrence.After(t)
+	}
+	for daysAgo := 0; daysAgo <= 7; daysAgo++ {
+		day := t.AddDate(0, 0, -daysAgo)
+		if !slices.Contains(r.Days, day.Weekday()) {
+			continue
+		}
+		occurrence := r.occurrenceOn(day)
+		if !occurrence.After(t) {
+			return occurrence, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// occurrenceOn uses the wall clock time, so that the rule keeps
+// its time across the DST changes.
+func (r ScheduleRule) occurrenceOn(day time.Time) time.Time {
+	year, month, date := day.Date()
+	return time.Date(year, month, date, int(r.At/time.Hour), int(r.At%time.Hour/time.Minute), 0, 0, day.Location())
+}
+
+func parseScheduleRules(lines []string) []ScheduleRule {
+	var rules []ScheduleRule
+	for _, line := range lines {
+		rule, err := ParseScheduleRule(line)
+		if err != nil {
+			slog.Warn("unable to parse the scheduled session", "rule", line, "error", err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// watchScheduledSessions starts the scheduled sessions (see ScheduleRule).
+// The time of the last check is persisted and the wall clock is compared
+// with it, so a session is still started (for the rest of its time) if
+// the computer was asleep or the application was not running at its start.
+func (p *Pomodoro) watchScheduledSessions() {
+	ticker := p.clock.NewTicker(scheduledSessionsCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.lifecycle.ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		p.locker.Lock()
+		p.checkScheduledSessions()
+		p.locker.Unlock()
+	}
+}
+
+func (p *Pomodoro) checkScheduledSessions() {
+	prefs := p.prefs
+	now := p.clock.Now().Round(0)
+	checkedAt, err := time.Parse(time.RFC3339, prefs.String(prefKeyScheduledSessionsCheckedAt))
+	if err != nil {
+		checkedAt = now
+	}
+	prefs.SetString(prefKeyScheduledSessionsCheckedAt, now.Format(time.RFC3339))
+
+	for _, rule := range parseScheduleRules(p.settings.ScheduledSessions) {
+		occurrence, ok := rule.LastOccurrence(now)
+		if !ok || !occurrence.After(checkedAt) {
+			continue
+		}
+		duration := rule.Duration
+		if duration == 0 {
+			duration = p.settings.WorkInterval
+		}
+		timeLeft := occurrence.Add(duration).Sub(now)
+		if timeLeft <= 0 {
+			continue
+		}
+		if p.isRunning() || p.isPaused {
+			slog.Info("the scheduled session is skipped, since the timer is already running", "at", occurrence)
+			continue
+		}
+		slog.Info("starting the scheduled session", "at", occurrence, "time_left", timeLeft)
+		if rule.Task != "" {
+			p.task = rule.Task
+		}
+		p.start(true)
+		p.overridePhaseDuration(timeLeft)
+		p.notify(l10n.T("The scheduled focus session has started."))
+	}
+}
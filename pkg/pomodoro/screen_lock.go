@@ -0,0 +1,118 @@
+package pomodoro
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// ScreenLockSettings configure locking the screen when a long break
+// starts (see Pomodoro.ScreenLocker).
+type ScreenLockSettings struct {
+	OnLongBreak bool
+
+	// Warning is how long the countdown is shown before the lock.
+	Warning time.Duration
+}
+
+func (p *Pomodoro) handleScreenLockEvent(ev Event) {
+	switch ev.Type {
+	case EventTypePhaseStarted:
+	case EventTypePhaseEnded, EventTypeStopped, EventTypePaused:
+		p.locker.Lock()
+		defer p.locker.Unlock()
+		p.cancelScreenLock()
+		return
+	default:
+		return
+	}
+
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.cancelScreenLock()
+	s := p.settings.ScreenLock
+	if !s.OnLongBreak || ev.Phase != PhaseLongRest || p.ScreenLocker == nil {
+		return
+	}
+	ctx, cancelFn := context.WithCancel(p.lifecycle.ctx)
+	p.screenLockCancel = cancelFn
+	lockNow := make(chan struct{}, 1)
+	p.screenLockNow = lockNow
+	p.view.ScreenLockIn = s.Warning
+	p.refresh()
+	p.notify(l10n.T("The screen will be locked in %d seconds for the long break.", int(s.Warning/time.Second)))
+	p.lifecycle.launch(func() {
+		p.lockScreenAfter(ctx, s.Warning, lockNow)
+	})
+}
+
+func (p *Pomodoro) cancelScreenLock() {
+	if p.screenLockCancel != nil {
+		p.screenLockCancel()
+		p.screenLockCancel = nil
+	}
+	p.screenLockNow = nil
+	if p.view.ScreenLockIn != 0 {
+		p.view.ScreenLockIn = 0
+		p.refresh()
+	}
+}
+
+// LockScreenNow skips the rest of the countdown to locking the screen
+// (see ScreenLockSettings.Warning).
+func (p *Pomodoro) LockScreenNow() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.screenLockNow == nil {
+		return
+	}
+	select {
+	case p.screenLockNow <- struct{}{}:
+	default:
+	}
+}
+
+// lockScreenAfter counts down the warning and locks the screen
+// unless the break was stopped meanwhile.
+func (p *Pomodoro) lockScreenAfter(
+	ctx context.Context,
+	warning time.Duration,
+	lockNow <-chan struct{},
+) {
+	deadline := p.clock.Now().Add(warning)
+	ticker := p.clock.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-lockNow:
+		case <-ticker.C():
+			p.locker.Lock()
+			timeLeft := p.until(deadline)
+			if timeLeft > 0 && ctx.Err() == nil {
+				p.view.ScreenLockIn = timeLeft
+				p.refresh()
+			}
+			p.locker.Unlock()
+			if timeLeft > 0 {
+				continue
+			}
+		}
+		break
+	}
+
+	p.locker.Lock()
+	if ctx.Err() != nil {
+		p.locker.Unlock()
+		return
+	}
+	locker := p.ScreenLocker
+	p.cancelScreenLock()
+	p.locker.Unlock()
+	if err := locker.Lock(); err != nil {
+		slog.Error("unable to lock the screen", "error", err)
+	}
+}
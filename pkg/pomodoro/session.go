@@ -0,0 +1,150 @@
+package pomodoro
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/tracker"
+)
+
+const (
+	historyFileName = "history.jsonl"
+	trackerTimeout  = 30 * time.Second
+)
+
+func (p *Pomodoro) openHistory() {
+	path, err := p.dataFilePath(historyFileName)
+	if err != nil {
+		slog.Warn("unable to prepare the data directory", "error", err)
+	}
+	h, err := history.Open(path)
+	if err != nil {
+		slog.Warn("unable to open the session history, using an in-memory one", "error", err)
+		h, _ = history.Open("")
+	}
+	p.history = h
+}
+
+// TaskOptions returns the previous tasks or, while a tag is being
+// typed, the previous tags starting the same way.
+func (p *Pomodoro) TaskOptions(task string) []string {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.history == nil {
+		return nil
+	}
+	lastWordIdx := strings.LastIndexAny(task, " \t") + 1
+	partialTag, ok := strings.CutPrefix(task[lastWordIdx:], "#")
+	if !ok {
+		return p.history.Tasks()
+	}
+	var options []string
+	for _, tag := range p.history.Tags() {
+		if strings.HasPrefix(tag, partialTag) && tag != partialTag {
+			options = append(options, task[:lastWordIdx]+"#"+tag)
+		}
+	}
+	return options
+}
+
+func (p *Pomodoro) SetTask(task string) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.task = task
+	if p.pickedTask != nil && p.pickedTask.Task.Title != task {
+		p.pickedTask = nil
+	}
+	if p.isRunning() || p.isPaused {
+		p.refreshTask()
+	}
+}
+
+func (p *Pomodoro) refreshTask() {
+	if !p.isWork {
+		p.rotateSuggestion(true)
+		return
+	}
+	p.view.Caption = p.task
+	p.refresh()
+}
+
+func (p *Pomodoro) elapsed() time.Duration {
+	elapsed := p.phaseElapsed
+	if p.isRunning() {
+		elapsed += p.since(p.phaseRunningSince)
+	}
+	return elapsed
+}
+
+// recordSession should be called before the ticker is cancelled,
+// otherwise the last running period is not accounted.
+func (p *Pomodoro) recordSession(completed bool) {
+	if p.history == nil || p.phaseStartedAt.IsZero() {
+		return
+	}
+	elapsed := p.elapsed()
+	session := history.Session{
+		StartedAt: p.phaseStartedAt,
+		EndedAt:   p.clock.Now(),
+		Phase:     p.phase().String(),
+		Planned:   p.phasePlanned,
+		Duration:  elapsed,
+		Completed: completed,
+		Overtime:  p.overtime(),
+
+		Interruptions: p.phaseInterruptions,
+	}
+	if p.isWork {
+		session.Task = p.task
+		session.Tags = history.ParseTags(p.task)
+		session.Ritual = p.ritual.phase
+	} else if p.settings.BreakCompliance != BreakCompliancePolicyOff && p.IdleDetector != nil {
+		active := min(p.phaseActive, elapsed)
+		session.Active = &active
+	}
+	p.phaseStartedAt = time.Time{}
+	p.phaseInterruptions = nil
+	p.ritual.phase = nil
+	if err := p.history.Add(session); err != nil {
+		slog.Error("unable to record the session", "error", err)
+	}
+	if completed && p.isWork {
+		p.lastWorkStartedAt = session.StartedAt
+		p.trackSession(session)
+		p.reportPickedTask(session)
+	}
+	p.refreshGoal()
+}
+
+func (p *Pomodoro) AddTracker(t tracker.Tracker) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.trackers = append(p.trackers, t)
+}
+
+func (p *Pomodoro) trackSession(session history.Session) {
+	if len(p.trackers) == 0 {
+		return
+	}
+	trackers := append([]tracker.Tracker(nil), p.trackers...)
+	entry := tracker.Entry{
+		Description: session.Task,
+		StartedAt:   session.StartedAt,
+		EndedAt:     session.EndedAt,
+		Duration:    session.Duration,
+		Tags:        append([]string{"pomodoro"}, session.Tags...),
+	}
+	p.lifecycle.launch(func() {
+		for _, t := range trackers {
+			ctx, cancelFn := context.WithTimeout(context.Background(), trackerTimeout)
+			err := t.AddEntry(ctx, entry)
+			cancelFn()
+			if err != nil {
+				slog.Error("unable to send the session to the time tracker", "error", err)
+			}
+		}
+	})
+}
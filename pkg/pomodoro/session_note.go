@@ -0,0 +1,23 @@
+package pomodoro
+
+import (
+	"fmt"
+)
+
+// SetSessionNote attaches the note (what was accomplished)
+// to the last completed work session.
+func (p *Pomodoro) SetSessionNote(note string) error {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.setSessionNote(note)
+}
+
+func (p *Pomodoro) setSessionNote(note string) error {
+	if p.history == nil || p.lastWorkStartedAt.IsZero() {
+		return fmt.Errorf("no completed work session")
+	}
+	if err := p.history.SetNote(p.lastWorkStartedAt, note); err != nil {
+		return fmt.Errorf("unable to save the note: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,716 @@
+package pomodoro
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/notify"
+)
+
+const (
+	prefKeyWorkInterval      = "work_interval"
+	prefKeyRestInterval      = "rest_interval"
+	prefKeyLongRestInterval  = "long_rest_interval"
+	prefKeyLongBreakEvery    = "long_break_every"
+	prefKeyBlink             = "blink"
+	prefKeyDelimiterAnim     = "delimiter_animation"
+	prefKeyPowerSaving       = "power_saving"
+	prefKeyShowTenths        = "show_tenths"
+	prefKeyTimeLeftRounding  = "time_left_rounding"
+	prefKeyEndFlash          = "end_flash"
+	prefKeyUrgencyColors     = "urgency_colors"
+	prefKeyAlarm             = "alarm"
+	prefKeyAlarmVolume       = "alarm_volume"
+	prefKeyAlarmFile         = "alarm_file"
+	prefKeyAlarmSound        = "alarm_sound"
+	prefKeyAlarmFadeIn       = "alarm_fade_in"
+	prefKeyAlarmRepeat       = "alarm_repeat"
+	prefKeyAlarmEscalation   = "alarm_escalation"
+	prefKeyAlarmDevice       = "alarm_device"
+	prefKeyCountdown         = "countdown"
+	prefKeyTheme             = "theme"
+	prefKeyColorPalette      = "color_palette"
+	prefKeyTrayIcon          = "tray_icon"
+	prefKeyAutostart         = "autostart"
+	prefKeySpeakFocusedTimer = "speak_focused_timer"
+	prefKeyLanguage          = "language"
+	prefKeyAutoContinue      = "auto_continue"
+	prefKeyOvertime          = "overtime"
+	prefKeyAutoContinueDelay = "auto_continue_delay"
+	prefKeyEndActionChooser  = "end_action_chooser"
+	prefKeyIdlePauseAfter    = "idle_pause_after"
+	prefKeyBreakCompliance   = "break_compliance"
+	prefKeyBreakDebtLimit    = "break_debt_limit"
+	prefKeyBreakDebtEnforce  = "break_debt_enforce"
+	prefKeySuspendPolicy     = "suspend_policy"
+	prefKeyWarnBefore        = "warn_before"
+	prefKeyOverrunNudgeEvery = "overrun_nudge_every"
+	prefKeyDoNotDisturb      = "do_not_disturb"
+	prefKeyAskSessionNote    = "ask_session_note"
+	prefKeyAskFocusRating    = "ask_focus_rating"
+	prefKeyRitual            = "ritual"
+	prefKeyRitualItems       = "ritual_items"
+	prefKeyCommitmentMode    = "commitment_mode"
+	prefKeyCommitmentPhrase  = "commitment_phrase"
+	prefKeyStrictBreak       = "strict_break"
+	prefKeyStrictBreakSkip   = "strict_break_skip_after"
+	prefKeyLockScreen        = "lock_screen_on_long_break"
+	prefKeyLockScreenWarning = "lock_screen_warning"
+	prefKeyDailyGoal         = "daily_goal"
+	prefKeyDayBoundary       = "day_boundary"
+	prefKeyDailySummary      = "daily_summary"
+	prefKeyDailySummaryAt    = "daily_summary_at"
+	prefKeyWorkSchedule      = "work_schedule"
+	prefKeyWorkScheduleStart = "work_schedule_start"
+	prefKeyWorkScheduleEnd   = "work_schedule_end"
+	prefKeyWorkScheduleDays  = "work_schedule_days"
+	prefKeyStartNudgeAfter   = "start_nudge_after"
+	prefKeyScheduledSessions = "scheduled_sessions"
+	prefKeyAnnounce          = "announce"
+	prefKeyAnnounceWork      = "announce_work"
+	prefKeyAnnounceRest      = "announce_rest"
+	prefKeyAnnounceLongRest  = "announce_long_rest"
+	prefKeyAmbience          = "ambience"
+	prefKeyAmbienceFile      = "ambience_file"
+	prefKeyAmbienceVolume    = "ambience_volume"
+	prefKeyMixerCountdown    = "mixer_countdown_volume"
+	prefKeyMixerMuted        = "mixer_muted"
+	prefKeyMixerDucking      = "mixer_ducking"
+	prefKeyBlockerSites      = "blocker_sites"
+	prefKeyBlockerProcesses  = "blocker_processes"
+	prefKeyBlockerKill       = "blocker_kill_processes"
+	prefKeyBlockerProxyAddr  = "blocker_proxy_addr"
+	prefKeyFocusMusic        = "focus_music"
+	prefKeyFocusMusicList    = "focus_music_playlist"
+	prefKeyFocusMusicVolume  = "focus_music_volume"
+	prefKeyHotkeyTogglePause = "hotkey_toggle_pause"
+	prefKeyHotkeyStartWork   = "hotkey_start_work"
+	prefKeyLegacyPresets     = "presets"
+	prefKeyPresets           = "preset_grid"
+	prefKeyLabelWork         = "label_work"
+	prefKeyLabelRest         = "label_rest"
+	prefKeyLabelLongRest     = "label_long_rest"
+	prefKeyLabelStopwatch    = "label_stopwatch"
+	prefKeyPresetColumns     = "preset_columns"
+	prefKeyBreakSuggestions  = "break_suggestions"
+	prefKeyHookOnWorkStart   = "hook_on_work_start"
+	prefKeyHookOnWorkEnd     = "hook_on_work_end"
+	prefKeyHookOnRestEnd     = "hook_on_rest_end"
+	prefKeyWebhookURLs       = "webhook_urls"
+	prefKeyWebhookSecret     = "webhook_secret"
+	prefKeyProfiles          = "profiles"
+	prefKeyOnboarded         = "onboarded"
+	prefKeyActiveProfile     = "active_profile"
+	prefKeyTogglAPIToken     = "toggl_api_token"
+	prefKeyTogglWorkspaceID  = "toggl_workspace_id"
+	prefKeyMQTTBrokerURL     = "mqtt_broker_url"
+	prefKeyMQTTUsername      = "mqtt_username"
+	prefKeyMQTTPassword      = "mqtt_password"
+	prefKeyMQTTTopicPrefix   = "mqtt_topic_prefix"
+	prefKeyCalDAVURL         = "caldav_url"
+	prefKeyCalDAVUsername    = "caldav_username"
+	prefKeyCalDAVPassword    = "caldav_password"
+	prefKeyWarnMeetings      = "warn_meetings"
+	prefKeyMeetingsFeedURL   = "meetings_feed_url"
+	prefKeySlackToken        = "slack_token"
+	prefKeyDailyNotePath     = "daily_note_path"
+	prefKeyTodoistAPIToken   = "todoist_api_token"
+	prefKeyGitHubToken       = "github_token"
+	prefKeyTaskComment       = "task_comment_on_session"
+	prefKeyTaskCompleteAfter = "task_complete_after"
+	prefKeyPeerSyncListen    = "peer_sync_listen"
+	prefKeyPeerSyncPeers     = "peer_sync_peers"
+	prefKeyPeerSyncSecret    = "peer_sync_secret"
+	prefKeyRoomHostAddr      = "room_host_addr"
+	prefKeyRoomJoinURL       = "room_join_url"
+	prefKeyRoomName          = "room_name"
+	prefKeyPlugins           = "plugins"
+	prefKeyNotifyBackends    = "notify_backends"
+	prefKeyNotifyWebhookURL  = "notify_webhook_url"
+	prefKeyNotifyOnPhaseEnd  = "notify_on_phase_end"
+)
+
+type Settings struct {
+	WorkInterval         time.Duration
+	RestInterval         time.Duration
+	LongRestInterval     time.Duration
+	LongBreakEvery       uint
+	DelimiterAnimation   DelimiterAnimation
+	EndFlash             bool
+	UrgencyColors        time.Duration // see gui.urgencyDigitsColor
+	PowerSaving          bool
+	ShowTenths           bool
+	TimeLeftRounding     TimeRounding
+	AlarmEnabled         bool
+	AlarmVolume          float64
+	AlarmSound           AlarmSound
+	AlarmFile            string
+	AlarmFadeIn          time.Duration
+	AlarmRepeat          uint // 0 means until acknowledged
+	AlarmEscalation      bool
+	AlarmDevice          string
+	Countdown            CountdownMode
+	Theme                ThemeVariant
+	ColorPalette         ColorPalette
+	TrayIcon             bool
+	Autostart            bool // see Pomodoro.setAutostart
+	SpeakFocusedTimer    bool // see Pomodoro.ReadTimerAloud
+	Language             string
+	AutoContinue         bool
+	Overtime             bool // see Pomodoro.startOvertime
+	AutoContinueDelay    time.Duration
+	EndActionChooser     bool
+	IdlePauseAfter       time.Duration
+	BreakCompliance      BreakCompliancePolicy
+	SuspendPolicy        SuspendPolicy
+	WarnBefore           time.Duration
+	OverrunNudgeEvery    time.Duration
+	DoNotDisturb         bool
+	AskSessionNote       bool
+	AskFocusRating       bool
+	Ritual               RitualSettings
+	Commitment           CommitmentSettings
+	StrictBreak          bool
+	StrictBreakSkipAfter time.Duration
+	ScreenLock           ScreenLockSettings
+	BreakDebt            BreakDebtSettings
+	DailyGoal            uint
+	DayBoundary          time.Duration
+	DailySummary         DailySummarySettings
+	WorkSchedule         WorkScheduleSettings
+	ScheduledSessions    []string // see ScheduleRule
+	Announcements        AnnouncementSettings
+	Ambience             AmbienceSettings
+	FocusMusic           FocusMusicSettings
+	Mixer                MixerSettings
+	Blocker              BlockerSettings
+	Notifications        NotificationSettings
+	GlobalHotkeys        GlobalHotkeySettings
+	Hooks                HookSettings
+	Webhooks             WebhookSettings
+	MQTT                 MQTTSettings
+	Toggl                TogglSettings
+	CalDAV               CalDAVSettings
+	Slack                SlackSettings
+	DailyNote            DailyNoteSettings
+	TaskProviders        TaskProviderSettings
+	PeerSync             PeerSyncSettings
+	Room                 RoomSettings
+	Plugins              []string // see pkg/plugin
+	Colors               Theme
+	Labels               PhaseLabels
+	Presets              []Preset
+	PresetColumns        uint
+	BreakSuggestions     []string
+	Profiles             []Profile
+	ActiveProfile        string
+}
+
+type AnnouncementSettings struct {
+	Enabled  bool
+	Work     string
+	Rest     string
+	LongRest string
+}
+
+func (s AnnouncementSettings) Phrase(phase Phase) string {
+	switch phase {
+	case PhaseWork:
+		return s.Work
+	case PhaseRest:
+		return s.Rest
+	case PhaseLongRest:
+		return s.LongRest
+	default:
+		return ""
+	}
+}
+
+// GlobalHotkeySettings contains system-wide shortcuts like "ctrl+alt+p",
+// an empty value disables the shortcut.
+type GlobalHotkeySettings struct {
+	TogglePause string
+	StartWork   string
+}
+
+type TogglSettings struct {
+	APIToken    string
+	WorkspaceID int64
+}
+
+// CalDAVSettings configure blocking the work sessions in a calendar,
+// an empty URL disables it.
+type CalDAVSettings struct {
+	URL      string
+	Username string
+	Password string
+
+	// WarnMeetings offers to shorten a work session colliding with
+	// a meeting from MeetingsFeedURL (an iCalendar feed) or, if it is
+	// empty, from the CalDAV calendar.
+	WarnMeetings    bool
+	MeetingsFeedURL string
+}
+
+// SlackSettings configure showing the focus sessions in the Slack status,
+// an empty token disables it.
+type SlackSettings struct {
+	Token string
+}
+
+// DailyNoteSettings configure appending the completed work sessions to
+// a Markdown daily note, an empty PathTemplate disables it.
+type DailyNoteSettings struct {
+	PathTemplate string
+}
+
+// PeerSyncSettings configure mirroring the timer between devices (see
+// pkg/peersync), it is disabled if both ListenAddr and Peers are empty.
+type PeerSyncSettings struct {
+	ListenAddr string
+	Peers      []string
+	Secret     string
+}
+
+// RoomSettings configure the shared room (see pkg/room): either hosting
+// it or joining somebody else's one.
+type RoomSettings struct {
+	HostAddr string
+	JoinURL  string
+	Name     string
+}
+
+type MQTTSettings struct {
+	BrokerURL   string
+	Username    string
+	Password    string
+	TopicPrefix string
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		WorkInterval:     60 * time.Minute,
+		RestInterval:     15 * time.Minute,
+		LongRestInterval: 30 * time.Minute,
+		LongBreakEvery:   4,
+		Notifications: NotificationSettings{
+			Backends: []notify.BackendName{notify.BackendNameFyne},
+		},
+		DelimiterAnimation:   DelimiterAnimationBlink,
+		EndFlash:             true,
+		TimeLeftRounding:     TimeRoundingCeil,
+		TrayIcon:             true,
+		AlarmEnabled:         false,
+		AlarmVolume:          1,
+		AlarmSound:           AlarmSoundClassic,
+		AlarmRepeat:          1,
+		Countdown:            CountdownModeOff,
+		Theme:                ThemeVariantSystem,
+		ColorPalette:         ColorPaletteDefault,
+		AutoContinue:         false,
+		AutoContinueDelay:    5 * time.Second,
+		BreakCompliance:      BreakCompliancePolicyOff,
+		SuspendPolicy:        SuspendPolicyFastForward,
+		StrictBreakSkipAfter: time.Minute,
+		ScreenLock: ScreenLockSettings{
+			Warning: 10 * time.Second,
+		},
+		Commitment: CommitmentSettings{
+			Mode:   CommitmentModeOff,
+			Phrase: l10n.T("I give up on this session"),
+		},
+		BreakDebt: BreakDebtSettings{
+			Limit: 30 * time.Minute,
+		},
+		Ritual: RitualSettings{
+			Items: DefaultRitualItems(),
+		},
+		OverrunNudgeEvery: 2 * time.Minute,
+		DailyGoal:         8,
+		DayBoundary:       4 * time.Hour,
+		DailySummary: DailySummarySettings{
+			At: 18 * time.Hour,
+		},
+		WorkSchedule: WorkScheduleSettings{
+			Start: 9 * time.Hour,
+			End:   18 * time.Hour,
+			Days:  DefaultWorkDays(),
+		},
+		Colors:           DefaultTheme(),
+		Presets:          DefaultPresets(),
+		PresetColumns:    4,
+		BreakSuggestions: DefaultBreakSuggestions(),
+		Profiles:         DefaultProfiles(),
+		Announcements: AnnouncementSettings{
+			Work:     l10n.T("Time to focus"),
+			Rest:     l10n.T("Focus time is over, take a break"),
+			LongRest: l10n.T("Well done, take a long break"),
+		},
+		Ambience: AmbienceSettings{
+			Volume: 0.3,
+		},
+		FocusMusic: FocusMusicSettings{
+			Volume: 0.5,
+		},
+		Mixer: MixerSettings{
+			CountdownVolume: 1,
+			Ducking:         0.3,
+		},
+		GlobalHotkeys: GlobalHotkeySettings{
+			TogglePause: "ctrl+alt+p",
+			StartWork:   "ctrl+alt+s",
+		},
+		MQTT: MQTTSettings{
+			TopicPrefix: "pomodoro",
+		},
+	}
+}
+
+// Preferences keep the settings (and some state like the break debt),
+// it is implemented by datadir.Preferences and fyne.Preferences.
+type Preferences interface {
+	BoolWithFallback(key string, fallback bool) bool
+	SetBool(key string, value bool)
+	FloatWithFallback(key string, fallback float64) float64
+	SetFloat(key string, value float64)
+	IntWithFallback(key string, fallback int) int
+	SetInt(key string, value int)
+	IntListWithFallback(key string, fallback []int) []int
+	SetIntList(key string, value []int)
+	String(key string) string
+	StringWithFallback(key, fallback string) string
+	SetString(key string, value string)
+	StringListWithFallback(key string, fallback []string) []string
+	SetStringList(key string, value []string)
+	RemoveValue(key string)
+}
+
+func LoadSettings(prefs Preferences) Settings {
+	s := DefaultSettings()
+	s.WorkInterval = durationWithFallback(prefs, prefKeyWorkInterval, s.WorkInterval)
+	s.RestInterval = durationWithFallback(prefs, prefKeyRestInterval, s.RestInterval)
+	s.LongRestInterval = durationWithFallback(prefs, prefKeyLongRestInterval, s.LongRestInterval)
+	s.LongBreakEvery = uint(prefs.IntWithFallback(prefKeyLongBreakEvery, int(s.LongBreakEvery)))
+	if !prefs.BoolWithFallback(prefKeyBlink, true) {
+		// the legacy on/off preference
+		s.DelimiterAnimation = DelimiterAnimationNone
+	}
+	s.DelimiterAnimation = DelimiterAnimation(prefs.StringWithFallback(prefKeyDelimiterAnim, string(s.DelimiterAnimation)))
+	s.EndFlash = prefs.BoolWithFallback(prefKeyEndFlash, s.EndFlash)
+	s.UrgencyColors = durationWithFallback(prefs, prefKeyUrgencyColors, s.UrgencyColors)
+	s.PowerSaving = prefs.BoolWithFallback(prefKeyPowerSaving, s.PowerSaving)
+	s.ShowTenths = prefs.BoolWithFallback(prefKeyShowTenths, s.ShowTenths)
+	s.TimeLeftRounding = TimeRounding(prefs.StringWithFallback(prefKeyTimeLeftRounding, string(s.TimeLeftRounding)))
+	s.AlarmEnabled = prefs.BoolWithFallback(prefKeyAlarm, s.AlarmEnabled)
+	s.AlarmVolume = prefs.FloatWithFallback(prefKeyAlarmVolume, s.AlarmVolume)
+	s.AlarmSound = AlarmSound(prefs.StringWithFallback(prefKeyAlarmSound, string(s.AlarmSound)))
+	s.AlarmFile = prefs.StringWithFallback(prefKeyAlarmFile, s.AlarmFile)
+	s.AlarmFadeIn = durationWithFallback(prefs, prefKeyAlarmFadeIn, s.AlarmFadeIn)
+	s.AlarmRepeat = uint(prefs.IntWithFallback(prefKeyAlarmRepeat, int(s.AlarmRepeat)))
+	s.AlarmEscalation = prefs.BoolWithFallback(prefKeyAlarmEscalation, s.AlarmEscalation)
+	s.AlarmDevice = prefs.StringWithFallback(prefKeyAlarmDevice, s.AlarmDevice)
+	s.Countdown = CountdownMode(prefs.StringWithFallback(prefKeyCountdown, string(s.Countdown)))
+	s.Theme = ThemeVariant(prefs.StringWithFallback(prefKeyTheme, string(s.Theme)))
+	s.ColorPalette = ColorPalette(prefs.StringWithFallback(prefKeyColorPalette, string(s.ColorPalette)))
+	s.TrayIcon = prefs.BoolWithFallback(prefKeyTrayIcon, s.TrayIcon)
+	s.Autostart = prefs.BoolWithFallback(prefKeyAutostart, s.Autostart)
+	s.SpeakFocusedTimer = prefs.BoolWithFallback(prefKeySpeakFocusedTimer, s.SpeakFocusedTimer)
+	s.Language = prefs.StringWithFallback(prefKeyLanguage, s.Language)
+	s.AutoContinue = prefs.BoolWithFallback(prefKeyAutoContinue, s.AutoContinue)
+	s.Overtime = prefs.BoolWithFallback(prefKeyOvertime, s.Overtime)
+	s.AutoContinueDelay = durationWithFallback(prefs, prefKeyAutoContinueDelay, s.AutoContinueDelay)
+	s.EndActionChooser = prefs.BoolWithFallback(prefKeyEndActionChooser, s.EndActionChooser)
+	s.IdlePauseAfter = durationWithFallback(prefs, prefKeyIdlePauseAfter, s.IdlePauseAfter)
+	s.BreakCompliance = BreakCompliancePolicy(prefs.StringWithFallback(prefKeyBreakCompliance, string(s.BreakCompliance)))
+	s.SuspendPolicy = SuspendPolicy(prefs.StringWithFallback(prefKeySuspendPolicy, string(s.SuspendPolicy)))
+	s.WarnBefore = durationWithFallback(prefs, prefKeyWarnBefore, s.WarnBefore)
+	s.OverrunNudgeEvery = durationWithFallback(prefs, prefKeyOverrunNudgeEvery, s.OverrunNudgeEvery)
+	s.DoNotDisturb = prefs.BoolWithFallback(prefKeyDoNotDisturb, s.DoNotDisturb)
+	s.AskSessionNote = prefs.BoolWithFallback(prefKeyAskSessionNote, s.AskSessionNote)
+	s.AskFocusRating = prefs.BoolWithFallback(prefKeyAskFocusRating, s.AskFocusRating)
+	s.Ritual.Enabled = prefs.BoolWithFallback(prefKeyRitual, s.Ritual.Enabled)
+	s.Ritual.Items = ParseLines(prefs.StringWithFallback(prefKeyRitualItems, strings.Join(s.Ritual.Items, "\n")))
+	s.Commitment.Mode = CommitmentMode(prefs.StringWithFallback(prefKeyCommitmentMode, string(s.Commitment.Mode)))
+	s.Commitment.Phrase = prefs.StringWithFallback(prefKeyCommitmentPhrase, s.Commitment.Phrase)
+	s.StrictBreak = prefs.BoolWithFallback(prefKeyStrictBreak, s.StrictBreak)
+	s.StrictBreakSkipAfter = durationWithFallback(prefs, prefKeyStrictBreakSkip, s.StrictBreakSkipAfter)
+	s.ScreenLock.OnLongBreak = prefs.BoolWithFallback(prefKeyLockScreen, s.ScreenLock.OnLongBreak)
+	s.ScreenLock.Warning = durationWithFallback(prefs, prefKeyLockScreenWarning, s.ScreenLock.Warning)
+	s.BreakDebt.Limit = durationWithFallback(prefs, prefKeyBreakDebtLimit, s.BreakDebt.Limit)
+	s.BreakDebt.Enforce = prefs.BoolWithFallback(prefKeyBreakDebtEnforce, s.BreakDebt.Enforce)
+	s.DailyGoal = uint(prefs.IntWithFallback(prefKeyDailyGoal, int(s.DailyGoal)))
+	s.DayBoundary = durationWithFallback(prefs, prefKeyDayBoundary, s.DayBoundary)
+	s.DailySummary.Enabled = prefs.BoolWithFallback(prefKeyDailySummary, s.DailySummary.Enabled)
+	s.DailySummary.At = durationWithFallback(prefs, prefKeyDailySummaryAt, s.DailySummary.At)
+	s.WorkSchedule.Enabled = prefs.BoolWithFallback(prefKeyWorkSchedule, s.WorkSchedule.Enabled)
+	s.WorkSchedule.Start = durationWithFallback(prefs, prefKeyWorkScheduleStart, s.WorkSchedule.Start)
+	s.WorkSchedule.End = durationWithFallback(prefs, prefKeyWorkScheduleEnd, s.WorkSchedule.End)
+	s.WorkSchedule.Days = intsToWeekdays(prefs.IntListWithFallback(prefKeyWorkScheduleDays, weekdaysToInts(s.WorkSchedule.Days)))
+	s.WorkSchedule.StartNudgeAfter = durationWithFallback(prefs, prefKeyStartNudgeAfter, s.WorkSchedule.StartNudgeAfter)
+	s.ScheduledSessions = ParseLines(prefs.StringWithFallback(prefKeyScheduledSessions, strings.Join(s.ScheduledSessions, "\n")))
+	s.Announcements.Enabled = prefs.BoolWithFallback(prefKeyAnnounce, s.Announcements.Enabled)
+	s.Announcements.Work = prefs.StringWithFallback(prefKeyAnnounceWork, s.Announcements.Work)
+	s.Announcements.Rest = prefs.StringWithFallback(prefKeyAnnounceRest, s.Announcements.Rest)
+	s.Announcements.LongRest = prefs.StringWithFallback(prefKeyAnnounceLongRest, s.Announcements.LongRest)
+	s.Ambience.Enabled = prefs.BoolWithFallback(prefKeyAmbience, s.Ambience.Enabled)
+	s.Ambience.File = prefs.StringWithFallback(prefKeyAmbienceFile, s.Ambience.File)
+	s.Ambience.Volume = prefs.FloatWithFallback(prefKeyAmbienceVolume, s.Ambience.Volume)
+	s.Mixer.CountdownVolume = prefs.FloatWithFallback(prefKeyMixerCountdown, s.Mixer.CountdownVolume)
+	s.Mixer.Muted = stringsToChannels(prefs.StringListWithFallback(prefKeyMixerMuted, channelsToStrings(s.Mixer.Muted)))
+	s.Mixer.Ducking = prefs.FloatWithFallback(prefKeyMixerDucking, s.Mixer.Ducking)
+	s.Blocker.Sites = ParseLines(prefs.StringWithFallback(prefKeyBlockerSites, strings.Join(s.Blocker.Sites, "\n")))
+	s.Blocker.Processes = ParseLines(prefs.StringWithFallback(prefKeyBlockerProcesses, strings.Join(s.Blocker.Processes, "\n")))
+	s.Blocker.KillProcesses = prefs.BoolWithFallback(prefKeyBlockerKill, s.Blocker.KillProcesses)
+	s.Blocker.ProxyAddr = prefs.StringWithFallback(prefKeyBlockerProxyAddr, s.Blocker.ProxyAddr)
+	s.FocusMusic.Enabled = prefs.BoolWithFallback(prefKeyFocusMusic, s.FocusMusic.Enabled)
+	s.FocusMusic.Playlist = prefs.StringListWithFallback(prefKeyFocusMusicList, s.FocusMusic.Playlist)
+	s.FocusMusic.Volume = prefs.FloatWithFallback(prefKeyFocusMusicVolume, s.FocusMusic.Volume)
+	s.MQTT.BrokerURL = prefs.StringWithFallback(prefKeyMQTTBrokerURL, s.MQTT.BrokerURL)
+	s.MQTT.Username = prefs.StringWithFallback(prefKeyMQTTUsername, s.MQTT.Username)
+	s.MQTT.Password = prefs.StringWithFallback(prefKeyMQTTPassword, s.MQTT.Password)
+	s.MQTT.TopicPrefix = prefs.StringWithFallback(prefKeyMQTTTopicPrefix, s.MQTT.TopicPrefix)
+	s.GlobalHotkeys.TogglePause = prefs.StringWithFallback(prefKeyHotkeyTogglePause, s.GlobalHotkeys.TogglePause)
+	s.GlobalHotkeys.StartWork = prefs.StringWithFallback(prefKeyHotkeyStartWork, s.GlobalHotkeys.StartWork)
+	s.Hooks.OnWorkStart = prefs.StringWithFallback(prefKeyHookOnWorkStart, s.Hooks.OnWorkStart)
+	s.Hooks.OnWorkEnd = prefs.StringWithFallback(prefKeyHookOnWorkEnd, s.Hooks.OnWorkEnd)
+	s.Hooks.OnRestEnd = prefs.StringWithFallback(prefKeyHookOnRestEnd, s.Hooks.OnRestEnd)
+	s.Webhooks.URLs = prefs.StringListWithFallback(prefKeyWebhookURLs, s.Webhooks.URLs)
+	s.Webhooks.Secret = prefs.StringWithFallback(prefKeyWebhookSecret, s.Webhooks.Secret)
+	s.Toggl.APIToken = prefs.StringWithFallback(prefKeyTogglAPIToken, s.Toggl.APIToken)
+	s.Toggl.WorkspaceID = int64(prefs.IntWithFallback(prefKeyTogglWorkspaceID, int(s.Toggl.WorkspaceID)))
+	s.CalDAV.URL = prefs.StringWithFallback(prefKeyCalDAVURL, s.CalDAV.URL)
+	s.CalDAV.Username = prefs.StringWithFallback(prefKeyCalDAVUsername, s.CalDAV.Username)
+	s.CalDAV.Password = prefs.StringWithFallback(prefKeyCalDAVPassword, s.CalDAV.Password)
+	s.CalDAV.WarnMeetings = prefs.BoolWithFallback(prefKeyWarnMeetings, s.CalDAV.WarnMeetings)
+	s.CalDAV.MeetingsFeedURL = prefs.StringWithFallback(prefKeyMeetingsFeedURL, s.CalDAV.MeetingsFeedURL)
+	s.Slack.Token = prefs.StringWithFallback(prefKeySlackToken, s.Slack.Token)
+	s.DailyNote.PathTemplate = prefs.StringWithFallback(prefKeyDailyNotePath, s.DailyNote.PathTemplate)
+	s.TaskProviders.TodoistAPIToken = prefs.StringWithFallback(prefKeyTodoistAPIToken, s.TaskProviders.TodoistAPIToken)
+	s.TaskProviders.GitHubToken = prefs.StringWithFallback(prefKeyGitHubToken, s.TaskProviders.GitHubToken)
+	s.TaskProviders.CommentOnSession = prefs.BoolWithFallback(prefKeyTaskComment, s.TaskProviders.CommentOnSession)
+	s.TaskProviders.CompleteAfter = uint(prefs.IntWithFallback(prefKeyTaskCompleteAfter, int(s.TaskProviders.CompleteAfter)))
+	s.PeerSync.ListenAddr = prefs.StringWithFallback(prefKeyPeerSyncListen, s.PeerSync.ListenAddr)
+	s.PeerSync.Peers = ParseLines(prefs.StringWithFallback(prefKeyPeerSyncPeers, strings.Join(s.PeerSync.Peers, "\n")))
+	s.PeerSync.Secret = prefs.StringWithFallback(prefKeyPeerSyncSecret, s.PeerSync.Secret)
+	s.Room.HostAddr = prefs.StringWithFallback(prefKeyRoomHostAddr, s.Room.HostAddr)
+	s.Room.JoinURL = prefs.StringWithFallback(prefKeyRoomJoinURL, s.Room.JoinURL)
+	s.Room.Name = prefs.StringWithFallback(prefKeyRoomName, s.Room.Name)
+	s.Plugins = prefs.StringListWithFallback(prefKeyPlugins, s.Plugins)
+	var notifyBackends []notify.BackendName
+	for _, name := range prefs.StringListWithFallback(prefKeyNotifyBackends, BackendNamesToStrings(s.Notifications.Backends)) {
+		notifyBackends = append(notifyBackends, notify.BackendName(name))
+	}
+	s.Notifications.Backends = notifyBackends
+	s.Notifications.WebhookURL = prefs.StringWithFallback(prefKeyNotifyWebhookURL, s.Notifications.WebhookURL)
+	s.Notifications.OnPhaseEnd = prefs.BoolWithFallback(prefKeyNotifyOnPhaseEnd, s.Notifications.OnPhaseEnd)
+	s.Colors = loadTheme(prefs)
+	s.Labels.Work = prefs.StringWithFallback(prefKeyLabelWork, s.Labels.Work)
+	s.Labels.Rest = prefs.StringWithFallback(prefKeyLabelRest, s.Labels.Rest)
+	s.Labels.LongRest = prefs.StringWithFallback(prefKeyLabelLongRest, s.Labels.LongRest)
+	s.Labels.Stopwatch = prefs.StringWithFallback(prefKeyLabelStopwatch, s.Labels.Stopwatch)
+	if legacy := prefs.String(prefKeyLegacyPresets); legacy != "" {
+		if presets, err := parseLegacyPresets(legacy); err == nil {
+			s.Presets = presets
+		}
+	}
+	if presets, err := ParsePresets(prefs.StringWithFallback(prefKeyPresets, FormatPresets(s.Presets))); err == nil {
+		s.Presets = presets
+	}
+	s.PresetColumns = uint(prefs.IntWithFallback(prefKeyPresetColumns, int(s.PresetColumns)))
+	s.BreakSuggestions = ParseLines(prefs.StringWithFallback(prefKeyBreakSuggestions, strings.Join(s.BreakSuggestions, "\n")))
+	s.Profiles = loadProfiles(prefs)
+	s.ActiveProfile = prefs.StringWithFallback(prefKeyActiveProfile, s.ActiveProfile)
+	return s
+}
+
+func (s Settings) Save(prefs Preferences) {
+	setDuration(prefs, prefKeyWorkInterval, s.WorkInterval)
+	setDuration(prefs, prefKeyRestInterval, s.RestInterval)
+	setDuration(prefs, prefKeyLongRestInterval, s.LongRestInterval)
+	prefs.SetInt(prefKeyLongBreakEvery, int(s.LongBreakEvery))
+	prefs.RemoveValue(prefKeyBlink)
+	prefs.SetString(prefKeyDelimiterAnim, string(s.DelimiterAnimation))
+	prefs.SetBool(prefKeyEndFlash, s.EndFlash)
+	setDuration(prefs, prefKeyUrgencyColors, s.UrgencyColors)
+	prefs.SetBool(prefKeyPowerSaving, s.PowerSaving)
+	prefs.SetBool(prefKeyShowTenths, s.ShowTenths)
+	prefs.SetString(prefKeyTimeLeftRounding, string(s.TimeLeftRounding))
+	prefs.SetBool(prefKeyAlarm, s.AlarmEnabled)
+	prefs.SetFloat(prefKeyAlarmVolume, s.AlarmVolume)
+	prefs.SetString(prefKeyAlarmSound, string(s.AlarmSound))
+	prefs.SetString(prefKeyAlarmFile, s.AlarmFile)
+	setDuration(prefs, prefKeyAlarmFadeIn, s.AlarmFadeIn)
+	prefs.SetInt(prefKeyAlarmRepeat, int(s.AlarmRepeat))
+	prefs.SetBool(prefKeyAlarmEscalation, s.AlarmEscalation)
+	prefs.SetString(prefKeyAlarmDevice, s.AlarmDevice)
+	prefs.SetString(prefKeyCountdown, string(s.Countdown))
+	prefs.SetString(prefKeyTheme, string(s.Theme))
+	prefs.SetString(prefKeyColorPalette, string(s.ColorPalette))
+	prefs.SetBool(prefKeyTrayIcon, s.TrayIcon)
+	prefs.SetBool(prefKeyAutostart, s.Autostart)
+	prefs.SetBool(prefKeySpeakFocusedTimer, s.SpeakFocusedTimer)
+	prefs.SetString(prefKeyLanguage, s.Language)
+	prefs.SetBool(prefKeyAutoContinue, s.AutoContinue)
+	prefs.SetBool(prefKeyOvertime, s.Overtime)
+	setDuration(prefs, prefKeyAutoContinueDelay, s.AutoContinueDelay)
+	prefs.SetBool(prefKeyEndActionChooser, s.EndActionChooser)
+	setDuration(prefs, prefKeyIdlePauseAfter, s.IdlePauseAfter)
+	prefs.SetString(prefKeyBreakCompliance, string(s.BreakCompliance))
+	prefs.SetString(prefKeySuspendPolicy, string(s.SuspendPolicy))
+	setDuration(prefs, prefKeyWarnBefore, s.WarnBefore)
+	setDuration(prefs, prefKeyOverrunNudgeEvery, s.OverrunNudgeEvery)
+	prefs.SetBool(prefKeyDoNotDisturb, s.DoNotDisturb)
+	prefs.SetBool(prefKeyAskSessionNote, s.AskSessionNote)
+	prefs.SetBool(prefKeyAskFocusRating, s.AskFocusRating)
+	prefs.SetBool(prefKeyRitual, s.Ritual.Enabled)
+	prefs.SetString(prefKeyRitualItems, strings.Join(s.Ritual.Items, "\n"))
+	prefs.SetString(prefKeyCommitmentMode, string(s.Commitment.Mode))
+	prefs.SetString(prefKeyCommitmentPhrase, s.Commitment.Phrase)
+	prefs.SetBool(prefKeyStrictBreak, s.StrictBreak)
+	setDuration(prefs, prefKeyStrictBreakSkip, s.StrictBreakSkipAfter)
+	prefs.SetBool(prefKeyLockScreen, s.ScreenLock.OnLongBreak)
+	setDuration(prefs, prefKeyLockScreenWarning, s.ScreenLock.Warning)
+	setDuration(prefs, prefKeyBreakDebtLimit, s.BreakDebt.Limit)
+	prefs.SetBool(prefKeyBreakDebtEnforce, s.BreakDebt.Enforce)
+	prefs.SetInt(prefKeyDailyGoal, int(s.DailyGoal))
+	setDuration(prefs, prefKeyDayBoundary, s.DayBoundary)
+	prefs.SetBool(prefKeyDailySummary, s.DailySummary.Enabled)
+	setDuration(prefs, prefKeyDailySummaryAt, s.DailySummary.At)
+	prefs.SetBool(prefKeyWorkSchedule, s.WorkSchedule.Enabled)
+	setDuration(prefs, prefKeyWorkScheduleStart, s.WorkSchedule.Start)
+	setDuration(prefs, prefKeyWorkScheduleEnd, s.WorkSchedule.End)
+	prefs.SetIntList(prefKeyWorkScheduleDays, weekdaysToInts(s.WorkSchedule.Days))
+	setDuration(prefs, prefKeyStartNudgeAfter, s.WorkSchedule.StartNudgeAfter)
+	prefs.SetString(prefKeyScheduledSessions, strings.Join(s.ScheduledSessions, "\n"))
+	prefs.SetBool(prefKeyAnnounce, s.Announcements.Enabled)
+	prefs.SetString(prefKeyAnnounceWork, s.Announcements.Work)
+	prefs.SetString(prefKeyAnnounceRest, s.Announcements.Rest)
+	prefs.SetString(prefKeyAnnounceLongRest, s.Announcements.LongRest)
+	prefs.SetBool(prefKeyAmbience, s.Ambience.Enabled)
+	prefs.SetString(prefKeyAmbienceFile, s.Ambience.File)
+	prefs.SetFloat(prefKeyAmbienceVolume, s.Ambience.Volume)
+	prefs.SetFloat(prefKeyMixerCountdown, s.Mixer.CountdownVolume)
+	prefs.SetStringList(prefKeyMixerMuted, channelsToStrings(s.Mixer.Muted))
+	prefs.SetFloat(prefKeyMixerDucking, s.Mixer.Ducking)
+	prefs.SetString(prefKeyBlockerSites, strings.Join(s.Blocker.Sites, "\n"))
+	prefs.SetString(prefKeyBlockerProcesses, strings.Join(s.Blocker.Processes, "\n"))
+	prefs.SetBool(prefKeyBlockerKill, s.Blocker.KillProcesses)
+	prefs.SetString(prefKeyBlockerProxyAddr, s.Blocker.ProxyAddr)
+	prefs.SetBool(prefKeyFocusMusic, s.FocusMusic.Enabled)
+	prefs.SetStringList(prefKeyFocusMusicList, s.FocusMusic.Playlist)
+	prefs.SetFloat(prefKeyFocusMusicVolume, s.FocusMusic.Volume)
+	prefs.SetString(prefKeyMQTTBrokerURL, s.MQTT.BrokerURL)
+	prefs.SetString(prefKeyMQTTUsername, s.MQTT.Username)
+	prefs.SetString(prefKeyMQTTPassword, s.MQTT.Password)
+	prefs.SetString(prefKeyMQTTTopicPrefix, s.MQTT.TopicPrefix)
+	prefs.SetString(prefKeyHotkeyTogglePause, s.GlobalHotkeys.TogglePause)
+	prefs.SetString(prefKeyHotkeyStartWork, s.GlobalHotkeys.StartWork)
+	prefs.SetString(prefKeyHookOnWorkStart, s.Hooks.OnWorkStart)
+	prefs.SetString(prefKeyHookOnWorkEnd, s.Hooks.OnWorkEnd)
+	prefs.SetString(prefKeyHookOnRestEnd, s.Hooks.OnRestEnd)
+	prefs.SetStringList(prefKeyWebhookURLs, s.Webhooks.URLs)
+	prefs.SetString(prefKeyWebhookSecret, s.Webhooks.Secret)
+	prefs.SetString(prefKeyTogglAPIToken, s.Toggl.APIToken)
+	prefs.SetInt(prefKeyTogglWorkspaceID, int(s.Toggl.WorkspaceID))
+	prefs.SetString(prefKeyCalDAVURL, s.CalDAV.URL)
+	prefs.SetString(prefKeyCalDAVUsername, s.CalDAV.Username)
+	prefs.SetString(prefKeyCalDAVPassword, s.CalDAV.Password)
+	prefs.SetBool(prefKeyWarnMeetings, s.CalDAV.WarnMeetings)
+	prefs.SetString(prefKeyMeetingsFeedURL, s.CalDAV.MeetingsFeedURL)
+	prefs.SetString(prefKeySlackToken, s.Slack.Token)
+	prefs.SetString(prefKeyDailyNotePath, s.DailyNote.PathTemplate)
+	prefs.SetString(prefKeyTodoistAPIToken, s.TaskProviders.TodoistAPIToken)
+	prefs.SetString(prefKeyGitHubToken, s.TaskProviders.GitHubToken)
+	prefs.SetBool(prefKeyTaskComment, s.TaskProviders.CommentOnSession)
+	prefs.SetInt(prefKeyTaskCompleteAfter, int(s.TaskProviders.CompleteAfter))
+	prefs.SetString(prefKeyPeerSyncListen, s.PeerSync.ListenAddr)
+	prefs.SetString(prefKeyPeerSyncPeers, strings.Join(s.PeerSync.Peers, "\n"))
+	prefs.SetString(prefKeyPeerSyncSecret, s.PeerSync.Secret)
+	prefs.SetString(prefKeyRoomHostAddr, s.Room.HostAddr)
+	prefs.SetString(prefKeyRoomJoinURL, s.Room.JoinURL)
+	prefs.SetString(prefKeyRoomName, s.Room.Name)
+	prefs.SetStringList(prefKeyPlugins, s.Plugins)
+	prefs.SetStringList(prefKeyNotifyBackends, BackendNamesToStrings(s.Notifications.Backends))
+	prefs.SetString(prefKeyNotifyWebhookURL, s.Notifications.WebhookURL)
+	prefs.SetBool(prefKeyNotifyOnPhaseEnd, s.Notifications.OnPhaseEnd)
+	saveTheme(prefs, s.Colors)
+	prefs.SetString(prefKeyLabelWork, s.Labels.Work)
+	prefs.SetString(prefKeyLabelRest, s.Labels.Rest)
+	prefs.SetString(prefKeyLabelLongRest, s.Labels.LongRest)
+	prefs.SetString(prefKeyLabelStopwatch, s.Labels.Stopwatch)
+	prefs.RemoveValue(prefKeyLegacyPresets)
+	prefs.SetString(prefKeyPresets, FormatPresets(s.Presets))
+	prefs.SetInt(prefKeyPresetColumns, int(s.PresetColumns))
+	prefs.SetString(prefKeyBreakSuggestions, strings.Join(s.BreakSuggestions, "\n"))
+	saveProfiles(prefs, s.Profiles)
+	prefs.SetString(prefKeyActiveProfile, s.ActiveProfile)
+}
+
+// durationWithFallback reads the duration stored in seconds, the negative
+// values and the ones not fitting into time.Duration (of a corrupted or
+// hand-edited file) are replaced with the fallback.
+func durationWithFallback(
+	prefs Preferences,
+	key string,
+	fallback time.Duration,
+) time.Duration {
+	seconds := int64(prefs.IntWithFallback(key, int(fallback/time.Second)))
+	if seconds < 0 || seconds > math.MaxInt64/int64(time.Second) {
+		return fallback
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func setDuration(
+	prefs Preferences,
+	key string,
+	value time.Duration,
+) {
+	prefs.SetInt(key, int(value/time.Second))
+}
+
+func (p *Pomodoro) ApplySettings(s Settings) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.applySettings(s)
+	s.Save(p.prefs)
+}
+
+// CurrentSettings returns a copy of the settings, safe to use
+// concurrently with the timer.
+func (p *Pomodoro) CurrentSettings() Settings {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.settings
+}
+
+func (p *Pomodoro) applySettings(s Settings) {
+	if p.isCommitted() {
+		// no way to bail out via the settings
+		s.Commitment = p.settings.Commitment
+	}
+	if s.Autostart != p.settings.Autostart && !p.headless {
+		p.setAutostart(s.Autostart)
+	}
+	p.settings = s
+	p.Player.SetDevice(s.AlarmDevice)
+	p.refreshMixer()
+	p.frontend.SettingsApplied(s)
+	p.refreshNotifier()
+	p.refreshGoal()
+	p.refreshOffHours()
+	p.refreshDescription()
+	p.refreshAmbience()
+	p.refreshBlocker()
+	p.refreshFocusMusic()
+	if p.isRunning() {
+		p.refresh()
+		return
+	}
+	p.nextWorkInterval = s.WorkInterval
+	p.nextRestInterval = s.RestInterval
+	p.nextLongRestInterval = s.LongRestInterval
+	p.setTimeLeft(p.nextInterval())
+}
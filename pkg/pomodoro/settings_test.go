@@ -0,0 +1,58 @@
+package pomodoro
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/datadir"
+)
+
+// FuzzLoadSettings checks that any preferences file is loaded into
+// the settings which are saved and loaded back unchanged.
+func FuzzLoadSettings(f *testing.F) {
+	f.Add(`{}`)
+	f.Add(`{"work_interval": 1500, "rest_interval": 300, "long_break_every": 4}`)
+	f.Add(`{"work_interval": 9223372036854775807, "rest_interval": -1}`)
+	f.Add(`{"work_interval": "25m", "blink": false, "alarm_volume": 2.5}`)
+	f.Add(`{"preset_grid": "Deep work, 90, work\n5, rest", "work_schedule_days": [1, 3, 9]}`)
+	f.Add(`{"scheduled_sessions": "weekdays 09:00 90m Deep work\nnonsense"}`)
+	f.Fuzz(func(t *testing.T, data string) {
+		prefs, err := datadir.DecodePreferences(strings.NewReader(data))
+		if err != nil {
+			return
+		}
+		loaded := LoadSettings(prefs)
+		saved := datadir.NewPreferences()
+		loaded.Save(saved)
+		reloaded := LoadSettings(saved)
+		if !reflect.DeepEqual(reloaded, loaded) {
+			t.Fatalf("the settings loaded from '%s' changed after saving:\n%+v\n%+v", data, loaded, reloaded)
+		}
+	})
+}
+
+func TestDurationWithFallback(t *testing.T) {
+	const fallback = 25 * time.Minute
+	for _, tc := range []struct {
+		Value    string
+		Expected time.Duration
+	}{
+		{Value: `1500`, Expected: 25 * time.Minute},
+		{Value: `0`, Expected: 0},
+		{Value: `-1`, Expected: fallback},
+		{Value: `9223372036`, Expected: 9223372036 * time.Second},
+		{Value: `9223372037`, Expected: fallback},
+		{Value: `10000000000`, Expected: fallback},
+		{Value: `"25m"`, Expected: fallback},
+	} {
+		prefs, err := datadir.DecodePreferences(strings.NewReader(`{"work_interval": ` + tc.Value + `}`))
+		if err != nil {
+			t.Fatalf("unable to decode %s: %v", tc.Value, err)
+		}
+		if d := durationWithFallback(prefs, prefKeyWorkInterval, fallback); d != tc.Expected {
+			t.Fatalf("expected %s to be loaded as %v, got %v", tc.Value, tc.Expected, d)
+		}
+	}
+}
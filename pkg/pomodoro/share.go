@@ -0,0 +1,18 @@
+package pomodoro
+
+// SetShareURL sets the URL of the read-only live timer page served
+// to the spectators (see httpapi.NewSpectator), the frontend shows it
+// with a QR code.
+func (p *Pomodoro) SetShareURL(shareURL string) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.shareURL = shareURL
+}
+
+// ShareURL returns the URL set by SetShareURL, empty if the timer
+// is not shared.
+func (p *Pomodoro) ShareURL() string {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.shareURL
+}
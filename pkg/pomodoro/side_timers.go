@@ -0,0 +1,178 @@
+package pomodoro
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/audio"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+// SideTimer is an additional named timer (like "tea: 4 min") running
+// alongside the pomodoro. It does not affect the sessions, it only
+// notifies (and optionally plays the alarm sound) once it ends.
+type SideTimer struct {
+	ID       uint64
+	Name     string
+	Deadline time.Time
+	Sound    bool
+}
+
+type sideTimer struct {
+	SideTimer
+	cancel context.CancelFunc
+}
+
+type sideTimersState struct {
+	lastID uint64
+	timers []*sideTimer
+}
+
+// SideTimers returns the running side timers ordered by their deadlines.
+func (p *Pomodoro) SideTimers() []SideTimer {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	result := make([]SideTimer, 0, len(p.sideTimers.timers))
+	for _, t := range p.sideTimers.timers {
+		result = append(result, t.SideTimer)
+	}
+	return result
+}
+
+// AddSideTimer starts a side timer lasting d, an empty name is replaced
+// with a generic one.
+func (p *Pomodoro) AddSideTimer(
+	name string,
+	d time.Duration,
+	sound bool,
+) SideTimer {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.addSideTimer(name, d, sound)
+}
+
+func (p *Pomodoro) addSideTimer(
+	name string,
+	d time.Duration,
+	sound bool,
+) SideTimer {
+	s := &p.sideTimers
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = l10n.T("Timer %d", s.lastID+1)
+	}
+	s.lastID++
+	ctx, cancelFn := context.WithCancel(p.lifecycle.ctx)
+	t := &sideTimer{
+		SideTimer: SideTimer{
+			ID:       s.lastID,
+			Name:     name,
+			Deadline: p.clock.Now().Add(d),
+			Sound:    sound,
+		},
+		cancel: cancelFn,
+	}
+	s.timers = append(s.timers, t)
+	slices.SortStableFunc(s.timers, func(a, b *sideTimer) int {
+		return a.Deadline.Compare(b.Deadline)
+	})
+	p.refresh()
+	p.lifecycle.launch(func() {
+		p.runSideTimer(ctx, t)
+	})
+	return t.SideTimer
+}
+
+// RemoveSideTimer cancels the side timer, it is a no-op
+// if the timer has already ended.
+func (p *Pomodoro) RemoveSideTimer(id uint64) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.removeSideTimer(id)
+}
+
+func (p *Pomodoro) removeSideTimer(id uint64) *sideTimer {
+	s := &p.sideTimers
+	idx := slices.IndexFunc(s.timers, func(t *sideTimer) bool { return t.ID == id })
+	if idx < 0 {
+		return nil
+	}
+	t := s.timers[idx]
+	t.cancel()
+	s.timers = slices.Delete(s.timers, idx, idx+1)
+	p.refresh()
+	return t
+}
+
+func (p *Pomodoro) runSideTimer(
+	ctx context.Context,
+	t *sideTimer,
+) {
+	ticker := p.clock.NewTicker(tickInterval)
+	defer ticker.Stop()
+	end := p.clock.After(p.until(t.Deadline))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			p.locker.Lock()
+			if ctx.Err() == nil {
+				p.refresh()
+			}
+			p.locker.Unlock()
+		case <-end:
+			p.endSideTimer(t.ID)
+			return
+		}
+	}
+}
+
+func (p *Pomodoro) endSideTimer(id uint64) {
+	p.locker.Lock()
+	t := p.removeSideTimer(id)
+	sound, file := p.settings.AlarmSound, p.settings.AlarmFile
+	p.locker.Unlock()
+	if t == nil {
+		// removed meanwhile
+		return
+	}
+
+	// the user asked for it explicitly, so the off-hours do not apply
+	p.deliverNotification(l10n.T("%s: time is up", t.Name))
+	if !t.Sound || !p.HasAudio() {
+		return
+	}
+	stream, err := openSound(sound, file)
+	if err != nil {
+		slog.Error("unable to open the alarm sound", "error", err)
+		return
+	}
+	p.Player.Start(p.lifecycle.ctx, audio.ChannelAlarm, stream, func() float64 { return 1 }, func(err error) {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			slog.Error("unable to play the side timer sound", "timer", t.Name, "error", err)
+		}
+	})
+}
+
+// sideTimerViews returns the side timers with the time left to show
+// (see View.SideTimers).
+func (p *Pomodoro) sideTimerViews() []SideTimerView {
+	if len(p.sideTimers.timers) == 0 {
+		return nil
+	}
+	result := make([]SideTimerView, 0, len(p.sideTimers.timers))
+	for _, t := range p.sideTimers.timers {
+		minutes, seconds := splitTimeLeft(p.until(t.Deadline), p.settings.TimeLeftRounding)
+		result = append(result, SideTimerView{
+			SideTimer: t.SideTimer,
+			Minutes:   minutes,
+			Seconds:   seconds,
+		})
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+package pomodoro
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/xaionaro-go/pomodoro/pkg/audio"
+)
+
+// AlarmSound is one of the embedded alarm sounds.
+type AlarmSound string
+
+const (
+	AlarmSoundClassic = AlarmSound("classic")
+	AlarmSoundBell    = AlarmSound("bell")
+	AlarmSoundChime   = AlarmSound("chime")
+	AlarmSoundGong    = AlarmSound("gong")
+)
+
+var AlarmSounds = []AlarmSound{
+	AlarmSoundClassic,
+	AlarmSoundBell,
+	AlarmSoundChime,
+	AlarmSoundGong,
+}
+
+func (s AlarmSound) Open() (audio.Stream, error) {
+	switch s {
+	case AlarmSoundBell:
+		return audio.NewWAVStream(bytes.NewReader(bellSoundFile))
+	case AlarmSoundChime:
+		return audio.NewWAVStream(bytes.NewReader(chimeSoundFile))
+	case AlarmSoundGong:
+		return audio.NewWAVStream(bytes.NewReader(gongSoundFile))
+	default:
+		return audio.NewOggVorbisStream(bytes.NewReader(alarmSoundFile))
+	}
+}
+
+// openSound opens the custom sound file if it is set,
+// and the embedded sound otherwise.
+func openSound(
+	sound AlarmSound,
+	file string,
+) (audio.Stream, error) {
+	if file != "" {
+		stream, err := audio.OpenFile(file)
+		if err == nil {
+			return stream, nil
+		}
+		slog.Warn("unable to open the custom alarm sound, falling back to the built-in one", "error", err)
+	}
+	return sound.Open()
+}
+
+func (p *Pomodoro) playSound(
+	channel audio.Channel,
+	sound AlarmSound,
+	file string,
+	volume float64,
+) error {
+	stream, err := openSound(sound, file)
+	if err != nil {
+		return fmt.Errorf("unable to open the alarm sound: %w", err)
+	}
+
+	err = p.Player.Play(p.lifecycle.ctx, channel, stream, volume)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// PreviewSound plays the sound in the background,
+// bypassing the mixer.
+func (p *Pomodoro) PreviewSound(
+	sound AlarmSound,
+	file string,
+	volume float64,
+) {
+	p.lifecycle.launch(func() {
+		if err := p.playSound(audio.ChannelNone, sound, file, volume); err != nil {
+			slog.Error("unable to preview the sound", "error", err)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package pomodoro
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/notify"
+	"github.com/xaionaro-go/pomodoro/pkg/urlscheme"
+)
+
+// StartNudgeSnoozes are the delays offered to snooze the suggestion
+// to start a session (see Pomodoro.SnoozeStartNudge).
+var StartNudgeSnoozes = []time.Duration{
+	15 * time.Minute,
+	time.Hour,
+}
+
+// checkStartNudge suggests starting a focus session if none has run
+// for WorkScheduleSettings.StartNudgeAfter within the working hours
+// (it is called by watchWorkSchedule).
+func (p *Pomodoro) checkStartNudge() {
+	p.locker.Lock()
+	after := p.settings.WorkSchedule.StartNudgeAfter
+	p.locker.Unlock()
+	if after <= 0 {
+		return
+	}
+	if p.IdleDetector != nil {
+		idleTime, err := p.IdleDetector.IdleTime()
+		if err != nil {
+			slog.Debug("unable to detect the idle time", "error", err)
+		} else if idleTime >= workScheduleCheckInterval {
+			// nobody to nudge
+			return
+		}
+	}
+
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	now := p.clock.Now()
+	if p.isRunning() || p.isPaused || p.IsOffHours() || now.Before(p.startNudgeSnoozedUntil) {
+		return
+	}
+	lastActivity := p.startNudgeBase
+	if p.history != nil {
+		if sessions := p.history.Sessions(); len(sessions) > 0 {
+			lastActivity = latest(lastActivity, sessions[len(sessions)-1].EndedAt)
+		}
+	}
+	if now.Sub(lastActivity) < after {
+		return
+	}
+	p.startNudgeBase = now
+	p.nudgeToStart(now.Sub(lastActivity))
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func (p *Pomodoro) nudgeToStart(idle time.Duration) {
+	message := l10n.T("No focus sessions for %s, maybe start one?", idle.Round(time.Minute))
+	p.notify(message,
+		notify.Action{
+			Label: l10n.T("Start"),
+			URL:   urlscheme.Scheme + "://start",
+		},
+		notify.Action{
+			Label: l10n.T("Snooze %d min", int(StartNudgeSnoozes[0]/time.Minute)),
+			URL:   fmt.Sprintf("%s://snooze-start?minutes=%d", urlscheme.Scheme, int(StartNudgeSnoozes[0]/time.Minute)),
+		},
+	)
+
+	p.frontend.NudgeToStart(message)
+}
+
+// SnoozeStartNudge postpones the suggestions to start a session by d.
+func (p *Pomodoro) SnoozeStartNudge(d time.Duration) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.startNudgeSnoozedUntil = p.clock.Now().Add(d)
+}
+
+// SnoozeStartNudgeForToday stops the suggestions to start a session
+// until the next day (see Settings.DayBoundary).
+func (p *Pomodoro) SnoozeStartNudgeForToday() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.startNudgeSnoozedUntil = history.DayStart(p.clock.Now(), p.settings.DayBoundary).AddDate(0, 0, 1)
+}
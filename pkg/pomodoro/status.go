@@ -0,0 +1,140 @@
+package pomodoro
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Status is a snapshot of the state of the timer (see Pomodoro.Status),
+// the same for all the frontends: the windows, the terminal UI, the APIs
+// and the status bars.
+type Status struct {
+	Phase                 Phase
+	IsRunning             bool
+	IsPaused              bool
+	IsOvertime            bool // TimeLeft is negative (see Settings.Overtime)
+	TimeLeft              time.Duration
+	DisplayedTimeLeft     time.Duration // see TimeRounding
+	Elapsed               time.Duration
+	Deadline              time.Time
+	Task                  string
+	CompletedWorkSessions uint
+	CompletedToday        uint // see Settings.DayBoundary
+	CycleWorkSessions     uint
+	BreakDebt             time.Duration
+}
+
+// String describes the status in a line, like "work 12:34 (paused)".
+func (s Status) String() string {
+	var b strings.Builder
+	b.WriteString(s.Phase.String())
+	switch {
+	case s.Phase == PhaseStopwatch:
+		b.WriteString(" " + formatStatusClock(s.Elapsed))
+	case s.IsOvertime:
+		b.WriteString(" +" + formatStatusClock(-s.DisplayedTimeLeft))
+	default:
+		b.WriteString(" " + formatStatusClock(s.DisplayedTimeLeft))
+	}
+	switch {
+	case s.IsPaused:
+		b.WriteString(" (paused)")
+	case !s.IsRunning:
+		b.WriteString(" (stopped)")
+	case s.IsOvertime:
+		b.WriteString(" (overtime)")
+	}
+	if s.Task != "" {
+		b.WriteString(": " + s.Task)
+	}
+	fmt.Fprintf(&b, ", %d done today", s.CompletedToday)
+	return b.String()
+}
+
+func formatStatusClock(d time.Duration) string {
+	minutes, seconds := splitElapsed(d)
+	return fmt.Sprintf("%02d:%02d", minutes, seconds)
+}
+
+// StatusJSON is the serialized Status, as it is sent by the APIs
+// (see Status.JSON).
+type StatusJSON struct {
+	Phase                 string     `json:"phase"`
+	IsRunning             bool       `json:"running"`
+	IsPaused              bool       `json:"paused"`
+	IsOvertime            bool       `json:"overtime"`
+	TimeLeftSeconds       int64      `json:"time_left_seconds"`
+	ElapsedSeconds        int64      `json:"elapsed_seconds"`
+	Deadline              *time.Time `json:"deadline,omitempty"`
+	Task                  string     `json:"task,omitempty"`
+	CompletedWorkSessions uint       `json:"completed_work_sessions"`
+	CompletedToday        uint       `json:"completed_today"`
+	CycleWorkSessions     uint       `json:"cycle_work_sessions"`
+	BreakDebtSeconds      int64      `json:"break_debt_seconds"`
+}
+
+// JSON returns the serialized status; the time left is the displayed one
+// (see TimeRounding) and the deadline is set only while counting down.
+func (s Status) JSON() StatusJSON {
+	result := StatusJSON{
+		Phase:                 s.Phase.String(),
+		IsRunning:             s.IsRunning,
+		IsPaused:              s.IsPaused,
+		IsOvertime:            s.IsOvertime,
+		TimeLeftSeconds:       int64(s.DisplayedTimeLeft.Seconds()),
+		ElapsedSeconds:        int64(s.Elapsed.Seconds()),
+		Task:                  s.Task,
+		CompletedWorkSessions: s.CompletedWorkSessions,
+		CompletedToday:        s.CompletedToday,
+		CycleWorkSessions:     s.CycleWorkSessions,
+		BreakDebtSeconds:      int64(s.BreakDebt.Seconds()),
+	}
+	if s.IsRunning && !s.Deadline.IsZero() {
+		deadline := s.Deadline
+		result.Deadline = &deadline
+	}
+	return result
+}
+
+func (s Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.JSON())
+}
+
+func (p *Pomodoro) Status() Status {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.status()
+}
+
+func (p *Pomodoro) status() Status {
+	s := Status{
+		Phase:                 p.phase(),
+		IsRunning:             p.isRunning(),
+		IsPaused:              p.isPaused,
+		IsOvertime:            p.isOvertime,
+		CompletedWorkSessions: p.completedWorkSessions,
+		CompletedToday:        p.completedToday(),
+		CycleWorkSessions:     p.cycleWorkSessions,
+		BreakDebt:             p.breakDebt,
+	}
+	if s.IsRunning || s.IsPaused {
+		s.Elapsed = p.elapsed()
+		if s.Phase == PhaseWork {
+			s.Task = p.task
+		}
+	}
+	switch {
+	case p.isStopwatch:
+	case s.IsRunning:
+		s.Deadline = p.wallDeadline()
+		s.TimeLeft = p.until(p.deadline)
+	case s.IsPaused:
+		s.TimeLeft = p.pausedTimeLeft
+	default:
+		s.TimeLeft = p.nextInterval()
+	}
+	s.DisplayedTimeLeft = p.displayedTimeLeft(s.TimeLeft)
+	return s
+}
@@ -0,0 +1,78 @@
+package pomodoro
+
+import (
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+func (p *Pomodoro) ToggleStopwatch() {
+	p.locker.Lock()
+	isStopwatch := p.isStopwatch
+	p.locker.Unlock()
+	if isStopwatch {
+		p.StopTimer()
+		return
+	}
+	p.StartStopwatch()
+}
+
+// StartStopwatch starts an open-ended focus block which measures
+// the elapsed time instead of counting down.
+func (p *Pomodoro) StartStopwatch() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.startStopwatch()
+}
+
+func (p *Pomodoro) startStopwatch() {
+	if p.refuseIfCommitted("start a stopwatch") {
+		return
+	}
+	if p.isRunning() || p.isPaused {
+		p.saveUndoSnapshot(l10n.T("The interval was interrupted"))
+	} else {
+		p.saveUndoSnapshot("")
+	}
+	p.cancelAutoContinue()
+	p.stopAlarm()
+	p.interruptPhase()
+	p.setIsWork(true)
+	p.isStopwatch = true
+	p.isPaused = false
+	p.pausedByIdle = false
+	p.setDescription(p.settings.Labels.Description(PhaseStopwatch))
+	p.deadline = p.clock.Now()
+	p.phaseStartedAt = p.clock.Now()
+	p.phaseRunningSince = p.phaseStartedAt
+	p.phaseElapsed = 0
+	p.phaseInterruptions = nil
+	p.phasePlanned = 0
+	p.setTimeLeft(0)
+	p.refreshTask()
+	p.startTicker()
+	p.refreshProgress()
+	p.emitEvent(EventTypePhaseStarted, 0)
+}
+
+// ConvertStopwatch finishes the stopwatch and records it as a completed
+// work session (counted in the statistics and the daily goal).
+func (p *Pomodoro) ConvertStopwatch() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if !p.isStopwatch {
+		return
+	}
+	p.isStopwatch = false
+	p.phasePlanned = p.elapsed()
+	p.recordSession(true)
+	if p.tickerCancel != nil {
+		p.tickerCancel()
+		p.tickerCancel = nil
+	}
+	p.isPaused = false
+	p.pausedByIdle = false
+	p.emitEvent(EventTypePhaseEnded, 0)
+	p.completedWorkSessions++
+	p.cycleWorkSessions++
+	p.setIsWork(false)
+	p.refreshProgress()
+}
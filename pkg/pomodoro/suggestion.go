@@ -0,0 +1,80 @@
+package pomodoro
+
+import (
+	"strings"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+const (
+	suggestionRotateInterval = time.Minute
+)
+
+// SuggestionProvider supplies activities to suggest during breaks
+// (in addition to Settings.BreakSuggestions). It is called while the timer
+// is locked, so it must not call methods of Pomodoro.
+type SuggestionProvider interface {
+	Suggestions() []string
+}
+
+type SuggestionProviderFunc func() []string
+
+func (fn SuggestionProviderFunc) Suggestions() []string {
+	return fn()
+}
+
+func DefaultBreakSuggestions() []string {
+	return []string{
+		l10n.T("Stretch"),
+		l10n.T("Drink some water"),
+		l10n.T("Look at something 20m away"),
+		l10n.T("Take a short walk"),
+		l10n.T("Breathe deeply"),
+	}
+}
+
+func (p *Pomodoro) AddSuggestionProvider(provider SuggestionProvider) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.suggestionProviders = append(p.suggestionProviders, provider)
+}
+
+func (p *Pomodoro) suggestions() []string {
+	suggestions := append([]string(nil), p.settings.BreakSuggestions...)
+	for _, provider := range p.suggestionProviders {
+		suggestions = append(suggestions, provider.Suggestions()...)
+	}
+	return suggestions
+}
+
+// rotateSuggestion shows the next suggestion if the current one has
+// been shown long enough (or force is set).
+func (p *Pomodoro) rotateSuggestion(force bool) {
+	if !force && p.since(p.suggestionShownAt) < suggestionRotateInterval {
+		return
+	}
+	suggestions := p.suggestions()
+	if len(suggestions) == 0 {
+		p.view.Caption = ""
+		p.refresh()
+		return
+	}
+	p.suggestionIndex = (p.suggestionIndex + 1) % len(suggestions)
+	p.suggestionShownAt = p.clock.Now()
+	p.view.Caption = suggestions[p.suggestionIndex]
+	p.refresh()
+}
+
+// ParseLines splits s into the non-empty trimmed lines.
+func ParseLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
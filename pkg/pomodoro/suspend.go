@@ -0,0 +1,101 @@
+package pomodoro
+
+import (
+	"time"
+)
+
+const (
+	tickInterval = time.Second
+
+	// suspendThreshold is the delay of a tick which is considered
+	// as a suspend of the system (rather than a hiccup of the scheduler).
+	suspendThreshold = 10 * time.Second
+)
+
+// SuspendPolicy defines what happens with the running interval
+// when the system was suspended.
+type SuspendPolicy string
+
+const (
+	// SuspendPolicyFastForward counts the suspended time as passed
+	// (as a kitchen timer would do).
+	SuspendPolicyFastForward = SuspendPolicy("fast-forward")
+
+	// SuspendPolicyPause pauses the interval at the moment of the suspend.
+	SuspendPolicyPause = SuspendPolicy("pause")
+
+	// SuspendPolicyExtend does not count the suspended time, the interval
+	// continues from the moment of the suspend.
+	SuspendPolicyExtend = SuspendPolicy("extend")
+)
+
+// detectSuspend compares the time passed since the previous tick with
+// the tick interval. It returns how long the system was suspended and
+// how much of it was not counted by the monotonic clock (which does not
+// advance during a suspend on some systems).
+//
+// The time passed is measured by the boot clock (see clock.Clock.SinceBoot),
+// so that the changes of the wall clock (the manual adjustments, the
+// synchronization; the time zones and DST do not change it anyway) are not
+// mistaken for a suspend; the wall clock is used only if there is no
+// boot clock.
+func (p *Pomodoro) detectSuspend() (suspended, notCounted time.Duration) {
+	now := p.clock.Now()
+	nowBoot, hasBoot := p.clock.SinceBoot()
+	last, lastBoot := p.lastTickAt, p.lastTickBoot
+	p.lastTickAt, p.lastTickBoot = now, nowBoot
+	if last.IsZero() {
+		return 0, 0
+	}
+
+	monotonic := now.Sub(last)
+	passed := now.Round(0).Sub(last.Round(0))
+	if hasBoot {
+		passed = nowBoot - lastBoot
+	}
+	suspended = max(passed, monotonic) - tickInterval
+	if suspended < suspendThreshold {
+		return 0, 0
+	}
+	return suspended, max(passed-monotonic, 0)
+}
+
+// handleSuspend applies Settings.SuspendPolicy if the system was suspended
+// since the previous tick, it returns true if the interval was paused.
+func (p *Pomodoro) handleSuspend() bool {
+	suspended, notCounted := p.detectSuspend()
+	if suspended == 0 {
+		return false
+	}
+	if p.mobile && p.inBackground {
+		// the app was frozen in the background rather than the system
+		// suspended, the interval goes on regardless of SuspendPolicy
+		p.deadline = p.deadline.Add(-notCounted)
+		p.phaseRunningSince = p.phaseRunningSince.Add(-notCounted)
+		return false
+	}
+
+	// first, exclude the suspended time completely (as in SuspendPolicyExtend)
+	shift := suspended - notCounted
+	p.deadline = p.deadline.Add(shift)
+	p.phaseRunningSince = p.phaseRunningSince.Add(shift)
+
+	var paused bool
+	switch p.settings.SuspendPolicy {
+	case SuspendPolicyPause:
+		p.pause()
+		paused = true
+	case SuspendPolicyExtend:
+	default:
+		p.deadline = p.deadline.Add(-suspended)
+		p.phaseRunningSince = p.phaseRunningSince.Add(-suspended)
+	}
+
+	ev := p.newEvent(EventTypeWokeUp, p.until(p.deadline))
+	if paused {
+		ev.TimeLeft = p.pausedTimeLeft
+	}
+	ev.Suspended = suspended
+	p.emit(ev)
+	return paused
+}
@@ -0,0 +1,133 @@
+package pomodoro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/tasks"
+)
+
+const (
+	// TaskProviderTimeout limits the requests to the task providers.
+	TaskProviderTimeout = 30 * time.Second
+)
+
+// TaskProviderSettings configure picking the tasks from the task managers,
+// an empty token disables the provider.
+type TaskProviderSettings struct {
+	TodoistAPIToken string
+	GitHubToken     string
+
+	// CommentOnSession posts a comment to the picked task
+	// after each completed work session on it.
+	CommentOnSession bool
+
+	// CompleteAfter completes the picked task after this number of
+	// the work sessions on it, 0 means never.
+	CompleteAfter uint
+}
+
+type pickedTask struct {
+	Provider tasks.Provider
+	Task     tasks.Task
+	Sessions uint
+}
+
+// ProvidedTask is an open task of a provider (see FetchOpenTasks).
+type ProvidedTask struct {
+	Provider tasks.Provider
+	Task     tasks.Task
+}
+
+// AddTaskProvider makes the open tasks of the provider available
+// for picking (see FetchOpenTasks).
+func (p *Pomodoro) AddTaskProvider(provider tasks.Provider) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.taskProviders = append(p.taskProviders, provider)
+	p.refresh()
+}
+
+// PickTask makes the task the current one, the completed work sessions
+// on it are reported to the provider (see TaskProviderSettings).
+func (p *Pomodoro) PickTask(
+	provider tasks.Provider,
+	task tasks.Task,
+) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.pickedTask = &pickedTask{
+		Provider: provider,
+		Task:     task,
+	}
+	p.task = task.Title
+	if p.isRunning() || p.isPaused {
+		p.refreshTask()
+	}
+}
+
+// FetchOpenTasks collects the open tasks of all the providers,
+// the failed providers are skipped and returned as the error.
+func (p *Pomodoro) FetchOpenTasks(ctx context.Context) ([]ProvidedTask, error) {
+	p.locker.Lock()
+	providers := append([]tasks.Provider(nil), p.taskProviders...)
+	p.locker.Unlock()
+
+	var (
+		result []ProvidedTask
+		errs   []error
+	)
+	for _, provider := range providers {
+		items, err := provider.OpenTasks(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to get the tasks from %s: %w", provider.Name(), err))
+			continue
+		}
+		for _, item := range items {
+			result = append(result, ProvidedTask{Provider: provider, Task: item})
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// reportPickedTask comments and completes the picked task
+// (see TaskProviderSettings) after a completed work session on it.
+func (p *Pomodoro) reportPickedTask(session history.Session) {
+	picked := p.pickedTask
+	if picked == nil || session.Task != picked.Task.Title {
+		return
+	}
+	picked.Sessions++
+	settings := p.settings.TaskProviders
+	complete := settings.CompleteAfter > 0 && picked.Sessions >= settings.CompleteAfter
+	if !settings.CommentOnSession && !complete {
+		return
+	}
+	if complete {
+		p.pickedTask = nil
+	}
+	provider, task, sessions := picked.Provider, picked.Task, picked.Sessions
+	p.lifecycle.launch(func() {
+		ctx, cancelFn := context.WithTimeout(context.Background(), TaskProviderTimeout)
+		defer cancelFn()
+		if settings.CommentOnSession {
+			text := fmt.Sprintf("🍅 Focus session #%d: %d min.", sessions, int(session.Duration.Round(time.Minute)/time.Minute))
+			if err := provider.Comment(ctx, task, text); err != nil {
+				slog.Error("unable to comment the task", "provider", provider.Name(), "task", task.ID, "error", err)
+			}
+		}
+		if !complete {
+			return
+		}
+		if err := provider.Complete(ctx, task); err != nil {
+			slog.Error("unable to complete the task", "provider", provider.Name(), "task", task.ID, "error", err)
+			return
+		}
+		p.notify(l10n.T("'%s' is completed in %s after %d pomodoros.", task.Title, provider.Name(), sessions))
+	})
+}
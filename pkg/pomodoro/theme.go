@@ -0,0 +1,26 @@
+package pomodoro
+
+type ThemeVariant string
+
+const (
+	ThemeVariantSystem = ThemeVariant("system")
+	ThemeVariantDark   = ThemeVariant("dark")
+	ThemeVariantLight  = ThemeVariant("light")
+
+	// ThemeVariantHighContrast is white and yellow on black, also for
+	// the timer itself.
+	ThemeVariantHighContrast = ThemeVariant("high-contrast")
+
+	// ThemeVariantLargeText is the system theme with enlarged texts
+	// and icons.
+	ThemeVariantLargeText = ThemeVariant("large-text")
+)
+
+// ThemeVariants are the choices of Settings.Theme.
+var ThemeVariants = []ThemeVariant{
+	ThemeVariantSystem,
+	ThemeVariantDark,
+	ThemeVariantLight,
+	ThemeVariantHighContrast,
+	ThemeVariantLargeText,
+}
@@ -0,0 +1,173 @@
+package pomodoro
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// tenthsThreshold is the time left since which the tenths of a second
+	// are shown (see Settings.ShowTenths).
+	tenthsThreshold = 10 * time.Second
+	tenthInterval   = time.Second / 10
+)
+
+// TimeRounding is how the time left is rounded to the displayed
+// seconds (see Settings.TimeLeftRounding). The displays, the notifications
+// and the integrations all show the same rounded value (see
+// Event.DisplayedTimeLeft and Status.DisplayedTimeLeft).
+type TimeRounding string
+
+const (
+	// TimeRoundingCeil shows "00:01" during the last second (like the
+	// countdowns do), so "00:00" is never shown while the timer runs.
+	TimeRoundingCeil  = TimeRounding("ceil")
+	TimeRoundingFloor = TimeRounding("floor")
+	TimeRoundingRound = TimeRounding("round")
+)
+
+// TimeRoundings are the choices of Settings.TimeLeftRounding.
+var TimeRoundings = []TimeRounding{
+	TimeRoundingCeil,
+	TimeRoundingFloor,
+	TimeRoundingRound,
+}
+
+// Round rounds the time left to a multiple of the unit. A negative time
+// left (the overtime) counts up like the elapsed time, so it is always
+// rounded towards zero.
+func (r TimeRounding) Round(
+	timeLeft time.Duration,
+	unit time.Duration,
+) time.Duration {
+	if timeLeft < 0 {
+		return timeLeft.Truncate(unit)
+	}
+	switch r {
+	case TimeRoundingFloor:
+		return timeLeft.Truncate(unit)
+	case TimeRoundingRound:
+		return timeLeft.Round(unit)
+	default:
+		rounded := timeLeft.Truncate(unit)
+		if rounded == timeLeft {
+			return rounded
+		}
+		if rounded > math.MaxInt64-unit {
+			// saturate the same way time.Duration.Round does
+			return math.MaxInt64
+		}
+		return rounded + unit
+	}
+}
+
+// boundaryOffset is how far from the multiples of the unit
+// the rounded value changes.
+func (r TimeRounding) boundaryOffset(unit time.Duration) time.Duration {
+	if r == TimeRoundingRound {
+		return unit / 2
+	}
+	return 0
+}
+
+// displayedTimeLeft is the time left as it is shown (see TimeRounding).
+func (p *Pomodoro) displayedTimeLeft(timeLeft time.Duration) time.Duration {
+	return p.settings.TimeLeftRounding.Round(timeLeft, time.Second)
+}
+
+// nextTickDelay returns the delay until the displayed time changes.
+// The ticks are aligned to the whole seconds of the time left (shifted
+// according to Settings.TimeLeftRounding), or of the elapsed time for
+// the stopwatch, so they do not drift against the deadline. In the last seconds with Settings.ShowTenths there are
+// also the renders of the tenths in between (isTick is false for them).
+func (p *Pomodoro) nextTickDelay() (delay time.Duration, isTick bool) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if p.isStopwatch {
+		return tickInterval - p.elapsed()%tickInterval, true
+	}
+
+	timeLeft := p.until(p.deadline)
+	rounding := p.settings.TimeLeftRounding
+	delay = untilBoundary(timeLeft-rounding.boundaryOffset(tickInterval), tickInterval)
+	if !p.showsTenths(timeLeft) {
+		return delay, true
+	}
+	if tenthDelay := untilBoundary(timeLeft-rounding.boundaryOffset(tenthInterval), tenthInterval); tenthDelay < delay {
+		return tenthDelay, false
+	}
+	return delay, true
+}
+
+// untilBoundary returns the time until the countdown reaches
+// the next multiple of the step.
+func untilBoundary(
+	timeLeft time.Duration,
+	step time.Duration,
+) time.Duration {
+	d := timeLeft % step
+	if d <= 0 {
+		d += step
+	}
+	return d
+}
+
+func (p *Pomodoro) showsTenths(timeLeft time.Duration) bool {
+	return p.settings.ShowTenths && !p.isStopwatch && timeLeft > 0 && timeLeft <= tenthsThreshold && !p.isRenderingReduced()
+}
+
+// renderTenths refreshes the displayed time between the ticks.
+func (p *Pomodoro) renderTenths() {
+	p.setTimeLeft(p.until(p.deadline))
+}
+
+// splitTimeLeft converts the time left into the displayed minutes and
+// seconds rounded according to the policy. The ticks come right after
+// the displayed value changes (see nextTickDelay), so slightly late ones
+// show it correctly.
+func splitTimeLeft(
+	timeLeft time.Duration,
+	rounding TimeRounding,
+) (minutes, seconds uint) {
+	if timeLeft < 0 {
+		return 0, 0
+	}
+	return splitDuration(rounding.Round(timeLeft, time.Second))
+}
+
+// splitElapsed is the counterpart of splitTimeLeft for the elapsed time
+// (of the stopwatch), which is rounded down.
+func splitElapsed(
+	elapsed time.Duration,
+) (minutes, seconds uint) {
+	if elapsed < 0 {
+		return 0, 0
+	}
+	return splitDuration(elapsed)
+}
+
+// split converts the shown time (the time left, or the elapsed time
+// for the stopwatch) into the displayed minutes and seconds.
+func (p *Pomodoro) split(shown time.Duration) (minutes, seconds uint) {
+	if p.isStopwatch {
+		return splitElapsed(shown)
+	}
+	return splitTimeLeft(shown, p.settings.TimeLeftRounding)
+}
+
+func splitDuration(d time.Duration) (minutes, seconds uint) {
+	return uint(d / time.Minute), uint((d % time.Minute) / time.Second)
+}
+
+// splitTenths is like splitTimeLeft, but it also returns the tenths
+// of a second.
+func splitTenths(
+	timeLeft time.Duration,
+	rounding TimeRounding,
+) (minutes, seconds, tenths uint) {
+	if timeLeft < 0 {
+		return 0, 0, 0
+	}
+	total := uint(rounding.Round(timeLeft, tenthInterval) / tenthInterval)
+	return total / 600, total / 10 % 60, total % 10
+}
@@ -0,0 +1,172 @@
+package pomodoro
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/clock"
+)
+
+var testRoundingUnits = []time.Duration{
+	time.Nanosecond,
+	tenthInterval,
+	time.Second,
+	time.Minute,
+}
+
+// quickDurations makes testing/quick generate the durations near zero
+// and near the limits of time.Duration as often as the arbitrary ones.
+var quickDurations = &quick.Config{
+	Values: func(args []reflect.Value, rng *rand.Rand) {
+		for idx := range args {
+			var d time.Duration
+			switch rng.Intn(4) {
+			case 0:
+				d = time.Duration(rng.Int63n(int64(time.Hour))) - 5*time.Second
+			case 1:
+				d = math.MaxInt64 - time.Duration(rng.Int63n(int64(2*time.Minute)))
+			case 2:
+				d = math.MinInt64 + time.Duration(rng.Int63n(int64(2*time.Minute)))
+			default:
+				d = time.Duration(rng.Uint64())
+			}
+			args[idx] = reflect.ValueOf(d)
+		}
+	},
+}
+
+func TestTimeRoundingRound(t *testing.T) {
+	for _, rounding := range TimeRoundings {
+		for _, unit := range testRoundingUnits {
+			t.Run(string(rounding)+"/"+unit.String(), func(t *testing.T) {
+				testTimeRoundingRound(t, rounding, unit)
+			})
+		}
+	}
+}
+
+func testTimeRoundingRound(
+	t *testing.T,
+	rounding TimeRounding,
+	unit time.Duration,
+) {
+	check := func(d time.Duration) bool {
+		r := rounding.Round(d, unit)
+		if r == math.MaxInt64 && r%unit != 0 {
+			// saturated, the same way as time.Duration.Round does
+			return rounding != TimeRoundingFloor && d > time.Duration(math.MaxInt64).Truncate(unit)
+		}
+		if r%unit != 0 {
+			t.Logf("%v rounded to %v is not a multiple of %v", d, r, unit)
+			return false
+		}
+		diff := r - d
+		if diff <= -unit || diff >= unit {
+			t.Logf("%v rounded to %v is a unit away or more", d, r)
+			return false
+		}
+		switch {
+		case d < 0:
+			// the overtime is rounded towards zero
+			return r <= 0 && r >= d
+		case rounding == TimeRoundingFloor:
+			return r <= d
+		case rounding == TimeRoundingCeil:
+			return r >= d
+		default:
+			return 2*diff.Abs() <= unit
+		}
+	}
+	if err := quick.Check(check, quickDurations); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSplitTimeLeft(t *testing.T) {
+	for _, rounding := range TimeRoundings {
+		t.Run(string(rounding), func(t *testing.T) {
+			check := func(d time.Duration) bool {
+				minutes, seconds := splitTimeLeft(d, rounding)
+				if seconds >= 60 {
+					t.Logf("%v is split into %d:%d", d, minutes, seconds)
+					return false
+				}
+				if d < 0 {
+					return minutes == 0 && seconds == 0
+				}
+				shown := time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+				// the saturated rounding is not a multiple of a second
+				return shown == rounding.Round(d, time.Second).Truncate(time.Second)
+			}
+			if err := quick.Check(check, quickDurations); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestSplitTenths(t *testing.T) {
+	for _, rounding := range TimeRoundings {
+		t.Run(string(rounding), func(t *testing.T) {
+			check := func(d time.Duration) bool {
+				minutes, seconds, tenths := splitTenths(d, rounding)
+				if seconds >= 60 || tenths >= 10 {
+					t.Logf("%v is split into %d:%d.%d", d, minutes, seconds, tenths)
+					return false
+				}
+				if d < 0 {
+					return minutes == 0 && seconds == 0 && tenths == 0
+				}
+				shown := time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second + time.Duration(tenths)*tenthInterval
+				return shown == rounding.Round(d, tenthInterval).Truncate(tenthInterval)
+			}
+			if err := quick.Check(check, quickDurations); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// newBenchmarkPomodoro creates a timer which renders the views as with
+// a window (but does nothing with them) and does not tick by itself.
+func newBenchmarkPomodoro(b *testing.B) *Pomodoro {
+	return newTestPomodoro(b,
+		WithFrontend(nopFrontend{}),
+		WithClock(clock.NewFake(time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC))),
+	)
+}
+
+// BenchmarkRenderTick is what the timer does once a second
+// while counting down (see tick).
+func BenchmarkRenderTick(b *testing.B) {
+	p := newBenchmarkPomodoro(b)
+	p.Start(true)
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for idx := 0; idx < b.N; idx++ {
+		p.renderTick(p.settings.WorkInterval - time.Duration(idx%3600)*time.Second)
+	}
+}
+
+// BenchmarkSetTimeLeftTenths is what the timer does ten times a second
+// in the last seconds (see Settings.ShowTenths).
+func BenchmarkSetTimeLeftTenths(b *testing.B) {
+	p := newBenchmarkPomodoro(b)
+	s := p.CurrentSettings()
+	s.ShowTenths = true
+	p.ApplySettings(s)
+	p.Start(true)
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for idx := 0; idx < b.N; idx++ {
+		p.setTimeLeft(tenthsThreshold - time.Duration(idx%100)*tenthInterval)
+	}
+}
@@ -0,0 +1,266 @@
+package pomodoro
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/clock"
+)
+
+const (
+	testWorkInterval     = 2 * time.Minute
+	testRestInterval     = time.Minute
+	testLongRestInterval = 3 * time.Minute
+)
+
+type fakeClockTimer struct {
+	*Pomodoro
+	Clock  *clock.Fake
+	Events chan Event
+}
+
+// newFakeClockTimer creates a timer with the short test intervals, which
+// time moves only by Clock.Advance.
+func newFakeClockTimer(
+	t *testing.T,
+	modify func(s *Settings),
+) *fakeClockTimer {
+	t.Helper()
+	c := clock.NewFake(time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC))
+	p := newTestPomodoro(t, WithClock(c))
+	s := p.CurrentSettings()
+	s.WorkInterval = testWorkInterval
+	s.RestInterval = testRestInterval
+	s.LongRestInterval = testLongRestInterval
+	s.LongBreakEvery = 2
+	s.IdlePauseAfter = 0
+	s.AutoContinue = false
+	s.AlarmEnabled = false
+	if modify != nil {
+		modify(&s)
+	}
+	p.ApplySettings(s)
+
+	events := make(chan Event, 1024)
+	p.OnEvent(func(ev Event) {
+		select {
+		case events <- ev:
+		default:
+		}
+	})
+	return &fakeClockTimer{Pomodoro: p, Clock: c, Events: events}
+}
+
+// advanceUntil moves the time forward tick by tick until an event of
+// the type is emitted. The ticker goroutine may miss a tick if it is
+// slow to wait for the next one, then it ticks a second later; so only
+// the deadline (not the moment of the tick) is exact.
+func (f *fakeClockTimer) advanceUntil(
+	t *testing.T,
+	eventType EventType,
+	limit time.Duration,
+) Event {
+	t.Helper()
+	until := f.Clock.Now().Add(limit)
+	for f.Clock.Now().Before(until) {
+		f.Clock.Advance(tickInterval)
+		for waiting := true; waiting; {
+			select {
+			case ev := <-f.Events:
+				if ev.Type == eventType {
+					return ev
+				}
+			case <-time.After(time.Millisecond):
+				waiting = false
+			}
+		}
+	}
+	t.Fatalf("no '%s' event within %v", eventType, limit)
+	return Event{}
+}
+
+func TestPhaseTransitions(t *testing.T) {
+	f := newFakeClockTimer(t, nil)
+
+	for idx, tc := range []struct {
+		IsWork   bool
+		Phase    Phase
+		Interval time.Duration
+		Next     Phase
+		NextLeft time.Duration
+		Cycle    uint
+	}{
+		{IsWork: true, Phase: PhaseWork, Interval: testWorkInterval, Next: PhaseRest, NextLeft: testRestInterval, Cycle: 1},
+		{IsWork: false, Phase: PhaseRest, Interval: testRestInterval, Next: PhaseWork, NextLeft: testWorkInterval, Cycle: 1},
+		{IsWork: true, Phase: PhaseWork, Interval: testWorkInterval, Next: PhaseLongRest, NextLeft: testLongRestInterval, Cycle: 2},
+		{IsWork: false, Phase: PhaseLongRest, Interval: testLongRestInterval, Next: PhaseWork, NextLeft: testWorkInterval, Cycle: 0},
+	} {
+		startedAt := f.Clock.Now()
+		f.Start(tc.IsWork)
+		s := f.Status()
+		if s.Phase != tc.Phase || !s.IsRunning {
+			t.Fatalf("#%d: expected a running %s, got %+v", idx, tc.Phase, s)
+		}
+		if want := startedAt.Add(tc.Interval); !s.Deadline.Equal(want) {
+			t.Fatalf("#%d: expected the deadline %v, got %v", idx, want, s.Deadline)
+		}
+
+		ev := f.advanceUntil(t, EventTypePhaseEnded, tc.Interval+time.Minute)
+		if ev.Phase != tc.Phase {
+			t.Fatalf("#%d: expected the end of %s, got the end of %s", idx, tc.Phase, ev.Phase)
+		}
+		if ended := ev.Time.Sub(startedAt); ended < tc.Interval {
+			t.Fatalf("#%d: %s ended %v after the start, before its interval %v", idx, tc.Phase, ended, tc.Interval)
+		}
+
+		s = f.Status()
+		if s.Phase != tc.Next || s.IsRunning || s.TimeLeft != tc.NextLeft {
+			t.Fatalf("#%d: expected a stopped %s with %v left, got %+v", idx, tc.Next, tc.NextLeft, s)
+		}
+		if s.CycleWorkSessions != tc.Cycle {
+			t.Fatalf("#%d: expected %d work sessions in the cycle, got %d", idx, tc.Cycle, s.CycleWorkSessions)
+		}
+	}
+	if s := f.Status(); s.CompletedWorkSessions != 2 {
+		t.Fatalf("expected 2 completed work sessions, got %d", s.CompletedWorkSessions)
+	}
+}
+
+func TestPauseResumeDeadline(t *testing.T) {
+	f := newFakeClockTimer(t, nil)
+	f.Start(true)
+
+	f.Clock.Advance(30 * time.Second)
+	if s := f.Status(); s.TimeLeft != testWorkInterval-30*time.Second {
+		t.Fatalf("expected %v left, got %v", testWorkInterval-30*time.Second, s.TimeLeft)
+	}
+
+	f.Pause()
+	f.Clock.Advance(time.Hour)
+	s := f.Status()
+	if !s.IsPaused || s.TimeLeft != testWorkInterval-30*time.Second {
+		t.Fatalf("expected the paused timer to keep %v left, got %+v", testWorkInterval-30*time.Second, s)
+	}
+
+	f.Resume()
+	s = f.Status()
+	if want := f.Clock.Now().Add(testWorkInterval - 30*time.Second); !s.IsRunning || !s.Deadline.Equal(want) {
+		t.Fatalf("expected the deadline %v after the resume, got %+v", want, s)
+	}
+
+	f.Extend(ExtendStep)
+	if got := f.Status().Deadline.Sub(s.Deadline); got != ExtendStep {
+		t.Fatalf("expected the deadline to move by %v, got %v", ExtendStep, got)
+	}
+	f.Extend(-ExtendStep)
+	if got := f.Status().Deadline; !got.Equal(s.Deadline) {
+		t.Fatalf("expected the deadline %v back, got %v", s.Deadline, got)
+	}
+}
+
+func TestDisplayedTimeLeft(t *testing.T) {
+	for _, tc := range []struct {
+		Rounding TimeRounding
+		Left     time.Duration
+		Expected time.Duration
+	}{
+		{Rounding: TimeRoundingCeil, Left: 10400 * time.Millisecond, Expected: 11 * time.Second},
+		{Rounding: TimeRoundingFloor, Left: 10400 * time.Millisecond, Expected: 10 * time.Second},
+		{Rounding: TimeRoundingRound, Left: 10400 * time.Millisecond, Expected: 10 * time.Second},
+		{Rounding: TimeRoundingRound, Left: 10600 * time.Millisecond, Expected: 11 * time.Second},
+		{Rounding: TimeRoundingCeil, Left: 10 * time.Second, Expected: 10 * time.Second},
+		{Rounding: TimeRoundingCeil, Left: -3700 * time.Millisecond, Expected: -3 * time.Second},
+		{Rounding: TimeRoundingRound, Left: -3700 * time.Millisecond, Expected: -3 * time.Second},
+	} {
+		t.Run(string(tc.Rounding)+"/"+tc.Left.String(), func(t *testing.T) {
+			f := newFakeClockTimer(t, func(s *Settings) {
+				s.TimeLeftRounding = tc.Rounding
+				s.Overtime = true
+			})
+			f.Start(true)
+			f.Clock.Advance(testWorkInterval - tc.Left)
+			s := f.Status()
+			if s.TimeLeft != tc.Left {
+				t.Fatalf("expected %v left, got %v", tc.Left, s.TimeLeft)
+			}
+			if s.DisplayedTimeLeft != tc.Expected {
+				t.Fatalf("expected %v displayed, got %v", tc.Expected, s.DisplayedTimeLeft)
+			}
+		})
+	}
+}
+
+func TestSwitchPhaseRecordsInterrupted(t *testing.T) {
+	f := newFakeClockTimer(t, nil)
+	f.Start(true)
+	f.Clock.Advance(time.Minute)
+	f.Start(false)
+	f.Clock.Advance(10 * time.Second)
+	f.StopTimer()
+
+	f.locker.Lock()
+	sessions := f.history.Sessions()
+	f.locker.Unlock()
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions in the history, got %+v", sessions)
+	}
+	for idx, expected := range []struct {
+		Phase    Phase
+		Duration time.Duration
+	}{
+		{Phase: PhaseWork, Duration: time.Minute},
+		{Phase: PhaseRest, Duration: 10 * time.Second},
+	} {
+		session := sessions[idx]
+		if session.Phase != expected.Phase.String() || session.Duration != expected.Duration || session.Completed {
+			t.Fatalf("#%d: expected an interrupted %s of %v, got %+v", idx, expected.Phase, expected.Duration, session)
+		}
+	}
+
+	var stopped []Phase
+	for len(stopped) < 2 {
+		select {
+		case ev := <-f.Events:
+			if ev.Type == EventTypeStopped {
+				stopped = append(stopped, ev.Phase)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected the stops of the work and the rest, got %v", stopped)
+		}
+	}
+	if stopped[0] != PhaseWork || stopped[1] != PhaseRest {
+		t.Fatalf("expected the stops of the work and the rest, got %v", stopped)
+	}
+}
+
+func TestUndoRestoresWithoutNewTransition(t *testing.T) {
+	f := newFakeClockTimer(t, nil)
+	f.Start(true)
+	f.Clock.Advance(time.Minute)
+	f.StopTimer()
+	f.Undo()
+	if s := f.Status(); s.Phase != PhaseWork || !s.IsRunning {
+		t.Fatalf("expected the running work to be restored, got %+v", s)
+	}
+
+	// a side-effecting subscriber (hooks, webhooks, calendar, etc)
+	started := 0
+	for restored := false; !restored; {
+		select {
+		case ev := <-f.Events:
+			if ev.Type != EventTypePhaseStarted {
+				continue
+			}
+			if ev.Restored {
+				restored = true
+				continue
+			}
+			started++
+		case <-time.After(time.Second):
+			t.Fatalf("no restored '%s' event", EventTypePhaseStarted)
+		}
+	}
+	if started != 1 {
+		t.Fatalf("expected the work to be started once, got %d", started)
+	}
+}
@@ -0,0 +1,173 @@
+package pomodoro
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/history"
+)
+
+const (
+	undoGraceWindow = 30 * time.Second
+)
+
+// timerSnapshot is the state of the timer before a Stop/Start action
+// (see Undo).
+type timerSnapshot struct {
+	TakenAt            time.Time
+	IsWork             bool
+	IsLongBreak        bool
+	IsStopwatch        bool
+	IsRunning          bool
+	IsPaused           bool
+	Deadline           time.Time
+	PausedTimeLeft     time.Duration
+	PhaseStartedAt     time.Time
+	PhaseRunningSince  time.Time
+	PhaseElapsed       time.Duration
+	PhasePlanned       time.Duration
+	PhaseBreakDebt     time.Duration
+	PhaseEndingEmitted bool
+	PhaseInterruptions []history.Interruption
+	PhaseRitual        *bool
+	BreakDebt          time.Duration
+	Description        string
+}
+
+// saveUndoSnapshot should be called before a Stop/Start action changes
+// anything; the toast with the "Undo" button is shown if toastText
+// is not empty (see View.UndoOffer).
+func (p *Pomodoro) saveUndoSnapshot(toastText string) {
+	s := &timerSnapshot{
+		TakenAt:            p.clock.Now(),
+		IsWork:             p.isWork,
+		IsLongBreak:        p.isLongBreak,
+		IsStopwatch:        p.isStopwatch,
+		IsRunning:          p.isRunning(),
+		IsPaused:           p.isPaused,
+		Deadline:           p.deadline,
+		PausedTimeLeft:     p.pausedTimeLeft,
+		PhaseStartedAt:     p.phaseStartedAt,
+		PhaseRunningSince:  p.phaseRunningSince,
+		PhaseElapsed:       p.phaseElapsed,
+		PhasePlanned:       p.phasePlanned,
+		PhaseBreakDebt:     p.phaseBreakDebt,
+		PhaseEndingEmitted: p.phaseEndingEmitted,
+		PhaseInterruptions: p.phaseInterruptions,
+		PhaseRitual:        p.ritual.phase,
+		BreakDebt:          p.breakDebt,
+		Description:        p.view.Description,
+	}
+	p.undoSnapshot = s
+	p.view.UndoOffer = toastText
+	p.refresh()
+	if toastText == "" || p.IsHeadless() {
+		return
+	}
+	p.lifecycle.launch(func() {
+		select {
+		case <-p.lifecycle.ctx.Done():
+			return
+		case <-p.clock.After(undoGraceWindow):
+		}
+		p.locker.Lock()
+		defer p.locker.Unlock()
+		if p.undoSnapshot == s {
+			p.view.UndoOffer = ""
+			p.refresh()
+		}
+	})
+}
+
+// CanUndo returns true if the last Stop/Start action could be undone.
+func (p *Pomodoro) CanUndo() bool {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	return p.canUndo()
+}
+
+func (p *Pomodoro) canUndo() bool {
+	return p.undoSnapshot != nil && p.since(p.undoSnapshot.TakenAt) <= undoGraceWindow
+}
+
+// Undo restores the phase and the deadline from before the last Stop/Start
+// action if it was done less than undoGraceWindow ago. The time passed since
+// then is counted as if the action did not happen.
+func (p *Pomodoro) Undo() {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.undo()
+}
+
+func (p *Pomodoro) undo() {
+	if !p.canUndo() {
+		return
+	}
+	s := p.undoSnapshot
+	p.undoSnapshot = nil
+	p.view.UndoOffer = ""
+
+	p.cancelAutoContinue()
+	p.stopAlarm()
+	if p.tickerCancel != nil {
+		p.tickerCancel()
+		p.tickerCancel = nil
+	}
+	if p.history != nil && !s.PhaseStartedAt.IsZero() {
+		// the phase is continued, so it is recorded when it ends
+		if err := p.history.Remove(s.PhaseStartedAt); err != nil {
+			slog.Error("unable to remove the undone session from the history", "error", err)
+		}
+	}
+
+	p.isWork = s.IsWork
+	p.isLongBreak = s.IsLongBreak
+	p.isStopwatch = s.IsStopwatch
+	p.isPaused = s.IsPaused
+	p.pausedByIdle = false
+	p.deadline = s.Deadline
+	p.pausedTimeLeft = s.PausedTimeLeft
+	p.phaseStartedAt = s.PhaseStartedAt
+	p.phaseRunningSince = s.PhaseRunningSince
+	p.phaseElapsed = s.PhaseElapsed
+	p.phasePlanned = s.PhasePlanned
+	p.phaseBreakDebt = s.PhaseBreakDebt
+	p.phaseEndingEmitted = s.PhaseEndingEmitted
+	p.phaseInterruptions = s.PhaseInterruptions
+	p.ritual.phase = s.PhaseRitual
+	if p.breakDebt != s.BreakDebt {
+		p.breakDebt = s.BreakDebt
+		setDuration(p.prefs, prefKeyBreakDebt, p.breakDebt)
+	}
+	p.setDescription(s.Description)
+
+	switch {
+	case s.IsRunning:
+		p.startTicker()
+		p.emitRestoredEvent(EventTypePhaseStarted, p.until(p.deadline))
+	case s.IsPaused:
+		p.setTimeLeft(p.pausedTimeLeft)
+		p.emitRestoredEvent(EventTypePhaseStarted, p.pausedTimeLeft)
+		p.emitRestoredEvent(EventTypePaused, p.pausedTimeLeft)
+	default:
+		p.setTimeLeft(p.nextInterval())
+		p.emitRestoredEvent(EventTypeStopped, p.nextInterval())
+	}
+	if s.IsRunning || s.IsPaused {
+		p.refreshTask()
+	} else {
+		p.view.Caption = ""
+	}
+	p.refreshGoal()
+	p.refreshProgress()
+}
+
+// emitRestoredEvent reports the state restored by undo (see Event.Restored).
+func (p *Pomodoro) emitRestoredEvent(
+	eventType EventType,
+	timeLeft time.Duration,
+) {
+	ev := p.newEvent(eventType, timeLeft)
+	ev.Restored = true
+	p.emit(ev)
+}
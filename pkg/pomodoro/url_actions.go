@@ -0,0 +1,145 @@
+package pomodoro
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/notify"
+	"github.com/xaionaro-go/pomodoro/pkg/urlscheme"
+)
+
+const (
+	defaultSnooze = 5 * time.Minute
+)
+
+// OpenURL runs the action of a "pomodoro://" URL (see pkg/urlscheme):
+//
+//	pomodoro://start?minutes=25          start the work (for 25 minutes)
+//	pomodoro://start?phase=rest          start a break
+//	pomodoro://start-break
+//	pomodoro://snooze?minutes=5          continue the interval which has just ended
+//	pomodoro://snooze-start?minutes=15   postpone the suggestion to start a session
+//	pomodoro://timer?minutes=4&name=tea  start a side timer (add "&sound=1" to ring)
+//	pomodoro://pause                     any of Commands
+func (p *Pomodoro) OpenURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("unable to parse the URL '%s': %w", rawURL, err)
+	}
+	if !strings.EqualFold(u.Scheme, urlscheme.Scheme) {
+		return fmt.Errorf("unexpected scheme '%s', expected '%s'", u.Scheme, urlscheme.Scheme)
+	}
+	action := u.Host
+	if action == "" {
+		// "pomodoro:pause"
+		action = u.Opaque
+	}
+	action = strings.Trim(action, "/")
+	minutes, err := urlMinutes(u.Query())
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "start":
+		isWork := true
+		switch phase := u.Query().Get("phase"); phase {
+		case "", "work":
+		case "rest":
+			isWork = false
+		default:
+			return fmt.Errorf("unknown phase '%s', expected 'work' or 'rest'", phase)
+		}
+		if minutes > 0 {
+			p.StartFor(isWork, minutes)
+		} else {
+			p.Start(isWork)
+		}
+	case "start-break":
+		if minutes > 0 {
+			p.StartFor(false, minutes)
+		} else {
+			p.Start(false)
+		}
+	case "snooze":
+		if minutes <= 0 {
+			minutes = defaultSnooze
+		}
+		p.ContinueEnded(minutes)
+	case "snooze-start":
+		if minutes <= 0 {
+			minutes = StartNudgeSnoozes[0]
+		}
+		p.SnoozeStartNudge(minutes)
+	case "timer":
+		if minutes <= 0 {
+			return fmt.Errorf("the side timer requires the 'minutes' parameter")
+		}
+		sound, _ := strconv.ParseBool(u.Query().Get("sound"))
+		p.AddSideTimer(u.Query().Get("name"), minutes, sound)
+	default:
+		if !slices.Contains(Commands, action) {
+			return fmt.Errorf("unknown action '%s' in the URL '%s'", action, rawURL)
+		}
+		return p.RunCommand(action)
+	}
+	return nil
+}
+
+func urlMinutes(query url.Values) (time.Duration, error) {
+	value := query.Get("minutes")
+	if value == "" {
+		return 0, nil
+	}
+	minutes, err := strconv.ParseFloat(value, 64)
+	if err != nil || minutes <= 0 {
+		return 0, fmt.Errorf("invalid 'minutes' value '%s', expected a positive number", value)
+	}
+	return time.Duration(minutes * float64(time.Minute)), nil
+}
+
+// StartFor starts the work or a break lasting d instead
+// of the configured interval.
+func (p *Pomodoro) StartFor(
+	isWork bool,
+	d time.Duration,
+) {
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	p.start(isWork)
+	p.overridePhaseDuration(d)
+}
+
+// handlePhaseEndNotificationEvent notifies that an interval has ended
+// (see NotificationSettings.OnPhaseEnd), the toasts on Windows get
+// the buttons to start the next interval or to snooze.
+func (p *Pomodoro) handlePhaseEndNotificationEvent(ev Event) {
+	if ev.Type != EventTypePhaseEnded || ev.Phase == PhaseStopwatch {
+		return
+	}
+	p.locker.Lock()
+	defer p.locker.Unlock()
+	if !p.settings.Notifications.OnPhaseEnd {
+		return
+	}
+	snooze := notify.Action{
+		Label: l10n.T("Snooze %d min", int(defaultSnooze/time.Minute)),
+		URL:   urlscheme.Scheme + "://snooze",
+	}
+	if ev.Phase == PhaseWork {
+		p.notify(l10n.T("The focus session is over"),
+			notify.Action{Label: l10n.T("Start break"), URL: urlscheme.Scheme + "://start-break"},
+			snooze,
+		)
+		return
+	}
+	p.notify(l10n.T("The break is over"),
+		notify.Action{Label: l10n.T("Start work"), URL: urlscheme.Scheme + "://start"},
+		snooze,
+	)
+}
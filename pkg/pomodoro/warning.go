@@ -0,0 +1,50 @@
+package pomodoro
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/audio"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+)
+
+const (
+	warningVolumeFactor = 0.5
+)
+
+// checkPhaseEnding emits EventTypePhaseEnding once per phase, when
+// the time left reaches Settings.WarnBefore.
+func (p *Pomodoro) checkPhaseEnding(timeLeft time.Duration) {
+	warnBefore := p.settings.WarnBefore
+	if p.phaseEndingEmitted || warnBefore <= 0 || timeLeft > warnBefore || timeLeft <= 0 {
+		return
+	}
+	p.phaseEndingEmitted = true
+	if p.phasePlanned <= warnBefore {
+		return
+	}
+	p.emitEvent(EventTypePhaseEnding, timeLeft)
+}
+
+func (p *Pomodoro) handleWarningEvent(ev Event) {
+	if ev.Type != EventTypePhaseEnding {
+		return
+	}
+
+	p.locker.Lock()
+	alarmEnabled := p.settings.AlarmEnabled
+	sound, file := p.settings.AlarmSound, p.settings.AlarmFile
+	rounding := p.settings.TimeLeftRounding
+	p.locker.Unlock()
+
+	what := l10n.T("The break")
+	if ev.Phase == PhaseWork {
+		what = l10n.T("The focus session")
+	}
+	p.notify(l10n.T("%s ends in %s, time to wrap up.", what, rounding.Round(ev.TimeLeft, time.Minute)))
+	if alarmEnabled && p.HasAudio() {
+		if err := p.playSound(audio.ChannelAlarm, sound, file, warningVolumeFactor); err != nil {
+			slog.Error("unable to play the warning sound", "error", err)
+		}
+	}
+}
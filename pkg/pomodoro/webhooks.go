@@ -0,0 +1,139 @@
+package pomodoro
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	webhookTimeout         = 10 * time.Second
+	webhookAttempts        = 4
+	webhookRetryDelay      = 2 * time.Second
+	webhookSignatureHeader = "X-Pomodoro-Signature"
+)
+
+// WebhookSettings contain the URLs the session events are POSTed to.
+// If the secret is set, the body is signed with HMAC-SHA256 and the signature
+// is passed in the X-Pomodoro-Signature header as "sha256=<hex>".
+type WebhookSettings struct {
+	URLs   []string
+	Secret string
+}
+
+type WebhookPayload struct {
+	Event           string    `json:"event"`
+	Phase           string    `json:"phase"`
+	DurationSeconds int64     `json:"duration_seconds"`
+	TimeLeftSeconds int64     `json:"time_left_seconds"`
+	Task            string    `json:"task,omitempty"`
+	Time            time.Time `json:"time"`
+}
+
+func (p *Pomodoro) handleWebhookEvent(ev Event) {
+	if ev.Type == EventTypeTick || ev.Restored {
+		return
+	}
+
+	p.locker.Lock()
+	settings := p.settings.Webhooks
+	p.locker.Unlock()
+	if len(settings.URLs) == 0 {
+		return
+	}
+	task := ev.Task
+	if ev.Phase != PhaseWork && ev.Phase != PhaseStopwatch {
+		task = ""
+	}
+
+	body, err := json.Marshal(WebhookPayload{
+		Event:           ev.Type.String(),
+		Phase:           ev.Phase.String(),
+		DurationSeconds: int64(ev.Elapsed / time.Second),
+		TimeLeftSeconds: int64(ev.DisplayedTimeLeft / time.Second),
+		Task:            task,
+		Time:            ev.Time,
+	})
+	if err != nil {
+		slog.Error("unable to serialize the webhook payload", "error", err)
+		return
+	}
+	var signature string
+	if settings.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(settings.Secret))
+		mac.Write(body)
+		signature = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	for _, url := range settings.URLs {
+		p.lifecycle.launch(func() {
+			if err := p.deliverWebhook(url, body, signature); err != nil {
+				slog.Error("unable to deliver the event to the webhook", "event", ev.Type, "url", url, "error", err)
+			}
+		})
+	}
+}
+
+// deliverWebhook POSTs the body retrying with an exponential backoff
+// on network errors and 5xx responses.
+func (p *Pomodoro) deliverWebhook(
+	url string,
+	body []byte,
+	signature string,
+) error {
+	var err error
+	delay := webhookRetryDelay
+	for attempt := 0; attempt < webhookAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-p.lifecycle.ctx.Done():
+				return err
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+
+		var retry bool
+		retry, err = p.postWebhook(url, body, signature)
+		if err == nil || !retry {
+			return err
+		}
+	}
+	return err
+}
+
+func (p *Pomodoro) postWebhook(
+	url string,
+	body []byte,
+	signature string,
+) (bool, error) {
+	ctx, cancelFn := context.WithTimeout(p.lifecycle.ctx, webhookTimeout)
+	defer cancelFn()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("unable to create the request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set(webhookSignatureHeader, signature)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("unable to send the request: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return true, fmt.Errorf("received status %d", resp.StatusCode)
+	case resp.StatusCode/100 != 2:
+		return false, fmt.Errorf("received status %d", resp.StatusCode)
+	}
+	return false, nil
+}
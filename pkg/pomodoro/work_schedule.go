@@ -0,0 +1,111 @@
+package pomodoro
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	workScheduleCheckInterval = time.Minute
+)
+
+// WorkScheduleSettings define the working hours: from Start till End
+// (since the midnight) on the Days. If End is not after Start, the hours
+// continue past the midnight (and belong to the day they start on).
+type WorkScheduleSettings struct {
+	Enabled bool
+	Start   time.Duration
+	End     time.Duration
+	Days    []time.Weekday
+
+	// StartNudgeAfter is how long without sessions it takes to suggest
+	// starting one, 0 disables the suggestions (see checkStartNudge).
+	StartNudgeAfter time.Duration
+}
+
+func DefaultWorkDays() []time.Weekday {
+	return []time.Weekday{
+		time.Monday,
+		time.Tuesday,
+		time.Wednesday,
+		time.Thursday,
+		time.Friday,
+	}
+}
+
+// Weekdays are all the days of the week, starting with Monday.
+var Weekdays = []time.Weekday{
+	time.Monday,
+	time.Tuesday,
+	time.Wednesday,
+	time.Thursday,
+	time.Friday,
+	time.Saturday,
+	time.Sunday,
+}
+
+// Contains returns true if t is within the working hours,
+// it is always true if the schedule is disabled.
+func (s WorkScheduleSettings) Contains(t time.Time) bool {
+	if !s.Enabled {
+		return true
+	}
+	sinceMidnight := time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+	day := t.Weekday()
+	if s.End > s.Start {
+		return slices.Contains(s.Days, day) && sinceMidnight >= s.Start && sinceMidnight < s.End
+	}
+	if sinceMidnight < s.End {
+		previousDay := (day + 6) % 7
+		return slices.Contains(s.Days, previousDay)
+	}
+	return slices.Contains(s.Days, day) && sinceMidnight >= s.Start
+}
+
+func weekdaysToInts(days []time.Weekday) []int {
+	result := make([]int, 0, len(days))
+	for _, day := range days {
+		result = append(result, int(day))
+	}
+	return result
+}
+
+func intsToWeekdays(ints []int) []time.Weekday {
+	result := make([]time.Weekday, 0, len(ints))
+	for _, i := range ints {
+		result = append(result, time.Weekday(i))
+	}
+	return result
+}
+
+// IsOffHours returns true if it is outside the working hours now
+// (the auto-continue and the notifications are suppressed then).
+func (p *Pomodoro) IsOffHours() bool {
+	return p.offHours.Load()
+}
+
+func (p *Pomodoro) refreshOffHours() {
+	offHours := !p.settings.WorkSchedule.Contains(p.clock.Now())
+	if p.offHours.Swap(offHours) != offHours {
+		p.refresh()
+	}
+}
+
+func (p *Pomodoro) watchWorkSchedule() {
+	ticker := p.clock.NewTicker(workScheduleCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.lifecycle.ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		p.locker.Lock()
+		p.refreshOffHours()
+		p.locker.Unlock()
+		p.checkStartNudge()
+	}
+}
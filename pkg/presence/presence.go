@@ -0,0 +1,22 @@
+// Package presence publishes the focus state as the user status
+// in messengers, so that the colleagues know when one will be back.
+package presence
+
+import (
+	"context"
+	"time"
+)
+
+type Status struct {
+	Text  string
+	Emoji string
+	// Expiration is when the messenger should clear the status by itself
+	// (in case the timer is not running anymore); zero means never.
+	Expiration time.Time
+}
+
+// Provider is a messenger where the status is shown.
+type Provider interface {
+	SetStatus(ctx context.Context, status Status) error
+	ClearStatus(ctx context.Context) error
+}
@@ -0,0 +1,95 @@
+package presence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	SlackDefaultAPIURL = "https://slack.com/api"
+)
+
+// Slack sets the status via the users.profile.set method, the token
+// should be a user token with the "users.profile:write" scope.
+type Slack struct {
+	APIURL     string
+	Token      string
+	HTTPClient *http.Client
+}
+
+var _ Provider = (*Slack)(nil)
+
+func NewSlack(token string) *Slack {
+	return &Slack{
+		APIURL:     SlackDefaultAPIURL,
+		Token:      token,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+type slackProfile struct {
+	StatusText       string `json:"status_text"`
+	StatusEmoji      string `json:"status_emoji"`
+	StatusExpiration int64  `json:"status_expiration"`
+}
+
+type slackResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+func (s *Slack) SetStatus(
+	ctx context.Context,
+	status Status,
+) error {
+	profile := slackProfile{
+		StatusText:  status.Text,
+		StatusEmoji: status.Emoji,
+	}
+	if !status.Expiration.IsZero() {
+		profile.StatusExpiration = status.Expiration.Unix()
+	}
+	return s.setProfile(ctx, profile)
+}
+
+func (s *Slack) ClearStatus(ctx context.Context) error {
+	return s.setProfile(ctx, slackProfile{})
+}
+
+func (s *Slack) setProfile(
+	ctx context.Context,
+	profile slackProfile,
+) error {
+	body, err := json.Marshal(map[string]any{"profile": profile})
+	if err != nil {
+		return fmt.Errorf("unable to serialize the profile: %w", err)
+	}
+
+	url := s.APIURL + "/users.profile.set"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create a request to '%s': %w", url, err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+s.Token)
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send the status to '%s': %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %d from '%s'", resp.StatusCode, url)
+	}
+	var result slackResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("unable to parse the response from '%s': %w", url, err)
+	}
+	if !result.OK {
+		return fmt.Errorf("slack returned an error: %s", result.Error)
+	}
+	return nil
+}
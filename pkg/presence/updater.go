@@ -0,0 +1,91 @@
+package presence
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	requestTimeout = 30 * time.Second
+)
+
+type Timer interface {
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+}
+
+// Updater sets the status when a work session starts (or is extended)
+// and clears it when the session ends.
+type Updater struct {
+	Providers []Provider
+	Emoji     string
+
+	deadline    time.Time
+	unsubscribe func()
+}
+
+func NewUpdater(
+	timer Timer,
+	providers ...Provider,
+) *Updater {
+	u := &Updater{
+		Providers: providers,
+		Emoji:     ":tomato:",
+	}
+	u.unsubscribe = timer.OnEvent(u.onEvent)
+	return u
+}
+
+// onEvent is called sequentially, so no locking is needed.
+func (u *Updater) onEvent(ev pomodoro.Event) {
+	isWork := ev.Phase == pomodoro.PhaseWork
+	switch ev.Type {
+	case pomodoro.EventTypePhaseStarted, pomodoro.EventTypeResumed, pomodoro.EventTypeTick:
+		switch {
+		case isWork && !u.deadline.Equal(ev.Deadline):
+			u.deadline = ev.Deadline
+			u.set(ev.Deadline)
+		case !isWork && !u.deadline.IsZero():
+			u.clear()
+		}
+	case pomodoro.EventTypePhaseEnded, pomodoro.EventTypeStopped:
+		if !u.deadline.IsZero() {
+			u.clear()
+		}
+	}
+}
+
+func (u *Updater) set(deadline time.Time) {
+	status := Status{
+		Text:       l10n.T("focusing — back at %s", l10n.FormatClock(deadline)),
+		Emoji:      u.Emoji,
+		Expiration: deadline,
+	}
+	for _, provider := range u.Providers {
+		ctx, cancelFn := context.WithTimeout(context.Background(), requestTimeout)
+		err := provider.SetStatus(ctx, status)
+		cancelFn()
+		if err != nil {
+			slog.Error("unable to set the status", "error", err)
+		}
+	}
+}
+
+func (u *Updater) clear() {
+	u.deadline = time.Time{}
+	for _, provider := range u.Providers {
+		ctx, cancelFn := context.WithTimeout(context.Background(), requestTimeout)
+		err := provider.ClearStatus(ctx)
+		cancelFn()
+		if err != nil {
+			slog.Error("unable to clear the status", "error", err)
+		}
+	}
+}
+
+func (u *Updater) Close() {
+	u.unsubscribe()
+}
@@ -0,0 +1,171 @@
+package room
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	reconnectDelay = 5 * time.Second
+
+	// maxDrift is the difference of the deadlines (with the host)
+	// which is not corrected.
+	maxDrift = time.Second
+)
+
+// Guest is a member of a room hosted by somebody else: the timer follows
+// the host's one.
+type Guest struct {
+	membersNotifier
+	url        string
+	name       string
+	timer      Timer
+	cancelFn   context.CancelFunc
+	goroutines sync.WaitGroup
+
+	connLocker sync.Mutex
+	conn       *websocket.Conn
+}
+
+// Join connects to the room (like 'ws://host.example.com:8789/room'),
+// reconnecting until Close is called.
+func Join(
+	url string,
+	name string,
+	timer Timer,
+) *Guest {
+	ctx, cancelFn := context.WithCancel(context.Background())
+	g := &Guest{
+		url:      url,
+		name:     name,
+		timer:    timer,
+		cancelFn: cancelFn,
+	}
+	g.goroutines.Add(1)
+	go func() {
+		defer g.goroutines.Done()
+		g.connectLoop(ctx)
+	}()
+	return g
+}
+
+func (g *Guest) connectLoop(ctx context.Context) {
+	for {
+		if err := g.connect(ctx); err != nil && ctx.Err() == nil {
+			slog.Warn("disconnected from the room", "url", g.url, "error", err)
+		}
+		g.setMembers(nil)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (g *Guest) connect(ctx context.Context) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, g.url, nil)
+	if err != nil {
+		return fmt.Errorf("unable to connect: %w", err)
+	}
+	g.connLocker.Lock()
+	if ctx.Err() != nil {
+		g.connLocker.Unlock()
+		conn.Close()
+		return ctx.Err()
+	}
+	g.conn = conn
+	g.connLocker.Unlock()
+	defer func() {
+		g.connLocker.Lock()
+		g.conn = nil
+		g.connLocker.Unlock()
+		conn.Close()
+	}()
+
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err := conn.WriteJSON(Message{Type: MessageTypeHello, Name: g.name}); err != nil {
+		return fmt.Errorf("unable to introduce ourselves: %w", err)
+	}
+
+	for {
+		var msg Message
+		if err := conn.ReadJSON(&msg); err != nil {
+			return err
+		}
+		switch msg.Type {
+		case MessageTypeState:
+			if msg.State != nil {
+				g.follow(*msg.State)
+			}
+		case MessageTypeMembers:
+			g.setMembers(msg.Members)
+		}
+	}
+}
+
+// follow brings the timer to the state of the host's one.
+func (g *Guest) follow(state State) {
+	status := g.timer.Status()
+	if !state.IsRunning && !state.IsPaused {
+		if status.IsRunning || status.IsPaused {
+			g.timer.StopTimer()
+		}
+		return
+	}
+
+	if status.Phase.String() != state.Phase || (!status.IsRunning && !status.IsPaused) {
+		switch state.Phase {
+		case pomodoro.PhaseWork.String():
+			g.timer.Start(true)
+		case pomodoro.PhaseRest.String(), pomodoro.PhaseLongRest.String():
+			g.timer.Start(false)
+		case pomodoro.PhaseStopwatch.String():
+			g.timer.StartStopwatch()
+		default:
+			slog.Warn("the host is in an unknown phase", "phase", state.Phase)
+			return
+		}
+		status = g.timer.Status()
+	}
+
+	switch {
+	case state.IsPaused && status.IsRunning:
+		g.timer.Pause()
+		status = g.timer.Status()
+	case state.IsRunning && status.IsPaused:
+		g.timer.Resume()
+		status = g.timer.Status()
+	}
+	if state.Phase == pomodoro.PhaseStopwatch.String() {
+		return
+	}
+
+	var drift time.Duration
+	if state.IsRunning {
+		drift = state.Deadline.Sub(status.Deadline)
+	} else {
+		drift = state.TimeLeft - status.TimeLeft
+	}
+	if drift > maxDrift || drift < -maxDrift {
+		g.timer.Extend(drift)
+	}
+}
+
+func (g *Guest) Close() error {
+	g.cancelFn()
+	g.connLocker.Lock()
+	if g.conn != nil {
+		g.conn.Close()
+	}
+	g.connLocker.Unlock()
+	g.goroutines.Wait()
+	return nil
+}
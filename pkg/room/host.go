@@ -0,0 +1,218 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	stateResendInterval = 10 * time.Second
+	helloTimeout        = 10 * time.Second
+)
+
+// Host serves a room: the guests' timers follow the host's one.
+type Host struct {
+	membersNotifier
+	name        string
+	timer       Timer
+	upgrader    websocket.Upgrader
+	httpServer  *http.Server
+	unsubscribe func()
+	cancelFn    context.CancelFunc
+	goroutines  sync.WaitGroup
+
+	// lastDeadline is accessed only from the event handler
+	lastDeadline time.Time
+
+	guestsLocker sync.Mutex
+	guests       []*guestConn
+}
+
+type guestConn struct {
+	conn        *websocket.Conn
+	name        string
+	writeLocker sync.Mutex
+}
+
+func (c *guestConn) send(msg Message) error {
+	c.writeLocker.Lock()
+	defer c.writeLocker.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return c.conn.WriteJSON(msg)
+}
+
+// NewHost starts serving a room on the address (the guests join it
+// via 'ws://<address>/room').
+func NewHost(
+	listenAddr string,
+	name string,
+	timer Timer,
+) (*Host, error) {
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on '%s': %w", listenAddr, err)
+	}
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	h := &Host{
+		name:     name,
+		timer:    timer,
+		cancelFn: cancelFn,
+	}
+	h.setMembers([]string{name})
+	mux := http.NewServeMux()
+	mux.HandleFunc(roomPath, h.handleGuest)
+	h.httpServer = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	h.goroutines.Add(2)
+	go func() {
+		defer h.goroutines.Done()
+		err := h.httpServer.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("the room server stopped", "error", err)
+		}
+	}()
+	go func() {
+		defer h.goroutines.Done()
+		h.resendStateLoop(ctx)
+	}()
+	h.unsubscribe = timer.OnEvent(h.onEvent)
+	return h, nil
+}
+
+func (h *Host) handleGuest(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		slog.Error("unable to upgrade the guest connection to WebSocket", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	var hello Message
+	conn.SetReadDeadline(time.Now().Add(helloTimeout))
+	if err := conn.ReadJSON(&hello); err != nil || hello.Type != MessageTypeHello {
+		slog.Warn("a guest did not introduce themselves, disconnecting")
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+	guest := &guestConn{
+		conn: conn,
+		name: strings.TrimSpace(hello.Name),
+	}
+	if guest.name == "" {
+		guest.name = conn.RemoteAddr().String()
+	}
+
+	state := newState(h.timer.Status())
+	if err := guest.send(Message{Type: MessageTypeState, State: &state}); err != nil {
+		return
+	}
+	h.guestsLocker.Lock()
+	h.guests = append(h.guests, guest)
+	h.guestsLocker.Unlock()
+	h.broadcastMembers()
+	defer func() {
+		h.guestsLocker.Lock()
+		for idx, g := range h.guests {
+			if g == guest {
+				h.guests = append(h.guests[:idx], h.guests[idx+1:]...)
+				break
+			}
+		}
+		h.guestsLocker.Unlock()
+		h.broadcastMembers()
+	}()
+
+	for {
+		// the guests are not supposed to send anything else,
+		// just wait for the disconnection
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (h *Host) broadcastMembers() {
+	h.guestsLocker.Lock()
+	members := []string{h.name}
+	for _, guest := range h.guests {
+		members = append(members, guest.name)
+	}
+	guests := append([]*guestConn{}, h.guests...)
+	h.guestsLocker.Unlock()
+
+	h.setMembers(members)
+	h.broadcast(guests, Message{Type: MessageTypeMembers, Members: members})
+}
+
+func (h *Host) broadcastState() {
+	h.guestsLocker.Lock()
+	guests := append([]*guestConn{}, h.guests...)
+	h.guestsLocker.Unlock()
+
+	state := newState(h.timer.Status())
+	h.broadcast(guests, Message{Type: MessageTypeState, State: &state})
+}
+
+func (h *Host) broadcast(
+	guests []*guestConn,
+	msg Message,
+) {
+	for _, guest := range guests {
+		if err := guest.send(msg); err != nil {
+			slog.Error("unable to send the message to the guest", "type", msg.Type, "guest", guest.name, "error", err)
+		}
+	}
+}
+
+func (h *Host) onEvent(ev pomodoro.Event) {
+	switch ev.Type {
+	case pomodoro.EventTypePhaseEnding:
+		return
+	case pomodoro.EventTypeTick:
+		// ticks are sent only if the deadline was moved (see Extend)
+		if ev.Deadline.Equal(h.lastDeadline) {
+			return
+		}
+	}
+	h.lastDeadline = ev.Deadline
+	h.broadcastState()
+}
+
+func (h *Host) resendStateLoop(ctx context.Context) {
+	ticker := time.NewTicker(stateResendInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		h.broadcastState()
+	}
+}
+
+func (h *Host) Close() error {
+	h.unsubscribe()
+	h.cancelFn()
+	h.guestsLocker.Lock()
+	for _, guest := range h.guests {
+		guest.conn.Close()
+	}
+	h.guestsLocker.Unlock()
+	err := h.httpServer.Close()
+	h.goroutines.Wait()
+	return err
+}
@@ -0,0 +1,47 @@
+package room
+
+import (
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+type MessageType string
+
+const (
+	// MessageTypeHello is sent by a guest right after connecting.
+	MessageTypeHello = MessageType("hello")
+
+	// MessageTypeState is sent by the host on every change of its timer
+	// (and periodically to correct the drift).
+	MessageTypeState = MessageType("state")
+
+	// MessageTypeMembers is sent by the host when somebody joins or leaves.
+	MessageTypeMembers = MessageType("members")
+)
+
+type Message struct {
+	Type    MessageType `json:"type"`
+	Name    string      `json:"name,omitempty"`
+	State   *State      `json:"state,omitempty"`
+	Members []string    `json:"members,omitempty"`
+}
+
+// State is the state of the host's timer.
+type State struct {
+	Phase     string        `json:"phase"`
+	IsRunning bool          `json:"running"`
+	IsPaused  bool          `json:"paused"`
+	Deadline  time.Time     `json:"deadline,omitempty"`
+	TimeLeft  time.Duration `json:"time_left"`
+}
+
+func newState(status pomodoro.Status) State {
+	return State{
+		Phase:     status.Phase.String(),
+		IsRunning: status.IsRunning,
+		IsPaused:  status.IsPaused,
+		Deadline:  status.Deadline,
+		TimeLeft:  status.TimeLeft,
+	}
+}
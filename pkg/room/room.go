@@ -0,0 +1,57 @@
+package room
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	roomPath     = "/room"
+	writeTimeout = 5 * time.Second
+)
+
+type Timer interface {
+	Start(isWork bool)
+	StartStopwatch()
+	Pause()
+	Resume()
+	StopTimer()
+	Extend(d time.Duration)
+	Status() pomodoro.Status
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+}
+
+// membersNotifier keeps the list of the room members and notifies
+// the subscribers about its changes.
+type membersNotifier struct {
+	locker   sync.Mutex
+	members  []string
+	handlers []func(members []string)
+}
+
+// Members returns the names of the users in the room, the host goes first.
+func (n *membersNotifier) Members() []string {
+	n.locker.Lock()
+	defer n.locker.Unlock()
+	return append([]string{}, n.members...)
+}
+
+// OnMembersChanged registers a handler called (from an arbitrary goroutine)
+// when somebody joins or leaves the room.
+func (n *membersNotifier) OnMembersChanged(handler func(members []string)) {
+	n.locker.Lock()
+	defer n.locker.Unlock()
+	n.handlers = append(n.handlers, handler)
+}
+
+func (n *membersNotifier) setMembers(members []string) {
+	n.locker.Lock()
+	n.members = members
+	handlers := append([]func([]string){}, n.handlers...)
+	n.locker.Unlock()
+	for _, handler := range handlers {
+		handler(append([]string{}, members...))
+	}
+}
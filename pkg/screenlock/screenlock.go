@@ -0,0 +1,38 @@
+// Package screenlock locks the screen of the current session
+// (to make the user step away from the computer during a break).
+package screenlock
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Locker locks the screen, unlocking is left to the user.
+type Locker interface {
+	Lock() error
+}
+
+// CommandLocker runs the commands one by one until one of them succeeds
+// (the first ones may be unavailable in the environment).
+type CommandLocker struct {
+	Commands [][]string
+}
+
+var _ Locker = (*CommandLocker)(nil)
+
+func (l *CommandLocker) Lock() error {
+	var errs []error
+	for _, command := range l.Commands {
+		output, err := exec.Command(command[0], command[1:]...).CombinedOutput()
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("unable to execute '%s': %w (output: '%s')", strings.Join(command, " "), err, output))
+	}
+	if len(errs) == 0 {
+		return fmt.Errorf("no command to lock the screen")
+	}
+	return errors.Join(errs...)
+}
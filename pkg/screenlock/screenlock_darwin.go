@@ -0,0 +1,11 @@
+package screenlock
+
+// NewDefaultLocker puts the display to sleep, which locks the screen
+// if a password is required after sleep (the default on macOS).
+func NewDefaultLocker() Locker {
+	return &CommandLocker{
+		Commands: [][]string{
+			{"pmset", "displaysleepnow"},
+		},
+	}
+}
@@ -0,0 +1,10 @@
+package screenlock
+
+func NewDefaultLocker() Locker {
+	return &CommandLocker{
+		Commands: [][]string{
+			{"loginctl", "lock-session"},
+			{"xdg-screensaver", "lock"},
+		},
+	}
+}
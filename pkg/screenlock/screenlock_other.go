@@ -0,0 +1,18 @@
+//go:build !linux && !darwin && !windows
+
+package screenlock
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func NewDefaultLocker() Locker {
+	return unsupportedLocker{}
+}
+
+type unsupportedLocker struct{}
+
+func (unsupportedLocker) Lock() error {
+	return fmt.Errorf("locking the screen is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,9 @@
+package screenlock
+
+func NewDefaultLocker() Locker {
+	return &CommandLocker{
+		Commands: [][]string{
+			{"rundll32.exe", "user32.dll,LockWorkStation"},
+		},
+	}
+}
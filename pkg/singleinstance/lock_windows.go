@@ -0,0 +1,29 @@
+package singleinstance
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockOffset is where the locked byte is: a locked region could not be read
+// by the other processes, so it is placed after the PID.
+const lockOffset = 1 << 32
+
+func lockFile(f *os.File) error {
+	overlapped := &windows.Overlapped{
+		Offset:     lockOffset & 0xffffffff,
+		OffsetHigh: lockOffset >> 32,
+	}
+	err := windows.LockFileEx(
+		windows.Handle(f.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY,
+		0, 1, 0,
+		overlapped,
+	)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return errLocked
+	}
+	return err
+}
@@ -0,0 +1,199 @@
+// Package singleinstance makes sure only one instance of the application
+// runs per user: the first instance listens on a local socket (guarded by
+// a lock file) and the next ones forward their arguments to it and exit.
+package singleinstance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	dialTimeout    = time.Second
+	startupTimeout = 3 * time.Second
+	retryInterval  = 100 * time.Millisecond
+)
+
+// ErrForwarded is returned by Acquire if another instance is running
+// and the arguments were sent to it.
+var ErrForwarded = errors.New("the arguments were forwarded to the running instance")
+
+// errLocked is returned by lockFile if the file is locked by another process.
+var errLocked = errors.New("the file is locked by another process")
+
+type Instance struct {
+	socketPath string
+	lockPath   string
+	lockFile   *os.File
+	listener   net.Listener
+	handler    func(args []string)
+	closeOnce  sync.Once
+}
+
+type message struct {
+	Args []string `json:"args"`
+}
+
+// Dir returns the directory for the lock and the socket files.
+func Dir(appName string) (string, error) {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, appName), nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to get the cache directory: %w", err)
+	}
+	return filepath.Join(dir, appName), nil
+}
+
+// Acquire either becomes the running instance (handler is then called
+// for the arguments of each subsequently started instance), or forwards
+// args to the running instance and returns ErrForwarded.
+func Acquire(
+	dir string,
+	args []string,
+	handler func(args []string),
+) (*Instance, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("unable to create the directory '%s': %w", dir, err)
+	}
+	i := &Instance{
+		socketPath: filepath.Join(dir, "instance.sock"),
+		lockPath:   filepath.Join(dir, "instance.lock"),
+		handler:    handler,
+	}
+
+	deadline := time.Now().Add(startupTimeout)
+	for {
+		if err := i.forward(args); err == nil {
+			return nil, ErrForwarded
+		}
+
+		err := i.lock()
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, errLocked) {
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("the instance with PID %s holds the lock '%s', but does not answer", i.lockOwner(), i.lockPath)
+		}
+		// the other instance may be still starting
+		time.Sleep(retryInterval)
+	}
+
+	// the socket of a crashed instance is left behind
+	_ = os.Remove(i.socketPath)
+	listener, err := net.Listen("unix", i.socketPath)
+	if err != nil {
+		_ = i.lockFile.Close()
+		return nil, fmt.Errorf("unable to listen on '%s': %w", i.socketPath, err)
+	}
+	i.listener = listener
+	go i.serve()
+	return i, nil
+}
+
+// lock takes the lock of the OS on the lock file and writes the PID into
+// it. The lock is held until Close and it is released by the OS if the
+// process dies, so the lock of a crashed instance is never taken for
+// a running one.
+func (i *Instance) lock() error {
+	f, err := os.OpenFile(i.lockPath, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open the lock file '%s': %w", i.lockPath, err)
+	}
+	if err := lockFile(f); err != nil {
+		_ = f.Close()
+		if errors.Is(err, errLocked) {
+			return err
+		}
+		return fmt.Errorf("unable to lock the file '%s': %w", i.lockPath, err)
+	}
+	if err := f.Truncate(0); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("unable to truncate the lock file '%s': %w", i.lockPath, err)
+	}
+	if _, err := fmt.Fprintf(f, "%d\n", os.Getpid()); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("unable to write to the lock file '%s': %w", i.lockPath, err)
+	}
+	i.lockFile = f
+	return nil
+}
+
+// lockOwner returns the PID written to the lock file (for the messages).
+func (i *Instance) lockOwner() string {
+	b, err := os.ReadFile(i.lockPath)
+	if err != nil {
+		return "unknown"
+	}
+	pid := strings.TrimSpace(string(b))
+	if pid == "" {
+		return "unknown"
+	}
+	return pid
+}
+
+func (i *Instance) forward(args []string) error {
+	conn, err := net.DialTimeout("unix", i.socketPath, dialTimeout)
+	if err != nil {
+		return fmt.Errorf("unable to connect to '%s': %w", i.socketPath, err)
+	}
+	defer conn.Close()
+	if err := json.NewEncoder(conn).Encode(message{Args: args}); err != nil {
+		return fmt.Errorf("unable to send the arguments: %w", err)
+	}
+	return nil
+}
+
+func (i *Instance) serve() {
+	for {
+		conn, err := i.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				slog.Error("unable to accept a connection from another instance", "error", err)
+			}
+			return
+		}
+		go i.handleConn(conn)
+	}
+}
+
+func (i *Instance) handleConn(conn net.Conn) {
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(dialTimeout))
+	var msg message
+	if err := json.NewDecoder(conn).Decode(&msg); err != nil {
+		slog.Error("unable to read the arguments of another instance", "error", err)
+		return
+	}
+	i.handler(msg.Args)
+}
+
+// Close stops listening and releases the lock, so that the next started
+// instance becomes the running one. The lock file itself is kept: removing
+// it would let an instance lock the removed file while another one
+// locks a new file.
+func (i *Instance) Close() error {
+	var result error
+	i.closeOnce.Do(func() {
+		if err := i.listener.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("unable to close the listener: %w", err))
+		}
+		_ = os.Remove(i.socketPath)
+		if err := i.lockFile.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("unable to release the lock file '%s': %w", i.lockPath, err))
+		}
+	})
+	return result
+}
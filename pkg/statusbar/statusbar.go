@@ -0,0 +1,124 @@
+// Package statusbar prints the timer status for status bars
+// of tiling window managers (Waybar, i3blocks, Polybar).
+package statusbar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xaionaro-go/pomodoro/pkg/dbusservice"
+)
+
+type Format string
+
+const (
+	FormatUndefined = Format("")
+	FormatWaybar    = Format("waybar")
+	FormatI3Blocks  = Format("i3blocks")
+)
+
+const (
+	refreshInterval = time.Second
+)
+
+type Source interface {
+	GetStatus() (dbusservice.RemoteStatus, error)
+}
+
+// Run prints the status once per second until the context is cancelled.
+func Run(
+	ctx context.Context,
+	format Format,
+	source Source,
+	w io.Writer,
+) error {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		status, err := source.GetStatus()
+		if err != nil {
+			status = dbusservice.RemoteStatus{}
+		}
+		line, err := Render(format, status)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return fmt.Errorf("unable to write the status: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+type waybarOutput struct {
+	Text    string `json:"text"`
+	Tooltip string `json:"tooltip"`
+	Class   string `json:"class"`
+}
+
+// Render formats the status; an empty phase means the timer is not available.
+func Render(
+	format Format,
+	status dbusservice.RemoteStatus,
+) (string, error) {
+	text, class := describe(status)
+	switch format {
+	case FormatWaybar:
+		b, err := json.Marshal(waybarOutput{
+			Text:    text,
+			Tooltip: fmt.Sprintf("%s (%s)", status.Phase, class),
+			Class:   class,
+		})
+		if err != nil {
+			return "", fmt.Errorf("unable to serialize the status: %w", err)
+		}
+		return string(b) + "\n", nil
+	case FormatI3Blocks:
+		// full_text, short_text and color
+		return fmt.Sprintf("%s\n%s\n%s\n", text, text, classColor(class)), nil
+	default:
+		return "", fmt.Errorf("unknown status format '%s', expected '%s' or '%s'", format, FormatWaybar, FormatI3Blocks)
+	}
+}
+
+func describe(status dbusservice.RemoteStatus) (text, class string) {
+	if status.Phase == "" {
+		return "", "unavailable"
+	}
+	// already rounded by the running instance (see pomodoro.TimeRounding)
+	timeLeft := status.TimeLeft
+	clock := fmt.Sprintf("%02d:%02d", int(timeLeft/time.Minute), int(timeLeft%time.Minute/time.Second))
+	icon := "🍅"
+	if status.Phase != "work" && status.Phase != "stopwatch" {
+		icon = "☕"
+	}
+	switch {
+	case status.IsPaused:
+		return "⏸ " + clock, "paused"
+	case status.IsRunning:
+		return icon + " " + clock, status.Phase
+	default:
+		return icon, "idle"
+	}
+}
+
+func classColor(class string) string {
+	switch class {
+	case "work", "stopwatch":
+		return "#ff6347"
+	case "rest", "long_rest":
+		return "#3cb371"
+	case "paused":
+		return "#ffd700"
+	default:
+		return "#a0a0a0"
+	}
+}
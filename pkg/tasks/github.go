@@ -0,0 +1,147 @@
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	GitHubDefaultAPIURL = "https://api.github.com"
+)
+
+// GitHub uses the issues assigned to the user (the owner of the token)
+// as the tasks, their IDs are like "owner/repo#123".
+type GitHub struct {
+	APIURL     string
+	Token      string
+	HTTPClient *http.Client
+}
+
+var _ Provider = (*GitHub)(nil)
+
+func NewGitHub(token string) *GitHub {
+	return &GitHub{
+		APIURL:     GitHubDefaultAPIURL,
+		Token:      token,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+func (g *GitHub) Name() string {
+	return "GitHub"
+}
+
+type gitHubIssue struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	HTMLURL    string `json:"html_url"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+func (g *GitHub) OpenTasks(ctx context.Context) ([]Task, error) {
+	req, err := g.newRequest(ctx, http.MethodGet, "/issues?filter=assigned&state=open&per_page=100", nil)
+	if err != nil {
+		return nil, err
+	}
+	var issues []gitHubIssue
+	err = doRequest(g.HTTPClient, req, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&issues)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Task, 0, len(issues))
+	for _, issue := range issues {
+		id := fmt.Sprintf("%s#%d", issue.Repository.FullName, issue.Number)
+		result = append(result, Task{
+			ID:    id,
+			Title: fmt.Sprintf("%s (%s)", issue.Title, id),
+			URL:   issue.HTMLURL,
+		})
+	}
+	return result, nil
+}
+
+func (g *GitHub) Comment(
+	ctx context.Context,
+	task Task,
+	text string,
+) error {
+	path, err := issuePath(task.ID)
+	if err != nil {
+		return err
+	}
+	req, err := g.newRequest(ctx, http.MethodPost, path+"/comments", map[string]string{
+		"body": text,
+	})
+	if err != nil {
+		return err
+	}
+	return doRequest(g.HTTPClient, req, nil)
+}
+
+func (g *GitHub) Complete(
+	ctx context.Context,
+	task Task,
+) error {
+	path, err := issuePath(task.ID)
+	if err != nil {
+		return err
+	}
+	req, err := g.newRequest(ctx, http.MethodPatch, path, map[string]string{
+		"state":        "closed",
+		"state_reason": "completed",
+	})
+	if err != nil {
+		return err
+	}
+	return doRequest(g.HTTPClient, req, nil)
+}
+
+// issuePath converts an ID like "owner/repo#123" into
+// the API path "/repos/owner/repo/issues/123".
+func issuePath(id string) (string, error) {
+	repo, number, ok := strings.Cut(id, "#")
+	if !ok || strings.Count(repo, "/") != 1 {
+		return "", fmt.Errorf("invalid issue ID '%s', expected 'owner/repo#number'", id)
+	}
+	if _, err := strconv.ParseUint(number, 10, 64); err != nil {
+		return "", fmt.Errorf("invalid issue number in '%s': %w", id, err)
+	}
+	return fmt.Sprintf("/repos/%s/issues/%s", repo, number), nil
+}
+
+func (g *GitHub) newRequest(
+	ctx context.Context,
+	method string,
+	path string,
+	body any,
+) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		serialized, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("unable to serialize the request: %w", err)
+		}
+		reader = bytes.NewReader(serialized)
+	}
+	url := g.APIURL + path
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a request to '%s': %w", url, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+g.Token)
+	return req, nil
+}
@@ -0,0 +1,51 @@
+// Package tasks fetches the open tasks from task managers (to pick one
+// to work on) and reports the work sessions back to them.
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type Task struct {
+	// ID identifies the task within its provider.
+	ID    string
+	Title string
+	URL   string
+}
+
+// Provider is a task manager.
+type Provider interface {
+	Name() string
+	OpenTasks(ctx context.Context) ([]Task, error)
+	Comment(ctx context.Context, task Task, text string) error
+	Complete(ctx context.Context, task Task) error
+}
+
+// doRequest sends the request and decodes the response (if decode is
+// not nil), any non-2xx status is an error.
+func doRequest(
+	client *http.Client,
+	req *http.Request,
+	decode func(io.Reader) error,
+) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send the request to '%s': %w", req.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("unexpected status %d from '%s': %s", resp.StatusCode, req.URL, bytes.TrimSpace(respBody))
+	}
+	if decode == nil {
+		return nil
+	}
+	if err := decode(resp.Body); err != nil {
+		return fmt.Errorf("unable to parse the response from '%s': %w", req.URL, err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	TodoistDefaultAPIURL = "https://api.todoist.com/rest/v2"
+)
+
+// Todoist uses the Todoist REST API v2.
+type Todoist struct {
+	APIURL   string
+	APIToken string
+
+	// Filter selects the tasks (in the Todoist filter syntax),
+	// empty for all the open tasks.
+	Filter     string
+	HTTPClient *http.Client
+}
+
+var _ Provider = (*Todoist)(nil)
+
+func NewTodoist(apiToken string) *Todoist {
+	return &Todoist{
+		APIURL:     TodoistDefaultAPIURL,
+		APIToken:   apiToken,
+		Filter:     "today | overdue",
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+func (t *Todoist) Name() string {
+	return "Todoist"
+}
+
+type todoistTask struct {
+	ID      string `json:"id"`
+	Content string `json:"content"`
+	URL     string `json:"url"`
+}
+
+func (t *Todoist) OpenTasks(ctx context.Context) ([]Task, error) {
+	req, err := t.newRequest(ctx, http.MethodGet, "/tasks", nil)
+	if err != nil {
+		return nil, err
+	}
+	if t.Filter != "" {
+		q := req.URL.Query()
+		q.Set("filter", t.Filter)
+		req.URL.RawQuery = q.Encode()
+	}
+	var items []todoistTask
+	err = doRequest(t.HTTPClient, req, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&items)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Task, 0, len(items))
+	for _, item := range items {
+		result = append(result, Task{
+			ID:    item.ID,
+			Title: item.Content,
+			URL:   item.URL,
+		})
+	}
+	return result, nil
+}
+
+func (t *Todoist) Comment(
+	ctx context.Context,
+	task Task,
+	text string,
+) error {
+	req, err := t.newRequest(ctx, http.MethodPost, "/comments", map[string]string{
+		"task_id": task.ID,
+		"content": text,
+	})
+	if err != nil {
+		return err
+	}
+	return doRequest(t.HTTPClient, req, nil)
+}
+
+func (t *Todoist) Complete(
+	ctx context.Context,
+	task Task,
+) error {
+	req, err := t.newRequest(ctx, http.MethodPost, "/tasks/"+task.ID+"/close", nil)
+	if err != nil {
+		return err
+	}
+	return doRequest(t.HTTPClient, req, nil)
+}
+
+func (t *Todoist) newRequest(
+	ctx context.Context,
+	method string,
+	path string,
+	body any,
+) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		serialized, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("unable to serialize the request: %w", err)
+		}
+		reader = bytes.NewReader(serialized)
+	}
+	url := t.APIURL + path
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a request to '%s': %w", url, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+t.APIToken)
+	return req, nil
+}
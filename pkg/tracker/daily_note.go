@@ -0,0 +1,107 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DailyNote appends the sessions as Markdown list items to a daily note
+// (as in Obsidian or Logseq journals), for example:
+//
+//   - 10:00–10:25 🍅 Write report #project-x
+type DailyNote struct {
+	// PathTemplate is the path of the note, where '{{date}}' is replaced
+	// with the date of the session ('2006-01-02'), '{{year}}', '{{month}}'
+	// and '{{day}}' with its parts, and a leading '~' with the home directory.
+	PathTemplate string
+
+	locker sync.Mutex
+}
+
+var _ Tracker = (*DailyNote)(nil)
+
+func NewDailyNote(pathTemplate string) *DailyNote {
+	return &DailyNote{
+		PathTemplate: pathTemplate,
+	}
+}
+
+func (n *DailyNote) AddEntry(
+	ctx context.Context,
+	entry Entry,
+) error {
+	path, err := n.path(entry)
+	if err != nil {
+		return err
+	}
+
+	n.locker.Lock()
+	defer n.locker.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("unable to create the directory of the daily note '%s': %w", path, err)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open the daily note '%s': %w", path, err)
+	}
+	defer f.Close()
+
+	line := formatDailyNoteLine(entry)
+	if !endsWithNewline(f) {
+		line = "\n" + line
+	}
+	if _, err := f.WriteString(line); err != nil {
+		return fmt.Errorf("unable to append to the daily note '%s': %w", path, err)
+	}
+	return nil
+}
+
+func (n *DailyNote) path(entry Entry) (string, error) {
+	startedAt := entry.StartedAt.Local()
+	path := strings.NewReplacer(
+		"{{date}}", startedAt.Format("2006-01-02"),
+		"{{year}}", startedAt.Format("2006"),
+		"{{month}}", startedAt.Format("01"),
+		"{{day}}", startedAt.Format("02"),
+	).Replace(n.PathTemplate)
+	if rest, ok := strings.CutPrefix(path, "~"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("unable to get the home directory: %w", err)
+		}
+		path = filepath.Join(home, rest)
+	}
+	return path, nil
+}
+
+func formatDailyNoteLine(entry Entry) string {
+	startedAt := entry.StartedAt.Local()
+	endedAt := entry.EndedAt.Local()
+	if entry.EndedAt.IsZero() {
+		endedAt = startedAt.Add(entry.Duration)
+	}
+	line := fmt.Sprintf("- %s–%s 🍅", startedAt.Format("15:04"), endedAt.Format("15:04"))
+	if entry.Description != "" {
+		line += " " + entry.Description
+	}
+	return line + "\n"
+}
+
+// endsWithNewline checks if the file is empty or ends with a newline
+// (otherwise the new item would be glued to the last line).
+func endsWithNewline(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return true
+	}
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil && err != io.EOF {
+		return true
+	}
+	return last[0] == '\n'
+}
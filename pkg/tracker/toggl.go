@@ -0,0 +1,82 @@
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	TogglDefaultAPIURL = "https://api.track.toggl.com/api/v9"
+)
+
+// Toggl creates time entries via the Toggl Track API v9.
+type Toggl struct {
+	APIURL      string
+	APIToken    string
+	WorkspaceID int64
+	HTTPClient  *http.Client
+}
+
+var _ Tracker = (*Toggl)(nil)
+
+func NewToggl(
+	apiToken string,
+	workspaceID int64,
+) *Toggl {
+	return &Toggl{
+		APIURL:      TogglDefaultAPIURL,
+		APIToken:    apiToken,
+		WorkspaceID: workspaceID,
+		HTTPClient:  http.DefaultClient,
+	}
+}
+
+type togglTimeEntry struct {
+	CreatedWith string   `json:"created_with"`
+	Description string   `json:"description"`
+	Start       string   `json:"start"`
+	Duration    int64    `json:"duration"`
+	WorkspaceID int64    `json:"workspace_id"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+func (t *Toggl) AddEntry(
+	ctx context.Context,
+	entry Entry,
+) error {
+	body, err := json.Marshal(togglTimeEntry{
+		CreatedWith: "pomodoro",
+		Description: entry.Description,
+		Start:       entry.StartedAt.UTC().Format(time.RFC3339),
+		Duration:    int64(entry.Duration / time.Second),
+		WorkspaceID: t.WorkspaceID,
+		Tags:        entry.Tags,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to serialize the time entry: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/workspaces/%d/time_entries", t.APIURL, t.WorkspaceID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create a request to '%s': %w", url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(t.APIToken, "api_token")
+
+	resp, err := t.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send the time entry to '%s': %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("unexpected status %d from '%s': %s", resp.StatusCode, url, bytes.TrimSpace(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package tracker
+
+import (
+	"context"
+	"time"
+)
+
+// Entry is a completed work session to be recorded in a time tracker.
+type Entry struct {
+	Description string
+	StartedAt   time.Time
+	EndedAt     time.Time
+	Duration    time.Duration
+	Tags        []string
+}
+
+// Tracker is a time-tracking service.
+type Tracker interface {
+	AddEntry(ctx context.Context, entry Entry) error
+}
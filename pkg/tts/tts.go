@@ -0,0 +1,95 @@
+package tts
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// Speaker pronounces a text, blocking until it is finished.
+type Speaker interface {
+	Speak(text string) error
+}
+
+type SpeakerFunc func(text string) error
+
+func (fn SpeakerFunc) Speak(text string) error {
+	return fn(text)
+}
+
+// CommandSpeaker runs an external speech synthesizer passing the text
+// as the last argument.
+type CommandSpeaker struct {
+	Name string
+	Args []string
+}
+
+func (s CommandSpeaker) Speak(text string) error {
+	args := append(append([]string{}, s.Args...), text)
+	if output, err := exec.Command(s.Name, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to run '%s': %w (output: '%s')", s.Name, err, output)
+	}
+	return nil
+}
+
+// Synthesizer renders a text into a WAV (to be played later without
+// the delay of the synthesis).
+type Synthesizer interface {
+	Synthesize(text string) ([]byte, error)
+}
+
+type SynthesizerFunc func(text string) ([]byte, error)
+
+func (fn SynthesizerFunc) Synthesize(text string) ([]byte, error) {
+	return fn(text)
+}
+
+// CommandSynthesizer runs an external speech synthesizer which writes
+// the WAV to the standard output, passing the text as the last argument.
+type CommandSynthesizer struct {
+	Name string
+	Args []string
+}
+
+func (s CommandSynthesizer) Synthesize(text string) ([]byte, error) {
+	args := append(append([]string{}, s.Args...), text)
+	var stderr bytes.Buffer
+	cmd := exec.Command(s.Name, args...)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to run '%s': %w (output: '%s')", s.Name, err, stderr.Bytes())
+	}
+	return output, nil
+}
+
+// SynthesizerChain tries the synthesizers in order until one of them succeeds.
+type SynthesizerChain []Synthesizer
+
+func (c SynthesizerChain) Synthesize(text string) ([]byte, error) {
+	var errs []error
+	for _, s := range c {
+		wav, err := s.Synthesize(text)
+		if err == nil {
+			return wav, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, fmt.Errorf("no synthesizer succeeded: %w", errors.Join(errs...))
+}
+
+// Chain tries the speakers in order until one of them succeeds.
+type Chain []Speaker
+
+func (c Chain) Speak(text string) error {
+	var errs []error
+	for _, s := range c {
+		err := s.Speak(text)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf("no speaker succeeded: %w", errors.Join(errs...))
+}
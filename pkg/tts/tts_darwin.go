@@ -0,0 +1,36 @@
+package tts
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+func NewDefaultSpeaker() Speaker {
+	return CommandSpeaker{Name: "say"}
+}
+
+func NewDefaultSynthesizer() Synthesizer {
+	return SynthesizerFunc(saySynthesize)
+}
+
+// saySynthesize uses "say", which is unable to write a WAV to the standard
+// output, so a temporary file is used.
+func saySynthesize(text string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "pomodoro-tts-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "speech.wav")
+	cmd := exec.Command("say", "-o", path, "--file-format=WAVE", "--data-format=LEI16@22050", "--", text)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("unable to run 'say': %w (output: '%s')", err, output)
+	}
+	wav, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read '%s': %w", path, err)
+	}
+	return wav, nil
+}
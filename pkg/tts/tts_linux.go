@@ -0,0 +1,16 @@
+package tts
+
+func NewDefaultSpeaker() Speaker {
+	return Chain{
+		CommandSpeaker{Name: "spd-say", Args: []string{"--wait", "--"}},
+		CommandSpeaker{Name: "espeak-ng", Args: []string{"--"}},
+		CommandSpeaker{Name: "espeak", Args: []string{"--"}},
+	}
+}
+
+func NewDefaultSynthesizer() Synthesizer {
+	return SynthesizerChain{
+		CommandSynthesizer{Name: "espeak-ng", Args: []string{"--stdout", "--"}},
+		CommandSynthesizer{Name: "espeak", Args: []string{"--stdout", "--"}},
+	}
+}
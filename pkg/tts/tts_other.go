@@ -0,0 +1,20 @@
+//go:build !linux && !darwin && !windows
+
+package tts
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func NewDefaultSpeaker() Speaker {
+	return SpeakerFunc(func(string) error {
+		return fmt.Errorf("text-to-speech is not supported on %s", runtime.GOOS)
+	})
+}
+
+func NewDefaultSynthesizer() Synthesizer {
+	return SynthesizerFunc(func(string) ([]byte, error) {
+		return nil, fmt.Errorf("text-to-speech is not supported on %s", runtime.GOOS)
+	})
+}
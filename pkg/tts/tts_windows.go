@@ -0,0 +1,49 @@
+package tts
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const sapiScript = `Add-Type -AssemblyName System.Speech; ` +
+	`(New-Object System.Speech.Synthesis.SpeechSynthesizer).Speak([Console]::In.ReadToEnd())`
+
+const sapiSynthesizeScript = `Add-Type -AssemblyName System.Speech; ` +
+	`$s = New-Object System.Speech.Synthesis.SpeechSynthesizer; ` +
+	`$m = New-Object System.IO.MemoryStream; ` +
+	`$s.SetOutputToWaveStream($m); ` +
+	`$s.Speak([Console]::In.ReadToEnd()); ` +
+	`$m.WriteTo([Console]::OpenStandardOutput())`
+
+func NewDefaultSpeaker() Speaker {
+	return SpeakerFunc(sapiSpeak)
+}
+
+func NewDefaultSynthesizer() Synthesizer {
+	return SynthesizerFunc(sapiSynthesize)
+}
+
+// sapiSpeak uses SAPI through PowerShell; the text is passed via stdin
+// to avoid escaping it.
+func sapiSpeak(text string) error {
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", sapiScript)
+	cmd.Stdin = strings.NewReader(text)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("unable to speak via SAPI: %w (output: '%s')", err, output)
+	}
+	return nil
+}
+
+func sapiSynthesize(text string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", sapiSynthesizeScript)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stderr = &stderr
+	wav, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to synthesize via SAPI: %w (output: '%s')", err, stderr.Bytes())
+	}
+	return wav, nil
+}
@@ -0,0 +1,107 @@
+package tui
+
+// glyphs are the large digits the countdown is drawn with,
+// all of them are glyphHeight rows high.
+var glyphs = map[rune][]string{
+	'0': {
+		"█████",
+		"█   █",
+		"█   █",
+		"█   █",
+		"█████",
+	},
+	'1': {
+		"  █  ",
+		" ██  ",
+		"  █  ",
+		"  █  ",
+		" ███ ",
+	},
+	'2': {
+		"█████",
+		"    █",
+		"█████",
+		"█    ",
+		"█████",
+	},
+	'3': {
+		"█████",
+		"    █",
+		" ████",
+		"    █",
+		"█████",
+	},
+	'4': {
+		"█   █",
+		"█   █",
+		"█████",
+		"    █",
+		"    █",
+	},
+	'5': {
+		"█████",
+		"█    ",
+		"█████",
+		"    █",
+		"█████",
+	},
+	'6': {
+		"█████",
+		"█    ",
+		"█████",
+		"█   █",
+		"█████",
+	},
+	'7': {
+		"█████",
+		"    █",
+		"   █ ",
+		"  █  ",
+		"  █  ",
+	},
+	'8': {
+		"█████",
+		"█   █",
+		"█████",
+		"█   █",
+		"█████",
+	},
+	'9': {
+		"█████",
+		"█   █",
+		"█████",
+		"    █",
+		"█████",
+	},
+	':': {
+		" ",
+		"█",
+		" ",
+		"█",
+		" ",
+	},
+}
+
+const (
+	glyphHeight = 5
+)
+
+// bigText renders the text with glyphs, the runes without a glyph are skipped.
+func bigText(text string) []string {
+	rows := make([]string, glyphHeight)
+	first := true
+	for _, r := range text {
+		glyph, ok := glyphs[r]
+		if !ok {
+			continue
+		}
+		for idx := range rows {
+			if !first {
+				rows[idx] += " "
+			}
+			rows[idx] += glyph[idx]
+		}
+		first = false
+	}
+	return rows
+}
@@ -0,0 +1,228 @@
+// Package tui shows the timer in a terminal: the countdown drawn with
+// large digits and the same controls as in the window.
+//
+// It needs only the timer engine (see pomodoro.New), so it works without
+// a display: neither Fyne nor the global hotkeys are initialized.
+package tui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/xaionaro-go/pomodoro/pkg/l10n"
+	"github.com/xaionaro-go/pomodoro/pkg/logging"
+	"github.com/xaionaro-go/pomodoro/pkg/pomodoro"
+)
+
+const (
+	refreshInterval = time.Second
+)
+
+type Timer interface {
+	Start(isWork bool)
+	StartStopwatch()
+	TogglePause()
+	StopTimer()
+	Extend(d time.Duration)
+	AcknowledgeAlarm()
+	Status() pomodoro.Status
+	OnEvent(handler func(pomodoro.Event)) (unsubscribe func())
+}
+
+type logBuffer struct {
+	locker sync.Mutex
+	buf    bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+	return b.buf.Write(p)
+}
+
+// Run shows the timer until the context is cancelled or the user quits.
+// The log messages are held back while the terminal is occupied and
+// printed afterwards.
+func Run(
+	ctx context.Context,
+	timer Timer,
+) error {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return fmt.Errorf("unable to open the terminal: %w", err)
+	}
+	if err := screen.Init(); err != nil {
+		return fmt.Errorf("unable to initialize the terminal: %w", err)
+	}
+
+	logs := &logBuffer{}
+	prevLogOutput := logging.SetOutput(logs)
+	defer func() {
+		screen.Fini()
+		logging.SetOutput(prevLogOutput)
+		prevLogOutput.Write(logs.buf.Bytes())
+	}()
+
+	unsubscribe := timer.OnEvent(func(ev pomodoro.Event) {
+		if ev.Type != pomodoro.EventTypeTick {
+			screen.PostEvent(tcell.NewEventInterrupt(nil))
+		}
+	})
+	defer unsubscribe()
+
+	events := make(chan tcell.Event)
+	quit := make(chan struct{})
+	go screen.ChannelEvents(events, quit)
+	defer close(quit)
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		draw(screen, timer.Status())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case ev := <-events:
+			switch ev := ev.(type) {
+			case *tcell.EventResize:
+				screen.Sync()
+			case *tcell.EventKey:
+				if !handleKey(timer, ev) {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// handleKey runs the action bound to the key, it returns false
+// if the user wants to quit.
+func handleKey(
+	timer Timer,
+	ev *tcell.EventKey,
+) bool {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		return false
+	case tcell.KeyRune:
+	default:
+		return true
+	}
+
+	switch ev.Rune() {
+	case 'q':
+		return false
+	case 'w':
+		timer.Start(true)
+	case 'r':
+		timer.Start(false)
+	case ' ', 'p':
+		timer.TogglePause()
+	case 's':
+		timer.StopTimer()
+	case 't':
+		timer.StartStopwatch()
+	case '+', '=':
+		timer.Extend(pomodoro.ExtendStep)
+	case '-':
+		timer.Extend(-pomodoro.ExtendStep)
+	case 'a':
+		timer.AcknowledgeAlarm()
+	}
+	return true
+}
+
+func phaseTitle(status pomodoro.Status) string {
+	var title string
+	switch status.Phase {
+	case pomodoro.PhaseWork:
+		title = l10n.T("Work")
+	case pomodoro.PhaseRest:
+		title = l10n.T("Rest")
+	case pomodoro.PhaseLongRest:
+		title = l10n.T("Long rest")
+	case pomodoro.PhaseStopwatch:
+		title = l10n.T("Stopwatch")
+	}
+	switch {
+	case status.IsPaused:
+		title = l10n.T("%s (paused)", title)
+	case !status.IsRunning:
+		title = l10n.T("%s (next)", title)
+	}
+	return title
+}
+
+func phaseColor(status pomodoro.Status) tcell.Color {
+	switch {
+	case status.IsPaused:
+		return tcell.ColorGold
+	case !status.IsRunning:
+		return tcell.ColorSilver
+	}
+	switch status.Phase {
+	case pomodoro.PhaseRest, pomodoro.PhaseLongRest:
+		return tcell.ColorMediumSeaGreen
+	default:
+		return tcell.ColorTomato
+	}
+}
+
+func formatClock(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	if d < 0 {
+		d = 0
+	}
+	if d >= time.Hour {
+		return fmt.Sprintf("%d:%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second))
+	}
+	return fmt.Sprintf("%02d:%02d", int(d/time.Minute), int(d%time.Minute/time.Second))
+}
+
+func draw(
+	screen tcell.Screen,
+	status pomodoro.Status,
+) {
+	screen.Clear()
+	width, height := screen.Size()
+
+	clock := status.DisplayedTimeLeft
+	if status.Phase == pomodoro.PhaseStopwatch {
+		clock = status.Elapsed
+	}
+	digits := bigText(formatClock(clock))
+	style := tcell.StyleDefault.Foreground(phaseColor(status))
+
+	help := l10n.T("w: work  r: rest  space: pause/resume  s: stop  t: stopwatch  +/-: extend/shorten  a: acknowledge  q: quit")
+	sessions := l10n.T("Completed sessions: %d", status.CompletedWorkSessions)
+
+	top := max(0, (height-glyphHeight-6)/2)
+	drawCentered(screen, width, top, phaseTitle(status), style.Bold(true))
+	for idx, row := range digits {
+		drawCentered(screen, width, top+2+idx, row, style)
+	}
+	drawCentered(screen, width, top+glyphHeight+3, sessions, tcell.StyleDefault)
+	drawCentered(screen, width, height-1, help, tcell.StyleDefault.Dim(true))
+	screen.Show()
+}
+
+func drawCentered(
+	screen tcell.Screen,
+	width int,
+	y int,
+	text string,
+	style tcell.Style,
+) {
+	x := max(0, (width-runewidth.StringWidth(text))/2)
+	for _, r := range text {
+		screen.SetContent(x, y, r, nil, style)
+		x += runewidth.RuneWidth(r)
+	}
+}
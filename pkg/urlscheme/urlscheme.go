@@ -0,0 +1,16 @@
+// Package urlscheme registers the application as the handler of the
+// "pomodoro://" URLs, so the OS automation (Apple Shortcuts, the buttons
+// of the Windows toasts, links) could drive the timer.
+package urlscheme
+
+import (
+	"strings"
+)
+
+const Scheme = "pomodoro"
+
+// IsURL checks if the string is a URL of the scheme (it does not
+// validate the rest of it).
+func IsURL(s string) bool {
+	return strings.HasPrefix(strings.ToLower(s), Scheme+":")
+}
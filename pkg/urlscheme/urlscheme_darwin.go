@@ -0,0 +1,35 @@
+package urlscheme
+
+/*
+#cgo LDFLAGS: -framework Foundation -framework CoreServices
+
+void registerURLHandler(void);
+*/
+import "C"
+
+import (
+	"sync/atomic"
+)
+
+var urlHandler atomic.Pointer[func(url string)]
+
+//export handleURL
+func handleURL(url *C.char) {
+	if fn := urlHandler.Load(); fn != nil {
+		(*fn)(C.GoString(url))
+	}
+}
+
+// Register is a no-op on macOS: the scheme is declared
+// by CFBundleURLTypes in the Info.plist of the application bundle.
+func Register(string, ...string) error {
+	return nil
+}
+
+// HandleURLs calls fn for every URL of the scheme opened while
+// the application runs (including the one it was launched with),
+// macOS delivers them as Apple Events to the running instance.
+func HandleURLs(fn func(url string)) {
+	urlHandler.Store(&fn)
+	C.registerURLHandler()
+}
@@ -0,0 +1,30 @@
+#import <Foundation/Foundation.h>
+#import <CoreServices/CoreServices.h>
+
+#include "_cgo_export.h"
+
+@interface PomodoroURLHandler : NSObject
+- (void)handleGetURLEvent:(NSAppleEventDescriptor *)event withReplyEvent:(NSAppleEventDescriptor *)reply;
+@end
+
+@implementation PomodoroURLHandler
+- (void)handleGetURLEvent:(NSAppleEventDescriptor *)event withReplyEvent:(NSAppleEventDescriptor *)reply {
+	NSString *url = [[event paramDescriptorForKeyword:keyDirectObject] stringValue];
+	if (url != nil) {
+		handleURL((char *)[url UTF8String]);
+	}
+}
+@end
+
+void registerURLHandler(void) {
+	static PomodoroURLHandler *handler = nil;
+	if (handler != nil) {
+		return;
+	}
+	handler = [[PomodoroURLHandler alloc] init];
+	[[NSAppleEventManager sharedAppleEventManager]
+		setEventHandler:handler
+		andSelector:@selector(handleGetURLEvent:withReplyEvent:)
+		forEventClass:kInternetEventClass
+		andEventID:kAEGetURL];
+}
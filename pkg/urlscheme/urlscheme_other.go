@@ -0,0 +1,14 @@
+//go:build !windows && !darwin
+
+package urlscheme
+
+// Register is a no-op: on the freedesktop systems the scheme is declared
+// by 'MimeType=x-scheme-handler/pomodoro' in the desktop entry, and
+// the URL is passed as an argument (see HandleURLs).
+func Register(string, ...string) error {
+	return nil
+}
+
+// HandleURLs is a no-op: the URLs come as the command line
+// arguments of a new process.
+func HandleURLs(func(url string)) {}
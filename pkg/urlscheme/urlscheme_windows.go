@@ -0,0 +1,51 @@
+package urlscheme
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// Register makes Windows start the executable with the URL as the last
+// argument (after args) when a URL of the scheme is opened; the running
+// instance then receives it as a command (see pkg/singleinstance).
+func Register(
+	executable string,
+	args ...string,
+) error {
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, `Software\Classes\`+Scheme, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("unable to create the registry key of the '%s' scheme: %w", Scheme, err)
+	}
+	defer key.Close()
+	if err := key.SetStringValue("", "URL:Pomodoro"); err != nil {
+		return fmt.Errorf("unable to set the description of the '%s' scheme: %w", Scheme, err)
+	}
+	if err := key.SetStringValue("URL Protocol", ""); err != nil {
+		return fmt.Errorf("unable to mark '%s' as a URL scheme: %w", Scheme, err)
+	}
+
+	commandKey, _, err := registry.CreateKey(key, `shell\open\command`, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("unable to create the registry key of the '%s' command: %w", Scheme, err)
+	}
+	defer commandKey.Close()
+	command := []string{quote(executable)}
+	for _, arg := range args {
+		command = append(command, quote(arg))
+	}
+	command = append(command, `"%1"`)
+	if err := commandKey.SetStringValue("", strings.Join(command, " ")); err != nil {
+		return fmt.Errorf("unable to set the command of the '%s' scheme: %w", Scheme, err)
+	}
+	return nil
+}
+
+func quote(arg string) string {
+	return `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+}
+
+// HandleURLs is a no-op on Windows: the URLs come as the command line
+// arguments of a new process (see Register).
+func HandleURLs(func(url string)) {}